@@ -0,0 +1,9 @@
+// Package api holds the OpenAPI contract of the HTTP API, embedded into the binary
+package api
+
+import _ "embed"
+
+// OpenAPISpec is the OpenAPI 3 document describing every route of the HTTP API
+//
+//go:embed openapi.yml
+var OpenAPISpec []byte
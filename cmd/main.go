@@ -8,10 +8,21 @@ import (
 	"syscall"
 	"time"
 
+	"beers-challenge/internal/adapters/cli"
+	"beers-challenge/internal/infrastructure/buildinfo"
 	"beers-challenge/internal/infrastructure/dependencies"
 )
 
 func main() {
+	// The version subcommand needs no configuration or storage
+	if len(os.Args) > 1 && os.Args[1] == "version" {
+		if err := cli.RunVersion(os.Args[2:], os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(2)
+		}
+		return
+	}
+
 	// Create dependency injection container
 	container, err := dependencies.NewContainer()
 	if err != nil {
@@ -24,9 +35,23 @@ func main() {
 		}
 	}()
 
+	// Administrative subcommands run against the configured storage and exit
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), container, os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			container.Close()
+			os.Exit(2)
+		}
+		return
+	}
+
 	logger := container.GetLogger()
+	build := buildinfo.Get()
 	logger.Info(context.Background(), "Starting Beer API", map[string]interface{}{
-		"version": "1.0.0",
+		"version":    build.Version,
+		"commit":     build.Commit,
+		"build_date": build.BuildDate,
+		"go_version": build.GoVersion,
 	})
 
 	// Get HTTP server
@@ -40,6 +65,34 @@ func main() {
 		}
 	}()
 
+	// Start the gRPC server alongside it, when enabled
+	grpcServer := container.GetGRPCServer()
+	if grpcServer != nil {
+		go func() {
+			if err := grpcServer.Start(); err != nil {
+				logger.Error(context.Background(), "gRPC server failed to start", err, nil)
+				os.Exit(1)
+			}
+		}()
+	}
+
+	// Deliver webhooks in the background until shutdown
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	dispatched := make(chan struct{})
+	if dispatcher := container.GetWebhookDispatcher(); dispatcher != nil {
+		go func() {
+			defer close(dispatched)
+			dispatcher.Run(backgroundCtx)
+		}()
+	} else {
+		close(dispatched)
+	}
+
+	// Pick up renewed TLS certificates until shutdown
+	if certificates := container.GetCertificateReloader(); certificates != nil {
+		go certificates.Run(backgroundCtx)
+	}
+
 	// Wait for interrupt signal to gracefully shutdown the server
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -57,5 +110,29 @@ func main() {
 		os.Exit(1)
 	}
 
+	if grpcServer != nil {
+		if err := grpcServer.Stop(ctx); err != nil {
+			logger.Error(ctx, "gRPC server forced to shutdown", err, nil)
+			os.Exit(1)
+		}
+	}
+
+	// Attempts in flight are cut short and retried once their lease expires
+	stopBackground()
+	<-dispatched
+
 	logger.Info(context.Background(), "Server shutdown completed", nil)
 }
+
+// runCommand runs an administrative subcommand
+func runCommand(ctx context.Context, container *dependencies.Container, args []string) error {
+	switch args[0] {
+	case "apikey":
+		if container.GetConfig().GetString("database.type") != "postgres" {
+			fmt.Fprintln(os.Stderr, "warning: API keys are only persisted with DB_TYPE=postgres")
+		}
+		return cli.RunAPIKeys(ctx, container.GetAuthService(), args[1:], os.Stdout)
+	default:
+		return fmt.Errorf("unknown command %q\n%v\n%v", args[0], cli.ErrUsage, cli.ErrVersionUsage)
+	}
+}
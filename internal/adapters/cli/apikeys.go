@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"beers-challenge/internal/core/ports/primary"
+)
+
+// apiKeyUsage describes the apikey subcommands
+const apiKeyUsage = `usage:
+  apikey create --name <name> --role <reader|editor|admin>
+  apikey revoke <id>
+  apikey list`
+
+// ErrUsage is returned when the command line does not match any subcommand
+var ErrUsage = errors.New(apiKeyUsage)
+
+// RunAPIKeys runs an apikey subcommand against the auth service and writes its output to out
+func RunAPIKeys(ctx context.Context, authService primary.AuthService, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrUsage
+	}
+
+	switch args[0] {
+	case "create":
+		return createAPIKey(ctx, authService, args[1:], out)
+	case "revoke":
+		if len(args) != 2 {
+			return ErrUsage
+		}
+		return revokeAPIKey(ctx, authService, args[1], out)
+	case "list":
+		return listAPIKeys(ctx, authService, out)
+	default:
+		return ErrUsage
+	}
+}
+
+// createAPIKey creates a key and prints its token, which cannot be shown again
+func createAPIKey(ctx context.Context, authService primary.AuthService, args []string, out io.Writer) error {
+	flags := flag.NewFlagSet("apikey create", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	name := flags.String("name", "", "name describing who uses the key")
+	role := flags.String("role", "reader", "role granted by the key")
+
+	if err := flags.Parse(args); err != nil || flags.NArg() > 0 {
+		return ErrUsage
+	}
+
+	created, err := authService.CreateAPIKey(ctx, primary.CreateAPIKeyRequest{Name: *name, Role: *role})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Created API key %s (%s, role %s)\n", created.Key.ID, created.Key.Name, created.Key.Role)
+	fmt.Fprintf(out, "Token: %s\n", created.Token)
+	fmt.Fprintln(out, "Store the token now; it is not saved and cannot be shown again.")
+
+	return nil
+}
+
+// revokeAPIKey revokes a key by its ID
+func revokeAPIKey(ctx context.Context, authService primary.AuthService, id string, out io.Writer) error {
+	key, err := authService.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "Revoked API key %s (%s) at %s\n", key.ID, key.Name, key.RevokedAt.UTC().Format(time.RFC3339))
+	return nil
+}
+
+// listAPIKeys prints every key without its hash
+func listAPIKeys(ctx context.Context, authService primary.AuthService, out io.Writer) error {
+	keys, err := authService.ListAPIKeys(ctx)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tROLE\tCREATED\tREVOKED")
+	for _, key := range keys {
+		revoked := "-"
+		if key.RevokedAt != nil {
+			revoked = key.RevokedAt.UTC().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", key.ID, key.Name, key.Role, key.CreatedAt.UTC().Format(time.RFC3339), revoked)
+	}
+
+	return w.Flush()
+}
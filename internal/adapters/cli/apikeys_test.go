@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/services"
+	"beers-challenge/internal/infrastructure/logger"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+func TestRunAPIKeys(t *testing.T) {
+	ctx := context.Background()
+	authService := services.NewAuthService(inmemory.NewAPIKeyRepository(), logger.NewNoOpLogger())
+
+	var out bytes.Buffer
+	assert.NoError(t, RunAPIKeys(ctx, authService, []string{"create", "--name", "ci", "--role", "editor"}, &out))
+	assert.Contains(t, out.String(), "Token: bk_")
+
+	keys, _ := authService.ListAPIKeys(ctx)
+	assert.Len(t, keys, 1)
+
+	out.Reset()
+	assert.NoError(t, RunAPIKeys(ctx, authService, []string{"revoke", keys[0].ID}, &out))
+	assert.Contains(t, out.String(), "Revoked API key "+keys[0].ID)
+
+	out.Reset()
+	assert.NoError(t, RunAPIKeys(ctx, authService, []string{"list"}, &out))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "editor")
+	assert.NotContains(t, lines[1], " - ")
+}
+
+func TestRunAPIKeysErrors(t *testing.T) {
+	ctx := context.Background()
+	authService := services.NewAuthService(inmemory.NewAPIKeyRepository(), logger.NewNoOpLogger())
+	var out bytes.Buffer
+
+	assert.Equal(t, ErrUsage, RunAPIKeys(ctx, authService, nil, &out))
+	assert.Equal(t, ErrUsage, RunAPIKeys(ctx, authService, []string{"rotate"}, &out))
+	assert.Equal(t, ErrUsage, RunAPIKeys(ctx, authService, []string{"revoke"}, &out))
+	assert.Equal(t, ErrUsage, RunAPIKeys(ctx, authService, []string{"create", "--bogus"}, &out))
+
+	assert.Error(t, RunAPIKeys(ctx, authService, []string{"create", "--name", "ci", "--role", "owner"}, &out))
+	assert.Error(t, RunAPIKeys(ctx, authService, []string{"revoke", "key_missing"}, &out))
+}
@@ -0,0 +1,33 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"beers-challenge/internal/infrastructure/buildinfo"
+)
+
+// versionUsage describes the version subcommand
+const versionUsage = `  version [--json]`
+
+// ErrVersionUsage is returned when the version subcommand gets unknown arguments
+var ErrVersionUsage = errors.New(versionUsage)
+
+// RunVersion prints the metadata of the running build, as text or as JSON
+func RunVersion(args []string, out io.Writer) error {
+	info := buildinfo.Get()
+
+	switch {
+	case len(args) == 0:
+		_, err := fmt.Fprint(out, info.String())
+		return err
+	case len(args) == 1 && args[0] == "--json":
+		encoder := json.NewEncoder(out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	default:
+		return ErrVersionUsage
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/infrastructure/buildinfo"
+)
+
+func TestRunVersion(t *testing.T) {
+	var out bytes.Buffer
+	assert.NoError(t, RunVersion(nil, &out))
+	assert.Equal(t, buildinfo.Get().String(), out.String())
+
+	out.Reset()
+	assert.NoError(t, RunVersion([]string{"--json"}, &out))
+	var info buildinfo.Info
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &info))
+	assert.Equal(t, buildinfo.Get(), info)
+
+	assert.ErrorIs(t, RunVersion([]string{"--yaml"}, &out), ErrVersionUsage)
+}
@@ -0,0 +1,70 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+)
+
+// resolverError is a GraphQL error whose extensions carry the same code a
+// problem response of the REST API would, and the invalid field, if any
+type resolverError struct {
+	code    string
+	message string
+	field   string
+}
+
+// newResolverError creates an error with a code
+func newResolverError(code, message string) *resolverError {
+	return &resolverError{code: code, message: message}
+}
+
+// newFieldError creates a validation error for an invalid argument
+func newFieldError(field, message string) *resolverError {
+	return &resolverError{code: "VALIDATION_ERROR", message: message, field: field}
+}
+
+// Error implements the error interface
+func (e *resolverError) Error() string {
+	return e.message
+}
+
+// Extensions is added to the error in the GraphQL response
+func (e *resolverError) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if e.field != "" {
+		extensions["field"] = e.field
+	}
+	return extensions
+}
+
+// resolverError logs a service error and maps it to a GraphQL error. Unexpected
+// errors are reported without their details.
+func (r *rootResolver) resolverError(ctx context.Context, message string, err error) error {
+	r.logger.Error(ctx, message, err, nil)
+
+	var validationErr *beers.ValidationError
+	if errors.As(err, &validationErr) {
+		return newFieldError(validationErr.Field, validationErr.Error())
+	}
+
+	var domainErr *beers.DomainError
+	if errors.As(err, &domainErr) {
+		return newResolverError(domainErr.Code, domainErr.Message)
+	}
+
+	var currencyErr *currency.CurrencyError
+	if errors.As(err, &currencyErr) {
+		return newResolverError(currencyErr.Code, currencyErr.Message)
+	}
+
+	return newResolverError("INTERNAL_ERROR", "An internal error occurred")
+}
+
+// isBeerNotFound reports whether err says a beer does not exist
+func isBeerNotFound(err error) bool {
+	var domainErr *beers.DomainError
+	return errors.As(err, &domainErr) && domainErr.Code == "BEER_NOT_FOUND"
+}
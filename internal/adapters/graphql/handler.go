@@ -0,0 +1,118 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// Query limits guarding the server against expensive documents
+const (
+	maxDepth       = 8
+	maxParallelism = 10
+)
+
+//go:embed schema.graphql
+var schemaSDL string
+
+// errCodeRequestTooLarge is the error code of a body over the server's body
+// limit, matching the REST problem code
+const errCodeRequestTooLarge = "REQUEST_TOO_LARGE"
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Handler serves GraphQL requests over HTTP on top of primary.BeerService
+type Handler struct {
+	schema *graphql.Schema
+	logger secondary.Logger
+}
+
+// NewHandler creates a GraphQL handler for the beer catalogue
+func NewHandler(beerService primary.BeerService, logger secondary.Logger) *Handler {
+	resolver := &rootResolver{beerService: beerService, logger: logger}
+
+	return &Handler{
+		schema: graphql.MustParseSchema(schemaSDL, resolver,
+			graphql.UseFieldResolvers(),
+			graphql.MaxDepth(maxDepth),
+			graphql.MaxParallelism(maxParallelism),
+			graphql.Logger(&panicLogger{logger: logger}),
+		),
+		logger: logger,
+	}
+}
+
+// ServeHTTP executes a JSON encoded GraphQL request. Field errors are returned
+// next to the data with a 200, as GraphQL clients expect; only requests that
+// cannot be executed at all get a 400, or a 413 when the body is over its limit.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, http.StatusRequestEntityTooLarge, errCodeRequestTooLarge,
+				fmt.Sprintf("Request body exceeds the limit of %d bytes", maxBytesErr.Limit))
+			return
+		}
+		writeRequestError(w, "Request body must be a JSON GraphQL request")
+		return
+	}
+
+	if req.Query == "" {
+		writeRequestError(w, "A query is required")
+		return
+	}
+
+	// Resolvers of one request share exchange rate lookups
+	ctx := currency.WithRateBatch(r.Context())
+	response := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeRequestError sends a 400 with a single GraphQL error
+func writeRequestError(w http.ResponseWriter, message string) {
+	writeError(w, http.StatusBadRequest, "INVALID_REQUEST", message)
+}
+
+// writeError sends a single GraphQL error with the given status and code
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"message":    message,
+			"extensions": map[string]interface{}{"code": code},
+		}},
+	})
+}
+
+// writeJSON sends a JSON response
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// panicLogger reports resolver panics, which graphql-go recovers from, to the
+// application logger
+type panicLogger struct {
+	logger secondary.Logger
+}
+
+// LogPanic implements graphql-go's log.Logger
+func (l *panicLogger) LogPanic(ctx context.Context, value interface{}) {
+	l.logger.Error(ctx, "Panic while resolving GraphQL query", fmt.Errorf("%v", value), nil)
+}
@@ -0,0 +1,240 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockBeerService is a mock implementation of primary.BeerService
+type MockBeerService struct {
+	mock.Mock
+}
+
+func (m *MockBeerService) CreateBeer(ctx context.Context, req primary.CreateBeerRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockBeerService) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (*beers.Beer, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) FindBeerByID(ctx context.Context, id int) (*beers.Beer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) FindAllBeers(ctx context.Context) ([]beers.Beer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) CalculateBoxPrice(ctx context.Context, req primary.CalculateBoxPriceRequest) (*primary.BoxPriceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.BoxPriceResponse), args.Error(1)
+}
+
+// graphqlResponse is the decoded body of a GraphQL response
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Path       []interface{}          `json:"path"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func execute(t *testing.T, handler http.Handler, ctx context.Context, query string, variables map[string]interface{}) (int, graphqlResponse) {
+	t.Helper()
+
+	body, err := json.Marshal(Request{Query: query, Variables: variables})
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	var resp graphqlResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return w.Code, resp
+}
+
+var catalogue = []beers.Beer{
+	{ID: 1, Name: "Kunstmann Torobayo", Brewery: "Kunstmann", Country: "Chile", Price: 2500, Currency: "CLP",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{ID: 2, Name: "Kunstmann Lager", Brewery: "Kunstmann", Country: "Chile", Price: 2000, Currency: "CLP", ABV: 4.3},
+	{ID: 3, Name: "Heineken", Brewery: "Heineken", Country: "Netherlands", Price: 1.5, Currency: "EUR"},
+}
+
+// ratedBeerService prices boxes with rates looked up through the request's rate
+// batch, as the currency service decorator does
+type ratedBeerService struct {
+	*MockBeerService
+	fetches atomic.Int32
+}
+
+func (s *ratedBeerService) CalculateBoxPrice(ctx context.Context, req primary.CalculateBoxPriceRequest) (*primary.BoxPriceResponse, error) {
+	batch, ok := currency.RateBatchFromContext(ctx)
+	if !ok {
+		return nil, errors.New("no rate batch on the context")
+	}
+
+	rate, err := batch.Rate(ctx, "CLP", req.Currency, func(ctx context.Context, from, to string) (float64, error) {
+		s.fetches.Add(1)
+		return 0.001, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &primary.BoxPriceResponse{BeerID: req.BeerID, Quantity: req.Quantity, TotalPrice: float64(req.Quantity) * 2000 * rate,
+		Currency: req.Currency, ExchangeRate: rate}, nil
+}
+
+func TestBeersQueryFiltersAndBatchesExchangeRates(t *testing.T) {
+	service := &ratedBeerService{MockBeerService: new(MockBeerService)}
+	service.On("FindAllBeers", mock.Anything).Return(catalogue, nil)
+
+	handler := NewHandler(service, logger.NewNoOpLogger())
+	status, resp := execute(t, handler, context.Background(),
+		`{ beers(filter: {brewery: "kunstmann", maxPrice: 3000}) { id name abv boxPrice(quantity: 6) { totalPrice currency exchangeRate taxes { name } } } }`, nil)
+
+	assert.Equal(t, http.StatusOK, status)
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"beers": [
+		{"id": "1", "name": "Kunstmann Torobayo", "abv": null, "boxPrice": {"totalPrice": 12, "currency": "USD", "exchangeRate": 0.001, "taxes": []}},
+		{"id": "2", "name": "Kunstmann Lager", "abv": 4.3, "boxPrice": {"totalPrice": 12, "currency": "USD", "exchangeRate": 0.001, "taxes": []}}
+	]}`, string(resp.Data))
+	assert.Equal(t, int32(1), service.fetches.Load(), "both beers share one CLP/USD lookup")
+}
+
+func TestBeerQuery(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("FindBeerByID", mock.Anything, 1).Return(&catalogue[0], nil)
+	service.On("FindBeerByID", mock.Anything, 99).
+		Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 99 not found", nil))
+	service.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{BeerID: 1, Quantity: 1, Currency: "EUR"}).
+		Return(nil, currency.NewCurrencyError("RATE_NOT_FOUND", "No exchange rate from CLP to EUR", nil))
+
+	handler := NewHandler(service, logger.NewNoOpLogger())
+
+	_, resp := execute(t, handler, context.Background(),
+		`query($id: ID!) { beer(id: $id) { name brewery createdAt } }`, map[string]interface{}{"id": "1"})
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"beer": {"name": "Kunstmann Torobayo", "brewery": "Kunstmann", "createdAt": "2024-01-01T00:00:00Z"}}`, string(resp.Data))
+
+	_, resp = execute(t, handler, context.Background(), `{ beer(id: 99) { name } }`, nil)
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"beer": null}`, string(resp.Data))
+
+	_, resp = execute(t, handler, context.Background(), `{ beer(id: "abc") { name } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "VALIDATION_ERROR", resp.Errors[0].Extensions["code"])
+	assert.Equal(t, "id", resp.Errors[0].Extensions["field"])
+
+	_, resp = execute(t, handler, context.Background(), `{ beer(id: 1) { name boxPrice(currency: "EUR") { totalPrice } } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "RATE_NOT_FOUND", resp.Errors[0].Extensions["code"])
+	assert.Equal(t, []interface{}{"beer", "boxPrice"}, resp.Errors[0].Path)
+
+	_, resp = execute(t, handler, context.Background(), `{ beer(id: 1) { boxPrice(quantity: 0) { totalPrice } } }`, nil)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "quantity", resp.Errors[0].Extensions["field"])
+}
+
+func TestCreateBeerMutation(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("CreateBeer", mock.Anything, primary.CreateBeerRequest{
+		ID: 4, Name: "Austral Calafate", Brewery: "Austral", Country: "Chile", Price: 2200, Currency: "CLP", VolumeML: 330,
+	}).Return(nil)
+	service.On("FindBeerByID", mock.Anything, 4).Return(&beers.Beer{
+		ID: 4, Name: "Austral Calafate", Brewery: "Austral", Country: "Chile", Price: 2200, Currency: "CLP", VolumeML: 330,
+	}, nil)
+	service.On("CreateBeer", mock.Anything, mock.MatchedBy(func(req primary.CreateBeerRequest) bool {
+		return req.ID == 1
+	})).Return(beers.NewDomainError("BEER_ALREADY_EXISTS", "Beer with this ID already exists", nil))
+
+	handler := NewHandler(service, logger.NewNoOpLogger())
+	mutation := `mutation($input: CreateBeerInput!) { createBeer(input: $input) { id name volumeMl } }`
+	input := func(id string) map[string]interface{} {
+		return map[string]interface{}{"input": map[string]interface{}{
+			"id": id, "name": "Austral Calafate", "brewery": "Austral", "country": "Chile",
+			"price": 2200, "currency": "CLP", "volumeMl": 330,
+		}}
+	}
+
+	_, resp := execute(t, handler, context.Background(), mutation, input("4"))
+	assert.Empty(t, resp.Errors)
+	assert.JSONEq(t, `{"createBeer": {"id": "4", "name": "Austral Calafate", "volumeMl": 330}}`, string(resp.Data))
+
+	_, resp = execute(t, handler, context.Background(), mutation, input("1"))
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "BEER_ALREADY_EXISTS", resp.Errors[0].Extensions["code"])
+
+	reader := auth.WithPrincipal(context.Background(), &auth.Principal{Subject: "reader", Role: auth.RoleReader})
+	_, resp = execute(t, handler, reader, mutation, input("4"))
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, auth.ErrCodeForbidden, resp.Errors[0].Extensions["code"])
+	service.AssertNumberOfCalls(t, "CreateBeer", 2)
+}
+
+func TestInvalidGraphQLRequests(t *testing.T) {
+	handler := NewHandler(new(MockBeerService), logger.NewNoOpLogger())
+
+	for _, body := range []string{`not json`, `{"variables": {}}`} {
+		req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), `"code":"INVALID_REQUEST"`)
+	}
+
+	status, resp := execute(t, handler, context.Background(), `{ beers { unknownField } }`, nil)
+	assert.Equal(t, http.StatusOK, status)
+	assert.NotEmpty(t, resp.Errors)
+}
+
+func TestGraphQLRequestOverBodyLimit(t *testing.T) {
+	handler := NewHandler(new(MockBeerService), logger.NewNoOpLogger())
+
+	body := `{"query": "{ beers { id } }", "variables": {"padding": "` + strings.Repeat("x", 64) + `"}}`
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(w, req.Body, 32)
+	handler.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"REQUEST_TOO_LARGE"`)
+	assert.Contains(t, w.Body.String(), "limit of 32 bytes")
+}
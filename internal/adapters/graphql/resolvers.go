@@ -0,0 +1,354 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// rootResolver resolves the Query and Mutation types
+type rootResolver struct {
+	beerService primary.BeerService
+	logger      secondary.Logger
+}
+
+// beerFilter is the BeerFilter input
+type beerFilter struct {
+	Name     *string
+	Brewery  *string
+	Country  *string
+	Currency *string
+	MinPrice *float64
+	MaxPrice *float64
+}
+
+// matches reports whether a beer passes every filter that is set
+func (f *beerFilter) matches(beer *beers.Beer) bool {
+	if f == nil {
+		return true
+	}
+
+	if f.Name != nil && !strings.Contains(strings.ToLower(beer.Name), strings.ToLower(*f.Name)) {
+		return false
+	}
+	if f.Brewery != nil && !strings.EqualFold(beer.Brewery, *f.Brewery) {
+		return false
+	}
+	if f.Country != nil && !strings.EqualFold(beer.Country, *f.Country) {
+		return false
+	}
+	if f.Currency != nil && !strings.EqualFold(beer.Currency, *f.Currency) {
+		return false
+	}
+	if f.MinPrice != nil && beer.Price < *f.MinPrice {
+		return false
+	}
+	if f.MaxPrice != nil && beer.Price > *f.MaxPrice {
+		return false
+	}
+
+	return true
+}
+
+// Beers resolves Query.beers
+func (r *rootResolver) Beers(ctx context.Context, args struct{ Filter *beerFilter }) ([]*beerResolver, error) {
+	beersSlice, err := r.beerService.FindAllBeers(ctx)
+	if err != nil {
+		return nil, r.resolverError(ctx, "Failed to find beers", err)
+	}
+
+	resolvers := make([]*beerResolver, 0, len(beersSlice))
+	for i := range beersSlice {
+		if args.Filter.matches(&beersSlice[i]) {
+			resolvers = append(resolvers, r.newBeerResolver(&beersSlice[i]))
+		}
+	}
+
+	return resolvers, nil
+}
+
+// Beer resolves Query.beer. A beer that does not exist resolves to null.
+func (r *rootResolver) Beer(ctx context.Context, args struct{ ID graphql.ID }) (*beerResolver, error) {
+	id, err := parseBeerID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	beer, err := r.beerService.FindBeerByID(ctx, id)
+	if err != nil {
+		if isBeerNotFound(err) {
+			return nil, nil
+		}
+		return nil, r.resolverError(ctx, "Failed to find beer", err)
+	}
+
+	return r.newBeerResolver(beer), nil
+}
+
+// createBeerInput is the CreateBeerInput input
+type createBeerInput struct {
+	ID       graphql.ID
+	Name     string
+	Brewery  string
+	Country  string
+	Price    float64
+	Currency string
+	Abv      *float64
+	VolumeMl *int32
+}
+
+// CreateBeer resolves Mutation.createBeer and returns the created beer
+func (r *rootResolver) CreateBeer(ctx context.Context, args struct{ Input createBeerInput }) (*beerResolver, error) {
+	// The route lets readers in for queries; mutations need the editor role
+	if principal, ok := auth.PrincipalFromContext(ctx); ok && !principal.Role.Allows(auth.RoleEditor) {
+		return nil, newResolverError(auth.ErrCodeForbidden, "This operation requires the "+string(auth.RoleEditor)+" role")
+	}
+
+	id, err := parseBeerID(args.Input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	req := primary.CreateBeerRequest{
+		ID:       id,
+		Name:     args.Input.Name,
+		Brewery:  args.Input.Brewery,
+		Country:  args.Input.Country,
+		Price:    args.Input.Price,
+		Currency: args.Input.Currency,
+	}
+	if args.Input.Abv != nil {
+		req.ABV = *args.Input.Abv
+	}
+	if args.Input.VolumeMl != nil {
+		req.VolumeML = int(*args.Input.VolumeMl)
+	}
+
+	if err := r.beerService.CreateBeer(ctx, req); err != nil {
+		return nil, r.resolverError(ctx, "Failed to create beer", err)
+	}
+
+	beer, err := r.beerService.FindBeerByID(ctx, id)
+	if err != nil {
+		return nil, r.resolverError(ctx, "Failed to find created beer", err)
+	}
+
+	return r.newBeerResolver(beer), nil
+}
+
+// newBeerResolver resolves a beer
+func (r *rootResolver) newBeerResolver(beer *beers.Beer) *beerResolver {
+	return &beerResolver{
+		ID:        graphql.ID(strconv.Itoa(beer.ID)),
+		Name:      beer.Name,
+		Brewery:   beer.Brewery,
+		Country:   beer.Country,
+		Price:     beer.Price,
+		Currency:  beer.Currency,
+		Abv:       optionalFloat(beer.ABV),
+		VolumeMl:  optionalInt(beer.VolumeML),
+		CreatedAt: graphql.Time{Time: beer.CreatedAt},
+		UpdatedAt: graphql.Time{Time: beer.UpdatedAt},
+		beerID:    beer.ID,
+		root:      r,
+	}
+}
+
+// beerResolver resolves the Beer type. Scalar fields are resolved from the struct
+// fields; boxPrice calls the beer service.
+type beerResolver struct {
+	ID        graphql.ID
+	Name      string
+	Brewery   string
+	Country   string
+	Price     float64
+	Currency  string
+	Abv       *float64
+	VolumeMl  *int32
+	CreatedAt graphql.Time
+	UpdatedAt graphql.Time
+
+	beerID int
+	root   *rootResolver
+}
+
+// boxPriceArgs are the arguments of Beer.boxPrice
+type boxPriceArgs struct {
+	Quantity    int32
+	Currency    string
+	Destination *string
+	Coupon      *string
+}
+
+// BoxPrice resolves Beer.boxPrice. Its exchange rate lookups share the request's
+// rate batch, so pricing every beer of a list in one currency fetches each rate once.
+func (b *beerResolver) BoxPrice(ctx context.Context, args boxPriceArgs) (*boxPrice, error) {
+	if args.Quantity < 1 {
+		return nil, newFieldError("quantity", "Quantity must be a positive integer")
+	}
+
+	req := primary.CalculateBoxPriceRequest{
+		BeerID:   b.beerID,
+		Quantity: int(args.Quantity),
+		Currency: args.Currency,
+	}
+	if args.Destination != nil {
+		req.Destination = *args.Destination
+	}
+	if args.Coupon != nil {
+		req.Coupon = *args.Coupon
+	}
+
+	response, err := b.root.beerService.CalculateBoxPrice(ctx, req)
+	if err != nil {
+		return nil, b.root.resolverError(ctx, "Failed to calculate box price", err)
+	}
+
+	return newBoxPrice(response), nil
+}
+
+// boxPrice resolves the BoxPrice type
+type boxPrice struct {
+	Quantity          int32
+	UnitPrice         float64
+	TotalPrice        float64
+	Currency          string
+	ExchangeRate      *float64
+	Subtotal          *float64
+	DiscountPercent   *float64
+	Discount          *float64
+	Breakdown         []priceLine
+	PromotionDiscount *float64
+	Promotions        []promotionOutcome
+	Destination       *string
+	Taxes             []taxLine
+	TotalTax          *float64
+	TotalWithTax      *float64
+}
+
+// priceLine resolves the PriceLine type
+type priceLine struct {
+	Type        string
+	Sku         *string
+	Description string
+	PackSize    *int32
+	Count       *int32
+	Price       *float64
+	Amount      float64
+}
+
+// promotionOutcome resolves the PromotionOutcome type
+type promotionOutcome struct {
+	PromotionID graphql.ID
+	Name        string
+	Applied     bool
+	Reason      *string
+	Discount    *float64
+}
+
+// taxLine resolves the TaxLine type
+type taxLine struct {
+	RuleID graphql.ID
+	Name   string
+	Kind   string
+	Rate   float64
+	Base   float64
+	Amount float64
+}
+
+// newBoxPrice converts a box price; amounts the REST API omits resolve to null
+func newBoxPrice(response *primary.BoxPriceResponse) *boxPrice {
+	price := &boxPrice{
+		Quantity:          int32(response.Quantity),
+		UnitPrice:         response.UnitPrice,
+		TotalPrice:        response.TotalPrice,
+		Currency:          response.Currency,
+		ExchangeRate:      optionalFloat(response.ExchangeRate),
+		Subtotal:          optionalFloat(response.Subtotal),
+		DiscountPercent:   optionalFloat(response.DiscountPercent),
+		Discount:          optionalFloat(response.Discount),
+		Breakdown:         make([]priceLine, 0, len(response.Breakdown)),
+		PromotionDiscount: optionalFloat(response.PromotionDiscount),
+		Promotions:        make([]promotionOutcome, 0, len(response.Promotions)),
+		Destination:       optionalString(response.Destination),
+		Taxes:             make([]taxLine, 0, len(response.Taxes)),
+		TotalTax:          optionalFloat(response.TotalTax),
+		TotalWithTax:      optionalFloat(response.TotalWithTax),
+	}
+
+	for _, line := range response.Breakdown {
+		price.Breakdown = append(price.Breakdown, priceLine{
+			Type:        line.Type,
+			Sku:         optionalString(line.SKU),
+			Description: line.Description,
+			PackSize:    optionalInt(line.PackSize),
+			Count:       optionalInt(line.Count),
+			Price:       optionalFloat(line.Price),
+			Amount:      line.Amount,
+		})
+	}
+
+	for _, outcome := range response.Promotions {
+		price.Promotions = append(price.Promotions, promotionOutcome{
+			PromotionID: graphql.ID(outcome.PromotionID),
+			Name:        outcome.Name,
+			Applied:     outcome.Applied,
+			Reason:      optionalString(outcome.Reason),
+			Discount:    optionalFloat(outcome.Discount),
+		})
+	}
+
+	for _, line := range response.Taxes {
+		price.Taxes = append(price.Taxes, taxLine{
+			RuleID: graphql.ID(line.RuleID),
+			Name:   line.Name,
+			Kind:   line.Kind,
+			Rate:   line.Rate,
+			Base:   line.Base,
+			Amount: line.Amount,
+		})
+	}
+
+	return price
+}
+
+// parseBeerID converts a GraphQL ID into a beer id
+func parseBeerID(id graphql.ID) (int, error) {
+	beerID, err := strconv.Atoi(string(id))
+	if err != nil {
+		return 0, newFieldError("id", "Beer ID must be a valid integer")
+	}
+	return beerID, nil
+}
+
+// optionalFloat resolves a zero amount to null
+func optionalFloat(v float64) *float64 {
+	if v == 0 {
+		return nil
+	}
+	return &v
+}
+
+// optionalInt resolves a zero count to null
+func optionalInt(v int) *int32 {
+	if v == 0 {
+		return nil
+	}
+	n := int32(v)
+	return &n
+}
+
+// optionalString resolves an empty string to null
+func optionalString(v string) *string {
+	if v == "" {
+		return nil
+	}
+	return &v
+}
@@ -0,0 +1,176 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	beersv1 "beers-challenge/api/proto/beers/v1"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// defaultCurrency prices boxes when the request does not name a currency, as the
+// HTTP API does
+const defaultCurrency = "USD"
+
+// beerServer implements beersv1.BeerServiceServer on top of primary.BeerService
+type beerServer struct {
+	beersv1.UnimplementedBeerServiceServer
+	beerService primary.BeerService
+	logger      secondary.Logger
+}
+
+// CreateBeer adds a beer to the catalogue
+func (s *beerServer) CreateBeer(ctx context.Context, req *beersv1.CreateBeerRequest) (*beersv1.CreateBeerResponse, error) {
+	err := s.beerService.CreateBeer(ctx, primary.CreateBeerRequest{
+		ID:       int(req.GetId()),
+		Name:     req.GetName(),
+		Brewery:  req.GetBrewery(),
+		Country:  req.GetCountry(),
+		Price:    req.GetPrice(),
+		Currency: req.GetCurrency(),
+		ABV:      req.GetAbv(),
+		VolumeML: int(req.GetVolumeMl()),
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, "Failed to create beer", err)
+	}
+
+	return &beersv1.CreateBeerResponse{}, nil
+}
+
+// GetBeer returns a single beer
+func (s *beerServer) GetBeer(ctx context.Context, req *beersv1.GetBeerRequest) (*beersv1.GetBeerResponse, error) {
+	beer, err := s.beerService.FindBeerByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, s.handleError(ctx, "Failed to find beer", err)
+	}
+
+	return &beersv1.GetBeerResponse{Beer: toProtoBeer(beer)}, nil
+}
+
+// ListBeers streams every beer of the catalogue, one message per beer
+func (s *beerServer) ListBeers(_ *beersv1.ListBeersRequest, stream beersv1.BeerService_ListBeersServer) error {
+	ctx := stream.Context()
+
+	beersSlice, err := s.beerService.FindAllBeers(ctx)
+	if err != nil {
+		return s.handleError(ctx, "Failed to find beers", err)
+	}
+
+	for i := range beersSlice {
+		if err := stream.Send(&beersv1.ListBeersResponse{Beer: toProtoBeer(&beersSlice[i])}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CalculateBoxPrice prices a box of a beer
+func (s *beerServer) CalculateBoxPrice(ctx context.Context, req *beersv1.CalculateBoxPriceRequest) (*beersv1.CalculateBoxPriceResponse, error) {
+	quantity := int(req.GetQuantity())
+	if quantity == 0 {
+		quantity = 1
+	}
+	if quantity < 0 {
+		return nil, invalidArgument("quantity", "Quantity must be a positive integer")
+	}
+
+	currency := req.GetCurrency()
+	if currency == "" {
+		currency = defaultCurrency
+	}
+
+	response, err := s.beerService.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID:      int(req.GetBeerId()),
+		Quantity:    quantity,
+		Currency:    currency,
+		Destination: req.GetDestination(),
+		Coupon:      req.GetCoupon(),
+	})
+	if err != nil {
+		return nil, s.handleError(ctx, "Failed to calculate box price", err)
+	}
+
+	return toProtoBoxPrice(response), nil
+}
+
+// handleError logs a service error and maps it to a gRPC status
+func (s *beerServer) handleError(ctx context.Context, message string, err error) error {
+	s.logger.Error(ctx, message, err, nil)
+	return statusFromError(err)
+}
+
+// toProtoBeer converts a domain beer into its protobuf message
+func toProtoBeer(beer *beers.Beer) *beersv1.Beer {
+	return &beersv1.Beer{
+		Id:        int64(beer.ID),
+		Name:      beer.Name,
+		Brewery:   beer.Brewery,
+		Country:   beer.Country,
+		Price:     beer.Price,
+		Currency:  beer.Currency,
+		Abv:       beer.ABV,
+		VolumeMl:  int32(beer.VolumeML),
+		CreatedAt: timestamppb.New(beer.CreatedAt),
+		UpdatedAt: timestamppb.New(beer.UpdatedAt),
+	}
+}
+
+// toProtoBoxPrice converts a box price into its protobuf message
+func toProtoBoxPrice(response *primary.BoxPriceResponse) *beersv1.CalculateBoxPriceResponse {
+	message := &beersv1.CalculateBoxPriceResponse{
+		BeerId:            int64(response.BeerID),
+		BeerName:          response.BeerName,
+		Quantity:          int32(response.Quantity),
+		UnitPrice:         response.UnitPrice,
+		TotalPrice:        response.TotalPrice,
+		Currency:          response.Currency,
+		ExchangeRate:      response.ExchangeRate,
+		Subtotal:          response.Subtotal,
+		DiscountPercent:   response.DiscountPercent,
+		Discount:          response.Discount,
+		PromotionDiscount: response.PromotionDiscount,
+		Destination:       response.Destination,
+		TotalTax:          response.TotalTax,
+		TotalWithTax:      response.TotalWithTax,
+	}
+
+	for _, line := range response.Breakdown {
+		message.Breakdown = append(message.Breakdown, &beersv1.PriceLine{
+			Type:        line.Type,
+			Sku:         line.SKU,
+			Description: line.Description,
+			PackSize:    int32(line.PackSize),
+			Count:       int32(line.Count),
+			Price:       line.Price,
+			Amount:      line.Amount,
+		})
+	}
+
+	for _, outcome := range response.Promotions {
+		message.Promotions = append(message.Promotions, &beersv1.PromotionOutcome{
+			PromotionId: outcome.PromotionID,
+			Name:        outcome.Name,
+			Applied:     outcome.Applied,
+			Reason:      outcome.Reason,
+			Discount:    outcome.Discount,
+		})
+	}
+
+	for _, line := range response.Taxes {
+		message.Taxes = append(message.Taxes, &beersv1.TaxLine{
+			RuleId: line.RuleID,
+			Name:   line.Name,
+			Kind:   line.Kind,
+			Rate:   line.Rate,
+			Base:   line.Base,
+			Amount: line.Amount,
+		})
+	}
+
+	return message
+}
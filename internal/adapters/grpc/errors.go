@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/domain/tax"
+)
+
+// errorDomain names this service in the ErrorInfo detail of error statuses
+const errorDomain = "beers-challenge"
+
+// domainErrorCodes maps domain error codes to gRPC status codes, following the
+// HTTP statuses the REST API sends for them
+var domainErrorCodes = map[string]codes.Code{
+	"BEER_NOT_FOUND":              codes.NotFound,
+	"BEER_ALREADY_EXISTS":         codes.AlreadyExists,
+	"INVALID_CURRENCY":            codes.InvalidArgument,
+	tax.ErrCodeAttributesMissing:  codes.FailedPrecondition,
+	tax.ErrCodeRulesNotConfigured: codes.FailedPrecondition,
+	promotions.ErrCodeNotFound:    codes.NotFound,
+	auth.ErrCodeUnauthenticated:   codes.Unauthenticated,
+	auth.ErrCodeForbidden:         codes.PermissionDenied,
+	ratelimit.ErrCodeRateLimited:  codes.ResourceExhausted,
+}
+
+// currencyErrorCodes maps currency error codes to gRPC status codes.
+// Unlisted codes are failures of the rate provider and map to Unavailable.
+var currencyErrorCodes = map[string]codes.Code{
+	"RATE_NOT_FOUND":   codes.FailedPrecondition,
+	"INVALID_CURRENCY": codes.InvalidArgument,
+}
+
+// statusFromError maps a service error, however deeply wrapped, to a gRPC status.
+// The domain error code travels in an ErrorInfo detail so clients can branch on it
+// as they do on the code of a problem response.
+func statusFromError(err error) error {
+	var validationErr *beers.ValidationError
+	if errors.As(err, &validationErr) {
+		return invalidArgument(validationErr.Field, validationErr.Message)
+	}
+
+	var domainErr *beers.DomainError
+	if errors.As(err, &domainErr) {
+		code, known := domainErrorCodes[domainErr.Code]
+		if !known {
+			code = codes.Internal
+		}
+		return newStatus(code, domainErr.Code, domainErr.Message)
+	}
+
+	var currencyErr *currency.CurrencyError
+	if errors.As(err, &currencyErr) {
+		code, known := currencyErrorCodes[currencyErr.Code]
+		if !known {
+			code = codes.Unavailable
+		}
+		return newStatus(code, currencyErr.Code, currencyErr.Message)
+	}
+
+	return newStatus(codes.Internal, "INTERNAL_ERROR", "An internal error occurred")
+}
+
+// invalidArgument builds an InvalidArgument status describing an invalid field
+func invalidArgument(field, message string) error {
+	st := status.New(codes.InvalidArgument, field+": "+message)
+	detailed, err := st.WithDetails(
+		&errdetails.ErrorInfo{Reason: "VALIDATION_ERROR", Domain: errorDomain},
+		&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+			{Field: field, Description: message},
+		}},
+	)
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// newStatus builds a status carrying reason in an ErrorInfo detail
+func newStatus(code codes.Code, reason, message string) error {
+	st := status.New(code, message)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{Reason: reason, Domain: errorDomain})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,214 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	beersv1 "beers-challenge/api/proto/beers/v1"
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/correlation"
+	"beers-challenge/internal/core/ports/primary"
+)
+
+const instrumentationName = "beers-challenge/internal/adapters/grpc"
+
+// Metadata keys read from and sent to callers, matching the HTTP headers
+const (
+	requestIDKey     = "x-request-id"
+	apiKeyKey        = "x-api-key"
+	authorizationKey = "authorization"
+)
+
+// methodRoles is the role each BeerService method requires when authentication
+// is enabled. Methods not listed, such as health checks and reflection, are open.
+var methodRoles = map[string]auth.Role{
+	beersv1.BeerService_CreateBeer_FullMethodName:        auth.RoleEditor,
+	beersv1.BeerService_GetBeer_FullMethodName:           auth.RoleReader,
+	beersv1.BeerService_ListBeers_FullMethodName:         auth.RoleReader,
+	beersv1.BeerService_CalculateBoxPrice_FullMethodName: auth.RoleReader,
+}
+
+// unaryInterceptor runs every unary call through intercept
+func (s *Server) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	var resp interface{}
+	err := s.intercept(ctx, info.FullMethod, func(ctx context.Context) error {
+		var err error
+		resp, err = handler(ctx, req)
+		return err
+	})
+	return resp, err
+}
+
+// streamInterceptor runs every streaming call through intercept
+func (s *Server) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	return s.intercept(stream.Context(), info.FullMethod, func(ctx context.Context) error {
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	})
+}
+
+// contextStream replaces the context of a server stream
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// intercept gives a call what the HTTP middleware gives a request: a request id,
+// a server span continuing the caller's trace, an access log line, authorization
+// and recovery from panics
+func (s *Server) intercept(ctx context.Context, method string, call func(context.Context) error) (err error) {
+	start := time.Now()
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	id := firstValue(md, requestIDKey)
+	if !correlation.IsValidRequestID(id) {
+		id = correlation.NewRequestID()
+	}
+	ctx = correlation.WithRequestID(ctx, id)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, metadataCarrier(md))
+	service, rpc := splitMethod(method)
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, strings.TrimPrefix(method, "/"),
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			attribute.String("rpc.system", "grpc"),
+			attribute.String("rpc.service", service),
+			attribute.String("rpc.method", rpc),
+		))
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			s.logger.Error(ctx, "Panic while handling gRPC call", fmt.Errorf("%v", recovered), map[string]interface{}{
+				"method": method,
+			})
+			err = status.Error(codes.Internal, "An internal error occurred")
+		}
+
+		code := status.Code(err)
+		span.SetAttributes(attribute.Int("rpc.grpc.status_code", int(code)))
+		if isServerError(code) {
+			span.SetStatus(otelcodes.Error, code.String())
+		}
+		span.End()
+
+		fields := map[string]interface{}{
+			"method":   method,
+			"code":     code.String(),
+			"duration": time.Since(start).String(),
+		}
+		if isServerError(code) {
+			s.logger.Error(ctx, "gRPC call failed", err, fields)
+		} else {
+			s.logger.Info(ctx, "gRPC call", fields)
+		}
+	}()
+
+	ctx, err = s.authorize(ctx, md, method)
+	if err != nil {
+		return err
+	}
+
+	return call(ctx)
+}
+
+// authorize authenticates the credentials in the call metadata and checks the
+// principal has the role the method requires. It lets every call through when
+// authentication is disabled.
+func (s *Server) authorize(ctx context.Context, md metadata.MD, method string) (context.Context, error) {
+	role, protected := methodRoles[method]
+	if s.authService == nil || !protected {
+		return ctx, nil
+	}
+
+	credentials := primary.Credentials{APIKey: firstValue(md, apiKeyKey)}
+
+	if header := firstValue(md, authorizationKey); header != "" {
+		scheme, token, _ := strings.Cut(header, " ")
+		if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+			return ctx, newStatus(codes.Unauthenticated, auth.ErrCodeUnauthenticated,
+				"Authorization metadata must use the Bearer scheme")
+		}
+		credentials.BearerToken = strings.TrimSpace(token)
+	}
+
+	if credentials.APIKey == "" && credentials.BearerToken == "" {
+		return ctx, newStatus(codes.Unauthenticated, auth.ErrCodeUnauthenticated, "Authentication required")
+	}
+
+	principal, err := s.authService.Authenticate(ctx, credentials)
+	if err != nil {
+		s.logger.Warn(ctx, "Authentication failed", map[string]interface{}{
+			"method": method,
+		})
+		return ctx, statusFromError(err)
+	}
+
+	if !principal.Role.Allows(role) {
+		return ctx, statusFromError(beers.NewDomainError(auth.ErrCodeForbidden,
+			"This operation requires the "+string(role)+" role", nil))
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+// metadataCarrier adapts call metadata, whose keys are lowercase, to the otel
+// propagation API
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	return firstValue(metadata.MD(c), key)
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// firstValue returns the first value of a metadata key, or "" when it is missing
+func firstValue(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// splitMethod splits a full method name such as /beers.v1.BeerService/GetBeer
+// into its service and method
+func splitMethod(fullMethod string) (string, string) {
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return service, method
+}
+
+// isServerError reports whether a status code is the server's fault rather than
+// the caller's
+func isServerError(code codes.Code) bool {
+	switch code {
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss, codes.Unimplemented:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,135 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	beersv1 "beers-challenge/api/proto/beers/v1"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+	"beers-challenge/internal/infrastructure/config"
+)
+
+// Server represents the gRPC server. It serves the beers.v1.BeerService API on top
+// of the same primary.BeerService as the HTTP adapter, along with the standard
+// gRPC health service and, when enabled, server reflection.
+type Server struct {
+	beerService primary.BeerService
+	authService primary.AuthService
+	config      *config.ConfigProvider
+	logger      secondary.Logger
+	tlsConfig   *tls.Config
+	server      *grpc.Server
+	health      *health.Server
+}
+
+// ServerOption configures optional server dependencies
+type ServerOption func(*Server)
+
+// WithAuthService requires callers to present an API key or bearer token with a
+// role allowing the method they call. Health and reflection stay open.
+func WithAuthService(authService primary.AuthService) ServerOption {
+	return func(s *Server) {
+		s.authService = authService
+	}
+}
+
+// WithTLS serves gRPC over TLS with the TLS configuration
+func WithTLS(tlsConfig *tls.Config) ServerOption {
+	return func(s *Server) {
+		s.tlsConfig = tlsConfig
+	}
+}
+
+// NewServer creates a new gRPC server
+func NewServer(beerService primary.BeerService, config *config.ConfigProvider, logger secondary.Logger, opts ...ServerOption) *Server {
+	s := &Server{
+		beerService: beerService,
+		config:      config,
+		logger:      logger,
+		health:      health.NewServer(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	serverOpts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(s.unaryInterceptor),
+		grpc.ChainStreamInterceptor(s.streamInterceptor),
+	}
+	if s.tlsConfig != nil {
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(s.tlsConfig)))
+	}
+
+	s.server = grpc.NewServer(serverOpts...)
+
+	beersv1.RegisterBeerServiceServer(s.server, &beerServer{beerService: beerService, logger: logger})
+	healthpb.RegisterHealthServer(s.server, s.health)
+
+	if config.GetBool("grpc.reflection") {
+		reflection.Register(s.server)
+	}
+
+	return s
+}
+
+// Start listens on grpc.port and serves until Stop is called
+func (s *Server) Start() error {
+	address := fmt.Sprintf("%s:%d",
+		s.config.GetString("server.host"),
+		s.config.GetInt("grpc.port"))
+
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", address, err)
+	}
+
+	s.logger.Info(context.Background(), "Starting gRPC server", map[string]interface{}{
+		"address": address,
+	})
+
+	return s.Serve(listener)
+}
+
+// Serve serves on an existing listener until Stop is called
+func (s *Server) Serve(listener net.Listener) error {
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(beersv1.BeerService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	if err := s.server.Serve(listener); err != nil && err != grpc.ErrServerStopped {
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+
+	return nil
+}
+
+// Stop reports NOT_SERVING to health checks and waits for in-flight calls to
+// finish. Calls still running when ctx is done are cancelled.
+func (s *Server) Stop(ctx context.Context) error {
+	s.logger.Info(ctx, "Stopping gRPC server", nil)
+
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		s.server.Stop()
+		return ctx.Err()
+	}
+}
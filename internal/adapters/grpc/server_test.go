@@ -0,0 +1,392 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	beersv1 "beers-challenge/api/proto/beers/v1"
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockBeerService is a mock implementation of primary.BeerService
+type MockBeerService struct {
+	mock.Mock
+}
+
+func (m *MockBeerService) CreateBeer(ctx context.Context, req primary.CreateBeerRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func (m *MockBeerService) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (*beers.Beer, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) FindBeerByID(ctx context.Context, id int) (*beers.Beer, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) FindAllBeers(ctx context.Context) ([]beers.Beer, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]beers.Beer), args.Error(1)
+}
+
+func (m *MockBeerService) CalculateBoxPrice(ctx context.Context, req primary.CalculateBoxPriceRequest) (*primary.BoxPriceResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.BoxPriceResponse), args.Error(1)
+}
+
+// MockAuthService is a mock implementation of primary.AuthService
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) Authenticate(ctx context.Context, credentials primary.Credentials) (*auth.Principal, error) {
+	args := m.Called(ctx, credentials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIKey(ctx context.Context, req primary.CreateAPIKeyRequest) (*primary.CreatedAPIKey, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAuthService) ListAPIKeys(ctx context.Context) ([]auth.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]auth.APIKey), args.Error(1)
+}
+
+// startTestServer serves a Server over an in-memory listener and returns a
+// client connection to it
+func startTestServer(t *testing.T, beerService primary.BeerService, opts ...ServerOption) (*Server, *grpc.ClientConn) {
+	t.Helper()
+
+	server := NewServer(beerService, config.NewConfigProvider(), logger.NewNoOpLogger(), opts...)
+	listener := bufconn.Listen(1024 * 1024)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = server.Stop(ctx)
+	})
+
+	return server, conn
+}
+
+// errorReason returns the reason of the ErrorInfo detail of a status error
+func errorReason(t *testing.T, err error) string {
+	t.Helper()
+
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.Reason
+		}
+	}
+	return ""
+}
+
+func TestGetBeer(t *testing.T) {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	service := new(MockBeerService)
+	service.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{
+		ID: 1, Name: "IPA", Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD",
+		ABV: 6.5, VolumeML: 355, CreatedAt: createdAt, UpdatedAt: createdAt,
+	}, nil)
+	service.On("FindBeerByID", mock.Anything, 99).
+		Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 99 not found", nil))
+
+	_, conn := startTestServer(t, service)
+	client := beersv1.NewBeerServiceClient(conn)
+
+	resp, err := client.GetBeer(context.Background(), &beersv1.GetBeerRequest{Id: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "IPA", resp.GetBeer().GetName())
+	assert.Equal(t, 2.5, resp.GetBeer().GetPrice())
+	assert.Equal(t, int32(355), resp.GetBeer().GetVolumeMl())
+	assert.Equal(t, createdAt, resp.GetBeer().GetCreatedAt().AsTime())
+
+	_, err = client.GetBeer(context.Background(), &beersv1.GetBeerRequest{Id: 99})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+	assert.Equal(t, "Beer with ID 99 not found", status.Convert(err).Message())
+	assert.Equal(t, "BEER_NOT_FOUND", errorReason(t, err))
+}
+
+func TestCreateBeer(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("CreateBeer", mock.Anything, primary.CreateBeerRequest{
+		ID: 1, Name: "IPA", Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD", VolumeML: 330,
+	}).Return(nil)
+	service.On("CreateBeer", mock.Anything, mock.MatchedBy(func(req primary.CreateBeerRequest) bool {
+		return req.ID == 2
+	})).Return(beers.NewDomainError("BEER_ALREADY_EXISTS", "Beer with this ID already exists", nil))
+	service.On("CreateBeer", mock.Anything, mock.MatchedBy(func(req primary.CreateBeerRequest) bool {
+		return req.ID == 3
+	})).Return(fmt.Errorf("failed to create beer: %w", beers.NewValidationError("name", beers.ErrCannotBeEmpty)))
+
+	_, conn := startTestServer(t, service)
+	client := beersv1.NewBeerServiceClient(conn)
+
+	_, err := client.CreateBeer(context.Background(), &beersv1.CreateBeerRequest{
+		Id: 1, Name: "IPA", Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD", VolumeMl: 330,
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateBeer(context.Background(), &beersv1.CreateBeerRequest{Id: 2})
+	assert.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	_, err = client.CreateBeer(context.Background(), &beersv1.CreateBeerRequest{Id: 3})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	var violations []*errdetails.BadRequest_FieldViolation
+	for _, detail := range status.Convert(err).Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			violations = badRequest.GetFieldViolations()
+		}
+	}
+	require.Len(t, violations, 1)
+	assert.Equal(t, "name", violations[0].GetField())
+}
+
+func TestListBeers(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("FindAllBeers", mock.Anything).Return([]beers.Beer{
+		{ID: 1, Name: "IPA"},
+		{ID: 2, Name: "Stout"},
+	}, nil)
+
+	_, conn := startTestServer(t, service)
+	stream, err := beersv1.NewBeerServiceClient(conn).ListBeers(context.Background(), &beersv1.ListBeersRequest{})
+	require.NoError(t, err)
+
+	var names []string
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, resp.GetBeer().GetName())
+	}
+	assert.Equal(t, []string{"IPA", "Stout"}, names)
+}
+
+func TestListBeersError(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("FindAllBeers", mock.Anything).Return(nil, errors.New("connection refused"))
+
+	_, conn := startTestServer(t, service)
+	stream, err := beersv1.NewBeerServiceClient(conn).ListBeers(context.Background(), &beersv1.ListBeersRequest{})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Internal, status.Code(err))
+	assert.Equal(t, "An internal error occurred", status.Convert(err).Message())
+}
+
+func TestCalculateBoxPrice(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{BeerID: 1, Quantity: 1, Currency: "USD"}).
+		Return(&primary.BoxPriceResponse{BeerID: 1, BeerName: "IPA", Quantity: 1, UnitPrice: 2.5, TotalPrice: 2.5, Currency: "USD"}, nil)
+	service.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{
+		BeerID: 1, Quantity: 6, Currency: "EUR", Destination: "CL", Coupon: "SUMMER",
+	}).Return(&primary.BoxPriceResponse{
+		BeerID: 1, BeerName: "IPA", Quantity: 6, TotalPrice: 12, Currency: "EUR",
+		Promotions:   []promotions.Outcome{{PromotionID: "promo_1", Name: "Summer", Applied: true, Discount: 1.5}},
+		Taxes:        []tax.Line{{RuleID: "cl-vat", Name: "IVA", Kind: "vat", Rate: 0.19, Base: 12, Amount: 2.28}},
+		TotalWithTax: 14.28,
+	}, nil)
+	service.On("CalculateBoxPrice", mock.Anything, mock.MatchedBy(func(req primary.CalculateBoxPriceRequest) bool {
+		return req.Currency == "XYZ"
+	})).Return(nil, currency.NewCurrencyError("API_ERROR", "Currency service unavailable", nil))
+
+	_, conn := startTestServer(t, service)
+	client := beersv1.NewBeerServiceClient(conn)
+
+	resp, err := client.CalculateBoxPrice(context.Background(), &beersv1.CalculateBoxPriceRequest{BeerId: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, resp.GetTotalPrice())
+	assert.Equal(t, "USD", resp.GetCurrency())
+
+	resp, err = client.CalculateBoxPrice(context.Background(), &beersv1.CalculateBoxPriceRequest{
+		BeerId: 1, Quantity: 6, Currency: "EUR", Destination: "CL", Coupon: "SUMMER",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 14.28, resp.GetTotalWithTax())
+	require.Len(t, resp.GetPromotions(), 1)
+	assert.True(t, resp.GetPromotions()[0].GetApplied())
+	require.Len(t, resp.GetTaxes(), 1)
+	assert.Equal(t, "cl-vat", resp.GetTaxes()[0].GetRuleId())
+
+	_, err = client.CalculateBoxPrice(context.Background(), &beersv1.CalculateBoxPriceRequest{BeerId: 1, Quantity: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	_, err = client.CalculateBoxPrice(context.Background(), &beersv1.CalculateBoxPriceRequest{BeerId: 1, Currency: "XYZ"})
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, "API_ERROR", errorReason(t, err))
+}
+
+func TestAuthorization(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Name: "IPA"}, nil)
+	service.On("CreateBeer", mock.Anything, mock.Anything).Return(nil)
+
+	authService := new(MockAuthService)
+	authService.On("Authenticate", mock.Anything, primary.Credentials{APIKey: "reader-key"}).
+		Return(&auth.Principal{Subject: "reader", Role: auth.RoleReader}, nil)
+	authService.On("Authenticate", mock.Anything, primary.Credentials{BearerToken: "editor-token"}).
+		Return(&auth.Principal{Subject: "editor", Role: auth.RoleEditor}, nil)
+	authService.On("Authenticate", mock.Anything, primary.Credentials{APIKey: "revoked-key"}).
+		Return(nil, beers.NewDomainError(auth.ErrCodeUnauthenticated, "Invalid API key", nil))
+
+	_, conn := startTestServer(t, service, WithAuthService(authService))
+	client := beersv1.NewBeerServiceClient(conn)
+
+	withMetadata := func(pairs ...string) context.Context {
+		return metadata.NewOutgoingContext(context.Background(), metadata.Pairs(pairs...))
+	}
+
+	_, err := client.GetBeer(context.Background(), &beersv1.GetBeerRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetBeer(withMetadata("x-api-key", "revoked-key"), &beersv1.GetBeerRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetBeer(withMetadata("authorization", "Basic abc"), &beersv1.GetBeerRequest{Id: 1})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = client.GetBeer(withMetadata("x-api-key", "reader-key"), &beersv1.GetBeerRequest{Id: 1})
+	assert.NoError(t, err)
+
+	_, err = client.CreateBeer(withMetadata("x-api-key", "reader-key"), &beersv1.CreateBeerRequest{Id: 2})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, auth.ErrCodeForbidden, errorReason(t, err))
+
+	_, err = client.CreateBeer(withMetadata("authorization", "Bearer editor-token"), &beersv1.CreateBeerRequest{Id: 2})
+	assert.NoError(t, err)
+
+	// Health checks stay open to probes without credentials
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.NoError(t, err)
+}
+
+func TestRequestIDMetadata(t *testing.T) {
+	service := new(MockBeerService)
+	service.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Name: "IPA"}, nil)
+
+	_, conn := startTestServer(t, service)
+	client := beersv1.NewBeerServiceClient(conn)
+
+	var header metadata.MD
+	ctx := metadata.NewOutgoingContext(context.Background(), metadata.Pairs("x-request-id", "req-123"))
+	_, err := client.GetBeer(ctx, &beersv1.GetBeerRequest{Id: 1}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"req-123"}, header.Get("x-request-id"))
+
+	_, err = client.GetBeer(context.Background(), &beersv1.GetBeerRequest{Id: 1}, grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Len(t, header.Get("x-request-id"), 1)
+	assert.NotEqual(t, "req-123", header.Get("x-request-id")[0])
+}
+
+func TestHealth(t *testing.T) {
+	server, conn := startTestServer(t, new(MockBeerService))
+	client := healthpb.NewHealthClient(conn)
+
+	for _, service := range []string{"", beersv1.BeerService_ServiceDesc.ServiceName} {
+		resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+	}
+
+	server.health.Shutdown()
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+}
+
+func TestReflection(t *testing.T) {
+	_, conn := startTestServer(t, new(MockBeerService))
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	require.NoError(t, err)
+	require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	}))
+
+	resp, err := stream.Recv()
+	require.NoError(t, err)
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.GetName())
+	}
+	assert.Contains(t, services, beersv1.BeerService_ServiceDesc.ServiceName)
+	assert.Contains(t, services, "grpc.health.v1.Health")
+}
@@ -0,0 +1,93 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+const (
+	// APIKeyHeader carries an API key token
+	APIKeyHeader = "X-API-Key"
+
+	// authChallenge is sent with 401 responses
+	authChallenge = `Bearer realm="beer-api"`
+)
+
+// AuthMiddleware authenticates the API key or bearer token of a request, if any,
+// and puts the principal on the request context. Requests without credentials
+// continue anonymously; requireRole decides whether a route accepts them.
+func AuthMiddleware(authService primary.AuthService, logger secondary.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		credentials := primary.Credentials{APIKey: c.GetHeader(APIKeyHeader)}
+
+		if header := c.GetHeader("Authorization"); header != "" {
+			scheme, token, _ := strings.Cut(header, " ")
+			if !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+				writeUnauthenticated(c, "Authorization header must use the Bearer scheme")
+				return
+			}
+			credentials.BearerToken = strings.TrimSpace(token)
+		}
+
+		if credentials.APIKey == "" && credentials.BearerToken == "" {
+			c.Next()
+			return
+		}
+
+		principal, err := authService.Authenticate(c.Request.Context(), credentials)
+		if err != nil {
+			logger.Warn(c.Request.Context(), "Authentication failed", map[string]interface{}{
+				"client_ip": c.ClientIP(),
+				"path":      c.Request.URL.Path,
+			})
+			c.Header("WWW-Authenticate", authChallenge)
+			writeError(c, err)
+			return
+		}
+
+		c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		c.Next()
+	}
+}
+
+// RequireRole rejects requests whose principal is missing (401) or lacks the role (403)
+func RequireRole(role auth.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal, ok := auth.PrincipalFromContext(c.Request.Context())
+		if !ok {
+			writeUnauthenticated(c, "Authentication required")
+			return
+		}
+
+		if !principal.Role.Allows(role) {
+			writeError(c, beers.NewDomainError(auth.ErrCodeForbidden,
+				"This operation requires the "+string(role)+" role", nil))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireRole returns the RequireRole middleware when authentication is enabled
+// and a pass-through handler otherwise
+func (s *Server) requireRole(role auth.Role) gin.HandlerFunc {
+	if s.authService == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return RequireRole(role)
+}
+
+// writeUnauthenticated sends a 401 problem with an authentication challenge
+func writeUnauthenticated(c *gin.Context, detail string) {
+	c.Header("WWW-Authenticate", authChallenge)
+	writeProblem(c, newProblem(http.StatusUnauthorized, auth.ErrCodeUnauthenticated, detail))
+}
@@ -0,0 +1,150 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockAuthService struct {
+	mock.Mock
+}
+
+func (m *MockAuthService) Authenticate(ctx context.Context, credentials primary.Credentials) (*auth.Principal, error) {
+	args := m.Called(ctx, credentials)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Principal), args.Error(1)
+}
+
+func (m *MockAuthService) CreateAPIKey(ctx context.Context, req primary.CreateAPIKeyRequest) (*primary.CreatedAPIKey, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.CreatedAPIKey), args.Error(1)
+}
+
+func (m *MockAuthService) RevokeAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAuthService) ListAPIKeys(ctx context.Context) ([]auth.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]auth.APIKey), args.Error(1)
+}
+
+func newAuthTestServer(authService primary.AuthService) *Server {
+	return NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithAuthService(authService), WithQuoteService(new(MockQuoteService)))
+}
+
+func TestAuthRequiresCredentials(t *testing.T) {
+	server := newAuthTestServer(new(MockAuthService))
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/beers", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, authChallenge, w.Header().Get("WWW-Authenticate"))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, auth.ErrCodeUnauthenticated, problem.Code)
+}
+
+func TestAuthLeavesHealthCheckOpen(t *testing.T) {
+	server := newAuthTestServer(new(MockAuthService))
+
+	req, _ := http.NewRequest(http.MethodGet, "/ping", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthRoles(t *testing.T) {
+	readerKey := primary.Credentials{APIKey: "bk_reader.secret"}
+	editorToken := primary.Credentials{BearerToken: "header.payload.signature"}
+
+	authService := new(MockAuthService)
+	authService.On("Authenticate", mock.Anything, readerKey).
+		Return(&auth.Principal{Subject: "key_reader", Role: auth.RoleReader, Method: auth.MethodAPIKey}, nil)
+	authService.On("Authenticate", mock.Anything, editorToken).
+		Return(&auth.Principal{Subject: "user-1", Role: auth.RoleEditor, Method: auth.MethodJWT}, nil)
+	server := newAuthTestServer(authService)
+
+	cases := map[string]struct {
+		method string
+		path   string
+		header string
+		value  string
+		status int
+	}{
+		"reader can list":          {http.MethodGet, "/api/v1/beers", APIKeyHeader, readerKey.APIKey, http.StatusOK},
+		"reader cannot create":     {http.MethodPost, "/api/v1/beers", APIKeyHeader, readerKey.APIKey, http.StatusForbidden},
+		"reader cannot quote":      {http.MethodPost, "/api/v1/quotes", APIKeyHeader, readerKey.APIKey, http.StatusForbidden},
+		"editor can quote":         {http.MethodPost, "/api/v1/quotes", "Authorization", "Bearer " + editorToken.BearerToken, http.StatusBadRequest},
+		"editor reaches handler":   {http.MethodPost, "/beers", "Authorization", "Bearer " + editorToken.BearerToken, http.StatusBadRequest},
+		"non bearer scheme":        {http.MethodGet, "/api/v1/beers", "Authorization", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		"unknown route stays 404":  {http.MethodGet, "/nowhere", APIKeyHeader, readerKey.APIKey, http.StatusNotFound},
+		"editor reads legacy list": {http.MethodGet, "/beers", "Authorization", "Bearer " + editorToken.BearerToken, http.StatusOK},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.path, nil)
+			req.Header.Set(tc.header, tc.value)
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code, w.Body.String())
+		})
+	}
+}
+
+func TestAuthRejectsInvalidCredentials(t *testing.T) {
+	credentials := primary.Credentials{APIKey: "bk_revoked.secret"}
+	authService := new(MockAuthService)
+	authService.On("Authenticate", mock.Anything, credentials).
+		Return(nil, beers.NewDomainError(auth.ErrCodeUnauthenticated, "Invalid API key", nil))
+	server := newAuthTestServer(authService)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/beers", nil)
+	req.Header.Set(APIKeyHeader, credentials.APIKey)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Equal(t, authChallenge, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestAuthDisabledLeavesRoutesOpen(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/beers", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -1,12 +1,13 @@
 package http
 
 import (
-	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
 	"beers-challenge/internal/core/ports/primary"
 	"beers-challenge/internal/core/ports/secondary"
 )
@@ -14,6 +15,7 @@ import (
 // BeerHandler handles HTTP requests for beer operations
 type BeerHandler struct {
 	beerService primary.BeerService
+	caching     *CachePolicy
 	logger      secondary.Logger
 }
 
@@ -25,13 +27,6 @@ func NewBeerHandler(beerService primary.BeerService, logger secondary.Logger) *B
 	}
 }
 
-// ErrorResponse represents an error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message"`
-	Code    string `json:"code,omitempty"`
-}
-
 // CreateBeer handles POST /beers
 func (h *BeerHandler) CreateBeer(c *gin.Context) {
 	var req primary.CreateBeerRequest
@@ -40,10 +35,7 @@ func (h *BeerHandler) CreateBeer(c *gin.Context) {
 		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
 			"endpoint": "POST /beers",
 		})
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_REQUEST",
-			Message: "Invalid request body: " + err.Error(),
-		})
+		writeBindingError(c, err)
 		return
 	}
 
@@ -57,21 +49,62 @@ func (h *BeerHandler) CreateBeer(c *gin.Context) {
 		"name":    req.Name,
 	})
 
-	c.Status(http.StatusCreated)
+	shaperOf(c).created(c, req.ID, func() *beers.Beer {
+		beer, err := h.beerService.FindBeerByID(c.Request.Context(), req.ID)
+		if err != nil {
+			h.logger.Error(c.Request.Context(), "Failed to read created beer", err, map[string]interface{}{
+				"beer_id": req.ID,
+			})
+			return nil
+		}
+		return beer
+	})
+}
+
+// UpdateBeer handles PUT /beers/:id
+func (h *BeerHandler) UpdateBeer(c *gin.Context) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid beer ID", err, map[string]interface{}{
+			"id_param": idParam,
+		})
+		writeInvalidParam(c, "INVALID_ID", "id", "Beer ID must be a valid integer")
+		return
+	}
+
+	var req primary.UpdateBeerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+			"endpoint": "PUT /beers/:id",
+		})
+		writeBindingError(c, err)
+		return
+	}
+
+	beer, err := h.beerService.UpdateBeer(c.Request.Context(), id, req)
+	if err != nil {
+		h.handleError(c, "Failed to update beer", err)
+		return
+	}
+
+	shaperOf(c).updated(c, beer)
 }
 
 // GetBeer handles GET /beers/:id
 func (h *BeerHandler) GetBeer(c *gin.Context) {
+	format, ok := negotiate(c)
+	if !ok {
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		h.logger.Error(c.Request.Context(), "Invalid beer ID", err, map[string]interface{}{
 			"id_param": idParam,
 		})
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: "Beer ID must be a valid integer",
-		})
+		writeInvalidParam(c, "INVALID_ID", "id", "Beer ID must be a valid integer")
 		return
 	}
 
@@ -81,60 +114,81 @@ func (h *BeerHandler) GetBeer(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, beer)
+	shaperOf(c).beer(c, format, beer, h.caching.catalogueCaching(beer.UpdatedAt))
 }
 
 // GetAllBeers handles GET /beers
 func (h *BeerHandler) GetAllBeers(c *gin.Context) {
+	format, ok := negotiate(c)
+	if !ok {
+		return
+	}
+
+	shaper := shaperOf(c)
+	page, ok := shaper.page(c)
+	if !ok {
+		return
+	}
+
 	beersSlice, err := h.beerService.FindAllBeers(c.Request.Context())
 	if err != nil {
 		h.handleError(c, "Failed to find beers", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, beersSlice)
+	var lastModified time.Time
+	for _, beer := range beersSlice {
+		if beer.UpdatedAt.After(lastModified) {
+			lastModified = beer.UpdatedAt
+		}
+	}
+
+	shaper.beers(c, format, page, beersSlice, h.caching.catalogueCaching(lastModified))
 }
 
 // CalculateBoxPrice handles GET /beers/:id/boxprice
 func (h *BeerHandler) CalculateBoxPrice(c *gin.Context) {
+	format, ok := negotiate(c)
+	if !ok {
+		return
+	}
+
 	idParam := c.Param("id")
 	id, err := strconv.Atoi(idParam)
 	if err != nil {
 		h.logger.Error(c.Request.Context(), "Invalid beer ID", err, map[string]interface{}{
 			"id_param": idParam,
 		})
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_ID",
-			Message: "Beer ID must be a valid integer",
-		})
+		writeInvalidParam(c, "INVALID_ID", "id", "Beer ID must be a valid integer")
 		return
 	}
 
 	quantityParam := c.DefaultQuery("quantity", "1")
 	quantity, err := strconv.Atoi(quantityParam)
 	if err != nil || quantity < 1 {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "INVALID_QUANTITY",
-			Message: "Quantity must be a positive integer",
-		})
+		writeInvalidParam(c, "INVALID_QUANTITY", "quantity", "Quantity must be a positive integer")
 		return
 	}
 
-	currency := c.DefaultQuery("currency", "USD")
+	targetCurrency := c.DefaultQuery("currency", "USD")
 
 	req := primary.CalculateBoxPriceRequest{
-		BeerID:   id,
-		Quantity: quantity,
-		Currency: currency,
+		BeerID:      id,
+		Quantity:    quantity,
+		Currency:    targetCurrency,
+		Destination: c.Query("destination"),
+		Coupon:      c.Query("coupon"),
 	}
 
-	response, err := h.beerService.CalculateBoxPrice(c.Request.Context(), req)
+	// The price may be cached as long as the exchange rates it was priced with
+	ctx, rates := currency.WithRateFreshness(c.Request.Context())
+	response, err := h.beerService.CalculateBoxPrice(ctx, req)
 	if err != nil {
 		h.handleError(c, "Failed to calculate box price", err)
 		return
 	}
 
-	c.JSON(http.StatusOK, response)
+	shaperOf(c).boxPrice(c, format, response, rates, h.caching.boxPriceCaching(rates))
 }
 
 // handleError handles errors and sends appropriate HTTP responses
@@ -143,38 +197,5 @@ func (h *BeerHandler) handleError(c *gin.Context, message string, err error) {
 		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
 	})
 
-	// Check if it's a validation error
-	if validationErr, ok := err.(*beers.ValidationError); ok {
-		c.JSON(http.StatusBadRequest, ErrorResponse{
-			Error:   "VALIDATION_ERROR",
-			Message: validationErr.Error(),
-		})
-		return
-	}
-
-	// Check if it's a domain error
-	if domainErr, ok := err.(*beers.DomainError); ok {
-		statusCode := http.StatusInternalServerError
-
-		switch domainErr.Code {
-		case "BEER_NOT_FOUND":
-			statusCode = http.StatusNotFound
-		case "BEER_ALREADY_EXISTS":
-			statusCode = http.StatusConflict
-		case "INVALID_CURRENCY":
-			statusCode = http.StatusBadRequest
-		}
-
-		c.JSON(statusCode, ErrorResponse{
-			Error:   domainErr.Code,
-			Message: domainErr.Message,
-		})
-		return
-	}
-
-	// Default to internal server error
-	c.JSON(http.StatusInternalServerError, ErrorResponse{
-		Error:   "INTERNAL_ERROR",
-		Message: "An internal error occurred",
-	})
+	writeError(c, err)
 }
@@ -35,6 +35,14 @@ func (m *MockBeerService) CreateBeer(ctx context.Context, req primary.CreateBeer
 	return args.Error(0)
 }
 
+func (m *MockBeerService) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (*beers.Beer, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
 func (m *MockBeerService) FindBeerByID(ctx context.Context, id int) (*beers.Beer, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -155,6 +163,65 @@ func TestGetBeer(t *testing.T) {
 	})
 }
 
+func TestUpdateBeer(t *testing.T) {
+	mockService := new(MockBeerService)
+	log := logger.NewNoOpLogger()
+	handler := NewBeerHandler(mockService, log)
+
+	r := setupRouter()
+	r.PUT("/beers/:id", handler.UpdateBeer)
+
+	reqBody := primary.UpdateBeerRequest{Name: testBeerName, Brewery: "Test", Country: "Test", Price: 2.5, Currency: "USD"}
+	body, _ := json.Marshal(reqBody)
+
+	t.Run("success", func(t *testing.T) {
+		beer := &beers.Beer{ID: 1, Name: testBeerName, Brewery: "Test", Country: "Test", Price: 2.5, Currency: "USD"}
+		mockService.On("UpdateBeer", mock.Anything, 1, reqBody).Return(beer, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPut, "/beers/1", bytes.NewBuffer(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respBeer beers.Beer
+		json.Unmarshal(w.Body.Bytes(), &respBeer)
+		assert.Equal(t, *beer, respBeer)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/beers/abc", bytes.NewBuffer(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPut, "/beers/1", bytes.NewBuffer([]byte("invalid json")))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockService.On("UpdateBeer", mock.Anything, 2, reqBody).
+			Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "not found", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodPut, "/beers/2", bytes.NewBuffer(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockService.AssertExpectations(t)
+	})
+}
+
 func TestGetAllBeers(t *testing.T) {
 	mockService := new(MockBeerService)
 	log := logger.NewNoOpLogger()
@@ -213,6 +280,35 @@ func TestCalculateBoxPrice(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("destination and coupon", func(t *testing.T) {
+		boxPrice := &primary.BoxPriceResponse{TotalPrice: 24.0, Destination: "CL", TotalWithTax: 28.56}
+		mockService.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{
+			BeerID:      1,
+			Quantity:    6,
+			Currency:    "CLP",
+			Destination: "CL",
+			Coupon:      "WELCOME10",
+		}).Return(boxPrice, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/beers/1/boxprice?quantity=6&currency=CLP&destination=CL&coupon=WELCOME10", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("missing tax attributes", func(t *testing.T) {
+		mockService.On("CalculateBoxPrice", mock.Anything, mock.Anything).
+			Return(nil, beers.NewDomainError("TAX_ATTRIBUTES_MISSING", "Tax rule cl-ila requires the beer volume", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/beers/1/boxprice?quantity=6&destination=CL", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	})
+
 	t.Run("invalid quantity", func(t *testing.T) {
 		req, _ := http.NewRequest(http.MethodGet, "/beers/1/boxprice?quantity=abc", nil)
 		w := httptest.NewRecorder()
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ErrCodeRequestTooLarge is the error code of a request body over its route's limit
+const ErrCodeRequestTooLarge = "REQUEST_TOO_LARGE"
+
+// byteUnits maps the unit of a size spec to its multiplier
+var byteUnits = map[string]int64{
+	"":   1,
+	"B":  1,
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+}
+
+// BodyLimits caps request bodies with a default size and per-route overrides keyed
+// by "METHOD /route/:param". A zero size leaves bodies unlimited.
+type BodyLimits struct {
+	Default int64
+	Routes  map[string]int64
+}
+
+// ParseByteSize parses a size spec such as "512", "64KB" or "1MB"
+func ParseByteSize(spec string) (int64, error) {
+	spec = strings.ToUpper(strings.TrimSpace(spec))
+	digits := strings.TrimRight(spec, "BKM")
+
+	multiplier, known := byteUnits[spec[len(digits):]]
+	if !known {
+		return 0, fmt.Errorf("invalid size %q: unit must be B, KB or MB", spec)
+	}
+
+	size, err := strconv.ParseInt(strings.TrimSpace(digits), 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid size %q: expected a non-negative number of bytes", spec)
+	}
+
+	if size > math.MaxInt64/multiplier {
+		return 0, fmt.Errorf("invalid size %q: too large", spec)
+	}
+
+	return size * multiplier, nil
+}
+
+// ParseBodyLimits parses a default size spec and a comma separated list of route
+// overrides such as "POST /api/v1/beers=64KB". An empty default leaves routes
+// without an override unlimited.
+func ParseBodyLimits(defaultSpec, routesSpec string) (*BodyLimits, error) {
+	limits := &BodyLimits{Routes: map[string]int64{}}
+
+	if strings.TrimSpace(defaultSpec) != "" {
+		size, err := ParseByteSize(defaultSpec)
+		if err != nil {
+			return nil, err
+		}
+		limits.Default = size
+	}
+
+	for _, entry := range strings.Split(routesSpec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		route, spec, found := strings.Cut(entry, "=")
+		method, path, hasMethod := strings.Cut(strings.TrimSpace(route), " ")
+		if !found || !hasMethod || strings.TrimSpace(path) == "" {
+			return nil, fmt.Errorf("invalid route body limit %q: expected \"METHOD /path=<size>\"", entry)
+		}
+
+		size, err := ParseByteSize(spec)
+		if err != nil {
+			return nil, err
+		}
+		limits.Routes[bodyLimitKey(method, path)] = size
+	}
+
+	return limits, nil
+}
+
+// LimitFor returns the body size limit of a route, falling back to the default
+func (l *BodyLimits) LimitFor(method, route string) int64 {
+	if size, exists := l.Routes[bodyLimitKey(method, route)]; exists {
+		return size
+	}
+	return l.Default
+}
+
+// bodyLimitKey normalizes a method and route pattern into a limits key
+func bodyLimitKey(method, route string) string {
+	return strings.ToUpper(strings.TrimSpace(method)) + " " + strings.TrimSpace(route)
+}
+
+// BodyLimitMiddleware rejects request bodies over the limit of their route with
+// 413. A declared Content-Length over the limit is refused before the body is
+// read; otherwise reading stops at the limit and the reader reports it.
+func BodyLimitMiddleware(limits *BodyLimits) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		limit := limits.LimitFor(c.Request.Method, route)
+		if limit <= 0 {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > limit {
+			writeProblem(c, requestTooLargeProblem(limit))
+			return
+		}
+
+		if c.Request.Body != nil && c.Request.Body != http.NoBody {
+			c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		}
+		c.Next()
+	}
+}
+
+// requestTooLargeProblem is the 413 problem of a body over a limit of limit bytes
+func requestTooLargeProblem(limit int64) *Problem {
+	return newProblem(http.StatusRequestEntityTooLarge, ErrCodeRequestTooLarge,
+		fmt.Sprintf("Request body exceeds the limit of %d bytes", limit))
+}
+
+// asRequestTooLarge returns the 413 problem of an error caused by a body over its limit
+func asRequestTooLarge(err error) (*Problem, bool) {
+	var maxBytesErr *http.MaxBytesError
+	if !errors.As(err, &maxBytesErr) {
+		return nil, false
+	}
+	return requestTooLargeProblem(maxBytesErr.Limit), true
+}
@@ -0,0 +1,108 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		spec    string
+		size    int64
+		wantErr bool
+	}{
+		{"512", 512, false},
+		{"512B", 512, false},
+		{"64KB", 64 << 10, false},
+		{" 1mb ", 1 << 20, false},
+		{"0", 0, false},
+		{"1GB", 0, true},
+		{"KB", 0, true},
+		{"-1KB", 0, true},
+		{"1.5MB", 0, true},
+		{"9223372036854775807B", 9223372036854775807, false},
+		{"9223372036854775807KB", 0, true},
+		{"8796093022208MB", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			size, err := ParseByteSize(tt.spec)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.size, size)
+		})
+	}
+}
+
+func TestParseBodyLimits(t *testing.T) {
+	limits, err := ParseBodyLimits("1MB", "post /api/v1/beers=16KB, PUT /api/v1/beers/:id=2KB")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(16<<10), limits.LimitFor(http.MethodPost, "/api/v1/beers"))
+	assert.Equal(t, int64(2<<10), limits.LimitFor(http.MethodPut, "/api/v1/beers/:id"))
+	assert.Equal(t, int64(1<<20), limits.LimitFor(http.MethodPost, "/api/v1/quotes"))
+
+	unlimited, err := ParseBodyLimits("", "")
+	require.NoError(t, err)
+	assert.Zero(t, unlimited.LimitFor(http.MethodPost, "/api/v1/beers"))
+
+	for _, routes := range []string{"/api/v1/beers=16KB", "POST /api/v1/beers", "POST /api/v1/beers=lots"} {
+		_, err := ParseBodyLimits("", routes)
+		assert.Error(t, err, routes)
+	}
+}
+
+func TestBodyLimitMiddleware(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithBodyLimits(&BodyLimits{Default: 1 << 20, Routes: map[string]int64{"POST /api/v1/beers": 128}}))
+
+	valid := `{"id": 1, "name": "IPA", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`
+	oversized := `{"id": 1, "name": "` + strings.Repeat("I", 128) + `", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`
+
+	tests := []struct {
+		name   string
+		target string
+		body   io.Reader
+		status int
+	}{
+		{"within the limit", "/api/v1/beers", strings.NewReader(valid), http.StatusCreated},
+		{"declared length over the limit", "/api/v1/beers", strings.NewReader(oversized), http.StatusRequestEntityTooLarge},
+		// Without a Content-Length the body is only found to be too large while it is read
+		{"streamed body over the limit", "/api/v1/beers", io.MultiReader(strings.NewReader(oversized)), http.StatusRequestEntityTooLarge},
+		{"route without an override", "/beers", strings.NewReader(oversized), http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodPost, tt.target, tt.body)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			if tt.status != http.StatusRequestEntityTooLarge {
+				return
+			}
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, ErrCodeRequestTooLarge, problem.Code)
+			assert.Equal(t, "Request body exceeds the limit of 128 bytes", problem.Detail)
+		})
+	}
+}
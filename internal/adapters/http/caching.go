@@ -0,0 +1,111 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/currency"
+)
+
+// noCache lets clients store a response but makes them revalidate it before reuse
+const noCache = "private, no-cache"
+
+// CachePolicy sets how clients may cache beers and box prices
+type CachePolicy struct {
+	// CacheControl is sent with beers and beer lists, which clients revalidate
+	// with their ETag or Last-Modified
+	CacheControl string
+	// BoxPriceMaxAge caps how long a box price may be reused. It is never reused
+	// past the expiry of the exchange rates it was priced with.
+	BoxPriceMaxAge time.Duration
+}
+
+// cacheHeaders are the caching headers of one response. Its strong ETag is
+// derived from the response body.
+type cacheHeaders struct {
+	cacheControl string
+	lastModified time.Time
+}
+
+// catalogueCaching returns the caching headers of beers last changed at
+// lastModified, or nil without a cache policy
+func (p *CachePolicy) catalogueCaching(lastModified time.Time) *cacheHeaders {
+	if p == nil {
+		return nil
+	}
+
+	return &cacheHeaders{cacheControl: p.CacheControl, lastModified: lastModified}
+}
+
+// boxPriceCaching returns the caching headers of a box price priced with the
+// rates observed by freshness, or nil without a cache policy
+func (p *CachePolicy) boxPriceCaching(freshness *currency.RateFreshness) *cacheHeaders {
+	if p == nil {
+		return nil
+	}
+
+	maxAge := p.BoxPriceMaxAge
+	if _, expiresAt, ok := freshness.Observed(); ok {
+		if untilExpiry := time.Until(expiresAt); untilExpiry < maxAge {
+			maxAge = untilExpiry
+		}
+	}
+
+	seconds := int(maxAge / time.Second)
+	if seconds <= 0 {
+		return &cacheHeaders{cacheControl: noCache}
+	}
+	return &cacheHeaders{cacheControl: fmt.Sprintf("private, max-age=%d", seconds)}
+}
+
+// write sends the validators and Cache-Control of a response body, and reports
+// whether the preconditions of the request show the client already holds it
+func (h *cacheHeaders) write(c *gin.Context, body []byte) (notModified bool) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	header := c.Writer.Header()
+	header.Set("ETag", etag)
+	if !h.lastModified.IsZero() {
+		header.Set("Last-Modified", h.lastModified.UTC().Format(http.TimeFormat))
+	}
+	if h.cacheControl != "" {
+		header.Set("Cache-Control", h.cacheControl)
+	}
+
+	return isNotModified(c.Request, etag, h.lastModified)
+}
+
+// isNotModified evaluates If-None-Match or, when it is absent, If-Modified-Since
+// as RFC 9110 orders them for GET requests
+func isNotModified(req *http.Request, etag string, lastModified time.Time) bool {
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		for _, candidate := range strings.Split(ifNoneMatch, ",") {
+			candidate = strings.TrimSpace(candidate)
+			// If-None-Match uses the weak comparison
+			if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	ifModifiedSince := req.Header.Get("If-Modified-Since")
+	if ifModifiedSince == "" || lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(ifModifiedSince)
+	if err != nil {
+		return false
+	}
+
+	// HTTP dates have a resolution of a second
+	return !lastModified.Truncate(time.Second).After(since)
+}
@@ -0,0 +1,161 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+var testCachePolicy = CachePolicy{CacheControl: "private, no-cache", BoxPriceMaxAge: time.Minute}
+
+func newCachingRouter(service primary.BeerService, policy *CachePolicy) *gin.Engine {
+	handler := NewBeerHandler(service, logger.NewNoOpLogger())
+	handler.caching = policy
+
+	r := setupRouter()
+	r.GET(beersEndpoint, handler.GetAllBeers)
+	r.GET("/beers/:id", handler.GetBeer)
+	r.GET("/beers/:id/boxprice", handler.CalculateBoxPrice)
+	return r
+}
+
+func getWithHeaders(r *gin.Engine, target string, headers map[string]string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, target, nil)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestConditionalBeerRequests(t *testing.T) {
+	older := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	newer := time.Date(2024, 2, 1, 8, 0, 0, 500, time.UTC)
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return([]beers.Beer{
+		{ID: 1, Name: testBeerName, UpdatedAt: newer},
+		{ID: 2, Name: "Other", UpdatedAt: older},
+	}, nil)
+	r := newCachingRouter(mockService, &testCachePolicy)
+
+	first := getWithHeaders(r, beersEndpoint, nil)
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, etag)
+	assert.Equal(t, "Thu, 01 Feb 2024 08:00:00 GMT", first.Header().Get("Last-Modified"))
+	assert.Equal(t, "private, no-cache", first.Header().Get("Cache-Control"))
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		status  int
+	}{
+		{"matching etag", map[string]string{"If-None-Match": etag}, http.StatusNotModified},
+		{"one of several etags", map[string]string{"If-None-Match": `"stale", ` + etag}, http.StatusNotModified},
+		{"weak comparison", map[string]string{"If-None-Match": "W/" + etag}, http.StatusNotModified},
+		{"any etag", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+		{"stale etag", map[string]string{"If-None-Match": `"stale"`}, http.StatusOK},
+		{"unchanged since", map[string]string{"If-Modified-Since": "Thu, 01 Feb 2024 08:00:00 GMT"}, http.StatusNotModified},
+		{"changed since", map[string]string{"If-Modified-Since": "Wed, 31 Jan 2024 08:00:00 GMT"}, http.StatusOK},
+		{"invalid date", map[string]string{"If-Modified-Since": "yesterday"}, http.StatusOK},
+		{"etag takes precedence over date", map[string]string{
+			"If-None-Match":     `"stale"`,
+			"If-Modified-Since": "Thu, 01 Feb 2024 08:00:00 GMT",
+		}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getWithHeaders(r, beersEndpoint, tt.headers)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, etag, w.Header().Get("ETag"))
+			if tt.status == http.StatusNotModified {
+				assert.Empty(t, w.Body.String())
+			} else {
+				assert.Equal(t, first.Body.String(), w.Body.String())
+			}
+		})
+	}
+
+	t.Run("formats have their own etag", func(t *testing.T) {
+		w := getWithHeaders(r, beersEndpoint+"?format=csv", map[string]string{"If-None-Match": etag})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	})
+}
+
+func TestConditionalBeerRequestSeesChanges(t *testing.T) {
+	updated := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	mockService := new(MockBeerService)
+	mockService.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Price: 2.5, UpdatedAt: updated}, nil).Once()
+	mockService.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Price: 3, UpdatedAt: updated.Add(time.Hour)}, nil).Once()
+	r := newCachingRouter(mockService, &testCachePolicy)
+
+	first := getWithHeaders(r, "/beers/1", nil)
+	w := getWithHeaders(r, "/beers/1", map[string]string{"If-None-Match": first.Header().Get("ETag")})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"price":3`)
+	assert.Equal(t, "Mon, 15 Jan 2024 11:30:00 GMT", w.Header().Get("Last-Modified"))
+}
+
+func TestBoxPriceCacheFollowsRateFreshness(t *testing.T) {
+	tests := []struct {
+		name         string
+		rateExpiry   time.Duration
+		observe      bool
+		cacheControl string
+	}{
+		{"same currency", 0, false, "private, max-age=60"},
+		{"rate outlives the max age", 10 * time.Minute, true, "private, max-age=60"},
+		{"rate expires first", 20*time.Second + 500*time.Millisecond, true, "private, max-age=20"},
+		{"rate expiring now", 0, true, "private, no-cache"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockService := new(MockBeerService)
+			mockService.On("CalculateBoxPrice", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if tt.observe {
+					now := time.Now()
+					currency.ObserveRate(args.Get(0).(context.Context), now, now.Add(tt.rateExpiry))
+				}
+			}).Return(&primary.BoxPriceResponse{BeerID: 1, Quantity: 6, TotalPrice: 18, Currency: "USD"}, nil)
+			r := newCachingRouter(mockService, &testCachePolicy)
+
+			w := getWithHeaders(r, "/beers/1/boxprice?quantity=6", nil)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.cacheControl, w.Header().Get("Cache-Control"))
+			assert.NotEmpty(t, w.Header().Get("ETag"))
+			assert.Empty(t, w.Header().Get("Last-Modified"))
+		})
+	}
+}
+
+func TestNoCacheHeadersWithoutPolicy(t *testing.T) {
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return([]beers.Beer{{ID: 1, UpdatedAt: time.Now()}}, nil)
+	r := newCachingRouter(mockService, nil)
+
+	w := getWithHeaders(r, beersEndpoint, map[string]string{"If-None-Match": "*"})
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("ETag"))
+	assert.Empty(t, w.Header().Get("Cache-Control"))
+}
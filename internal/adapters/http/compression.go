@@ -0,0 +1,227 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+)
+
+// encoder compresses a response body. Encoders are pooled and reset for every response.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// contentEncoding is a compression a client may ask for in Accept-Encoding
+type contentEncoding struct {
+	name     string
+	encoders *sync.Pool
+}
+
+// contentEncodings are the supported compressions, preferred in this order when a
+// client accepts several equally. Responses are compressed on the fly, so the
+// levels favour speed over ratio.
+var contentEncodings = []contentEncoding{
+	{name: "br", encoders: &sync.Pool{New: func() interface{} {
+		return brotli.NewWriterLevel(nil, 4)
+	}}},
+	{name: "gzip", encoders: &sync.Pool{New: func() interface{} {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	}}},
+}
+
+// CompressionMiddleware compresses response bodies of at least minSize bytes with
+// the best encoding the client accepts. Event streams and WebSocket upgrades are
+// never compressed, and neither are responses that already have an encoding.
+func CompressionMiddleware(minSize int) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.Method == http.MethodHead || c.GetHeader("Upgrade") != "" {
+			c.Next()
+			return
+		}
+
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+
+		encoding, ok := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if !ok {
+			c.Next()
+			return
+		}
+
+		writer := &compressingWriter{ResponseWriter: c.Writer, encoding: encoding, minSize: minSize}
+		c.Writer = writer
+		defer func() {
+			writer.finish()
+			c.Writer = writer.ResponseWriter
+		}()
+
+		c.Next()
+	}
+}
+
+// negotiateEncoding picks the supported encoding with the highest quality in an
+// Accept-Encoding header. An encoding not named takes the quality of "*", if any.
+func negotiateEncoding(acceptEncoding string) (contentEncoding, bool) {
+	qualities := make(map[string]float64)
+	for _, entry := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(entry, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if key, value, found := strings.Cut(strings.TrimSpace(params), "="); found && strings.TrimSpace(key) == "q" {
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				continue
+			}
+			quality = parsed
+		}
+		qualities[name] = quality
+	}
+
+	var best contentEncoding
+	bestQuality := 0.0
+	for _, encoding := range contentEncodings {
+		quality, named := qualities[encoding.name]
+		if !named {
+			quality = qualities["*"]
+		}
+		if quality > bestQuality {
+			best, bestQuality = encoding, quality
+		}
+	}
+
+	return best, bestQuality > 0
+}
+
+// compressingWriter holds the body back until minSize bytes are written or the
+// response is flushed or finished, then decides whether to compress it. Headers
+// are still open until then.
+type compressingWriter struct {
+	gin.ResponseWriter
+	encoding contentEncoding
+	minSize  int
+	buffer   bytes.Buffer
+	decided  bool
+	encoder  encoder
+}
+
+func (w *compressingWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer.Write(data)
+		if w.buffer.Len() < w.minSize {
+			return len(data), nil
+		}
+		if err := w.decide(); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// WriteHeaderNow commits to the body written so far, which is below the minimum size
+func (w *compressingWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+// Flush sends what is held back. A response flushed before reaching the minimum
+// size is a stream and is left uncompressed.
+func (w *compressingWriter) Flush() {
+	if !w.decided {
+		_ = w.decide()
+	}
+	if w.encoder != nil {
+		_ = w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide sets the headers of the response and writes out the held back body,
+// compressed if it is worth it
+func (w *compressingWriter) decide() error {
+	w.decided = true
+
+	header := w.Header()
+	// A strong ETag promises byte-for-byte identical bodies, which differ by
+	// encoding. Weak ETags still match in If-None-Match.
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		header.Set("ETag", "W/"+etag)
+	}
+
+	if w.compressible() {
+		header.Set("Content-Encoding", w.encoding.name)
+		header.Del("Content-Length")
+
+		w.encoder = w.encoding.encoders.Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	body := w.buffer.Bytes()
+	w.buffer = bytes.Buffer{}
+	if len(body) == 0 {
+		return nil
+	}
+
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(body)
+	} else {
+		_, err = w.ResponseWriter.Write(body)
+	}
+	return err
+}
+
+// compressible reports whether the held back response should be compressed
+func (w *compressingWriter) compressible() bool {
+	if w.buffer.Len() < w.minSize {
+		return false
+	}
+
+	status := w.Status()
+	if status < http.StatusOK || status == http.StatusNoContent || status == http.StatusNotModified {
+		return false
+	}
+
+	header := w.Header()
+	if header.Get("Content-Encoding") != "" {
+		return false
+	}
+
+	return !strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+}
+
+// finish writes out a response that was never flushed and returns the encoder to its pool
+func (w *compressingWriter) finish() {
+	if !w.decided {
+		_ = w.decide()
+	}
+
+	if w.encoder != nil {
+		_ = w.encoder.Close()
+		w.encoding.encoders.Put(w.encoder)
+		w.encoder = nil
+	}
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+var largeBody = strings.Repeat(`{"name":"IPA","brewery":"Craft"},`, 64)
+
+func newCompressionRouter() *gin.Engine {
+	r := setupRouter()
+	r.Use(CompressionMiddleware(256))
+	r.GET("/large", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.Data(http.StatusOK, "application/json", []byte(largeBody))
+	})
+	r.GET("/small", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(`{"name":"IPA"}`))
+	})
+	r.GET("/encoded", func(c *gin.Context) {
+		c.Header("Content-Encoding", "identity")
+		c.Data(http.StatusOK, "application/json", []byte(largeBody))
+	})
+	r.GET("/stream", func(c *gin.Context) {
+		c.Header("Content-Type", "text/event-stream")
+		c.Status(http.StatusOK)
+		c.Writer.Flush()
+		_, _ = c.Writer.WriteString("data: " + largeBody + "\n\n")
+	})
+	r.GET("/not-modified", func(c *gin.Context) {
+		c.Header("ETag", `"abc"`)
+		c.Status(http.StatusNotModified)
+	})
+	return r
+}
+
+func decompress(t *testing.T, encoding string, body io.Reader) string {
+	var reader io.Reader
+	switch encoding {
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		require.NoError(t, err)
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(body)
+	default:
+		reader = body
+	}
+
+	data, err := io.ReadAll(reader)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		acceptEncoding string
+		encoding       string
+		ok             bool
+	}{
+		{"", "", false},
+		{"gzip", "gzip", true},
+		{"gzip, deflate, br", "br", true},
+		{"br;q=0.5, gzip", "gzip", true},
+		{"GZIP", "gzip", true},
+		{"br;q=0, gzip;q=0", "", false},
+		{"*", "br", true},
+		{"*;q=0.1, gzip;q=0.5", "gzip", true},
+		{"br;q=0, *", "gzip", true},
+		{"deflate, identity", "", false},
+		{"gzip;q=bad", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			encoding, ok := negotiateEncoding(tt.acceptEncoding)
+
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.encoding, encoding.name)
+		})
+	}
+}
+
+func TestCompressionMiddleware(t *testing.T) {
+	r := newCompressionRouter()
+
+	tests := []struct {
+		name           string
+		target         string
+		acceptEncoding string
+		encoding       string
+	}{
+		{"gzip", "/large", "gzip", "gzip"},
+		{"brotli", "/large", "gzip, br", "br"},
+		{"no accepted encoding", "/large", "", ""},
+		{"below the minimum size", "/small", "gzip", ""},
+		{"already encoded", "/encoded", "gzip", "identity"},
+		{"event stream", "/stream", "gzip", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getWithHeaders(r, tt.target, map[string]string{"Accept-Encoding": tt.acceptEncoding})
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tt.encoding, w.Header().Get("Content-Encoding"))
+			assert.Contains(t, w.Header().Values("Vary"), "Accept-Encoding")
+			assert.Contains(t, decompress(t, tt.encoding, w.Body), `{"name":"IPA"`)
+		})
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		w := getWithHeaders(r, "/large", map[string]string{"Accept-Encoding": "gzip"})
+
+		assert.Less(t, w.Body.Len(), len(largeBody))
+		assert.Equal(t, largeBody, decompress(t, "gzip", w.Body))
+	})
+
+	t.Run("etag is weakened", func(t *testing.T) {
+		compressed := getWithHeaders(r, "/large", map[string]string{"Accept-Encoding": "br"})
+		notModified := getWithHeaders(r, "/not-modified", map[string]string{"Accept-Encoding": "br"})
+		identity := getWithHeaders(r, "/large", nil)
+
+		assert.Equal(t, `W/"abc"`, compressed.Header().Get("ETag"))
+		assert.Equal(t, http.StatusNotModified, notModified.Code)
+		assert.Equal(t, `W/"abc"`, notModified.Header().Get("ETag"))
+		assert.Empty(t, notModified.Header().Get("Content-Encoding"))
+		assert.Equal(t, `"abc"`, identity.Header().Get("ETag"))
+	})
+
+	t.Run("websocket upgrade", func(t *testing.T) {
+		w := getWithHeaders(r, "/large", map[string]string{"Accept-Encoding": "gzip", "Upgrade": "websocket"})
+
+		assert.Empty(t, w.Header().Get("Content-Encoding"))
+		assert.Empty(t, w.Header().Values("Vary"))
+	})
+}
+
+func TestCompressedConditionalRequest(t *testing.T) {
+	catalogue := make([]beers.Beer, 0, 20)
+	for id := 1; id <= 20; id++ {
+		catalogue = append(catalogue, beers.Beer{ID: id, Name: testBeerName, UpdatedAt: time.Now()})
+	}
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return(catalogue, nil)
+
+	handler := NewBeerHandler(mockService, logger.NewNoOpLogger())
+	handler.caching = &testCachePolicy
+	r := setupRouter()
+	r.Use(CompressionMiddleware(256))
+	r.GET(beersEndpoint, handler.GetAllBeers)
+
+	first := getWithHeaders(r, beersEndpoint, map[string]string{"Accept-Encoding": "gzip"})
+	require.Equal(t, "gzip", first.Header().Get("Content-Encoding"))
+
+	w := getWithHeaders(r, beersEndpoint, map[string]string{
+		"Accept-Encoding": "gzip",
+		"If-None-Match":   first.Header().Get("ETag"),
+	})
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, first.Header().Get("ETag"), w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+}
@@ -0,0 +1,190 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+)
+
+// Page sizes of enveloped beer lists
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// Envelope is the body of every successful API v2 beer response: the resource
+// under data, facts about it under meta and related routes under links
+type Envelope struct {
+	Data  interface{}   `json:"data"`
+	Meta  EnvelopeMeta  `json:"meta"`
+	Links EnvelopeLinks `json:"links"`
+}
+
+// EnvelopeMeta holds the pagination of lists and the freshness of the exchange
+// rates a price was converted with; each is left out where it does not apply
+type EnvelopeMeta struct {
+	*PageMeta
+	Rates *RateFreshnessMeta `json:"rates,omitempty"`
+}
+
+// PageMeta places a page in its list
+type PageMeta struct {
+	Total   int `json:"total"`
+	Page    int `json:"page"`
+	PerPage int `json:"per_page"`
+}
+
+// RateFreshnessMeta tells when the oldest exchange rate behind a price was
+// fetched and when the first of them expires
+type RateFreshnessMeta struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// EnvelopeLinks are the routes related to a response
+type EnvelopeLinks struct {
+	Self     string `json:"self"`
+	Next     string `json:"next,omitempty"`
+	BoxPrice string `json:"boxprice,omitempty"`
+}
+
+// envelopeXMLNames names the root element of enveloped XML responses
+var envelopeXMLNames = xmlNames{root: "response"}
+
+// envelopeShaper serves envelopes with links under prefix and beer lists a page
+// at a time in ID order
+type envelopeShaper struct {
+	prefix string
+}
+
+// respond sends an envelope in the negotiated format. A CSV table has no room
+// for meta and links, so CSV responses carry the data alone.
+func (envelopeShaper) respond(c *gin.Context, format responseFormat, status int, envelope Envelope, caching *cacheHeaders) {
+	if format.name == formatCSV.name {
+		respond(c, format, status, envelopeXMLNames, envelope.Data, caching)
+		return
+	}
+
+	respond(c, format, status, envelopeXMLNames, envelope, caching)
+}
+
+// page reads the page and per_page query parameters
+func (envelopeShaper) page(c *gin.Context) (listPage, bool) {
+	number, ok := positiveQuery(c, "page", 1, "INVALID_PAGE", "Page must be a positive integer")
+	if !ok {
+		return listPage{}, false
+	}
+
+	perPageDetail := fmt.Sprintf("Page size must be an integer from 1 to %d", maxPageSize)
+	size, ok := positiveQuery(c, "per_page", defaultPageSize, "INVALID_PER_PAGE", perPageDetail)
+	if !ok {
+		return listPage{}, false
+	}
+	if size > maxPageSize {
+		writeInvalidParam(c, "INVALID_PER_PAGE", "per_page", perPageDetail)
+		return listPage{}, false
+	}
+
+	return listPage{number: number, size: size}, true
+}
+
+// created answers with the created beer and a Location header. The beer exists
+// now; failing to read it back leaves data empty rather than reporting a failed
+// creation.
+func (e envelopeShaper) created(c *gin.Context, id int, load func() *beers.Beer) {
+	self := e.beerPath(id)
+	c.Header("Location", self)
+
+	var data interface{}
+	if beer := load(); beer != nil {
+		data = beer
+	}
+
+	e.respond(c, formatJSON, http.StatusCreated, Envelope{
+		Data:  data,
+		Links: EnvelopeLinks{Self: self, BoxPrice: self + "/boxprice"},
+	}, nil)
+}
+
+// updated wraps the updated beer like any other
+func (e envelopeShaper) updated(c *gin.Context, beer *beers.Beer) {
+	e.beer(c, formatJSON, beer, nil)
+}
+
+// beer wraps a beer with links to itself and its box price
+func (e envelopeShaper) beer(c *gin.Context, format responseFormat, beer *beers.Beer, caching *cacheHeaders) {
+	self := e.beerPath(beer.ID)
+	e.respond(c, format, http.StatusOK, Envelope{
+		Data:  beer,
+		Links: EnvelopeLinks{Self: self, BoxPrice: self + "/boxprice"},
+	}, caching)
+}
+
+// beers serves one page of the catalogue; pages are only stable over a stable
+// order. The beers are sorted in a copy, as the service may share its slice.
+func (e envelopeShaper) beers(c *gin.Context, format responseFormat, page listPage, catalogue []beers.Beer, caching *cacheHeaders) {
+	all := append([]beers.Beer{}, catalogue...)
+	sort.Slice(all, func(i, j int) bool { return all[i].ID < all[j].ID })
+
+	total := len(all)
+	start, end := min((page.number-1)*page.size, total), min(page.number*page.size, total)
+
+	links := EnvelopeLinks{Self: e.pagePath(page.number, page.size)}
+	if end < total {
+		links.Next = e.pagePath(page.number+1, page.size)
+	}
+
+	e.respond(c, format, http.StatusOK, Envelope{
+		Data:  all[start:end],
+		Meta:  EnvelopeMeta{PageMeta: &PageMeta{Total: total, Page: page.number, PerPage: page.size}},
+		Links: links,
+	}, caching)
+}
+
+// boxPrice reports the freshness of the exchange rates the price was converted with
+func (e envelopeShaper) boxPrice(c *gin.Context, format responseFormat, price *primary.BoxPriceResponse, rates *currency.RateFreshness, caching *cacheHeaders) {
+	envelope := Envelope{Data: price, Links: EnvelopeLinks{Self: c.Request.URL.RequestURI()}}
+	if fetchedAt, expiresAt, ok := rates.Observed(); ok {
+		envelope.Meta.Rates = &RateFreshnessMeta{FetchedAt: fetchedAt.UTC(), ExpiresAt: expiresAt.UTC()}
+	}
+
+	e.respond(c, format, http.StatusOK, envelope, caching)
+}
+
+// beerPath is the route of a beer
+func (e envelopeShaper) beerPath(id int) string {
+	return e.prefix + BeersPath + "/" + strconv.Itoa(id)
+}
+
+// pagePath is the route of a page of beers
+func (e envelopeShaper) pagePath(page, perPage int) string {
+	query := url.Values{}
+	query.Set("page", strconv.Itoa(page))
+	query.Set("per_page", strconv.Itoa(perPage))
+	return e.prefix + BeersPath + "?" + query.Encode()
+}
+
+// positiveQuery parses a positive integer query parameter, sending a 400
+// problem with code and detail when it is not one
+func positiveQuery(c *gin.Context, name string, defaultValue int, code, detail string) (int, bool) {
+	param, ok := c.GetQuery(name)
+	if !ok {
+		return defaultValue, true
+	}
+
+	value, err := strconv.Atoi(param)
+	if err != nil || value < 1 {
+		writeInvalidParam(c, code, name, detail)
+		return 0, false
+	}
+	return value, true
+}
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+const beersV2Endpoint = "/api/v2/beers"
+
+// envelopeOf decodes an API v2 response, keeping data raw for the caller to decode
+type envelopeOf struct {
+	Data  json.RawMessage `json:"data"`
+	Meta  map[string]interface{}
+	Links map[string]string
+}
+
+// setupV2Router serves the beer handlers with the API v2 envelope shaper
+func setupV2Router(service primary.BeerService) *gin.Engine {
+	handler := NewBeerHandler(service, logger.NewNoOpLogger())
+	r := setupRouter()
+	v2 := r.Group("", shapeResponses(envelopeShaper{prefix: APIv2Prefix}))
+	v2.POST(beersV2Endpoint, handler.CreateBeer)
+	v2.GET(beersV2Endpoint, handler.GetAllBeers)
+	v2.GET(beersV2Endpoint+"/:id", handler.GetBeer)
+	v2.PUT(beersV2Endpoint+"/:id", handler.UpdateBeer)
+	v2.GET(beersV2Endpoint+"/:id/boxprice", handler.CalculateBoxPrice)
+	return r
+}
+
+func decodeEnvelope(t *testing.T, w *httptest.ResponseRecorder) envelopeOf {
+	var envelope envelopeOf
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &envelope))
+	return envelope
+}
+
+func TestGetAllBeersV2(t *testing.T) {
+	// The service returns beers in no particular order
+	catalogue := []beers.Beer{{ID: 3, Name: "Stout"}, {ID: 1, Name: "IPA"}, {ID: 5, Name: "Lager"}, {ID: 2, Name: "Pils"}, {ID: 4, Name: "Bock"}}
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return(catalogue, nil)
+	r := setupV2Router(mockService)
+
+	tests := []struct {
+		name  string
+		query string
+		ids   []int
+		page  float64
+		self  string
+		next  string
+	}{
+		{"first page", "?per_page=2", []int{1, 2}, 1, "/api/v2/beers?page=1&per_page=2", "/api/v2/beers?page=2&per_page=2"},
+		{"last page", "?page=3&per_page=2", []int{5}, 3, "/api/v2/beers?page=3&per_page=2", ""},
+		{"past the end", "?page=4&per_page=2", []int{}, 4, "/api/v2/beers?page=4&per_page=2", ""},
+		{"default page size", "", []int{1, 2, 3, 4, 5}, 1, "/api/v2/beers?page=1&per_page=20", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getWithHeaders(r, beersV2Endpoint+tt.query, nil)
+			require.Equal(t, http.StatusOK, w.Code)
+
+			envelope := decodeEnvelope(t, w)
+			var page []beers.Beer
+			require.NoError(t, json.Unmarshal(envelope.Data, &page))
+			ids := make([]int, 0, len(page))
+			for _, beer := range page {
+				ids = append(ids, beer.ID)
+			}
+
+			assert.Equal(t, tt.ids, ids)
+			assert.Equal(t, 5.0, envelope.Meta["total"])
+			assert.Equal(t, tt.page, envelope.Meta["page"])
+			assert.Equal(t, tt.self, envelope.Links["self"])
+			assert.Equal(t, tt.next, envelope.Links["next"])
+		})
+	}
+
+	for _, query := range []string{"?page=0", "?page=first", "?per_page=101", "?per_page=0"} {
+		t.Run("invalid "+query, func(t *testing.T) {
+			w := getWithHeaders(r, beersV2Endpoint+query, nil)
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+
+	t.Run("service slice left in its order", func(t *testing.T) {
+		shared := []beers.Beer{{ID: 2}, {ID: 1}}
+		sharedService := new(MockBeerService)
+		sharedService.On("FindAllBeers", mock.Anything).Return(shared, nil)
+
+		w := getWithHeaders(setupV2Router(sharedService), beersV2Endpoint, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []beers.Beer{{ID: 2}, {ID: 1}}, shared)
+	})
+
+	t.Run("empty catalogue", func(t *testing.T) {
+		emptyService := new(MockBeerService)
+		emptyService.On("FindAllBeers", mock.Anything).Return([]beers.Beer(nil), nil)
+
+		w := getWithHeaders(setupV2Router(emptyService), beersV2Endpoint, nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `[]`, string(decodeEnvelope(t, w).Data))
+	})
+}
+
+func TestGetBeerV2(t *testing.T) {
+	mockService := new(MockBeerService)
+	mockService.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Name: testBeerName}, nil)
+	mockService.On("FindBeerByID", mock.Anything, 99).
+		Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 99 not found", nil))
+	r := setupV2Router(mockService)
+
+	w := getWithHeaders(r, beersV2Endpoint+"/1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	envelope := decodeEnvelope(t, w)
+	var beer beers.Beer
+	require.NoError(t, json.Unmarshal(envelope.Data, &beer))
+	assert.Equal(t, testBeerName, beer.Name)
+	assert.Empty(t, envelope.Meta)
+	assert.Equal(t, map[string]string{"self": "/api/v2/beers/1", "boxprice": "/api/v2/beers/1/boxprice"}, envelope.Links)
+
+	// Problems are not enveloped
+	w = getWithHeaders(r, beersV2Endpoint+"/99", nil)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problemContentType, w.Header().Get(contentTypeHeader))
+}
+
+func TestBeersV2ContentNegotiation(t *testing.T) {
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return([]beers.Beer{{ID: 2, Name: "Pils"}, {ID: 1, Name: "IPA"}}, nil)
+	mockService.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Name: "IPA"}, nil)
+	r := setupV2Router(mockService)
+
+	t.Run("csv carries the page of data", func(t *testing.T) {
+		w := getWithHeaders(r, beersV2Endpoint, map[string]string{"Accept": "text/csv"})
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/csv; charset=utf-8", w.Header().Get(contentTypeHeader))
+		assert.Contains(t, w.Header().Values("Vary"), "Accept")
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		require.Len(t, lines, 3)
+		assert.True(t, strings.HasPrefix(lines[0], "id,name,"))
+		assert.True(t, strings.HasPrefix(lines[1], "1,IPA,"))
+	})
+
+	t.Run("xml keeps the envelope", func(t *testing.T) {
+		w := getWithHeaders(r, beersV2Endpoint+"/1?format=xml", nil)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "<response><data><id>1</id>")
+		assert.Contains(t, w.Body.String(), "<self>/api/v2/beers/1</self>")
+	})
+
+	t.Run("unsupported type", func(t *testing.T) {
+		w := getWithHeaders(r, beersV2Endpoint+"/1", map[string]string{"Accept": "application/pdf"})
+
+		assert.Equal(t, http.StatusNotAcceptable, w.Code)
+		assert.Equal(t, problemContentType, w.Header().Get(contentTypeHeader))
+		assert.Contains(t, w.Header().Values("Vary"), "Accept")
+	})
+}
+
+func TestCreateBeerV2(t *testing.T) {
+	created := &beers.Beer{ID: 7, Name: testBeerName, Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD"}
+	mockService := new(MockBeerService)
+	mockService.On("CreateBeer", mock.Anything, mock.Anything).Return(nil)
+	mockService.On("FindBeerByID", mock.Anything, 7).Return(created, nil)
+	r := setupV2Router(mockService)
+
+	body, _ := json.Marshal(primary.CreateBeerRequest{ID: 7, Name: testBeerName, Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD"})
+	req, _ := http.NewRequest(http.MethodPost, beersV2Endpoint, bytes.NewBuffer(body))
+	req.Header.Set(contentTypeHeader, jsonContentType)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "/api/v2/beers/7", w.Header().Get("Location"))
+
+	envelope := decodeEnvelope(t, w)
+	var beer beers.Beer
+	require.NoError(t, json.Unmarshal(envelope.Data, &beer))
+	assert.Equal(t, *created, beer)
+	assert.Equal(t, "/api/v2/beers/7/boxprice", envelope.Links["boxprice"])
+}
+
+func TestCalculateBoxPriceV2(t *testing.T) {
+	fetchedAt := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	mockService := new(MockBeerService)
+	mockService.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{BeerID: 1, Quantity: 6, Currency: "EUR"}).
+		Run(func(args mock.Arguments) {
+			currency.ObserveRate(args.Get(0).(context.Context), fetchedAt, fetchedAt.Add(5*time.Minute))
+		}).
+		Return(&primary.BoxPriceResponse{TotalPrice: 24.0}, nil)
+	mockService.On("CalculateBoxPrice", mock.Anything, primary.CalculateBoxPriceRequest{BeerID: 1, Quantity: 6, Currency: "USD"}).
+		Return(&primary.BoxPriceResponse{TotalPrice: 20.0}, nil)
+	r := setupV2Router(mockService)
+
+	w := getWithHeaders(r, beersV2Endpoint+"/1/boxprice?quantity=6&currency=EUR", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	envelope := decodeEnvelope(t, w)
+	var price primary.BoxPriceResponse
+	require.NoError(t, json.Unmarshal(envelope.Data, &price))
+	assert.Equal(t, 24.0, price.TotalPrice)
+	assert.Equal(t, map[string]interface{}{
+		"fetched_at": "2024-01-15T10:30:00Z",
+		"expires_at": "2024-01-15T10:35:00Z",
+	}, envelope.Meta["rates"])
+	assert.Equal(t, "/api/v2/beers/1/boxprice?quantity=6&currency=EUR", envelope.Links["self"])
+
+	// Without a conversion no rate was used
+	w = getWithHeaders(r, beersV2Endpoint+"/1/boxprice?quantity=6", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, decodeEnvelope(t, w).Meta, "rates")
+
+	w = getWithHeaders(r, beersV2Endpoint+"/1/boxprice?quantity=0", nil)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestVersionsShareBeerHandlers(t *testing.T) {
+	mockService := new(MockBeerService)
+	mockService.On("FindBeerByID", mock.Anything, 1).Return(&beers.Beer{ID: 1, Name: testBeerName}, nil)
+	server := NewServer(mockService, config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	w := getWithHeaders(server.router, APIPrefix+BeersPath+"/1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	var beer beers.Beer
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &beer))
+	assert.Equal(t, testBeerName, beer.Name)
+
+	w = getWithHeaders(server.router, beersV2Endpoint+"/1", nil)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.NoError(t, json.Unmarshal(decodeEnvelope(t, w).Data, &beer))
+	assert.Equal(t, testBeerName, beer.Name)
+	mockService.AssertNumberOfCalls(t, "FindBeerByID", 2)
+}
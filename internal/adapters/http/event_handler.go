@@ -0,0 +1,234 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// EventsPath is the path of the catalogue event stream
+const EventsPath = "/events"
+
+// eventStreamRetry is how long browsers wait before reconnecting to the stream
+const eventStreamRetry = 3 * time.Second
+
+// EventHandler streams catalogue events as Server-Sent Events and, optionally,
+// over WebSocket
+type EventHandler struct {
+	eventService primary.EventService
+	keepAlive    time.Duration
+	upgrader     *websocket.Upgrader
+	done         chan struct{}
+	closeOnce    sync.Once
+	logger       secondary.Logger
+}
+
+// NewEventHandler creates an event handler sending a keep-alive every
+// keepAlive while no events flow. The WebSocket endpoint is served only with websockets.
+func NewEventHandler(eventService primary.EventService, keepAlive time.Duration, websockets bool, logger secondary.Logger) *EventHandler {
+	handler := &EventHandler{
+		eventService: eventService,
+		keepAlive:    keepAlive,
+		done:         make(chan struct{}),
+		logger:       logger,
+	}
+
+	if websockets {
+		// Origins are left to CORS and authentication like the rest of the API
+		handler.upgrader = &websocket.Upgrader{
+			HandshakeTimeout: 10 * time.Second,
+			CheckOrigin:      func(r *http.Request) bool { return true },
+		}
+	}
+
+	return handler
+}
+
+// Close ends every open stream, so the server can shut down without waiting for clients
+func (h *EventHandler) Close() {
+	h.closeOnce.Do(func() { close(h.done) })
+}
+
+// StreamEvents handles GET /events. Clients resume with the Last-Event-ID
+// header, which browsers send when they reconnect, or the last_event_id
+// query parameter.
+func (h *EventHandler) StreamEvents(c *gin.Context) {
+	filter, ok := bindEventFilter(c)
+	if !ok {
+		return
+	}
+
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+
+	ctx := c.Request.Context()
+	stream, err := h.eventService.Subscribe(ctx, filter, lastEventID)
+	if err != nil {
+		h.handleError(c, "Failed to subscribe to events", err)
+		return
+	}
+
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	fmt.Fprintf(c.Writer, "retry: %d\n\n", eventStreamRetry.Milliseconds())
+	c.Writer.Flush()
+
+	keepAlive := time.NewTicker(h.keepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, open := <-stream:
+			if !open {
+				return
+			}
+			if err := writeServerSentEvent(c.Writer, event); err != nil {
+				h.logger.Warn(ctx, "Failed to write event", map[string]interface{}{
+					"event_id": event.ID,
+					"error":    err.Error(),
+				})
+				return
+			}
+		case <-keepAlive.C:
+			fmt.Fprint(c.Writer, ": keep-alive\n\n")
+		case <-ctx.Done():
+			return
+		case <-h.done:
+			return
+		}
+		c.Writer.Flush()
+	}
+}
+
+// writeServerSentEvent writes one event in the text/event-stream format
+func writeServerSentEvent(w gin.ResponseWriter, event events.Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", event.ID, event.Type, data)
+	return err
+}
+
+// StreamEventsWebSocket handles GET /events/ws, sending each event as a JSON
+// text message. Browsers cannot set headers on WebSocket requests, so clients
+// resume with the last_event_id query parameter.
+func (h *EventHandler) StreamEventsWebSocket(c *gin.Context) {
+	filter, ok := bindEventFilter(c)
+	if !ok {
+		return
+	}
+
+	ctx := c.Request.Context()
+	stream, err := h.eventService.Subscribe(ctx, filter, c.Query("last_event_id"))
+	if err != nil {
+		h.handleError(c, "Failed to subscribe to events", err)
+		return
+	}
+
+	// The upgrader answers failed handshakes itself
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		h.logger.Warn(ctx, "WebSocket handshake failed", map[string]interface{}{
+			"error": err.Error(),
+		})
+		return
+	}
+	defer conn.Close()
+
+	// Reading is needed to notice the client closing; messages are ignored
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := conn.NextReader(); err != nil {
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(h.keepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case event, open := <-stream:
+			if !open {
+				_ = conn.WriteMessage(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "subscription ended"))
+				return
+			}
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(h.keepAlive)); err != nil {
+				return
+			}
+		case <-closed:
+			return
+		case <-h.done:
+			_ = conn.WriteMessage(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseGoingAway, "server shutting down"))
+			return
+		}
+	}
+}
+
+// bindEventFilter reads the comma separated beer_id and currency query
+// parameters, answering with a problem when a beer ID is not an integer
+func bindEventFilter(c *gin.Context) (events.Filter, bool) {
+	var filter events.Filter
+
+	for _, value := range splitQueryList(c.Query("beer_id")) {
+		id, err := strconv.Atoi(value)
+		if err != nil || id < 1 {
+			writeInvalidParam(c, "INVALID_ID", "beer_id", "Beer IDs must be positive integers")
+			return filter, false
+		}
+		filter.BeerIDs = append(filter.BeerIDs, id)
+	}
+
+	filter.Currencies = splitQueryList(c.Query("currency"))
+
+	return filter, true
+}
+
+// splitQueryList splits a comma separated query parameter, dropping empty items
+func splitQueryList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// handleError handles errors and sends appropriate HTTP responses
+func (h *EventHandler) handleError(c *gin.Context, message string, err error) {
+	h.logger.Error(c.Request.Context(), message, err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+
+	writeError(c, err)
+}
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// stubEventService hands out a prepared stream and records how it was subscribed to
+type stubEventService struct {
+	stream      chan events.Event
+	err         error
+	filter      events.Filter
+	lastEventID string
+}
+
+func (s *stubEventService) Subscribe(ctx context.Context, filter events.Filter, lastEventID string) (<-chan events.Event, error) {
+	s.filter = filter
+	s.lastEventID = lastEventID
+	return s.stream, s.err
+}
+
+func newEventTestServer(service *stubEventService, websockets bool) *Server {
+	return NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithEventService(service, time.Minute, websockets))
+}
+
+var testEvent = events.Event{
+	ID:     "e-7",
+	Type:   events.TypeBeerCreated,
+	BeerID: 1,
+	Beer:   &beers.Beer{ID: 1, Name: testBeerName, Price: 2500, Currency: "CLP"},
+}
+
+func TestStreamEvents(t *testing.T) {
+	service := &stubEventService{stream: make(chan events.Event, 1)}
+	service.stream <- testEvent
+	close(service.stream)
+	server := newEventTestServer(service, false)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/events?beer_id=1,2&currency=CLP", nil)
+	req.Header.Set("Last-Event-ID", "e-6")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, events.Filter{BeerIDs: []int{1, 2}, Currencies: []string{"CLP"}}, service.filter)
+	assert.Equal(t, "e-6", service.lastEventID)
+
+	assert.True(t, strings.HasPrefix(w.Body.String(), "retry: 3000\n\n"))
+	assert.Contains(t, w.Body.String(), "id: e-7\nevent: beer.created\ndata: {\"id\":\"e-7\",\"type\":\"beer.created\",\"beer_id\":1,")
+}
+
+func TestStreamEventsErrors(t *testing.T) {
+	service := &stubEventService{}
+	server := newEventTestServer(service, false)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/events?beer_id=1,abc", nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), `"field":"beer_id"`)
+
+	service.err = beers.NewDomainError(events.ErrCodeUnavailable, "The event stream is shutting down", nil)
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/events?last_event_id=e-1", nil)
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "e-1", service.lastEventID)
+
+	// The WebSocket endpoint is off unless enabled
+	req, _ = http.NewRequest(http.MethodGet, "/api/v1/events/ws", nil)
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestStreamEventsEndsOnClose(t *testing.T) {
+	service := &stubEventService{stream: make(chan events.Event)}
+	// Validating responses must not hold the stream back
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithEventService(service, time.Minute, false),
+		WithContractValidation(loadTestContract(t), true))
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+
+	resp, err := http.Get(httpServer.URL + "/api/v1/events")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "retry: 3000\n", line)
+
+	service.stream <- testEvent
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "\n", line)
+	line, err = reader.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "id: e-7\n", line)
+
+	server.eventHandler.Close()
+	_, err = reader.ReadString(0)
+	assert.Error(t, err, "the stream ends once the handler is closed")
+}
+
+func TestStreamEventsWebSocket(t *testing.T) {
+	service := &stubEventService{stream: make(chan events.Event, 1)}
+	service.stream <- testEvent
+	server := newEventTestServer(service, true)
+	httpServer := httptest.NewServer(server.router)
+	defer httpServer.Close()
+
+	url := "ws" + strings.TrimPrefix(httpServer.URL, "http") + "/api/v1/events/ws?currency=clp&last_event_id=e-6"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	var received events.Event
+	require.NoError(t, conn.ReadJSON(&received))
+	assert.Equal(t, "e-7", received.ID)
+	assert.Equal(t, testBeerName, received.Beer.Name)
+	assert.Equal(t, events.Filter{Currencies: []string{"clp"}}, service.filter)
+	assert.Equal(t, "e-6", service.lastEventID)
+
+	// A dropped subscription asks the client to reconnect
+	close(service.stream)
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseTryAgainLater), "unexpected error %v", err)
+}
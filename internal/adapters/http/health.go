@@ -0,0 +1,57 @@
+package http
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/health"
+)
+
+// Health probe paths
+const (
+	LivenessPath  = "/health/live"
+	ReadinessPath = "/health/ready"
+)
+
+// HealthReporter runs the dependency health checks of the service
+type HealthReporter interface {
+	Check(ctx context.Context) health.Report
+}
+
+// readinessResponse is a health report, flagged while the server shuts down
+type readinessResponse struct {
+	health.Report
+	ShuttingDown bool `json:"shutting_down,omitempty"`
+}
+
+// liveness answers as long as the process serves requests. It checks no
+// dependency, so an outage never gets healthy replicas restarted.
+func (s *Server) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": health.StatusUp})
+}
+
+// readiness reports whether the server should receive traffic: every critical
+// dependency is up and the server is not shutting down
+func (s *Server) readiness(c *gin.Context) {
+	if s.shuttingDown.Load() {
+		c.JSON(http.StatusServiceUnavailable, readinessResponse{
+			Report:       health.Report{Status: health.StatusDown, Components: []health.Component{}},
+			ShuttingDown: true,
+		})
+		return
+	}
+
+	report := health.NewReport([]health.Component{})
+	if s.healthReporter != nil {
+		report = s.healthReporter.Check(c.Request.Context())
+	}
+
+	status := http.StatusOK
+	if !report.IsReady() {
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, readinessResponse{Report: report})
+}
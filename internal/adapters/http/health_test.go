@@ -0,0 +1,86 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/health"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// stubHealthReporter reports fixed component results
+type stubHealthReporter []health.Component
+
+func (r stubHealthReporter) Check(context.Context) health.Report {
+	return health.NewReport(r)
+}
+
+func probe(server *Server, path string) (*httptest.ResponseRecorder, readinessResponse) {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	var response readinessResponse
+	_ = json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func newHealthTestServer(components ...health.Component) *Server {
+	return NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithHealthChecks(stubHealthReporter(components)))
+}
+
+func TestLiveness(t *testing.T) {
+	server := newHealthTestServer(health.Component{Name: "database", Status: health.StatusDown, Critical: true})
+
+	w, response := probe(server, LivenessPath)
+
+	assert.Equal(t, http.StatusOK, w.Code, "liveness ignores dependencies")
+	assert.Equal(t, health.StatusUp, response.Status)
+}
+
+func TestReadiness(t *testing.T) {
+	database := health.Component{Name: "database", Status: health.StatusUp, Critical: true, LatencyMs: 1.5}
+	provider := health.Component{Name: "currency_provider", Status: health.StatusDown, Error: "quota exceeded"}
+
+	w, response := probe(newHealthTestServer(database, provider), ReadinessPath)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, health.StatusDegraded, response.Status)
+	assert.Equal(t, []health.Component{database, provider}, response.Components)
+
+	database.Status = health.StatusDown
+	w, response = probe(newHealthTestServer(database, provider), ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, health.StatusDown, response.Status)
+}
+
+func TestReadinessFailsDuringShutdown(t *testing.T) {
+	server := newHealthTestServer(health.Component{Name: "database", Status: health.StatusUp, Critical: true})
+
+	w, _ := probe(server, ReadinessPath)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	assert.NoError(t, server.Stop(context.Background()))
+
+	w, response := probe(server, ReadinessPath)
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.True(t, response.ShuttingDown)
+
+	w, _ = probe(server, LivenessPath)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestReadinessWithoutChecks(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	w, response := probe(server, ReadinessPath)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, health.StatusUp, response.Status)
+}
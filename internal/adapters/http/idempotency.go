@@ -0,0 +1,156 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+const (
+	// IdempotencyKeyHeader carries the client chosen key of a retryable POST
+	IdempotencyKeyHeader = "Idempotency-Key"
+
+	// IdempotentReplayedHeader is set on responses replayed from a stored record
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+)
+
+// responseRecorder copies the response body while it is written to the client
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// IdempotencyMiddleware makes POST requests carrying an Idempotency-Key safe to retry.
+// The first request is processed and its response recorded for ttl; retries with the
+// same key and payload get the recorded status and body back without reprocessing.
+// Reusing a key with a different payload returns 422, and a retry that arrives while
+// the first request is still running returns 409. Server errors and panics are not
+// recorded, so the request can be retried.
+func IdempotencyMiddleware(store secondary.IdempotencyStore, ttl time.Duration, logger secondary.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		if len(key) > idempotency.MaxKeyLength {
+			detail := fmt.Sprintf("must be at most %d characters", idempotency.MaxKeyLength)
+			writeProblem(c, newProblem(http.StatusBadRequest, idempotency.ErrCodeInvalidKey,
+				IdempotencyKeyHeader+" "+detail, FieldError{Field: IdempotencyKeyHeader, Message: detail}))
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			writeBindingError(c, err)
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		caller := ""
+		if principal, ok := auth.PrincipalFromContext(ctx); ok {
+			caller = principal.Subject
+		}
+
+		record := idempotency.NewRecord(
+			idempotency.ScopedKey(caller, c.Request.Method, c.Request.URL.Path, key),
+			idempotency.Hash(c.Request.Method, c.Request.URL.RequestURI(), string(body)),
+			time.Now(),
+			ttl,
+		)
+
+		existing, err := store.Reserve(ctx, record)
+		if err != nil {
+			logger.Error(ctx, "Failed to reserve idempotency key", err, nil)
+			writeError(c, err)
+			return
+		}
+
+		if existing != nil {
+			replay(c, existing, record.RequestHash)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		// Release the key unless a response was recorded, including when a
+		// handler panics, so the request can be retried
+		recorded := false
+		defer func() {
+			if recorded {
+				return
+			}
+			if err := store.Release(ctx, record.Key); err != nil {
+				logger.Error(ctx, "Failed to release idempotency key", err, nil)
+			}
+		}()
+
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			return
+		}
+
+		recorded = true
+		record.Complete(recorder.Status(), recorder.Header().Get("Content-Type"), recorder.body.Bytes())
+		if err := store.Complete(ctx, record); err != nil {
+			logger.Error(ctx, "Failed to record idempotent response", err, nil)
+		}
+	}
+}
+
+// replay answers a retry from the record of the first request with its key
+func replay(c *gin.Context, existing *idempotency.Record, requestHash string) {
+	if !existing.Matches(requestHash) {
+		writeError(c, beers.NewDomainError(idempotency.ErrCodeKeyReused,
+			IdempotencyKeyHeader+" was already used with a different request", nil))
+		return
+	}
+
+	if !existing.Completed {
+		writeError(c, beers.NewDomainError(idempotency.ErrCodeInProgress,
+			"A request with this "+IdempotencyKeyHeader+" is still being processed", nil))
+		return
+	}
+
+	c.Header(IdempotentReplayedHeader, "true")
+	if len(existing.Body) == 0 {
+		c.AbortWithStatus(existing.StatusCode)
+		return
+	}
+
+	c.Data(existing.StatusCode, existing.ContentType, existing.Body)
+	c.Abort()
+}
+
+// idempotent returns the IdempotencyMiddleware when an idempotency store is
+// configured and a pass-through handler otherwise
+func (s *Server) idempotent() gin.HandlerFunc {
+	if s.idempotencyStore == nil {
+		return func(c *gin.Context) { c.Next() }
+	}
+
+	return IdempotencyMiddleware(s.idempotencyStore, s.idempotencyTTL, s.logger)
+}
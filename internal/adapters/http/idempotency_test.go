@@ -0,0 +1,181 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/infrastructure/logger"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+// setupIdempotentRouter serves a creation endpoint that counts how often it runs
+// and answers with the given status
+func setupIdempotentRouter(status *int, calls *int) *gin.Engine {
+	r := setupRouter()
+	r.POST(beersEndpoint, IdempotencyMiddleware(inmemory.NewIdempotencyStore(), time.Hour, logger.NewNoOpLogger()),
+		func(c *gin.Context) {
+			*calls++
+			c.JSON(*status, gin.H{"call": *calls})
+		})
+	return r
+}
+
+func postWithKey(r http.Handler, key, body string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodPost, beersEndpoint, strings.NewReader(body))
+	req.Header.Set(contentTypeHeader, jsonContentType)
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplay(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	first := postWithKey(r, "retry-1", `{"id":1}`)
+	second := postWithKey(r, "retry-1", `{"id":1}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, jsonContentType+"; charset=utf-8", second.Header().Get(contentTypeHeader))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotencyKeyReusedWithDifferentPayload(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	postWithKey(r, "retry-1", `{"id":1}`)
+	w := postWithKey(r, "retry-1", `{"id":2}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, idempotency.ErrCodeKeyReused, problem.Code)
+}
+
+func TestIdempotencyWithoutKey(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	postWithKey(r, "", `{"id":1}`)
+	postWithKey(r, "", `{"id":1}`)
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyReplaysClientErrors(t *testing.T) {
+	status, calls := http.StatusConflict, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	postWithKey(r, "retry-1", `{"id":1}`)
+	status = http.StatusCreated
+	w := postWithKey(r, "retry-1", `{"id":1}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotencyRetriesServerErrors(t *testing.T) {
+	status, calls := http.StatusInternalServerError, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	postWithKey(r, "retry-1", `{"id":1}`)
+	status = http.StatusCreated
+	w := postWithKey(r, "retry-1", `{"id":1}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestIdempotencyReleasesKeyWhenHandlerPanics(t *testing.T) {
+	calls := 0
+	r := setupRouter()
+	r.POST(beersEndpoint, IdempotencyMiddleware(inmemory.NewIdempotencyStore(), time.Hour, logger.NewNoOpLogger()),
+		func(c *gin.Context) {
+			calls++
+			if calls == 1 {
+				panic("handler failed")
+			}
+			c.JSON(http.StatusCreated, gin.H{"call": calls})
+		})
+
+	first := postWithKey(r, "retry-1", `{"id":1}`)
+	second := postWithKey(r, "retry-1", `{"id":1}`)
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusCreated, second.Code)
+}
+
+func TestIdempotencyKeyTooLong(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	r := setupIdempotentRouter(&status, &calls)
+
+	w := postWithKey(r, strings.Repeat("k", idempotency.MaxKeyLength+1), `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Zero(t, calls)
+}
+
+func TestIdempotencyKeysAreScopedByPrincipal(t *testing.T) {
+	calls := 0
+	r := setupRouter()
+	r.POST(beersEndpoint,
+		func(c *gin.Context) {
+			principal := &auth.Principal{Subject: c.GetHeader("X-Test-Subject"), Role: auth.RoleEditor}
+			c.Request = c.Request.WithContext(auth.WithPrincipal(c.Request.Context(), principal))
+		},
+		IdempotencyMiddleware(inmemory.NewIdempotencyStore(), time.Hour, logger.NewNoOpLogger()),
+		func(c *gin.Context) {
+			calls++
+			c.Status(http.StatusCreated)
+		})
+
+	for _, subject := range []string{"key_a", "key_b"} {
+		req, _ := http.NewRequest(http.MethodPost, beersEndpoint, strings.NewReader(`{}`))
+		req.Header.Set(IdempotencyKeyHeader, "shared")
+		req.Header.Set("X-Test-Subject", subject)
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+type failingIdempotencyStore struct{}
+
+func (failingIdempotencyStore) Reserve(context.Context, *idempotency.Record) (*idempotency.Record, error) {
+	return nil, errors.New("connection refused")
+}
+func (failingIdempotencyStore) Complete(context.Context, *idempotency.Record) error { return nil }
+func (failingIdempotencyStore) Release(context.Context, string) error               { return nil }
+
+func TestIdempotencyStoreFailure(t *testing.T) {
+	calls := 0
+	r := setupRouter()
+	r.POST(beersEndpoint, IdempotencyMiddleware(failingIdempotencyStore{}, time.Hour, logger.NewNoOpLogger()),
+		func(c *gin.Context) { calls++ })
+
+	w := postWithKey(r, "retry-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Zero(t, calls)
+}
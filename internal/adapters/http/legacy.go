@@ -0,0 +1,148 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// What legacy unversioned routes do
+const (
+	// LegacyServe keeps serving legacy routes, flagged as deprecated
+	LegacyServe = "serve"
+	// LegacyRedirect sends legacy requests to their versioned route with 308 Permanent Redirect
+	LegacyRedirect = "redirect"
+	// LegacyGone answers legacy requests with 410 Gone
+	LegacyGone = "gone"
+)
+
+// ErrCodeLegacyRouteGone is the error code of a retired legacy route
+const ErrCodeLegacyRouteGone = "LEGACY_ROUTE_GONE"
+
+// Deprecation response headers of legacy routes (RFC 9745 and RFC 8594)
+const (
+	DeprecationHeader = "Deprecation"
+	SunsetHeader      = "Sunset"
+)
+
+// LegacyPolicy sets how legacy unversioned routes such as /beers are answered and
+// what clients are told about their retirement
+type LegacyPolicy struct {
+	// Mode is LegacyServe, LegacyRedirect or LegacyGone
+	Mode string
+	// DeprecatedAt is when the routes were deprecated; without it Deprecation is "true"
+	DeprecatedAt time.Time
+	// Sunset is when the routes stop being served, if decided
+	Sunset time.Time
+	// DocsURL documents the deprecation and migration, if set
+	DocsURL string
+}
+
+// Client labels of legacy requests that do not name the caller. IP addresses
+// and JWT subjects would make the label set unbounded, and metrics are public,
+// so only API key IDs are told apart; the debug log names every caller.
+const (
+	anonymousClient = "anonymous"
+	jwtClient       = "jwt"
+)
+
+// LegacyUsageRecorder counts requests to legacy routes by API key, so their
+// last users can be found before the routes are retired
+type LegacyUsageRecorder interface {
+	ObserveLegacyRequest(method, route, client string)
+}
+
+// ParseLegacyPolicy builds a legacy route policy from its mode, RFC 3339
+// deprecation and sunset timestamps, either of which may be empty, and docs URL
+func ParseLegacyPolicy(mode, deprecatedAt, sunset, docsURL string) (LegacyPolicy, error) {
+	policy := LegacyPolicy{Mode: strings.ToLower(strings.TrimSpace(mode)), DocsURL: docsURL}
+
+	switch policy.Mode {
+	case LegacyServe, LegacyRedirect, LegacyGone:
+	default:
+		return LegacyPolicy{}, fmt.Errorf("unknown legacy route mode %q: expected %s, %s or %s", mode, LegacyServe, LegacyRedirect, LegacyGone)
+	}
+
+	var err error
+	if deprecatedAt != "" {
+		if policy.DeprecatedAt, err = time.Parse(time.RFC3339, deprecatedAt); err != nil {
+			return LegacyPolicy{}, fmt.Errorf("invalid legacy deprecation date %q: %w", deprecatedAt, err)
+		}
+	}
+	if sunset != "" {
+		if policy.Sunset, err = time.Parse(time.RFC3339, sunset); err != nil {
+			return LegacyPolicy{}, fmt.Errorf("invalid legacy sunset date %q: %w", sunset, err)
+		}
+	}
+
+	return policy, nil
+}
+
+// LegacyMiddleware flags a legacy route as deprecated, pointing at its successor
+// under the versioned prefix, counts its use and, unless the policy still serves
+// it, redirects to the successor or answers 410 Gone. Each request is also
+// logged at debug level with the principal or IP address behind it, which
+// metrics leave out; at info level it would flood the logs of busy clients.
+func LegacyMiddleware(policy LegacyPolicy, recorder LegacyUsageRecorder, logger secondary.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		successor := APIPrefix + c.Request.URL.Path
+		if c.Request.URL.RawQuery != "" {
+			successor += "?" + c.Request.URL.RawQuery
+		}
+
+		if policy.DeprecatedAt.IsZero() {
+			c.Header(DeprecationHeader, "true")
+		} else {
+			c.Header(DeprecationHeader, fmt.Sprintf("@%d", policy.DeprecatedAt.Unix()))
+		}
+		if !policy.Sunset.IsZero() {
+			c.Header(SunsetHeader, policy.Sunset.UTC().Format(http.TimeFormat))
+		}
+
+		links := []string{fmt.Sprintf(`<%s>; rel="successor-version"`, successor)}
+		if policy.DocsURL != "" {
+			links = append(links, fmt.Sprintf(`<%s>; rel="deprecation"`, policy.DocsURL))
+		}
+		c.Header("Link", strings.Join(links, ", "))
+
+		logger.Debug(c.Request.Context(), "Legacy route requested", map[string]interface{}{
+			"method": c.Request.Method,
+			"route":  c.FullPath(),
+			"client": clientIdentity(c),
+		})
+		if recorder != nil {
+			recorder.ObserveLegacyRequest(c.Request.Method, c.FullPath(), legacyClientLabel(c))
+		}
+
+		switch policy.Mode {
+		case LegacyRedirect:
+			// 308 keeps the method and body, so writes are redirected too
+			c.Redirect(http.StatusPermanentRedirect, successor)
+			c.Abort()
+		case LegacyGone:
+			writeProblem(c, newProblem(http.StatusGone, ErrCodeLegacyRouteGone,
+				"This route has been retired; use "+APIPrefix+c.FullPath()))
+		default:
+			c.Next()
+		}
+	}
+}
+
+// legacyClientLabel names the API key behind a legacy request, jwtClient for
+// bearer tokens and anonymousClient when the request is unauthenticated
+func legacyClientLabel(c *gin.Context) string {
+	principal, ok := auth.PrincipalFromContext(c.Request.Context())
+	if !ok {
+		return anonymousClient
+	}
+	if principal.Method == auth.MethodAPIKey {
+		return "api_key:" + principal.Subject
+	}
+	return jwtClient
+}
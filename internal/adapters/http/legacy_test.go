@@ -0,0 +1,144 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// legacyRecordingMetrics also keeps the legacy requests as "METHOD route client"
+type legacyRecordingMetrics struct {
+	recordingMetrics
+	legacy []string
+}
+
+func (m *legacyRecordingMetrics) ObserveLegacyRequest(method, route, client string) {
+	m.legacy = append(m.legacy, method+" "+route+" "+client)
+}
+
+func TestParseLegacyPolicy(t *testing.T) {
+	policy, err := ParseLegacyPolicy(" Redirect ", "2025-01-01T00:00:00Z", "2025-12-31T23:59:59Z", "https://example.com/migrate")
+	require.NoError(t, err)
+	assert.Equal(t, LegacyRedirect, policy.Mode)
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), policy.DeprecatedAt.UTC())
+	assert.Equal(t, time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC), policy.Sunset.UTC())
+
+	undated, err := ParseLegacyPolicy("serve", "", "", "")
+	require.NoError(t, err)
+	assert.True(t, undated.DeprecatedAt.IsZero())
+	assert.True(t, undated.Sunset.IsZero())
+
+	for _, bad := range [][2]string{{"retire", ""}, {"gone", "next year"}} {
+		_, err := ParseLegacyPolicy(bad[0], "", bad[1], "")
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestLegacyRoutes(t *testing.T) {
+	body := `{"id": 1, "name": "IPA", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`
+	policy := LegacyPolicy{
+		DeprecatedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Sunset:       time.Date(2025, 12, 31, 23, 59, 59, 0, time.UTC),
+		DocsURL:      "https://example.com/migrate",
+	}
+
+	tests := []struct {
+		mode   string
+		target string
+		status int
+	}{
+		{LegacyServe, "/beers", http.StatusCreated},
+		{LegacyRedirect, "/beers?source=legacy", http.StatusPermanentRedirect},
+		{LegacyGone, "/beers", http.StatusGone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			policy.Mode = tt.mode
+			metrics := &legacyRecordingMetrics{}
+			server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+				WithContractValidation(loadTestContract(t), true), WithMetrics(metrics), WithLegacyRoutes(policy))
+
+			req, _ := http.NewRequest(http.MethodPost, tt.target, strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			req.RemoteAddr = "10.0.0.7:5000"
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Empty(t, w.Header().Get(ContractViolationHeader))
+			assert.Equal(t, "@1735689600", w.Header().Get(DeprecationHeader))
+			assert.Equal(t, "Wed, 31 Dec 2025 23:59:59 GMT", w.Header().Get(SunsetHeader))
+			assert.Equal(t, `<`+APIPrefix+tt.target+`>; rel="successor-version", <https://example.com/migrate>; rel="deprecation"`,
+				w.Header().Get("Link"))
+			assert.Empty(t, w.Header().Get(APIVersionHeader))
+			assert.Equal(t, []string{"POST /beers anonymous"}, metrics.legacy)
+
+			switch tt.mode {
+			case LegacyRedirect:
+				assert.Equal(t, APIPrefix+tt.target, w.Header().Get("Location"))
+			case LegacyGone:
+				var problem Problem
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+				assert.Equal(t, ErrCodeLegacyRouteGone, problem.Code)
+				assert.Equal(t, "This route has been retired; use /api/v1/beers", problem.Detail)
+			}
+		})
+	}
+
+	t.Run("undated deprecation", func(t *testing.T) {
+		server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+		req, _ := http.NewRequest(http.MethodPost, "/beers", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		server.router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "true", w.Header().Get(DeprecationHeader))
+		assert.Empty(t, w.Header().Get(SunsetHeader))
+		assert.Equal(t, `</api/v1/beers>; rel="successor-version"`, w.Header().Get("Link"))
+	})
+}
+
+func TestLegacyClientLabel(t *testing.T) {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodGet, "/beers", nil)
+	c.Request.RemoteAddr = "10.0.0.7:5000"
+	assert.Equal(t, "anonymous", legacyClientLabel(c))
+
+	anonymous := c.Request
+	c.Request = anonymous.WithContext(auth.WithPrincipal(anonymous.Context(),
+		&auth.Principal{Subject: "key_reporting", Role: auth.RoleReader, Method: auth.MethodAPIKey}))
+	assert.Equal(t, "api_key:key_reporting", legacyClientLabel(c))
+
+	// JWT subjects are unbounded, so bearer tokens share one label
+	c.Request = anonymous.WithContext(auth.WithPrincipal(anonymous.Context(),
+		&auth.Principal{Subject: "user-4711", Role: auth.RoleReader, Method: auth.MethodJWT}))
+	assert.Equal(t, "jwt", legacyClientLabel(c))
+}
+
+func TestVersionedRoutesNameTheirVersion(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	req, _ := http.NewRequest(http.MethodPost, "/api/v1/beers",
+		strings.NewReader(`{"id": 1, "name": "IPA", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "v1", w.Header().Get(APIVersionHeader))
+	assert.Empty(t, w.Header().Get(DeprecationHeader))
+}
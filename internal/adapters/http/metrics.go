@@ -0,0 +1,38 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricsPath is where the Prometheus metrics are served
+const MetricsPath = "/metrics"
+
+// unmatchedRoute labels requests that matched no route, so arbitrary paths
+// cannot grow the number of series
+const unmatchedRoute = "unmatched"
+
+// HTTPMetrics records request metrics and serves them for scraping
+type HTTPMetrics interface {
+	ObserveHTTPRequest(method, route string, status int, duration time.Duration)
+	Handler() http.Handler
+}
+
+// MetricsMiddleware records the count, errors and latency of every request,
+// labelled by route template rather than by raw path
+func MetricsMiddleware(metrics HTTPMetrics) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		metrics.ObserveHTTPRequest(c.Request.Method, route, c.Writer.Status(), time.Since(start))
+	}
+}
@@ -0,0 +1,66 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// recordingMetrics keeps the observed requests as "METHOD route status"
+type recordingMetrics struct {
+	observed []string
+}
+
+func (m *recordingMetrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	m.observed = append(m.observed, fmt.Sprintf("%s %s %d", method, route, status))
+}
+
+func (m *recordingMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("beers_http_requests_total 1\n"))
+	})
+}
+
+func TestMetricsMiddlewareLabelsByRouteTemplate(t *testing.T) {
+	metrics := &recordingMetrics{}
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(), WithMetrics(metrics))
+
+	for _, path := range []string{"/api/v1/beers/abc", "/no/such/route"} {
+		req, _ := http.NewRequest(http.MethodGet, path, nil)
+		server.router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	assert.Equal(t, []string{
+		"GET /api/v1/beers/:id 400",
+		"GET unmatched 404",
+	}, metrics.observed)
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithMetrics(&recordingMetrics{}))
+
+	req, _ := http.NewRequest(http.MethodGet, MetricsPath, nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "beers_http_requests_total")
+}
+
+func TestMetricsEndpointDisabled(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	req, _ := http.NewRequest(http.MethodGet, MetricsPath, nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
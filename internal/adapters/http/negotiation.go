@@ -0,0 +1,475 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+)
+
+// FormatParam is the query parameter overriding the Accept header of a request
+const FormatParam = "format"
+
+// xmlItemElement names the elements of the items of nested lists in XML responses
+const xmlItemElement = "item"
+
+// responseFormat is a representation the read endpoints can answer with
+type responseFormat struct {
+	// name is the value of the format query parameter selecting the format
+	name string
+	// contentType is sent as the Content-Type of the response
+	contentType string
+	// mediaTypes are the Accept media types selecting the format
+	mediaTypes []string
+}
+
+var (
+	formatJSON    = responseFormat{"json", "application/json; charset=utf-8", []string{"application/json"}}
+	formatXML     = responseFormat{"xml", "application/xml; charset=utf-8", []string{"application/xml", "text/xml"}}
+	formatCSV     = responseFormat{"csv", "text/csv; charset=utf-8", []string{"text/csv"}}
+	formatMsgPack = responseFormat{"msgpack", "application/msgpack", []string{"application/msgpack", "application/x-msgpack", "application/vnd.msgpack"}}
+)
+
+// responseFormats lists the supported formats, preferred first when the client
+// accepts several equally
+var responseFormats = []responseFormat{formatJSON, formatXML, formatCSV, formatMsgPack}
+
+// msgpackHandle encodes sorted map keys so equal documents encode to equal bytes,
+// and decodes into the types encoding/json would produce
+var msgpackHandle = newMsgpackHandle()
+
+func newMsgpackHandle() *codec.MsgpackHandle {
+	handle := &codec.MsgpackHandle{WriteExt: true}
+	handle.Canonical = true
+	handle.RawToString = true
+	handle.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return handle
+}
+
+// xmlNames names the root element of an XML response and, when the response is a
+// list, the element of each item
+type xmlNames struct {
+	root string
+	item string
+}
+
+// mediaRange is a member of an Accept header
+type mediaRange struct {
+	mainType string
+	subType  string
+	quality  float64
+}
+
+// negotiate picks the format of a response from the format query parameter or,
+// failing that, the Accept header. When no supported format is acceptable it
+// sends a 406 problem and returns false.
+func negotiate(c *gin.Context) (responseFormat, bool) {
+	c.Writer.Header().Add("Vary", "Accept")
+
+	if name := c.Query(FormatParam); name != "" {
+		for _, format := range responseFormats {
+			if strings.EqualFold(format.name, name) {
+				return format, true
+			}
+		}
+		writeNotAcceptable(c, fmt.Sprintf("Format %q is not supported", name))
+		return responseFormat{}, false
+	}
+
+	accept := c.GetHeader("Accept")
+	if strings.TrimSpace(accept) == "" {
+		return formatJSON, true
+	}
+
+	ranges := parseAccept(accept)
+	best, bestQuality := responseFormat{}, 0.0
+	for _, format := range responseFormats {
+		if quality := format.quality(ranges); quality > bestQuality {
+			best, bestQuality = format, quality
+		}
+	}
+
+	if bestQuality == 0 {
+		writeNotAcceptable(c, fmt.Sprintf("None of the accepted media types %q is supported", accept))
+		return responseFormat{}, false
+	}
+
+	return best, true
+}
+
+// writeNotAcceptable sends a 406 problem listing the supported media types
+func writeNotAcceptable(c *gin.Context, reason string) {
+	supported := make([]string, 0, len(responseFormats))
+	for _, format := range responseFormats {
+		supported = append(supported, format.mediaTypes[0])
+	}
+
+	detail := fmt.Sprintf("%s; supported: %s", reason, strings.Join(supported, ", "))
+	writeProblem(c, newProblem(http.StatusNotAcceptable, "NOT_ACCEPTABLE", detail))
+}
+
+// parseAccept parses an Accept header, skipping malformed members
+func parseAccept(header string) []mediaRange {
+	var ranges []mediaRange
+	for _, member := range strings.Split(header, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(member))
+		if err != nil {
+			continue
+		}
+
+		quality := 1.0
+		if value, ok := params["q"]; ok {
+			quality, err = strconv.ParseFloat(value, 64)
+			if err != nil || quality < 0 || quality > 1 {
+				continue
+			}
+		}
+
+		mainType, subType, _ := strings.Cut(mediaType, "/")
+		ranges = append(ranges, mediaRange{mainType: mainType, subType: subType, quality: quality})
+	}
+	return ranges
+}
+
+// specificity ranks how closely a range matches a media type: 3 for an exact
+// match, 2 for type/*, 1 for */* and 0 when it does not match
+func (r mediaRange) specificity(mainType, subType string) int {
+	switch {
+	case r.mainType == "*" && r.subType == "*":
+		return 1
+	case r.mainType != mainType:
+		return 0
+	case r.subType == "*":
+		return 2
+	case r.subType == subType:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// quality is the q-value the client gives the format: for each of its media
+// types that of the most specific matching range, and the highest of those
+func (f responseFormat) quality(ranges []mediaRange) float64 {
+	best := 0.0
+	for _, mediaType := range f.mediaTypes {
+		mainType, subType, _ := strings.Cut(mediaType, "/")
+
+		quality, specificity := 0.0, 0
+		for _, r := range ranges {
+			s := r.specificity(mainType, subType)
+			if s > specificity || (s == specificity && s > 0 && r.quality > quality) {
+				quality, specificity = r.quality, s
+			}
+		}
+
+		if quality > best {
+			best = quality
+		}
+	}
+	return best
+}
+
+// respond sends data in a negotiated format. Every format carries the fields of
+// the JSON representation under the same names. With caching headers, a request
+// whose preconditions show it holds the response already gets 304 Not Modified.
+func respond(c *gin.Context, format responseFormat, status int, names xmlNames, data interface{}, caching *cacheHeaders) {
+	body, err := encode(format, names, data)
+	if err != nil {
+		writeError(c, fmt.Errorf("failed to encode %s response: %w", format.name, err))
+		return
+	}
+
+	if caching != nil && caching.write(c, body) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(status, format.contentType, body)
+}
+
+// encode renders data in a format
+func encode(format responseFormat, names xmlNames, data interface{}) ([]byte, error) {
+	if format.name == formatJSON.name {
+		return json.Marshal(data)
+	}
+
+	document, err := toDocument(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	switch format.name {
+	case formatXML.name:
+		err = writeXML(&body, names, document)
+	case formatCSV.name:
+		err = writeCSV(&body, document)
+	case formatMsgPack.name:
+		err = codec.NewEncoder(&body, msgpackHandle).Encode(plainValue(document))
+	default:
+		err = fmt.Errorf("unknown format %s", format.name)
+	}
+
+	return body.Bytes(), err
+}
+
+// A document is data as encoding/json sees it, keeping the order of object
+// members: nil, bool, json.Number, string, []interface{} or documentObject.
+type documentObject []documentMember
+
+// documentMember is a named value of a document object
+type documentMember struct {
+	name  string
+	value interface{}
+}
+
+// toDocument converts data into a document through its JSON encoding, so the
+// other formats follow its field names, omitted fields and time layout
+func toDocument(data interface{}) (interface{}, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	return decodeDocument(decoder)
+}
+
+// decodeDocument reads the next value of a JSON token stream
+func decodeDocument(decoder *json.Decoder) (interface{}, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		obj := documentObject{}
+		for decoder.More() {
+			name, err := decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			value, err := decodeDocument(decoder)
+			if err != nil {
+				return nil, err
+			}
+			obj = append(obj, documentMember{name: name.(string), value: value})
+		}
+		_, err = decoder.Token()
+		return obj, err
+	case json.Delim('['):
+		list := []interface{}{}
+		for decoder.More() {
+			value, err := decodeDocument(decoder)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, value)
+		}
+		_, err = decoder.Token()
+		return list, err
+	default:
+		return token, nil
+	}
+}
+
+// scalarText renders a document scalar as text
+func scalarText(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case json.Number:
+		return v.String()
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// plainValue converts a document into maps, slices and numbers a generic encoder
+// understands
+func plainValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case documentObject:
+		m := make(map[string]interface{}, len(v))
+		for _, field := range v {
+			m[field.name] = plainValue(field.value)
+		}
+		return m
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = plainValue(item)
+		}
+		return list
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	default:
+		return v
+	}
+}
+
+// writeXML writes a document as XML. Object members become child elements, list
+// items become names.item elements at the root and item elements below it, and
+// null members are left out.
+func writeXML(w io.Writer, names xmlNames, document interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	if list, ok := document.([]interface{}); ok && names.item != "" {
+		root := xml.StartElement{Name: xml.Name{Local: names.root}}
+		if err := encoder.EncodeToken(root); err != nil {
+			return err
+		}
+		for _, item := range list {
+			if err := writeXMLElement(encoder, names.item, item); err != nil {
+				return err
+			}
+		}
+		if err := encoder.EncodeToken(root.End()); err != nil {
+			return err
+		}
+	} else if err := writeXMLElement(encoder, names.root, document); err != nil {
+		return err
+	}
+
+	return encoder.Flush()
+}
+
+// writeXMLElement writes a document value as an element
+func writeXMLElement(encoder *xml.Encoder, name string, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+	if err := encoder.EncodeToken(start); err != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case documentObject:
+		for _, field := range v {
+			if err := writeXMLElement(encoder, field.name, field.value); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if err := writeXMLElement(encoder, xmlItemElement, item); err != nil {
+				return err
+			}
+		}
+	default:
+		if err := encoder.EncodeToken(xml.CharData(scalarText(v))); err != nil {
+			return err
+		}
+	}
+
+	return encoder.EncodeToken(start.End())
+}
+
+// writeCSV writes a document as CSV with a header row: a list has a row per item,
+// anything else a single row. Nested members flatten into dotted columns such as
+// taxes.0.amount, in the order they first appear.
+func writeCSV(w io.Writer, document interface{}) error {
+	rows, ok := document.([]interface{})
+	if !ok {
+		rows = []interface{}{document}
+	}
+
+	var columns []string
+	known := make(map[string]bool)
+	records := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		record := make(map[string]string)
+		flattenCSV("", row, func(column, value string) {
+			if !known[column] {
+				known[column] = true
+				columns = append(columns, column)
+			}
+			record[column] = value
+		})
+		records = append(records, record)
+	}
+
+	writer := csv.NewWriter(w)
+	if len(columns) > 0 {
+		if err := writer.Write(columns); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		line := make([]string, len(columns))
+		for i, column := range columns {
+			line[i] = record[column]
+		}
+		if err := writer.Write(line); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// flattenCSV reports every scalar of a document value with its dotted column
+func flattenCSV(column string, value interface{}, set func(column, value string)) {
+	switch v := value.(type) {
+	case documentObject:
+		for _, field := range v {
+			flattenCSV(csvColumn(column, field.name), field.value, set)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenCSV(csvColumn(column, strconv.Itoa(i)), item, set)
+		}
+	default:
+		if column == "" {
+			column = "value"
+		}
+		text := scalarText(v)
+		if _, isString := v.(string); isString {
+			text = escapeCSVFormula(text)
+		}
+		set(column, text)
+	}
+}
+
+// escapeCSVFormula quotes text a spreadsheet would run as a formula, such as a
+// beer named =HYPERLINK(...), with a leading apostrophe. Numbers are not
+// strings in a document, so negative amounts are written as they are.
+func escapeCSVFormula(text string) string {
+	if text != "" && strings.ContainsRune("=+-@\t\r", rune(text[0])) {
+		return "'" + text
+	}
+	return text
+}
+
+// csvColumn appends a member name to a dotted column
+func csvColumn(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
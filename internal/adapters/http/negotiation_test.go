@@ -0,0 +1,188 @@
+package http
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		target      string
+		accept      string
+		status      int
+		contentType string
+	}{
+		{"no accept header", "/beers", "", http.StatusOK, "application/json; charset=utf-8"},
+		{"anything", "/beers", "*/*", http.StatusOK, "application/json; charset=utf-8"},
+		{"xml", "/beers", "application/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"text xml", "/beers", "text/xml", http.StatusOK, "application/xml; charset=utf-8"},
+		{"type range", "/beers", "text/*", http.StatusOK, "application/xml; charset=utf-8"},
+		{"csv", "/beers", "text/csv", http.StatusOK, "text/csv; charset=utf-8"},
+		{"msgpack alias", "/beers", "application/x-msgpack", http.StatusOK, "application/msgpack"},
+		{"highest quality wins", "/beers", "application/json;q=0.5, text/csv;q=0.9", http.StatusOK, "text/csv; charset=utf-8"},
+		{"browser", "/beers", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", http.StatusOK, "application/xml; charset=utf-8"},
+		{"refused format", "/beers", "application/json;q=0, */*", http.StatusOK, "application/xml; charset=utf-8"},
+		{"format parameter overrides accept", "/beers?format=CSV", "application/xml", http.StatusOK, "text/csv; charset=utf-8"},
+		{"nothing acceptable", "/beers", "text/html", http.StatusNotAcceptable, problemContentType},
+		{"everything refused", "/beers", "*/*;q=0", http.StatusNotAcceptable, problemContentType},
+		{"unsupported format parameter", "/beers?format=yaml", "", http.StatusNotAcceptable, problemContentType},
+	}
+
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return([]beers.Beer{{ID: 1, Name: testBeerName}}, nil)
+	handler := NewBeerHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.GET(beersEndpoint, handler.GetAllBeers)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, tt.target, nil)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, tt.contentType, w.Header().Get(contentTypeHeader))
+			assert.Equal(t, "Accept", w.Header().Get("Vary"))
+			if tt.status == http.StatusNotAcceptable {
+				assert.Contains(t, w.Body.String(), `"code":"NOT_ACCEPTABLE"`)
+			}
+		})
+	}
+}
+
+func TestNotAcceptableSkipsTheService(t *testing.T) {
+	mockService := new(MockBeerService)
+	handler := NewBeerHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.GET("/beers/:id/boxprice", handler.CalculateBoxPrice)
+
+	req, _ := http.NewRequest(http.MethodGet, "/beers/1/boxprice", nil)
+	req.Header.Set("Accept", "application/pdf")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotAcceptable, w.Code)
+	mockService.AssertNotCalled(t, "CalculateBoxPrice", mock.Anything, mock.Anything)
+}
+
+func TestRespondFormats(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	list := []beers.Beer{
+		{ID: 1, Name: "Golden", Brewery: "Kross", Country: "Chile", Price: 2500, Currency: "CLP", CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Name: "Stout & Co", Brewery: "Guinness", Country: "Ireland", Price: 3.5, Currency: "EUR", ABV: 4.2, CreatedAt: created, UpdatedAt: created},
+	}
+	boxPrice := &primary.BoxPriceResponse{
+		BeerID: 1, BeerName: "Golden", Quantity: 6, UnitPrice: 3, TotalPrice: 18, Currency: "USD",
+		Destination: "CL",
+		Taxes:       []tax.Line{{RuleID: "cl-iva", Name: "IVA", Kind: "vat", Rate: 0.19, Base: 18, Amount: 3.42}},
+	}
+
+	mockService := new(MockBeerService)
+	mockService.On("FindAllBeers", mock.Anything).Return(list, nil)
+	mockService.On("CalculateBoxPrice", mock.Anything, mock.Anything).Return(boxPrice, nil)
+	handler := NewBeerHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.GET(beersEndpoint, handler.GetAllBeers)
+	r.GET("/beers/:id/boxprice", handler.CalculateBoxPrice)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+		return w
+	}
+
+	t.Run("xml", func(t *testing.T) {
+		body := get("/beers?format=xml").Body.String()
+
+		assert.Contains(t, body, `<?xml version="1.0" encoding="UTF-8"?>`)
+		assert.Contains(t, body, `<beers><beer><id>1</id><name>Golden</name>`)
+		assert.Contains(t, body, `<name>Stout &amp; Co</name>`)
+		assert.NotContains(t, body, `<volume_ml>`, "omitted JSON fields are omitted in XML too")
+		assert.Contains(t, body, `<created_at>2024-01-15T10:30:00Z</created_at>`)
+		assert.Contains(t, body, `<abv>4.2</abv>`)
+	})
+
+	t.Run("nested xml", func(t *testing.T) {
+		body := get("/beers/1/boxprice?format=xml").Body.String()
+
+		assert.Contains(t, body, `<box_price><beer_id>1</beer_id>`)
+		assert.Contains(t, body, `<taxes><item><rule_id>cl-iva</rule_id><name>IVA</name><kind>vat</kind><rate>0.19</rate><base>18</base><amount>3.42</amount></item></taxes>`)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		records, err := csv.NewReader(get("/beers?format=csv").Body).ReadAll()
+		require.NoError(t, err)
+
+		require.Len(t, records, 3)
+		assert.Equal(t, []string{"id", "name", "brewery", "country", "price", "currency", "created_at", "updated_at", "abv"}, records[0])
+		assert.Equal(t, []string{"1", "Golden", "Kross", "Chile", "2500", "CLP", "2024-01-15T10:30:00Z", "2024-01-15T10:30:00Z", ""}, records[1])
+		assert.Equal(t, "Stout & Co", records[2][1])
+		assert.Equal(t, "4.2", records[2][8])
+	})
+
+	t.Run("flattened csv", func(t *testing.T) {
+		records, err := csv.NewReader(get("/beers/1/boxprice?format=csv").Body).ReadAll()
+		require.NoError(t, err)
+
+		require.Len(t, records, 2)
+		assert.Contains(t, records[0], "taxes.0.amount")
+		assert.Equal(t, "3.42", records[1][len(records[1])-1])
+	})
+
+	t.Run("csv formulas", func(t *testing.T) {
+		var buf bytes.Buffer
+		document := []interface{}{
+			documentObject{{name: "name", value: "=HYPERLINK(\"http://evil\")"}, {name: "price", value: json.Number("-3.5")}},
+			documentObject{{name: "name", value: "+1"}, {name: "price", value: json.Number("2")}},
+			documentObject{{name: "name", value: "-1"}},
+			documentObject{{name: "name", value: "@SUM(A1)"}},
+			documentObject{{name: "name", value: "Golden"}},
+		}
+		require.NoError(t, writeCSV(&buf, document))
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"'=HYPERLINK(\"http://evil\")", "-3.5"}, records[1])
+		assert.Equal(t, "'+1", records[2][0])
+		assert.Equal(t, "'-1", records[3][0])
+		assert.Equal(t, "'@SUM(A1)", records[4][0])
+		assert.Equal(t, "Golden", records[5][0])
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		var decoded []map[string]interface{}
+		err := codec.NewDecoder(bytes.NewReader(get("/beers?format=msgpack").Body.Bytes()), msgpackHandle).Decode(&decoded)
+		require.NoError(t, err)
+
+		require.Len(t, decoded, 2)
+		assert.EqualValues(t, 1, decoded[0]["id"])
+		assert.Equal(t, "Golden", decoded[0]["name"])
+		assert.Equal(t, "2024-01-15T10:30:00Z", decoded[0]["created_at"])
+		assert.Equal(t, 4.2, decoded[1]["abv"])
+		assert.NotContains(t, decoded[0], "abv")
+	})
+}
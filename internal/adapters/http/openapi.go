@@ -0,0 +1,359 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+	"github.com/ugorji/go/codec"
+
+	"beers-challenge/api"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+const (
+	// OpenAPIPath serves the OpenAPI document of the API
+	OpenAPIPath = "/openapi.yml"
+
+	// DocsPath serves Swagger UI rendering the OpenAPI document
+	DocsPath = "/docs"
+
+	// ContractViolationHeader flags, when responses are validated, a response that
+	// does not match the OpenAPI document
+	ContractViolationHeader = "X-Contract-Violation"
+
+	// maxViolationHeaderLength bounds the summary sent in ContractViolationHeader
+	maxViolationHeaderLength = 512
+)
+
+// Contract validation modes
+const (
+	ContractValidationOff      = "off"
+	ContractValidationRequests = "requests"
+	ContractValidationTest     = "test"
+)
+
+// openAPIPathParam matches a {param} segment of an OpenAPI path
+var openAPIPathParam = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// docsPage loads Swagger UI from a CDN and points it at the embedded document
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Beer API - Swagger UI</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "` + OpenAPIPath + `", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>
+`
+
+// The negotiated formats are registered so responses in them can be validated:
+// XML as well-formed text, and MessagePack against the schema of the JSON body.
+func init() {
+	openapi3filter.RegisterBodyDecoder("application/xml", xmlBodyDecoder)
+	openapi3filter.RegisterBodyDecoder("application/msgpack", msgpackBodyDecoder)
+}
+
+// xmlBodyDecoder checks an XML body is well-formed and decodes it as a string
+func xmlBodyDecoder(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (interface{}, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			return string(data), nil
+		} else if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// msgpackBodyDecoder decodes a MessagePack body into the values of its JSON equivalent
+func msgpackBodyDecoder(body io.Reader, _ http.Header, _ *openapi3.SchemaRef, _ openapi3filter.EncodingFn) (interface{}, error) {
+	var value interface{}
+	if err := codec.NewDecoder(body, msgpackHandle).Decode(&value); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded interface{}
+	err = json.Unmarshal(encoded, &decoded)
+	return decoded, err
+}
+
+// Contract is a parsed OpenAPI document with its operations indexed by gin route
+type Contract struct {
+	routes map[string]*routers.Route
+}
+
+// LoadContract parses and validates an OpenAPI document
+func LoadContract(document []byte) (*Contract, error) {
+	loader := openapi3.NewLoader()
+	spec, err := loader.LoadFromData(document)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	if err := spec.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+
+	contract := &Contract{routes: make(map[string]*routers.Route)}
+
+	for path, item := range spec.Paths.Map() {
+		for method, operation := range item.Operations() {
+			contract.routes[method+" "+ginPath(path)] = &routers.Route{
+				Spec:      spec,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+
+	return contract, nil
+}
+
+// Describes reports whether the document has an operation for a gin route
+func (c *Contract) Describes(method, route string) bool {
+	_, ok := c.routes[method+" "+route]
+	return ok
+}
+
+// isStream reports whether an operation answers with a stream, Server-Sent
+// Events or a WebSocket upgrade, rather than a single response body
+func isStream(route *routers.Route) bool {
+	responses := route.Operation.Responses
+	if responses.Status(http.StatusSwitchingProtocols) != nil {
+		return true
+	}
+
+	ok := responses.Status(http.StatusOK)
+	return ok != nil && ok.Value != nil && ok.Value.Content.Get("text/event-stream") != nil
+}
+
+// ginPath converts an OpenAPI path template such as /beers/{id} into the gin route /beers/:id
+func ginPath(path string) string {
+	return openAPIPathParam.ReplaceAllString(path, ":$1")
+}
+
+// ContractMiddleware rejects requests to documented routes that do not match the
+// OpenAPI contract. With validateResponses, meant for test environments, responses
+// are buffered and checked too: a violation is logged and flagged in the
+// X-Contract-Violation header, but the response is still sent as is.
+// Credentials are not checked here; AuthMiddleware does that.
+func ContractMiddleware(contract *Contract, validateResponses bool, logger secondary.Logger) gin.HandlerFunc {
+	options := &openapi3filter.Options{
+		MultiError:            true,
+		SkipSettingDefaults:   true,
+		IncludeResponseStatus: true,
+		AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(c *gin.Context) {
+		route, documented := contract.routes[c.Request.Method+" "+c.FullPath()]
+		if !documented {
+			c.Next()
+			return
+		}
+
+		pathParams := make(map[string]string, len(c.Params))
+		for _, param := range c.Params {
+			pathParams[param.Key] = param.Value
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		ctx := c.Request.Context()
+		if err := openapi3filter.ValidateRequest(ctx, input); err != nil {
+			logger.Warn(ctx, "Request violates the OpenAPI contract", map[string]interface{}{
+				"operation": route.Operation.OperationID,
+				"violation": err.Error(),
+			})
+			if problem, tooLarge := asRequestTooLarge(err); tooLarge {
+				writeProblem(c, problem)
+				return
+			}
+			writeProblem(c, problemFromContractError(err))
+			return
+		}
+
+		// Streams cannot be buffered; only their request is checked
+		if !validateResponses || isStream(route) {
+			c.Next()
+			return
+		}
+
+		buffer := &bufferedResponse{ResponseWriter: c.Writer}
+		c.Writer = buffer
+		c.Next()
+		c.Writer = buffer.ResponseWriter
+
+		responseInput := &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 buffer.Status(),
+			Header:                 buffer.Header(),
+			Options:                options,
+		}
+		responseInput.SetBodyBytes(buffer.body.Bytes())
+
+		if err := openapi3filter.ValidateResponse(ctx, responseInput); err != nil {
+			logger.Error(ctx, "Response violates the OpenAPI contract", err, map[string]interface{}{
+				"operation": route.Operation.OperationID,
+				"status":    buffer.Status(),
+			})
+			c.Header(ContractViolationHeader, violationSummary(err))
+		}
+
+		if buffer.body.Len() > 0 {
+			if _, err := c.Writer.Write(buffer.body.Bytes()); err != nil {
+				logger.Error(ctx, "Failed to write buffered response", err, nil)
+			}
+		}
+	}
+}
+
+// bufferedResponse holds the response body back so headers can still be added once
+// the handler has finished. The status code is kept by the wrapped writer, which
+// gin writes out after the last handler when no body follows.
+type bufferedResponse struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bufferedResponse) Write(data []byte) (int, error) {
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponse) WriteString(s string) (int, error) {
+	return w.body.WriteString(s)
+}
+
+// WriteHeaderNow is deferred until the buffered body is written
+func (w *bufferedResponse) WriteHeaderNow() {}
+
+// Flush is deferred until the buffered body is written
+func (w *bufferedResponse) Flush() {}
+
+// violationSummary flattens a validation error into a single bounded header value
+func violationSummary(err error) string {
+	summary := strings.Join(strings.Fields(err.Error()), " ")
+	if len(summary) > maxViolationHeaderLength {
+		summary = summary[:maxViolationHeaderLength]
+	}
+	return summary
+}
+
+// problemFromContractError maps a request validation error to a 400 problem
+// listing every invalid parameter and body field
+func problemFromContractError(err error) *Problem {
+	var fieldErrors []FieldError
+	for _, violation := range flattenErrors(err) {
+		fieldErrors = append(fieldErrors, contractFieldErrors(violation)...)
+	}
+
+	return newProblem(http.StatusBadRequest, "INVALID_REQUEST", "Request does not match the API contract", fieldErrors...)
+}
+
+// contractFieldErrors describes a single request validation error
+func contractFieldErrors(err error) []FieldError {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return []FieldError{{Field: "request", Message: err.Error()}}
+	}
+
+	if requestErr.Parameter != nil {
+		return []FieldError{{Field: requestErr.Parameter.Name, Message: violationReason(requestErr)}}
+	}
+
+	if requestErr.RequestBody != nil && requestErr.Err != nil {
+		var fieldErrors []FieldError
+		for _, cause := range flattenErrors(requestErr.Err) {
+			var schemaErr *openapi3.SchemaError
+			if errors.As(cause, &schemaErr) {
+				field := strings.Join(schemaErr.JSONPointer(), ".")
+				if field == "" {
+					field = "body"
+				}
+				fieldErrors = append(fieldErrors, FieldError{Field: field, Message: schemaErr.Reason})
+			}
+		}
+		if len(fieldErrors) > 0 {
+			return fieldErrors
+		}
+	}
+
+	return []FieldError{{Field: "body", Message: violationReason(requestErr)}}
+}
+
+// violationReason picks the most specific explanation of a request error
+func violationReason(err *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err.Err, &schemaErr) {
+		return schemaErr.Reason
+	}
+
+	if err.Reason != "" {
+		return err.Reason
+	}
+
+	if err.Err != nil {
+		return err.Err.Error()
+	}
+
+	return "is invalid"
+}
+
+// flattenErrors expands the multi-errors kin-openapi collects into their members
+func flattenErrors(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var flattened []error
+	for _, member := range multi {
+		flattened = append(flattened, flattenErrors(member)...)
+	}
+	return flattened
+}
+
+// openAPIDocument serves the embedded OpenAPI document
+func (s *Server) openAPIDocument(c *gin.Context) {
+	c.Data(http.StatusOK, "application/yaml", api.OpenAPISpec)
+}
+
+// docs serves Swagger UI
+func (s *Server) docs(c *gin.Context) {
+	c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(docsPage))
+}
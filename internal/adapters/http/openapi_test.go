@@ -0,0 +1,228 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/api"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func loadTestContract(t *testing.T) *Contract {
+	contract, err := LoadContract(api.OpenAPISpec)
+	require.NoError(t, err)
+	return contract
+}
+
+func newContractTestServer(t *testing.T, beerService primary.BeerService) *Server {
+	return NewServer(beerService, config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithContractValidation(loadTestContract(t), true), WithHTTPCaching(testCachePolicy),
+		WithBodyLimits(&BodyLimits{Routes: map[string]int64{"POST /api/v1/beers": 128}}))
+}
+
+func TestLoadContract(t *testing.T) {
+	contract := loadTestContract(t)
+	assert.True(t, contract.Describes(http.MethodGet, "/api/v1/beers/:id/boxprice"))
+	assert.False(t, contract.Describes(http.MethodPatch, "/api/v1/beers/:id"))
+
+	_, err := LoadContract([]byte("openapi: 3.0.3\npaths: {}"))
+	assert.Error(t, err)
+}
+
+// TestContractDescribesEveryRoute catches routes added without documenting them
+func TestContractDescribesEveryRoute(t *testing.T) {
+	contract := loadTestContract(t)
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithOrderService(new(MockOrderService)),
+		WithQuoteService(new(MockQuoteService)),
+		WithPricingService(new(MockPricingService)),
+		WithPromotionService(new(MockPromotionService)),
+		WithMetrics(&recordingMetrics{}),
+		WithGraphQL(http.NotFoundHandler()),
+		WithEventService(&stubEventService{}, time.Minute, true),
+		WithWebhookService(new(MockWebhookService)),
+	)
+
+	for _, route := range server.router.Routes() {
+		assert.True(t, contract.Describes(route.Method, route.Path), "%s %s is not in openapi.yml", route.Method, route.Path)
+	}
+}
+
+func TestServeOpenAPIDocument(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	req, _ := http.NewRequest(http.MethodGet, OpenAPIPath, nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/yaml", w.Header().Get("Content-Type"))
+	assert.Equal(t, api.OpenAPISpec, w.Body.Bytes())
+
+	req, _ = http.NewRequest(http.MethodGet, DocsPath, nil)
+	w = httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `url: "/openapi.yml"`)
+}
+
+func TestContractRejectsInvalidRequests(t *testing.T) {
+	server := newContractTestServer(t, new(MockBeerService))
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		fields []string
+	}{
+		{"invalid body fields", http.MethodPost, "/api/v1/beers",
+			`{"id": 0, "name": "IPA", "brewery": "Craft", "country": "USA", "price": "cheap", "currency": "USD"}`,
+			[]string{"id", "price"}},
+		{"missing required field", http.MethodPost, "/api/v1/beers",
+			`{"id": 1, "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`,
+			[]string{"name"}},
+		{"invalid query parameter", http.MethodGet, "/api/v1/beers/1/boxprice?quantity=0", "", []string{"quantity"}},
+		{"invalid path parameter", http.MethodGet, "/api/v1/beers/abc", "", []string{"id"}},
+		{"legacy route", http.MethodGet, "/beers/1/boxprice?destination=CHL", "", []string{"destination"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Equal(t, problemContentType, w.Header().Get("Content-Type"))
+
+			var problem Problem
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+			assert.Equal(t, "INVALID_REQUEST", problem.Code)
+
+			fields := make([]string, 0, len(problem.Errors))
+			for _, fieldErr := range problem.Errors {
+				fields = append(fields, fieldErr.Field)
+			}
+			assert.ElementsMatch(t, tt.fields, fields)
+		})
+	}
+}
+
+func TestContractAcceptsValidRequests(t *testing.T) {
+	service := new(MockBeerService)
+	beer := &beers.Beer{ID: 1, Name: "IPA", Brewery: "Craft", Country: "USA", Price: 2.5, Currency: "USD",
+		CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	service.On("FindBeerByID", mock.Anything, 1).Return(beer, nil)
+	service.On("FindAllBeers", mock.Anything).Return([]beers.Beer{*beer}, nil)
+	service.On("CreateBeer", mock.Anything, mock.Anything).Return(nil)
+	service.On("CalculateBoxPrice", mock.Anything, mock.Anything).
+		Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 1 not found", nil))
+	server := newContractTestServer(t, service)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+	}{
+		{"get beer", http.MethodGet, "/api/v1/beers/1", "", http.StatusOK},
+		{"get beer as xml", http.MethodGet, "/api/v1/beers/1?format=xml", "", http.StatusOK},
+		{"get beer as csv", http.MethodGet, "/api/v1/beers/1?format=csv", "", http.StatusOK},
+		{"get beer as msgpack", http.MethodGet, "/api/v1/beers/1?format=msgpack", "", http.StatusOK},
+		{"unsupported format", http.MethodGet, "/api/v1/beers/1?format=yaml", "", http.StatusNotAcceptable},
+		{"create beer", http.MethodPost, "/api/v1/beers",
+			`{"id": 1, "name": "IPA", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`,
+			http.StatusCreated},
+		{"body over the limit", http.MethodPost, "/api/v1/beers",
+			`{"id": 1, "name": "` + strings.Repeat("I", 128) + `", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`,
+			http.StatusRequestEntityTooLarge},
+		{"problem response", http.MethodGet, "/api/v1/beers/1/boxprice?quantity=6&currency=EUR", "", http.StatusNotFound},
+		{"get beer v2", http.MethodGet, "/api/v2/beers/1", "", http.StatusOK},
+		{"get beer page v2", http.MethodGet, "/api/v2/beers?page=1&per_page=10", "", http.StatusOK},
+		{"create beer v2", http.MethodPost, "/api/v2/beers",
+			`{"id": 1, "name": "IPA", "brewery": "Craft", "country": "USA", "price": 2.5, "currency": "USD"}`,
+			http.StatusCreated},
+		{"problem response v2", http.MethodGet, "/api/v2/beers/1/boxprice?quantity=6&currency=EUR", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Empty(t, w.Header().Get(ContractViolationHeader))
+		})
+	}
+}
+
+func TestContractFlagsResponseViolations(t *testing.T) {
+	contract := loadTestContract(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ContractMiddleware(contract, true, logger.NewNoOpLogger()))
+	router.GET("/api/v1/beers/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id"), "name": "IPA"})
+	})
+	router.DELETE("/api/v1/beers/:id/pricing", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/beers/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get(ContractViolationHeader))
+	assert.JSONEq(t, `{"id": "1", "name": "IPA"}`, w.Body.String())
+
+	req, _ = http.NewRequest(http.MethodDelete, "/api/v1/beers/1/pricing", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get(ContractViolationHeader))
+}
+
+func TestContractSkipsResponsesWhenOnlyRequestsAreValidated(t *testing.T) {
+	contract := loadTestContract(t)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(ContractMiddleware(contract, false, logger.NewNoOpLogger()))
+	router.GET("/api/v1/beers/:id", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"id": c.Param("id")})
+	})
+	router.GET("/undocumented", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	for _, target := range []string{"/api/v1/beers/1", "/undocumented?anything=goes"} {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Header().Get(ContractViolationHeader))
+	}
+}
@@ -0,0 +1,175 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// OrderHandler handles HTTP requests for cart and order operations
+type OrderHandler struct {
+	orderService primary.OrderService
+	logger       secondary.Logger
+}
+
+// NewOrderHandler creates a new order handler
+func NewOrderHandler(orderService primary.OrderService, logger secondary.Logger) *OrderHandler {
+	return &OrderHandler{
+		orderService: orderService,
+		logger:       logger,
+	}
+}
+
+// CreateCart handles POST /carts
+func (h *OrderHandler) CreateCart(c *gin.Context) {
+	var req primary.CreateCartRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.invalidBody(c, err)
+		return
+	}
+
+	cart, err := h.orderService.CreateCart(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, "Failed to create cart", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, cart)
+}
+
+// GetCart handles GET /carts/:id
+func (h *OrderHandler) GetCart(c *gin.Context) {
+	cart, err := h.orderService.GetCart(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, "Failed to find cart", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// AddCartItem handles POST /carts/:id/items
+func (h *OrderHandler) AddCartItem(c *gin.Context) {
+	var req primary.CartItemRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.invalidBody(c, err)
+		return
+	}
+
+	cart, err := h.orderService.AddCartItem(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.handleError(c, "Failed to add cart item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// RemoveCartItem handles DELETE /carts/:id/items/:beer_id
+func (h *OrderHandler) RemoveCartItem(c *gin.Context) {
+	beerIDParam := c.Param("beer_id")
+	beerID, err := strconv.Atoi(beerIDParam)
+	if err != nil {
+		writeInvalidParam(c, "INVALID_ID", "beer_id", "Beer ID must be a valid integer")
+		return
+	}
+
+	cart, err := h.orderService.RemoveCartItem(c.Request.Context(), c.Param("id"), beerID)
+	if err != nil {
+		h.handleError(c, "Failed to remove cart item", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cart)
+}
+
+// Checkout handles POST /carts/:id/checkout. The body, which only carries an
+// optional coupon, may be left out.
+func (h *OrderHandler) Checkout(c *gin.Context) {
+	var req primary.CheckoutRequest
+
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			h.invalidBody(c, err)
+			return
+		}
+	}
+
+	order, err := h.orderService.Checkout(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.handleError(c, "Failed to checkout cart", err)
+		return
+	}
+
+	h.logger.Info(c.Request.Context(), "Order placed", map[string]interface{}{
+		"order_id": order.ID,
+		"cart_id":  order.CartID,
+	})
+
+	c.JSON(http.StatusCreated, order)
+}
+
+// GetOrder handles GET /orders/:id
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	order, err := h.orderService.GetOrder(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, "Failed to find order", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// ListOrders handles GET /orders
+func (h *OrderHandler) ListOrders(c *gin.Context) {
+	ordersSlice, err := h.orderService.ListOrders(c.Request.Context())
+	if err != nil {
+		h.handleError(c, "Failed to find orders", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, ordersSlice)
+}
+
+// UpdateOrderStatus handles PUT /orders/:id/status
+func (h *OrderHandler) UpdateOrderStatus(c *gin.Context) {
+	var req primary.UpdateOrderStatusRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.invalidBody(c, err)
+		return
+	}
+
+	order, err := h.orderService.UpdateOrderStatus(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.handleError(c, "Failed to update order status", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, order)
+}
+
+// invalidBody responds to a request body that cannot be decoded
+func (h *OrderHandler) invalidBody(c *gin.Context, err error) {
+	h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+	writeBindingError(c, err)
+}
+
+// handleError handles errors and sends appropriate HTTP responses
+func (h *OrderHandler) handleError(c *gin.Context, message string, err error) {
+	h.logger.Error(c.Request.Context(), message, err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+
+	writeError(c, err)
+}
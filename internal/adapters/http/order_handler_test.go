@@ -0,0 +1,242 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockOrderService is a mock of OrderService
+type MockOrderService struct {
+	mock.Mock
+}
+
+func (m *MockOrderService) CreateCart(ctx context.Context, req primary.CreateCartRequest) (*orders.Cart, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Cart), args.Error(1)
+}
+
+func (m *MockOrderService) GetCart(ctx context.Context, id string) (*orders.Cart, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Cart), args.Error(1)
+}
+
+func (m *MockOrderService) AddCartItem(ctx context.Context, cartID string, req primary.CartItemRequest) (*orders.Cart, error) {
+	args := m.Called(ctx, cartID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Cart), args.Error(1)
+}
+
+func (m *MockOrderService) RemoveCartItem(ctx context.Context, cartID string, beerID int) (*orders.Cart, error) {
+	args := m.Called(ctx, cartID, beerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Cart), args.Error(1)
+}
+
+func (m *MockOrderService) Checkout(ctx context.Context, cartID string, req primary.CheckoutRequest) (*orders.Order, error) {
+	args := m.Called(ctx, cartID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Order), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrder(ctx context.Context, id string) (*orders.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Order), args.Error(1)
+}
+
+func (m *MockOrderService) ListOrders(ctx context.Context) ([]orders.Order, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]orders.Order), args.Error(1)
+}
+
+func (m *MockOrderService) UpdateOrderStatus(ctx context.Context, id string, req primary.UpdateOrderStatusRequest) (*orders.Order, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Order), args.Error(1)
+}
+
+func TestCreateCart(t *testing.T) {
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.POST("/carts", handler.CreateCart)
+
+	t.Run("success", func(t *testing.T) {
+		cart := &orders.Cart{ID: "cart_1", Currency: "USD", Items: []orders.CartItem{}}
+		mockService.On("CreateCart", mock.Anything, primary.CreateCartRequest{Currency: "USD"}).Return(cart, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/carts", bytes.NewBufferString(`{"currency":"USD"}`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/carts", bytes.NewBufferString("invalid json"))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetCartNotFound(t *testing.T) {
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.GET("/carts/:id", handler.GetCart)
+
+	mockService.On("GetCart", mock.Anything, "missing").
+		Return(nil, beers.NewDomainError("CART_NOT_FOUND", "not found", nil)).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/carts/missing", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	mockService.AssertExpectations(t)
+}
+
+func TestRemoveCartItemInvalidID(t *testing.T) {
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.DELETE("/carts/:id/items/:beer_id", handler.RemoveCartItem)
+
+	req, _ := http.NewRequest(http.MethodDelete, "/carts/cart_1/items/abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCheckout(t *testing.T) {
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.POST("/carts/:id/checkout", handler.Checkout)
+
+	t.Run("success", func(t *testing.T) {
+		order := &orders.Order{ID: "ord_1", CartID: "cart_1", Currency: "USD", Total: 12, Status: orders.StatusPlaced}
+		mockService.On("Checkout", mock.Anything, "cart_1", primary.CheckoutRequest{}).Return(order, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/carts/cart_1/checkout", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var respOrder orders.Order
+		json.Unmarshal(w.Body.Bytes(), &respOrder)
+		assert.Equal(t, order.ID, respOrder.ID)
+		assert.Equal(t, orders.StatusPlaced, respOrder.Status)
+	})
+
+	t.Run("already checked out", func(t *testing.T) {
+		mockService.On("Checkout", mock.Anything, "cart_2", primary.CheckoutRequest{}).
+			Return(nil, beers.NewDomainError("CART_CHECKED_OUT", "checked out", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/carts/cart_2/checkout", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("with coupon", func(t *testing.T) {
+		order := &orders.Order{ID: "ord_3", CartID: "cart_3", Currency: "USD", Coupon: "SAVE20", Discount: 3, Total: 12, Status: orders.StatusPlaced}
+		mockService.On("Checkout", mock.Anything, "cart_3", primary.CheckoutRequest{Coupon: "save20"}).Return(order, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/carts/cart_3/checkout", strings.NewReader(`{"coupon": "save20"}`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var respOrder orders.Order
+		json.Unmarshal(w.Body.Bytes(), &respOrder)
+		assert.Equal(t, "SAVE20", respOrder.Coupon)
+		assert.Equal(t, 3.0, respOrder.Discount)
+	})
+
+	t.Run("coupon used up", func(t *testing.T) {
+		mockService.On("Checkout", mock.Anything, "cart_4", primary.CheckoutRequest{Coupon: "SAVE20"}).
+			Return(nil, beers.NewDomainError(promotions.ErrCodeUsageLimitReached, "Promotion has reached its usage limit", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/carts/cart_4/checkout", strings.NewReader(`{"coupon": "SAVE20"}`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	mockService := new(MockOrderService)
+	handler := NewOrderHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.PUT("/orders/:id/status", handler.UpdateOrderStatus)
+
+	t.Run("success", func(t *testing.T) {
+		order := &orders.Order{ID: "ord_1", Status: orders.StatusPaid}
+		mockService.On("UpdateOrderStatus", mock.Anything, "ord_1", primary.UpdateOrderStatusRequest{Status: orders.StatusPaid}).
+			Return(order, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodPut, "/orders/ord_1/status", bytes.NewBufferString(`{"status":"paid"}`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid transition", func(t *testing.T) {
+		mockService.On("UpdateOrderStatus", mock.Anything, "ord_2", primary.UpdateOrderStatusRequest{Status: orders.StatusShipped}).
+			Return(nil, beers.NewDomainError("INVALID_STATUS_TRANSITION", "nope", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodPut, "/orders/ord_2/status", bytes.NewBufferString(`{"status":"shipped"}`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+}
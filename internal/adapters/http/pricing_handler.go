@@ -0,0 +1,105 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PricingHandler handles HTTP requests for pack and tier pricing rules
+type PricingHandler struct {
+	pricingService primary.PricingService
+	logger         secondary.Logger
+}
+
+// NewPricingHandler creates a new pricing handler
+func NewPricingHandler(pricingService primary.PricingService, logger secondary.Logger) *PricingHandler {
+	return &PricingHandler{
+		pricingService: pricingService,
+		logger:         logger,
+	}
+}
+
+// SetPricingRule handles PUT /beers/:id/pricing
+func (h *PricingHandler) SetPricingRule(c *gin.Context) {
+	beerID, ok := h.beerID(c)
+	if !ok {
+		return
+	}
+
+	var req primary.PricingRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+			"endpoint": "PUT /beers/:id/pricing",
+		})
+		writeBindingError(c, err)
+		return
+	}
+
+	rule, err := h.pricingService.SetPricingRule(c.Request.Context(), beerID, req)
+	if err != nil {
+		h.handleError(c, "Failed to set pricing rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// GetPricingRule handles GET /beers/:id/pricing
+func (h *PricingHandler) GetPricingRule(c *gin.Context) {
+	beerID, ok := h.beerID(c)
+	if !ok {
+		return
+	}
+
+	rule, err := h.pricingService.GetPricingRule(c.Request.Context(), beerID)
+	if err != nil {
+		h.handleError(c, "Failed to find pricing rule", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rule)
+}
+
+// DeletePricingRule handles DELETE /beers/:id/pricing
+func (h *PricingHandler) DeletePricingRule(c *gin.Context) {
+	beerID, ok := h.beerID(c)
+	if !ok {
+		return
+	}
+
+	if err := h.pricingService.DeletePricingRule(c.Request.Context(), beerID); err != nil {
+		h.handleError(c, "Failed to delete pricing rule", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// beerID parses the beer ID path parameter, responding with 400 when invalid
+func (h *PricingHandler) beerID(c *gin.Context) (int, bool) {
+	idParam := c.Param("id")
+	id, err := strconv.Atoi(idParam)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid beer ID", err, map[string]interface{}{
+			"id_param": idParam,
+		})
+		writeInvalidParam(c, "INVALID_ID", "id", "Beer ID must be a valid integer")
+		return 0, false
+	}
+
+	return id, true
+}
+
+// handleError handles errors and sends appropriate HTTP responses
+func (h *PricingHandler) handleError(c *gin.Context, message string, err error) {
+	h.logger.Error(c.Request.Context(), message, err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+
+	writeError(c, err)
+}
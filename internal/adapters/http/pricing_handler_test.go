@@ -0,0 +1,100 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockPricingService is a mock of PricingService
+type MockPricingService struct {
+	mock.Mock
+}
+
+func (m *MockPricingService) SetPricingRule(ctx context.Context, beerID int, req primary.PricingRuleRequest) (*pricing.Rule, error) {
+	args := m.Called(ctx, beerID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pricing.Rule), args.Error(1)
+}
+
+func (m *MockPricingService) GetPricingRule(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	args := m.Called(ctx, beerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pricing.Rule), args.Error(1)
+}
+
+func (m *MockPricingService) DeletePricingRule(ctx context.Context, beerID int) error {
+	args := m.Called(ctx, beerID)
+	return args.Error(0)
+}
+
+func TestPricingHandler(t *testing.T) {
+	mockService := new(MockPricingService)
+	handler := NewPricingHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.PUT("/beers/:id/pricing", handler.SetPricingRule)
+	r.GET("/beers/:id/pricing", handler.GetPricingRule)
+	r.DELETE("/beers/:id/pricing", handler.DeletePricingRule)
+
+	t.Run("set rule", func(t *testing.T) {
+		reqBody := primary.PricingRuleRequest{
+			Packs: []pricing.PackSKU{{SKU: "SIX", Size: 6, Price: 5}},
+			Tiers: []pricing.DiscountTier{{MinUnits: 48, Percent: 5}},
+		}
+		rule := &pricing.Rule{BeerID: 1, Packs: reqBody.Packs, Tiers: reqBody.Tiers}
+		mockService.On("SetPricingRule", mock.Anything, 1, reqBody).Return(rule, nil).Once()
+
+		body := `{"packs":[{"sku":"SIX","size":6,"price":5}],"tiers":[{"min_units":48,"percent":5}]}`
+		req, _ := http.NewRequest(http.MethodPut, "/beers/1/pricing", bytes.NewBufferString(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("get missing rule", func(t *testing.T) {
+		mockService.On("GetPricingRule", mock.Anything, 2).
+			Return(nil, beers.NewDomainError(pricing.ErrCodeRuleNotFound, "no rule", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/beers/2/pricing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("delete rule", func(t *testing.T) {
+		mockService.On("DeletePricingRule", mock.Anything, 1).Return(nil).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/beers/1/pricing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("invalid id", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodGet, "/beers/abc/pricing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -0,0 +1,264 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/correlation"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/domain/webhooks"
+)
+
+const (
+	// problemContentType is the media type of RFC 7807 problem details
+	problemContentType = "application/problem+json"
+
+	// problemTypeBase prefixes the problem type URI reference of every error code
+	problemTypeBase = "/problems/"
+)
+
+// traceparentPattern matches a W3C traceparent header and captures its trace id
+var traceparentPattern = regexp.MustCompile(`^[0-9a-f]{2}-([0-9a-f]{32})-[0-9a-f]{16}-[0-9a-f]{2}$`)
+
+// Problem represents an RFC 7807 problem details response
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	TraceID  string       `json:"trace_id,omitempty"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single request field is invalid
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// newProblem creates a problem for an error code
+func newProblem(status int, code, detail string, fieldErrors ...FieldError) *Problem {
+	return &Problem{
+		Type:   problemTypeBase + strings.ToLower(strings.ReplaceAll(code, "_", "-")),
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+		Code:   code,
+		Errors: fieldErrors,
+	}
+}
+
+// domainErrorStatus maps domain error codes to HTTP status codes
+var domainErrorStatus = map[string]int{
+	"BEER_NOT_FOUND":                      http.StatusNotFound,
+	"BEER_ALREADY_EXISTS":                 http.StatusConflict,
+	"INVALID_CURRENCY":                    http.StatusBadRequest,
+	pricing.ErrCodeRuleNotFound:           http.StatusNotFound,
+	orders.ErrCodeCartNotFound:            http.StatusNotFound,
+	orders.ErrCodeCartItemNotFound:        http.StatusNotFound,
+	orders.ErrCodeCartCheckedOut:          http.StatusConflict,
+	orders.ErrCodeCartEmpty:               http.StatusUnprocessableEntity,
+	orders.ErrCodeOrderNotFound:           http.StatusNotFound,
+	orders.ErrCodeInvalidStatusTransition: http.StatusConflict,
+	orders.ErrCodeOrderStatusConflict:     http.StatusConflict,
+	tax.ErrCodeAttributesMissing:          http.StatusUnprocessableEntity,
+	tax.ErrCodeRulesNotConfigured:         http.StatusUnprocessableEntity,
+	promotions.ErrCodeNotFound:            http.StatusNotFound,
+	promotions.ErrCodeCouponExists:        http.StatusConflict,
+	promotions.ErrCodeUsageLimitReached:   http.StatusConflict,
+	promotions.ErrCodeCouponNotApplicable: http.StatusUnprocessableEntity,
+	auth.ErrCodeUnauthenticated:           http.StatusUnauthorized,
+	auth.ErrCodeForbidden:                 http.StatusForbidden,
+	auth.ErrCodeAPIKeyNotFound:            http.StatusNotFound,
+	idempotency.ErrCodeKeyReused:          http.StatusUnprocessableEntity,
+	idempotency.ErrCodeInProgress:         http.StatusConflict,
+	ratelimit.ErrCodeRateLimited:          http.StatusTooManyRequests,
+	events.ErrCodeUnavailable:             http.StatusServiceUnavailable,
+	webhooks.ErrCodeSubscriptionNotFound:  http.StatusNotFound,
+	webhooks.ErrCodeDeliveryNotFound:      http.StatusNotFound,
+	webhooks.ErrCodeDeliveryNotDead:       http.StatusConflict,
+}
+
+// currencyErrorStatus maps currency error codes to HTTP status codes.
+// Unlisted codes are failures of the rate provider and map to 502.
+var currencyErrorStatus = map[string]int{
+	"RATE_NOT_FOUND":   http.StatusUnprocessableEntity,
+	"INVALID_CURRENCY": http.StatusBadRequest,
+}
+
+// problemFromError maps a service error, however deeply wrapped, to a problem
+func problemFromError(err error) *Problem {
+	var validationErr *beers.ValidationError
+	if errors.As(err, &validationErr) {
+		return newProblem(http.StatusBadRequest, "VALIDATION_ERROR", validationErr.Error(),
+			FieldError{Field: validationErr.Field, Message: validationErr.Message})
+	}
+
+	var domainErr *beers.DomainError
+	if errors.As(err, &domainErr) {
+		status, known := domainErrorStatus[domainErr.Code]
+		if !known {
+			status = http.StatusInternalServerError
+		}
+		return newProblem(status, domainErr.Code, domainErr.Message)
+	}
+
+	var currencyErr *currency.CurrencyError
+	if errors.As(err, &currencyErr) {
+		status, known := currencyErrorStatus[currencyErr.Code]
+		if !known {
+			status = http.StatusBadGateway
+		}
+		return newProblem(status, currencyErr.Code, currencyErr.Message)
+	}
+
+	return newProblem(http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred")
+}
+
+// problemFromBindingError maps a request decoding or binding error to a problem
+// listing every invalid field, or to 413 when the body was over its limit
+func problemFromBindingError(err error) *Problem {
+	const code = "INVALID_REQUEST"
+
+	if problem, tooLarge := asRequestTooLarge(err); tooLarge {
+		return problem
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		fieldErrors := make([]FieldError, 0, len(validationErrs))
+		for _, fieldErr := range validationErrs {
+			fieldErrors = append(fieldErrors, FieldError{
+				Field:   toSnakeCase(fieldErr.Field()),
+				Message: validationMessage(fieldErr),
+			})
+		}
+		return newProblem(http.StatusBadRequest, code, "Request body has invalid fields", fieldErrors...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return newProblem(http.StatusBadRequest, code, "Request body has invalid fields", FieldError{
+			Field:   typeErr.Field,
+			Message: fmt.Sprintf("must be of type %s", typeErr.Type.String()),
+		})
+	}
+
+	var syntaxErr *json.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return newProblem(http.StatusBadRequest, code, fmt.Sprintf("Malformed JSON at offset %d", syntaxErr.Offset))
+	}
+
+	if errors.Is(err, io.EOF) {
+		return newProblem(http.StatusBadRequest, code, "Request body is empty")
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) {
+		return newProblem(http.StatusBadRequest, code, "Request body is truncated")
+	}
+
+	return newProblem(http.StatusBadRequest, code, "Invalid request body: "+err.Error())
+}
+
+// validationMessage describes a failed validator rule
+func validationMessage(fieldErr validator.FieldError) string {
+	switch fieldErr.Tag() {
+	case "required":
+		return beers.ErrCannotBeEmpty
+	case "min", "gte":
+		return "must be at least " + fieldErr.Param()
+	case "max", "lte":
+		return "must be at most " + fieldErr.Param()
+	case "gt":
+		return "must be greater than " + fieldErr.Param()
+	case "len":
+		return "must have length " + fieldErr.Param()
+	case "oneof":
+		return "must be one of: " + fieldErr.Param()
+	default:
+		return fmt.Sprintf("failed the %s rule", fieldErr.Tag())
+	}
+}
+
+// toSnakeCase converts a Go field name such as BeerID into its JSON name beer_id
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			startsWord := i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1])))
+			if startsWord {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// writeProblem sends a problem response and stops the handler chain
+func writeProblem(c *gin.Context, problem *Problem) {
+	problem.Instance = c.Request.URL.RequestURI()
+	problem.TraceID = traceID(c)
+
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(problem.Status, problem)
+}
+
+// writeError sends the problem response matching a service error
+func writeError(c *gin.Context, err error) {
+	writeProblem(c, problemFromError(err))
+}
+
+// writeBindingError sends the problem response matching a request binding error
+func writeBindingError(c *gin.Context, err error) {
+	writeProblem(c, problemFromBindingError(err))
+}
+
+// writeInvalidParam sends a 400 problem for an invalid path or query parameter
+func writeInvalidParam(c *gin.Context, code, field, detail string) {
+	writeProblem(c, newProblem(http.StatusBadRequest, code, detail, FieldError{Field: field, Message: detail}))
+}
+
+// traceID returns the trace id of the request: that of its span, else the one
+// in the W3C traceparent header or, failing that, the request id
+func traceID(c *gin.Context) string {
+	if spanContext := trace.SpanContextFromContext(c.Request.Context()); spanContext.IsValid() {
+		return spanContext.TraceID().String()
+	}
+
+	if match := traceparentPattern.FindStringSubmatch(c.GetHeader("traceparent")); match != nil {
+		return match[1]
+	}
+
+	if id, ok := correlation.RequestIDFromContext(c.Request.Context()); ok {
+		return id
+	}
+
+	return c.GetHeader(RequestIDHeader)
+}
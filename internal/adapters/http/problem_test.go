@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func TestProblemFromError(t *testing.T) {
+	cases := map[string]struct {
+		err    error
+		status int
+		code   string
+	}{
+		"wrapped not found": {
+			err:    fmt.Errorf("failed to find beer: %w", beers.NewDomainError("BEER_NOT_FOUND", "Beer not found", nil)),
+			status: http.StatusNotFound,
+			code:   "BEER_NOT_FOUND",
+		},
+		"unknown domain code": {
+			err:    beers.NewDomainError("SOMETHING_ELSE", "Something else", nil),
+			status: http.StatusInternalServerError,
+			code:   "SOMETHING_ELSE",
+		},
+		"wrapped currency provider failure": {
+			err:    fmt.Errorf("failed to get exchange rate: %w", currency.NewCurrencyError("API_REQUEST_FAILED", "Failed to make API request", errors.New("timeout"))),
+			status: http.StatusBadGateway,
+			code:   "API_REQUEST_FAILED",
+		},
+		"unknown rate": {
+			err:    currency.NewCurrencyError("RATE_NOT_FOUND", "Exchange rate not found for currency XXX", nil),
+			status: http.StatusUnprocessableEntity,
+			code:   "RATE_NOT_FOUND",
+		},
+		"unexpected": {
+			err:    errors.New("boom"),
+			status: http.StatusInternalServerError,
+			code:   "INTERNAL_ERROR",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			problem := problemFromError(tc.err)
+
+			assert.Equal(t, tc.status, problem.Status)
+			assert.Equal(t, tc.code, problem.Code)
+			assert.Equal(t, http.StatusText(tc.status), problem.Title)
+		})
+	}
+}
+
+func TestProblemFromValidationError(t *testing.T) {
+	err := fmt.Errorf("failed to create beer: %w", beers.NewValidationError("price", beers.ErrCannotBeNegative))
+
+	problem := problemFromError(err)
+
+	assert.Equal(t, http.StatusBadRequest, problem.Status)
+	assert.Equal(t, "/problems/validation-error", problem.Type)
+	assert.Equal(t, []FieldError{{Field: "price", Message: beers.ErrCannotBeNegative}}, problem.Errors)
+}
+
+func TestProblemFromBindingError(t *testing.T) {
+	t.Run("validator errors list every field", func(t *testing.T) {
+		err := validator.New().Struct(primary.CalculateBoxPriceRequest{Quantity: 2000, Currency: "US"})
+
+		problem := problemFromBindingError(err)
+
+		assert.Equal(t, http.StatusBadRequest, problem.Status)
+		assert.Equal(t, "INVALID_REQUEST", problem.Code)
+		assert.Equal(t, []FieldError{
+			{Field: "beer_id", Message: beers.ErrCannotBeEmpty},
+			{Field: "quantity", Message: "must be at most 1000"},
+			{Field: "currency", Message: "must have length 3"},
+		}, problem.Errors)
+	})
+
+	t.Run("type mismatch", func(t *testing.T) {
+		var req primary.CreateBeerRequest
+		err := json.Unmarshal([]byte(`{"price": "cheap"}`), &req)
+
+		problem := problemFromBindingError(err)
+
+		assert.Equal(t, "price", problem.Errors[0].Field)
+		assert.Equal(t, "must be of type float64", problem.Errors[0].Message)
+	})
+
+	t.Run("malformed json", func(t *testing.T) {
+		var req primary.CreateBeerRequest
+		err := json.Unmarshal([]byte(`{"price": }`), &req)
+
+		problem := problemFromBindingError(err)
+
+		assert.Contains(t, problem.Detail, "Malformed JSON")
+		assert.Empty(t, problem.Errors)
+	})
+}
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "beer_id", toSnakeCase("BeerID"))
+	assert.Equal(t, "volume_ml", toSnakeCase("VolumeML"))
+	assert.Equal(t, "abv", toSnakeCase("ABV"))
+	assert.Equal(t, "currency", toSnakeCase("Currency"))
+}
+
+func TestWriteProblem(t *testing.T) {
+	mockService := new(MockBeerService)
+	handler := NewBeerHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.GET("/beers/:id/boxprice", handler.CalculateBoxPrice)
+
+	mockService.On("CalculateBoxPrice", mock.Anything, mock.Anything).
+		Return(nil, fmt.Errorf("failed to find beer: %w", beers.NewDomainError("BEER_NOT_FOUND", "Beer not found", nil))).Once()
+
+	req, _ := http.NewRequest(http.MethodGet, "/beers/99/boxprice?quantity=6", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Equal(t, problemContentType, w.Header().Get(contentTypeHeader))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &problem))
+	assert.Equal(t, "/problems/beer-not-found", problem.Type)
+	assert.Equal(t, "Not Found", problem.Title)
+	assert.Equal(t, http.StatusNotFound, problem.Status)
+	assert.Equal(t, "Beer not found", problem.Detail)
+	assert.Equal(t, "/beers/99/boxprice?quantity=6", problem.Instance)
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", problem.TraceID)
+}
+
+func TestServerProblemResponses(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+	server.router.GET("/panic", func(c *gin.Context) { panic("boom") })
+
+	cases := map[string]struct {
+		method string
+		path   string
+		status int
+	}{
+		"unknown route":  {http.MethodGet, "/nope", http.StatusNotFound},
+		"unknown method": {http.MethodPatch, "/ping", http.StatusMethodNotAllowed},
+		"panic":          {http.MethodGet, "/panic", http.StatusInternalServerError},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req, _ := http.NewRequest(tc.method, tc.path, nil)
+			w := httptest.NewRecorder()
+			server.router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.status, w.Code)
+			assert.Equal(t, problemContentType, w.Header().Get(contentTypeHeader))
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PromotionHandler handles HTTP requests for promotion administration
+type PromotionHandler struct {
+	promotionService primary.PromotionService
+	logger           secondary.Logger
+}
+
+// NewPromotionHandler creates a new promotion handler
+func NewPromotionHandler(promotionService primary.PromotionService, logger secondary.Logger) *PromotionHandler {
+	return &PromotionHandler{
+		promotionService: promotionService,
+		logger:           logger,
+	}
+}
+
+// CreatePromotion handles POST /promotions
+func (h *PromotionHandler) CreatePromotion(c *gin.Context) {
+	req, ok := h.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionService.CreatePromotion(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, "Failed to create promotion", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, promotion)
+}
+
+// ListPromotions handles GET /promotions
+func (h *PromotionHandler) ListPromotions(c *gin.Context) {
+	result, err := h.promotionService.ListPromotions(c.Request.Context())
+	if err != nil {
+		h.handleError(c, "Failed to list promotions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetPromotion handles GET /promotions/:id
+func (h *PromotionHandler) GetPromotion(c *gin.Context) {
+	promotion, err := h.promotionService.GetPromotion(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, "Failed to find promotion", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// UpdatePromotion handles PUT /promotions/:id
+func (h *PromotionHandler) UpdatePromotion(c *gin.Context) {
+	req, ok := h.bindRequest(c)
+	if !ok {
+		return
+	}
+
+	promotion, err := h.promotionService.UpdatePromotion(c.Request.Context(), c.Param("id"), req)
+	if err != nil {
+		h.handleError(c, "Failed to update promotion", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, promotion)
+}
+
+// DeletePromotion handles DELETE /promotions/:id
+func (h *PromotionHandler) DeletePromotion(c *gin.Context) {
+	if err := h.promotionService.DeletePromotion(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, "Failed to delete promotion", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// bindRequest parses a promotion request body, responding with 400 when invalid
+func (h *PromotionHandler) bindRequest(c *gin.Context) (primary.PromotionRequest, bool) {
+	var req primary.PromotionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+			"endpoint": c.Request.Method + " " + c.FullPath(),
+		})
+		writeBindingError(c, err)
+		return req, false
+	}
+
+	return req, true
+}
+
+// handleError handles errors and sends appropriate HTTP responses
+func (h *PromotionHandler) handleError(c *gin.Context, message string, err error) {
+	h.logger.Error(c.Request.Context(), message, err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+
+	writeError(c, err)
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockPromotionService is a mock of PromotionService
+type MockPromotionService struct {
+	mock.Mock
+}
+
+func (m *MockPromotionService) CreatePromotion(ctx context.Context, req primary.PromotionRequest) (*promotions.Promotion, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) GetPromotion(ctx context.Context, id string) (*promotions.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) ListPromotions(ctx context.Context) ([]promotions.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) UpdatePromotion(ctx context.Context, id string, req primary.PromotionRequest) (*promotions.Promotion, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionService) DeletePromotion(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func TestPromotionHandler(t *testing.T) {
+	mockService := new(MockPromotionService)
+	handler := NewPromotionHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.POST("/promotions", handler.CreatePromotion)
+	r.GET("/promotions", handler.ListPromotions)
+	r.GET("/promotions/:id", handler.GetPromotion)
+	r.PUT("/promotions/:id", handler.UpdatePromotion)
+	r.DELETE("/promotions/:id", handler.DeletePromotion)
+
+	t.Run("create", func(t *testing.T) {
+		reqBody := primary.PromotionRequest{
+			Name:       "Welcome",
+			Type:       promotions.TypePercentage,
+			Value:      10,
+			CouponCode: "WELCOME10",
+			UsageLimit: 100,
+		}
+		mockService.On("CreatePromotion", mock.Anything, reqBody).
+			Return(&promotions.Promotion{ID: "promo_1", Name: "Welcome"}, nil).Once()
+
+		body := `{"name":"Welcome","type":"percentage","value":10,"coupon_code":"WELCOME10","usage_limit":100}`
+		req, _ := http.NewRequest(http.MethodPost, "/promotions", bytes.NewBufferString(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("create invalid body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/promotions", bytes.NewBufferString(`{"name":`))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("create duplicate coupon", func(t *testing.T) {
+		mockService.On("CreatePromotion", mock.Anything, mock.Anything).
+			Return(nil, beers.NewDomainError(promotions.ErrCodeCouponExists, "Coupon code WELCOME10 is already in use", nil)).Once()
+
+		body := `{"name":"Again","type":"percentage","value":5,"coupon_code":"WELCOME10"}`
+		req, _ := http.NewRequest(http.MethodPost, "/promotions", bytes.NewBufferString(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		mockService.On("ListPromotions", mock.Anything).Return([]promotions.Promotion{{ID: "promo_1"}}, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/promotions", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "promo_1")
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		mockService.On("GetPromotion", mock.Anything, "promo_x").
+			Return(nil, beers.NewDomainError(promotions.ErrCodeNotFound, "Promotion not found", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/promotions/promo_x", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		mockService.On("UpdatePromotion", mock.Anything, "promo_1", mock.Anything).
+			Return(&promotions.Promotion{ID: "promo_1", Name: "Renamed"}, nil).Once()
+
+		body := `{"name":"Renamed","type":"percentage","value":15}`
+		req, _ := http.NewRequest(http.MethodPut, "/promotions/promo_1", bytes.NewBufferString(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		mockService.On("DeletePromotion", mock.Anything, "promo_1").Return(nil).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/promotions/promo_1", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+}
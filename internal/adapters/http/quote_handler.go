@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// QuoteHandler handles HTTP requests for multi-line quotes
+type QuoteHandler struct {
+	quoteService primary.QuoteService
+	logger       secondary.Logger
+}
+
+// NewQuoteHandler creates a new quote handler
+func NewQuoteHandler(quoteService primary.QuoteService, logger secondary.Logger) *QuoteHandler {
+	return &QuoteHandler{
+		quoteService: quoteService,
+		logger:       logger,
+	}
+}
+
+// CreateQuote handles POST /quotes
+func (h *QuoteHandler) CreateQuote(c *gin.Context) {
+	var req primary.QuoteRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+			"endpoint": "POST /quotes",
+		})
+		writeBindingError(c, err)
+		return
+	}
+
+	response, err := h.quoteService.CreateQuote(c.Request.Context(), req)
+	if err != nil {
+		h.logger.Error(c.Request.Context(), "Failed to create quote", err, map[string]interface{}{
+			"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+		})
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,90 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockQuoteService is a mock of QuoteService
+type MockQuoteService struct {
+	mock.Mock
+}
+
+func (m *MockQuoteService) CreateQuote(ctx context.Context, req primary.QuoteRequest) (*primary.QuoteResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.QuoteResponse), args.Error(1)
+}
+
+func TestCreateQuote(t *testing.T) {
+	mockService := new(MockQuoteService)
+	handler := NewQuoteHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.POST("/quotes", handler.CreateQuote)
+
+	t.Run("success with failed line", func(t *testing.T) {
+		reqBody := primary.QuoteRequest{
+			Currency: "USD",
+			Items:    []primary.QuoteItemRequest{{BeerID: 1, Quantity: 6}, {BeerID: 2, Quantity: 6}},
+		}
+		quote := &primary.QuoteResponse{
+			Currency: "USD",
+			Lines: []primary.QuoteLine{
+				{BeerID: 1, Quantity: 6, UnitPrice: 1, LineTotal: 6},
+				{BeerID: 2, Quantity: 6, Error: &primary.QuoteLineError{Code: "BEER_NOT_FOUND", Message: "not found"}},
+			},
+			GrandTotal:  6,
+			PricedLines: 1,
+			FailedLines: 1,
+		}
+		mockService.On("CreateQuote", mock.Anything, reqBody).Return(quote, nil).Once()
+
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequest(http.MethodPost, "/quotes", bytes.NewBuffer(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var respQuote primary.QuoteResponse
+		json.Unmarshal(w.Body.Bytes(), &respQuote)
+		assert.Equal(t, *quote, respQuote)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("invalid request body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "/quotes", bytes.NewBufferString("invalid json"))
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("validation error", func(t *testing.T) {
+		reqBody := primary.QuoteRequest{Currency: "USD"}
+		mockService.On("CreateQuote", mock.Anything, reqBody).
+			Return(nil, beers.NewValidationError("items", beers.ErrCannotBeEmpty)).Once()
+
+		body, _ := json.Marshal(reqBody)
+		req, _ := http.NewRequest(http.MethodPost, "/quotes", bytes.NewBuffer(body))
+		req.Header.Set(contentTypeHeader, jsonContentType)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
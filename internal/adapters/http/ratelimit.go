@@ -0,0 +1,78 @@
+package http
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// Rate limit response headers
+const (
+	RateLimitLimitHeader     = "X-RateLimit-Limit"
+	RateLimitRemainingHeader = "X-RateLimit-Remaining"
+	RateLimitResetHeader     = "X-RateLimit-Reset"
+)
+
+// RateLimitMiddleware enforces the token bucket limit of each route, with one bucket
+// per client and route. Clients are identified by their authenticated principal,
+// falling back to their IP address. If the store fails, requests are let through.
+func RateLimitMiddleware(store secondary.RateLimitStore, policy *ratelimit.Policy, logger secondary.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := c.FullPath()
+		if route == "" {
+			c.Next()
+			return
+		}
+
+		limit := policy.LimitFor(c.Request.Method, route)
+		if limit.IsZero() {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		decision, err := store.Take(ctx, clientIdentity(c)+" "+ratelimit.RouteKey(c.Request.Method, route), limit)
+		if err != nil {
+			logger.Error(ctx, "Rate limit store failed, allowing request", err, map[string]interface{}{
+				"route": route,
+			})
+			c.Next()
+			return
+		}
+
+		c.Header(RateLimitLimitHeader, strconv.Itoa(decision.Limit))
+		c.Header(RateLimitRemainingHeader, strconv.Itoa(decision.Remaining))
+		c.Header(RateLimitResetHeader, ceilSeconds(decision.ResetAfter))
+
+		if !decision.Allowed {
+			c.Header("Retry-After", ceilSeconds(decision.RetryAfter))
+			writeProblem(c, newProblem(http.StatusTooManyRequests, ratelimit.ErrCodeRateLimited,
+				fmt.Sprintf("Rate limit of %d requests per %s exceeded", limit.Requests, limit.Period)))
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// clientIdentity identifies the client behind a request: its principal when
+// authenticated, its IP address otherwise
+func clientIdentity(c *gin.Context) string {
+	if principal, ok := auth.PrincipalFromContext(c.Request.Context()); ok {
+		return "principal:" + principal.Subject
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// ceilSeconds formats a duration as whole seconds, rounded up
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+func newRateLimitTestServer(t *testing.T, routes string) *Server {
+	policy, err := ratelimit.ParsePolicy("", routes)
+	assert.NoError(t, err)
+
+	return NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger(),
+		WithRateLimiter(inmemory.NewRateLimitStore(), policy))
+}
+
+func getFrom(r http.Handler, path, clientIP string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = clientIP + ":40000"
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+// getForwarded sends a request from a proxy at 10.0.0.1 forwarding for a client
+func getForwarded(r http.Handler, forwardedFor string) int {
+	req, _ := http.NewRequest(http.MethodGet, "/api/v1/beers", nil)
+	req.RemoteAddr = "10.0.0.1:40000"
+	req.Header.Set("X-Forwarded-For", forwardedFor)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestRateLimitMiddleware(t *testing.T) {
+	server := newRateLimitTestServer(t, "GET /api/v1/beers=2/m")
+
+	first := getFrom(server.router, "/api/v1/beers", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, first.Code)
+	assert.Equal(t, "2", first.Header().Get(RateLimitLimitHeader))
+	assert.Equal(t, "1", first.Header().Get(RateLimitRemainingHeader))
+	assert.Equal(t, "30", first.Header().Get(RateLimitResetHeader))
+
+	assert.Equal(t, http.StatusOK, getFrom(server.router, "/api/v1/beers", "10.0.0.1").Code)
+
+	limited := getFrom(server.router, "/api/v1/beers", "10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+	assert.Equal(t, "30", limited.Header().Get("Retry-After"))
+	assert.Equal(t, "0", limited.Header().Get(RateLimitRemainingHeader))
+
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(limited.Body.Bytes(), &problem))
+	assert.Equal(t, ratelimit.ErrCodeRateLimited, problem.Code)
+
+	// Other clients and other routes have their own buckets
+	assert.Equal(t, http.StatusOK, getFrom(server.router, "/api/v1/beers", "10.0.0.2").Code)
+	unlimited := getFrom(server.router, "/beers", "10.0.0.1")
+	assert.Equal(t, http.StatusOK, unlimited.Code)
+	assert.Empty(t, unlimited.Header().Get(RateLimitLimitHeader))
+}
+
+func TestRateLimitIgnoresForwardedForFromUntrustedPeers(t *testing.T) {
+	server := newRateLimitTestServer(t, "GET /api/v1/beers=1/m")
+
+	assert.Equal(t, http.StatusOK, getForwarded(server.router, "203.0.113.1"))
+	assert.Equal(t, http.StatusTooManyRequests, getForwarded(server.router, "203.0.113.2"),
+		"a spoofed X-Forwarded-For does not get a fresh bucket")
+}
+
+func TestRateLimitTrustedProxy(t *testing.T) {
+	os.Setenv("TRUSTED_PROXIES", "10.0.0.1, 192.168.0.0/16")
+	defer os.Unsetenv("TRUSTED_PROXIES")
+	server := newRateLimitTestServer(t, "GET /api/v1/beers=1/m")
+
+	assert.Equal(t, http.StatusOK, getForwarded(server.router, "203.0.113.1"))
+	assert.Equal(t, http.StatusOK, getForwarded(server.router, "203.0.113.2"), "clients behind a trusted proxy have their own buckets")
+	assert.Equal(t, http.StatusTooManyRequests, getForwarded(server.router, "203.0.113.1"))
+}
+
+func TestRateLimitSkipsHealthCheck(t *testing.T) {
+	server := newRateLimitTestServer(t, "GET /ping=1/m")
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, http.StatusOK, getFrom(server.router, "/ping", "10.0.0.1").Code)
+	}
+}
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, ratelimit.Limit) (ratelimit.Decision, error) {
+	return ratelimit.Decision{}, errors.New("connection refused")
+}
+
+func TestRateLimitFailsOpen(t *testing.T) {
+	policy, _ := ratelimit.ParsePolicy("1/m", "")
+	r := setupRouter()
+	r.GET(beersEndpoint, RateLimitMiddleware(failingRateLimitStore{}, policy, logger.NewNoOpLogger()),
+		func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	assert.Equal(t, http.StatusOK, getFrom(r, beersEndpoint, "10.0.0.1").Code)
+	assert.Equal(t, http.StatusOK, getFrom(r, beersEndpoint, "10.0.0.1").Code)
+}
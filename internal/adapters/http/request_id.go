@@ -0,0 +1,27 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/correlation"
+)
+
+// RequestIDHeader carries the id that correlates the log lines of one request
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware reuses the client's X-Request-ID, or generates one when it is
+// missing or unsafe to echo, stores it on the request context for the logger and
+// echoes it in the response
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if !correlation.IsValidRequestID(id) {
+			id = correlation.NewRequestID()
+		}
+
+		c.Request = c.Request.WithContext(correlation.WithRequestID(c.Request.Context(), id))
+		c.Header(RequestIDHeader, id)
+
+		c.Next()
+	}
+}
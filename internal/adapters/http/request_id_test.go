@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/correlation"
+	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// setupRequestIDRouter serves an endpoint that answers with the request id on its context
+func setupRequestIDRouter() *gin.Engine {
+	r := setupRouter()
+	r.GET(beersEndpoint, RequestIDMiddleware(), func(c *gin.Context) {
+		id, _ := correlation.RequestIDFromContext(c.Request.Context())
+		c.String(http.StatusOK, id)
+	})
+	return r
+}
+
+func getWithRequestID(r http.Handler, id string) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, beersEndpoint, nil)
+	if id != "" {
+		req.Header.Set(RequestIDHeader, id)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestRequestIDMiddlewareReusesClientID(t *testing.T) {
+	w := getWithRequestID(setupRequestIDRouter(), "req-42")
+
+	assert.Equal(t, "req-42", w.Header().Get(RequestIDHeader))
+	assert.Equal(t, "req-42", w.Body.String())
+}
+
+func TestRequestIDMiddlewareGeneratesID(t *testing.T) {
+	r := setupRequestIDRouter()
+
+	for _, id := range []string{"", "not safe to echo"} {
+		w := getWithRequestID(r, id)
+
+		generated := w.Header().Get(RequestIDHeader)
+		assert.Len(t, generated, 32)
+		assert.NotEqual(t, id, generated)
+		assert.Equal(t, generated, w.Body.String())
+	}
+}
+
+func TestProblemFallsBackToRequestID(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+
+	req, _ := http.NewRequest(http.MethodGet, "/no/such/route", nil)
+	req.Header.Set(RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	assert.Equal(t, "req-42", w.Header().Get(RequestIDHeader))
+	assert.Contains(t, w.Body.String(), `"trace_id":"req-42"`)
+}
@@ -2,30 +2,210 @@ package http
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/ratelimit"
 	"beers-challenge/internal/core/ports/primary"
 	"beers-challenge/internal/core/ports/secondary"
+	"beers-challenge/internal/infrastructure/buildinfo"
 	"beers-challenge/internal/infrastructure/config"
 )
 
 const (
 	// API paths
-	BeersPath = "/beers"
-	APIPrefix = "/api/v1"
+	BeersPath      = "/beers"
+	CartsPath      = "/carts"
+	OrdersPath     = "/orders"
+	QuotesPath     = "/quotes"
+	PromotionsPath = "/promotions"
+	WebhooksPath   = "/webhooks"
+	DeliveriesPath = "/webhook-deliveries"
+	APIPrefix      = "/api/v1"
+	APIv2Prefix    = "/api/v2"
+	VersionPath    = "/version"
+	GraphQLPath    = "/graphql"
 )
 
 // Server represents the HTTP server
 type Server struct {
-	router      *gin.Engine
-	beerHandler *BeerHandler
-	config      *config.ConfigProvider
-	logger      secondary.Logger
-	server      *http.Server
+	router           *gin.Engine
+	beerHandler      *BeerHandler
+	orderHandler     *OrderHandler
+	quoteHandler     *QuoteHandler
+	pricingHandler   *PricingHandler
+	promoHandler     *PromotionHandler
+	eventHandler     *EventHandler
+	webhookHandler   *WebhookHandler
+	authService      primary.AuthService
+	idempotencyStore secondary.IdempotencyStore
+	idempotencyTTL   time.Duration
+	rateLimitStore   secondary.RateLimitStore
+	rateLimitPolicy  *ratelimit.Policy
+	metrics          HTTPMetrics
+	healthReporter   HealthReporter
+	contract         *Contract
+	validateResponse bool
+	compression      bool
+	compressMinSize  int
+	bodyLimits       *BodyLimits
+	legacyPolicy     LegacyPolicy
+	tlsConfig        *tls.Config
+	http2            bool
+	graphql          http.Handler
+	shuttingDown     atomic.Bool
+	config           *config.ConfigProvider
+	logger           secondary.Logger
+	server           *http.Server
+}
+
+// ServerOption configures optional features of the HTTP server
+type ServerOption func(*Server)
+
+// WithOrderService enables the cart and order routes
+func WithOrderService(orderService primary.OrderService) ServerOption {
+	return func(s *Server) {
+		s.orderHandler = NewOrderHandler(orderService, s.logger)
+	}
+}
+
+// WithQuoteService enables the multi-line quote route
+func WithQuoteService(quoteService primary.QuoteService) ServerOption {
+	return func(s *Server) {
+		s.quoteHandler = NewQuoteHandler(quoteService, s.logger)
+	}
+}
+
+// WithPricingService enables the pack and tier pricing rule routes
+func WithPricingService(pricingService primary.PricingService) ServerOption {
+	return func(s *Server) {
+		s.pricingHandler = NewPricingHandler(pricingService, s.logger)
+	}
+}
+
+// WithPromotionService enables the promotion administration routes
+func WithPromotionService(promotionService primary.PromotionService) ServerOption {
+	return func(s *Server) {
+		s.promoHandler = NewPromotionHandler(promotionService, s.logger)
+	}
+}
+
+// WithWebhookService enables the webhook subscription and delivery administration routes
+func WithWebhookService(webhookService primary.WebhookService) ServerOption {
+	return func(s *Server) {
+		s.webhookHandler = NewWebhookHandler(webhookService, s.logger)
+	}
+}
+
+// WithHTTPCaching sends ETag, Last-Modified and Cache-Control with beers and box
+// prices, and answers conditional requests for them with 304 Not Modified
+func WithHTTPCaching(policy CachePolicy) ServerOption {
+	return func(s *Server) {
+		s.beerHandler.caching = &policy
+	}
+}
+
+// WithEventService streams catalogue events at /events, and over WebSocket at
+// /events/ws with websockets. Idle streams get a keep-alive every keepAlive.
+func WithEventService(eventService primary.EventService, keepAlive time.Duration, websockets bool) ServerOption {
+	return func(s *Server) {
+		s.eventHandler = NewEventHandler(eventService, keepAlive, websockets, s.logger)
+	}
+}
+
+// WithAuthService requires an API key or bearer token on every route except
+// the health check, and enforces the role each route needs
+func WithAuthService(authService primary.AuthService) ServerOption {
+	return func(s *Server) {
+		s.authService = authService
+	}
+}
+
+// WithIdempotencyStore honours the Idempotency-Key header on creation routes,
+// recording responses for ttl
+func WithIdempotencyStore(store secondary.IdempotencyStore, ttl time.Duration) ServerOption {
+	return func(s *Server) {
+		s.idempotencyStore = store
+		s.idempotencyTTL = ttl
+	}
+}
+
+// WithRateLimiter enforces the per-route limits of the policy, keeping token buckets in store
+func WithRateLimiter(store secondary.RateLimitStore, policy *ratelimit.Policy) ServerOption {
+	return func(s *Server) {
+		s.rateLimitStore = store
+		s.rateLimitPolicy = policy
+	}
+}
+
+// WithMetrics records request metrics and serves them at /metrics
+func WithMetrics(metrics HTTPMetrics) ServerOption {
+	return func(s *Server) {
+		s.metrics = metrics
+	}
+}
+
+// WithHealthChecks backs the readiness probe with the dependency checks of reporter
+func WithHealthChecks(reporter HealthReporter) ServerOption {
+	return func(s *Server) {
+		s.healthReporter = reporter
+	}
+}
+
+// WithContractValidation rejects requests that do not match the OpenAPI contract and,
+// with validateResponses, flags responses that do not match it either
+func WithContractValidation(contract *Contract, validateResponses bool) ServerOption {
+	return func(s *Server) {
+		s.contract = contract
+		s.validateResponse = validateResponses
+	}
+}
+
+// WithCompression compresses responses of at least minSize bytes with gzip or
+// brotli, as negotiated with Accept-Encoding
+func WithCompression(minSize int) ServerOption {
+	return func(s *Server) {
+		s.compression = true
+		s.compressMinSize = minSize
+	}
+}
+
+// WithBodyLimits rejects request bodies over the limit of their route with 413
+func WithBodyLimits(limits *BodyLimits) ServerOption {
+	return func(s *Server) {
+		s.bodyLimits = limits
+	}
+}
+
+// WithLegacyRoutes sets how the legacy unversioned routes are answered and what
+// clients are told about their retirement
+func WithLegacyRoutes(policy LegacyPolicy) ServerOption {
+	return func(s *Server) {
+		s.legacyPolicy = policy
+	}
+}
+
+// WithTLS serves HTTPS with the TLS configuration, negotiating HTTP/2 with
+// clients that support it when http2 is set
+func WithTLS(tlsConfig *tls.Config, http2 bool) ServerOption {
+	return func(s *Server) {
+		s.tlsConfig = tlsConfig
+		s.http2 = http2
+	}
+}
+
+// WithGraphQL serves the GraphQL handler at /graphql
+func WithGraphQL(handler http.Handler) ServerOption {
+	return func(s *Server) {
+		s.graphql = handler
+	}
 }
 
 // NewServer creates a new HTTP server
@@ -33,6 +213,7 @@ func NewServer(
 	beerService primary.BeerService,
 	config *config.ConfigProvider,
 	logger secondary.Logger,
+	opts ...ServerOption,
 ) *Server {
 	// Set Gin mode based on environment
 	if config.IsProduction() {
@@ -43,18 +224,34 @@ func NewServer(
 
 	router := gin.New()
 
-	// Add middleware
-	router.Use(gin.Recovery())
+	// Forwarded client addresses are only believed from trusted proxies, so
+	// clients cannot pick the IP address their rate limits are keyed by
+	if err := router.SetTrustedProxies(trustedProxies(config.GetString("server.trusted_proxies"))); err != nil {
+		logger.Error(context.Background(), "Invalid trusted proxies; forwarded client addresses are ignored", err, nil)
+		_ = router.SetTrustedProxies(nil)
+	}
+
+	// Add middleware. Tracing comes first so the span covers recovered panics too.
+	router.Use(TracingMiddleware())
+	router.Use(RequestIDMiddleware())
+	router.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		writeProblem(c, newProblem(http.StatusInternalServerError, "INTERNAL_ERROR", "An internal error occurred"))
+	}))
 	router.Use(LoggerMiddleware(logger))
 	router.Use(CORSMiddleware())
 
 	beerHandler := NewBeerHandler(beerService, logger)
 
 	server := &Server{
-		router:      router,
-		beerHandler: beerHandler,
-		config:      config,
-		logger:      logger,
+		router:       router,
+		beerHandler:  beerHandler,
+		legacyPolicy: LegacyPolicy{Mode: LegacyServe},
+		config:       config,
+		logger:       logger,
+	}
+
+	for _, opt := range opts {
+		opt(server)
 	}
 
 	server.setupRoutes()
@@ -62,29 +259,200 @@ func NewServer(
 	return server
 }
 
+// trustedProxies splits a comma separated list of proxy IP addresses and CIDRs
+func trustedProxies(spec string) []string {
+	var proxies []string
+	for _, proxy := range strings.Split(spec, ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
 // setupRoutes sets up the HTTP routes
 func (s *Server) setupRoutes() {
+	// Metrics cover every request, including unknown routes and rejected ones
+	if s.metrics != nil {
+		s.router.Use(MetricsMiddleware(s.metrics))
+	}
+
+	// Compression covers every response, problems included
+	if s.compression {
+		s.router.Use(CompressionMiddleware(s.compressMinSize))
+	}
+
+	// Unknown routes and methods answer with problem details too
+	s.router.HandleMethodNotAllowed = true
+	s.router.NoRoute(func(c *gin.Context) {
+		writeProblem(c, newProblem(http.StatusNotFound, "ROUTE_NOT_FOUND", "No route matches "+c.Request.URL.Path))
+	})
+	s.router.NoMethod(func(c *gin.Context) {
+		writeProblem(c, newProblem(http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED",
+			c.Request.Method+" is not allowed on "+c.Request.URL.Path))
+	})
+
 	// Health check
 	s.router.GET("/ping", s.healthCheck)
+	s.router.GET(VersionPath, s.version)
+	s.router.GET(LivenessPath, s.liveness)
+	s.router.GET(ReadinessPath, s.readiness)
+
+	// The API contract and its documentation are public
+	s.router.GET(OpenAPIPath, s.openAPIDocument)
+	s.router.GET(DocsPath, s.docs)
+
+	// Metrics are scraped without credentials, like the health check
+	if s.metrics != nil {
+		s.router.GET(MetricsPath, gin.WrapH(s.metrics.Handler()))
+	}
+
+	// Authentication applies to every route registered below
+	if s.authService != nil {
+		s.router.Use(AuthMiddleware(s.authService, s.logger))
+	}
+
+	// Rate limits are keyed by the authenticated principal, so they run after authentication
+	if s.rateLimitStore != nil {
+		s.router.Use(RateLimitMiddleware(s.rateLimitStore, s.rateLimitPolicy, s.logger))
+	}
+
+	// Body limits must be in place before anything reads the body
+	if s.bodyLimits != nil {
+		s.router.Use(BodyLimitMiddleware(s.bodyLimits))
+	}
+
+	// Contract validation runs once the caller is known to be allowed in, so
+	// unauthenticated requests get 401 rather than a validation error
+	if s.contract != nil {
+		s.router.Use(ContractMiddleware(s.contract, s.validateResponse, s.logger))
+	}
+
+	guards := routeGuards{
+		reader:     s.requireRole(auth.RoleReader),
+		editor:     s.requireRole(auth.RoleEditor),
+		admin:      s.requireRole(auth.RoleAdmin),
+		idempotent: s.idempotent(),
+	}
+
+	// Versioned API routes
+	for _, version := range s.apiVersions() {
+		version.routes(s.router.Group(version.prefix, APIVersionMiddleware(version.name), shapeResponses(version.shaper)), guards)
+	}
 
-	// API routes
-	api := s.router.Group(APIPrefix)
+	// GraphQL queries need the reader role; the createBeer mutation checks for editor itself
+	if s.graphql != nil {
+		s.router.POST(GraphQLPath, guards.reader, gin.WrapH(s.graphql))
+	}
+
+	// Legacy unversioned routes, deprecated in favour of their v1 successors
+	var usage LegacyUsageRecorder
+	if recorder, ok := s.metrics.(LegacyUsageRecorder); ok {
+		usage = recorder
+	}
+	legacy := s.router.Group("", LegacyMiddleware(s.legacyPolicy, usage, s.logger))
 	{
-		// Beer routes
-		beers := api.Group(BeersPath)
+		legacy.POST(BeersPath, guards.editor, guards.idempotent, s.beerHandler.CreateBeer)
+		legacy.GET(BeersPath, guards.reader, s.beerHandler.GetAllBeers)
+		legacy.GET(BeersPath+"/:id", guards.reader, s.beerHandler.GetBeer)
+		legacy.GET(BeersPath+"/:id/boxprice", guards.reader, s.beerHandler.CalculateBoxPrice)
+	}
+}
+
+// v1Routes registers the routes of API v1
+func (s *Server) v1Routes(api *gin.RouterGroup, guards routeGuards) {
+	reader, editor, admin, idempotent := guards.reader, guards.editor, guards.admin, guards.idempotent
+
+	// Beer routes
+	beers := api.Group(BeersPath)
+	{
+		beers.POST("", editor, idempotent, s.beerHandler.CreateBeer)
+		beers.GET("", reader, s.beerHandler.GetAllBeers)
+		beers.GET("/:id", reader, s.beerHandler.GetBeer)
+		beers.PUT("/:id", editor, s.beerHandler.UpdateBeer)
+		beers.GET("/:id/boxprice", reader, s.beerHandler.CalculateBoxPrice)
+
+		if s.pricingHandler != nil {
+			beers.GET("/:id/pricing", reader, s.pricingHandler.GetPricingRule)
+			beers.PUT("/:id/pricing", admin, s.pricingHandler.SetPricingRule)
+			beers.DELETE("/:id/pricing", admin, s.pricingHandler.DeletePricingRule)
+		}
+	}
+
+	// Cart and order routes
+	if s.orderHandler != nil {
+		carts := api.Group(CartsPath)
+		{
+			carts.POST("", editor, idempotent, s.orderHandler.CreateCart)
+			carts.GET("/:id", reader, s.orderHandler.GetCart)
+			carts.POST("/:id/items", editor, idempotent, s.orderHandler.AddCartItem)
+			carts.DELETE("/:id/items/:beer_id", editor, s.orderHandler.RemoveCartItem)
+			carts.POST("/:id/checkout", editor, idempotent, s.orderHandler.Checkout)
+		}
+
+		orders := api.Group(OrdersPath)
 		{
-			beers.POST("", s.beerHandler.CreateBeer)
-			beers.GET("", s.beerHandler.GetAllBeers)
-			beers.GET("/:id", s.beerHandler.GetBeer)
-			beers.GET("/:id/boxprice", s.beerHandler.CalculateBoxPrice)
+			orders.GET("", reader, s.orderHandler.ListOrders)
+			orders.GET("/:id", reader, s.orderHandler.GetOrder)
+			orders.PUT("/:id/status", editor, s.orderHandler.UpdateOrderStatus)
+		}
+	}
+
+	// Catalogue event streams
+	if s.eventHandler != nil {
+		api.GET(EventsPath, reader, s.eventHandler.StreamEvents)
+		if s.eventHandler.upgrader != nil {
+			api.GET(EventsPath+"/ws", reader, s.eventHandler.StreamEventsWebSocket)
 		}
 	}
 
-	// Legacy routes for backward compatibility
-	s.router.POST(BeersPath, s.beerHandler.CreateBeer)
-	s.router.GET(BeersPath, s.beerHandler.GetAllBeers)
-	s.router.GET(BeersPath+"/:id", s.beerHandler.GetBeer)
-	s.router.GET(BeersPath+"/:id/boxprice", s.beerHandler.CalculateBoxPrice)
+	// Quote routes
+	if s.quoteHandler != nil {
+		api.POST(QuotesPath, editor, s.quoteHandler.CreateQuote)
+	}
+
+	// Promotion administration routes
+	if s.promoHandler != nil {
+		promotions := api.Group(PromotionsPath)
+		{
+			promotions.POST("", admin, idempotent, s.promoHandler.CreatePromotion)
+			promotions.GET("", admin, s.promoHandler.ListPromotions)
+			promotions.GET("/:id", admin, s.promoHandler.GetPromotion)
+			promotions.PUT("/:id", admin, s.promoHandler.UpdatePromotion)
+			promotions.DELETE("/:id", admin, s.promoHandler.DeletePromotion)
+		}
+	}
+
+	// Webhook administration routes; deliveries have their own prefix because
+	// gin cannot route /webhooks/deliveries next to /webhooks/:id
+	if s.webhookHandler != nil {
+		webhooks := api.Group(WebhooksPath)
+		{
+			webhooks.POST("", admin, idempotent, s.webhookHandler.CreateSubscription)
+			webhooks.GET("", admin, s.webhookHandler.ListSubscriptions)
+			webhooks.GET("/:id", admin, s.webhookHandler.GetSubscription)
+			webhooks.DELETE("/:id", admin, s.webhookHandler.DeleteSubscription)
+		}
+
+		deliveries := api.Group(DeliveriesPath)
+		{
+			deliveries.GET("", admin, s.webhookHandler.ListDeliveries)
+			deliveries.POST("/:id/replay", admin, s.webhookHandler.ReplayDelivery)
+		}
+	}
+}
+
+// v2Routes registers the routes of API v2, whose beer routes answer with an
+// envelope of data, meta and links. Other resources are only served by v1.
+func (s *Server) v2Routes(api *gin.RouterGroup, guards routeGuards) {
+	beers := api.Group(BeersPath)
+	{
+		beers.POST("", guards.editor, guards.idempotent, s.beerHandler.CreateBeer)
+		beers.GET("", guards.reader, s.beerHandler.GetAllBeers)
+		beers.GET("/:id", guards.reader, s.beerHandler.GetBeer)
+		beers.PUT("/:id", guards.editor, s.beerHandler.UpdateBeer)
+		beers.GET("/:id/boxprice", guards.reader, s.beerHandler.CalculateBoxPrice)
+	}
 }
 
 // healthCheck handles health check requests
@@ -93,10 +461,15 @@ func (s *Server) healthCheck(c *gin.Context) {
 		"status":    "ok",
 		"timestamp": time.Now().UTC().Format(time.RFC3339),
 		"service":   "beer-api",
-		"version":   "1.0.0",
+		"version":   buildinfo.Get().Version,
 	})
 }
 
+// version reports the metadata of the running build
+func (s *Server) version(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
+
 // Start starts the HTTP server
 func (s *Server) Start() error {
 	address := fmt.Sprintf("%s:%d",
@@ -105,25 +478,81 @@ func (s *Server) Start() error {
 
 	s.server = &http.Server{
 		Addr:         address,
-		Handler:      s.router,
+		Handler:      s.streaming(s.router),
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
 	}
 
-	s.logger.Info(context.Background(), "Starting HTTP server", map[string]interface{}{
+	// Event streams never go idle, so they are ended when shutdown begins
+	if s.eventHandler != nil {
+		s.server.RegisterOnShutdown(s.eventHandler.Close)
+	}
+
+	if s.tlsConfig == nil {
+		s.logger.Info(context.Background(), "Starting HTTP server", map[string]interface{}{
+			"address": address,
+		})
+
+		if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			return fmt.Errorf("failed to start server: %w", err)
+		}
+		return nil
+	}
+
+	// The certificate comes from the TLS configuration, so no files are passed
+	s.server.TLSConfig = s.tlsConfig
+	s.server.Protocols = new(http.Protocols)
+	s.server.Protocols.SetHTTP1(true)
+	s.server.Protocols.SetHTTP2(s.http2)
+
+	s.logger.Info(context.Background(), "Starting HTTPS server", map[string]interface{}{
 		"address": address,
+		"http2":   s.http2,
 	})
 
-	if err := s.server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+	if err := s.server.ListenAndServeTLS("", ""); err != nil && err != http.ErrServerClosed {
 		return fmt.Errorf("failed to start server: %w", err)
 	}
 
 	return nil
 }
 
-// Stop gracefully stops the HTTP server
+// streaming lifts the write timeout for event streams, which stay open for as
+// long as the client listens. gin's writer cannot be unwrapped, so this is done
+// before the request reaches the router.
+func (s *Server) streaming(next http.Handler) http.Handler {
+	eventsPath := APIPrefix + EventsPath
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.eventHandler != nil && (r.URL.Path == eventsPath || r.URL.Path == eventsPath+"/ws") {
+			if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+				s.logger.Warn(r.Context(), "Failed to lift write timeout of event stream", map[string]interface{}{
+					"error": err.Error(),
+				})
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Stop gracefully stops the HTTP server. Readiness fails from the start, and the
+// server keeps serving for server.shutdown_delay seconds so load balancers can
+// take it out of rotation before its listener closes.
 func (s *Server) Stop(ctx context.Context) error {
+	s.shuttingDown.Store(true)
+
+	if delay := time.Duration(s.config.GetInt("server.shutdown_delay")) * time.Second; delay > 0 {
+		s.logger.Info(ctx, "Failing readiness before stopping HTTP server", map[string]interface{}{
+			"delay_seconds": delay.Seconds(),
+		})
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+		}
+	}
+
 	s.logger.Info(ctx, "Stopping HTTP server", nil)
 
 	if s.server != nil {
@@ -171,8 +600,9 @@ func CORSMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Header("Access-Control-Allow-Origin", "*")
 		c.Header("Access-Control-Allow-Credentials", "true")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, X-API-Key, Idempotency-Key, X-Request-ID, If-None-Match, If-Modified-Since, accept, origin, Cache-Control, X-Requested-With")
 		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Header("Access-Control-Expose-Headers", "Retry-After, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Idempotent-Replayed, X-Request-ID, X-Contract-Violation, ETag, Deprecation, Sunset, Link, API-Version")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(http.StatusNoContent)
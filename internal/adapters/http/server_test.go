@@ -2,12 +2,14 @@ package http
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 
 	"beers-challenge/internal/core/domain/beers"
 	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/buildinfo"
 	"beers-challenge/internal/infrastructure/config"
 	"beers-challenge/internal/infrastructure/logger"
 
@@ -23,6 +25,14 @@ type MockBeerServiceForServer struct {
 func (m *MockBeerServiceForServer) CreateBeer(ctx context.Context, req primary.CreateBeerRequest) error {
 	return nil
 }
+func (m *MockBeerServiceForServer) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (*beers.Beer, error) {
+	args := m.Called(ctx, id, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*beers.Beer), args.Error(1)
+}
+
 func (m *MockBeerServiceForServer) FindBeerByID(ctx context.Context, id int) (*beers.Beer, error) {
 	return nil, nil
 }
@@ -56,6 +66,18 @@ func TestHealthCheck(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "ok")
 }
 
+func TestVersion(t *testing.T) {
+	server := NewServer(new(MockBeerServiceForServer), config.NewConfigProvider(), logger.NewNoOpLogger())
+	req, _ := http.NewRequest(http.MethodGet, VersionPath, nil)
+	w := httptest.NewRecorder()
+	server.router.ServeHTTP(w, req)
+
+	var info buildinfo.Info
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, buildinfo.Get(), info)
+}
+
 func TestLoggerMiddleware(t *testing.T) {
 	log := logger.NewNoOpLogger()
 	router := gin.New()
@@ -0,0 +1,132 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/primary"
+)
+
+// APIVersionHeader names the API version that answered a request
+const APIVersionHeader = "API-Version"
+
+// routeGuards are the role and idempotency guards routes are registered with
+type routeGuards struct {
+	reader     gin.HandlerFunc
+	editor     gin.HandlerFunc
+	admin      gin.HandlerFunc
+	idempotent gin.HandlerFunc
+}
+
+// apiVersion is a versioned surface of the API. Every version is served by the
+// same handlers; a version picks which routes it registers under its prefix and
+// how their successful responses are shaped.
+type apiVersion struct {
+	// name is sent in the API-Version header, e.g. "v1"
+	name string
+	// prefix is the path every route of the version is served under
+	prefix string
+	// shaper shapes the successful beer responses of the version
+	shaper responseShaper
+	// routes registers the routes of the version
+	routes func(api *gin.RouterGroup, guards routeGuards)
+}
+
+// apiVersions lists the versions the server serves, oldest first
+func (s *Server) apiVersions() []apiVersion {
+	return []apiVersion{
+		{name: "v1", prefix: APIPrefix, shaper: plainShaper{}, routes: s.v1Routes},
+		{name: "v2", prefix: APIv2Prefix, shaper: envelopeShaper{prefix: APIv2Prefix}, routes: s.v2Routes},
+	}
+}
+
+// responseShaper shapes the successful beer responses of an API version. The
+// handlers, their validation and their errors are shared by every version;
+// only the body of a successful response changes.
+type responseShaper interface {
+	// page reads the page of a list a request asks for, sending a 400 problem
+	// and returning false when it is invalid
+	page(c *gin.Context) (listPage, bool)
+	// created answers the creation of the beer with id; load reads it back
+	// when the response carries it, returning nil when it cannot
+	created(c *gin.Context, id int, load func() *beers.Beer)
+	// updated answers with an updated beer
+	updated(c *gin.Context, beer *beers.Beer)
+	// beer answers with a beer
+	beer(c *gin.Context, format responseFormat, beer *beers.Beer, caching *cacheHeaders)
+	// beers answers with the page of the catalogue asked for
+	beers(c *gin.Context, format responseFormat, page listPage, all []beers.Beer, caching *cacheHeaders)
+	// boxPrice answers with a box price converted with the rates observed by rates
+	boxPrice(c *gin.Context, format responseFormat, price *primary.BoxPriceResponse, rates *currency.RateFreshness, caching *cacheHeaders)
+}
+
+// listPage is a page of a list, numbered from 1. The zero value is the whole list.
+type listPage struct {
+	number int
+	size   int
+}
+
+// plainShaper serves resources as they are and lists whole. It shapes API v1
+// and the legacy routes.
+type plainShaper struct{}
+
+// page always asks for the whole list
+func (plainShaper) page(c *gin.Context) (listPage, bool) {
+	return listPage{}, true
+}
+
+// created answers with an empty 201
+func (plainShaper) created(c *gin.Context, id int, load func() *beers.Beer) {
+	c.Status(http.StatusCreated)
+}
+
+// updated answers with the beer as JSON
+func (plainShaper) updated(c *gin.Context, beer *beers.Beer) {
+	c.JSON(http.StatusOK, beer)
+}
+
+// beer answers with the beer itself
+func (plainShaper) beer(c *gin.Context, format responseFormat, beer *beers.Beer, caching *cacheHeaders) {
+	respond(c, format, http.StatusOK, xmlNames{root: "beer"}, beer, caching)
+}
+
+// beers answers with the whole catalogue
+func (plainShaper) beers(c *gin.Context, format responseFormat, page listPage, all []beers.Beer, caching *cacheHeaders) {
+	respond(c, format, http.StatusOK, xmlNames{root: "beers", item: "beer"}, all, caching)
+}
+
+// boxPrice answers with the box price itself
+func (plainShaper) boxPrice(c *gin.Context, format responseFormat, price *primary.BoxPriceResponse, rates *currency.RateFreshness, caching *cacheHeaders) {
+	respond(c, format, http.StatusOK, xmlNames{root: "box_price"}, price, caching)
+}
+
+// responseShaperKey is the gin context key of the response shaper of a request
+const responseShaperKey = "response_shaper"
+
+// shapeResponses selects the response shaper of the routes it guards
+func shapeResponses(shaper responseShaper) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(responseShaperKey, shaper)
+		c.Next()
+	}
+}
+
+// shaperOf returns the response shaper selected for a request, plainShaper
+// when none was
+func shaperOf(c *gin.Context) responseShaper {
+	if shaper, ok := c.Get(responseShaperKey); ok {
+		return shaper.(responseShaper)
+	}
+	return plainShaper{}
+}
+
+// APIVersionMiddleware tags responses with the API version serving them
+func APIVersionMiddleware(version string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header(APIVersionHeader, version)
+		c.Next()
+	}
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// WebhookHandler handles HTTP requests for webhook subscriptions and deliveries
+type WebhookHandler struct {
+	webhookService primary.WebhookService
+	logger         secondary.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService primary.WebhookService, logger secondary.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// CreateSubscription handles POST /webhooks. The signing secret is only returned here.
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	var req primary.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error(c.Request.Context(), "Invalid request body", err, map[string]interface{}{
+			"endpoint": "POST /webhooks",
+		})
+		writeBindingError(c, err)
+		return
+	}
+
+	created, err := h.webhookService.CreateSubscription(c.Request.Context(), req)
+	if err != nil {
+		h.handleError(c, "Failed to create webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListSubscriptions handles GET /webhooks
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	result, err := h.webhookService.ListSubscriptions(c.Request.Context())
+	if err != nil {
+		h.handleError(c, "Failed to list webhook subscriptions", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetSubscription handles GET /webhooks/:id
+func (h *WebhookHandler) GetSubscription(c *gin.Context) {
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, "Failed to find webhook subscription", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, subscription)
+}
+
+// DeleteSubscription handles DELETE /webhooks/:id
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), c.Param("id")); err != nil {
+		h.handleError(c, "Failed to delete webhook subscription", err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListDeliveries handles GET /webhook-deliveries; ?status=dead lists the dead letters
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	result, err := h.webhookService.ListDeliveries(c.Request.Context(), c.Query("status"))
+	if err != nil {
+		h.handleError(c, "Failed to list webhook deliveries", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReplayDelivery handles POST /webhook-deliveries/:id/replay
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	delivery, err := h.webhookService.ReplayDelivery(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		h.handleError(c, "Failed to replay webhook delivery", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, delivery)
+}
+
+// handleError handles errors and sends appropriate HTTP responses
+func (h *WebhookHandler) handleError(c *gin.Context, message string, err error) {
+	h.logger.Error(c.Request.Context(), message, err, map[string]interface{}{
+		"endpoint": c.Request.Method + " " + c.Request.URL.Path,
+	})
+
+	writeError(c, err)
+}
@@ -0,0 +1,159 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// MockWebhookService is a mock of WebhookService
+type MockWebhookService struct {
+	mock.Mock
+}
+
+func (m *MockWebhookService) CreateSubscription(ctx context.Context, req primary.CreateWebhookRequest) (*primary.CreatedWebhookSubscription, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*primary.CreatedWebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookService) GetSubscription(ctx context.Context, id string) (*webhooks.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhooks.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) ListSubscriptions(ctx context.Context) ([]webhooks.Subscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhooks.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookService) ListDeliveries(ctx context.Context, status string) ([]webhooks.Delivery, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhooks.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookService) ReplayDelivery(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhooks.Delivery), args.Error(1)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	mockService := new(MockWebhookService)
+	handler := NewWebhookHandler(mockService, logger.NewNoOpLogger())
+
+	r := setupRouter()
+	r.POST("/webhooks", handler.CreateSubscription)
+	r.GET("/webhooks", handler.ListSubscriptions)
+	r.GET("/webhooks/:id", handler.GetSubscription)
+	r.DELETE("/webhooks/:id", handler.DeleteSubscription)
+	r.GET("/webhook-deliveries", handler.ListDeliveries)
+	r.POST("/webhook-deliveries/:id/replay", handler.ReplayDelivery)
+
+	subscription := &webhooks.Subscription{
+		ID:         "whk_1",
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []events.Type{events.TypePriceChanged},
+		Secret:     "whsec_secret",
+	}
+
+	t.Run("create", func(t *testing.T) {
+		reqBody := primary.CreateWebhookRequest{URL: subscription.URL, EventTypes: subscription.EventTypes}
+		mockService.On("CreateSubscription", mock.Anything, reqBody).
+			Return(&primary.CreatedWebhookSubscription{Subscription: subscription, Secret: subscription.Secret}, nil).Once()
+
+		body := `{"url":"https://partner.example.com/hooks","event_types":["beer.price_changed"]}`
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks", bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Contains(t, w.Body.String(), `"secret":"whsec_secret"`)
+		assert.Equal(t, 1, bytes.Count(w.Body.Bytes(), []byte("whsec_secret")), "the secret is not part of the subscription")
+	})
+
+	t.Run("get hides the secret", func(t *testing.T) {
+		mockService.On("GetSubscription", mock.Anything, "whk_1").Return(subscription, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/webhooks/whk_1", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"url":"https://partner.example.com/hooks"`)
+		assert.NotContains(t, w.Body.String(), "whsec_secret")
+	})
+
+	t.Run("delete missing", func(t *testing.T) {
+		mockService.On("DeleteSubscription", mock.Anything, "whk_missing").
+			Return(beers.NewDomainError(webhooks.ErrCodeSubscriptionNotFound, "Webhook subscription not found", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodDelete, "/webhooks/whk_missing", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Contains(t, w.Body.String(), webhooks.ErrCodeSubscriptionNotFound)
+	})
+
+	t.Run("dead letters", func(t *testing.T) {
+		dead := []webhooks.Delivery{{ID: "dlv_1", SubscriptionID: "whk_1", Status: webhooks.StatusDead, Attempts: 8}}
+		mockService.On("ListDeliveries", mock.Anything, "dead").Return(dead, nil).Once()
+
+		req, _ := http.NewRequest(http.MethodGet, "/webhook-deliveries?status=dead", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"status":"dead"`)
+	})
+
+	t.Run("replay", func(t *testing.T) {
+		mockService.On("ReplayDelivery", mock.Anything, "dlv_1").
+			Return(&webhooks.Delivery{ID: "dlv_1", Status: webhooks.StatusPending}, nil).Once()
+		mockService.On("ReplayDelivery", mock.Anything, "dlv_2").
+			Return(nil, beers.NewDomainError(webhooks.ErrCodeDeliveryNotDead, "Only dead-lettered deliveries can be replayed", nil)).Once()
+
+		req, _ := http.NewRequest(http.MethodPost, "/webhook-deliveries/dlv_1/replay", nil)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusAccepted, w.Code)
+
+		req, _ = http.NewRequest(http.MethodPost, "/webhook-deliveries/dlv_2/replay", nil)
+		w = httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	mockService.AssertExpectations(t)
+}
@@ -0,0 +1,200 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+// Role represents what an authenticated caller is allowed to do
+type Role string
+
+const (
+	// RoleReader may read beers, prices, orders and promotions
+	RoleReader Role = "reader"
+	// RoleEditor may additionally create beers, carts, orders and quotes
+	RoleEditor Role = "editor"
+	// RoleAdmin may additionally manage pricing rules, promotions and API keys
+	RoleAdmin Role = "admin"
+)
+
+// roleRank orders roles so that each role includes the permissions of the ones below it
+var roleRank = map[Role]int{
+	RoleReader: 1,
+	RoleEditor: 2,
+	RoleAdmin:  3,
+}
+
+// Authentication methods recorded on a principal
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+const (
+	// ErrCodeUnauthenticated is the domain error code used when credentials are missing or invalid
+	ErrCodeUnauthenticated = "UNAUTHENTICATED"
+	// ErrCodeForbidden is the domain error code used when the caller's role is insufficient
+	ErrCodeForbidden = "FORBIDDEN"
+	// ErrCodeAPIKeyNotFound is the domain error code used when an API key does not exist
+	ErrCodeAPIKeyNotFound = "API_KEY_NOT_FOUND"
+)
+
+// tokenPrefix marks API key tokens so they can be told apart from JWTs
+const tokenPrefix = "bk_"
+
+// ParseRole parses and validates a role name
+func ParseRole(name string) (Role, error) {
+	role := Role(strings.ToLower(strings.TrimSpace(name)))
+	if !role.IsValid() {
+		return "", beers.NewValidationError("role",
+			fmt.Sprintf("must be one of: %s, %s, %s", RoleReader, RoleEditor, RoleAdmin))
+	}
+	return role, nil
+}
+
+// IsValid reports whether the role is known
+func (r Role) IsValid() bool {
+	_, known := roleRank[r]
+	return known
+}
+
+// Allows reports whether the role grants the permissions of the required role
+func (r Role) Allows(required Role) bool {
+	return r.IsValid() && roleRank[r] >= roleRank[required]
+}
+
+// Principal represents an authenticated caller
+type Principal struct {
+	Subject string `json:"subject"`
+	Name    string `json:"name,omitempty"`
+	Role    Role   `json:"role"`
+	Method  string `json:"method"`
+}
+
+// APIKey represents a long-lived credential. Only the SHA-256 hash of the
+// token is stored; the token itself is shown once, when the key is created.
+type APIKey struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Role      Role       `json:"role"`
+	Hash      string     `json:"-"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// NewAPIKey creates a new API key and returns it along with its token
+func NewAPIKey(name string, role Role) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, "", beers.NewValidationError("name", beers.ErrCannotBeEmpty)
+	}
+
+	if len(name) > 100 {
+		return nil, "", beers.NewValidationError("name", beers.ErrCannotExceed100Chars)
+	}
+
+	if !role.IsValid() {
+		_, err := ParseRole(string(role))
+		return nil, "", err
+	}
+
+	id, err := randomHex(8)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key id: %w", err)
+	}
+
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to generate API key secret: %w", err)
+	}
+
+	token := tokenPrefix + id + "." + secret
+	key := &APIKey{
+		ID:        "key_" + id,
+		Name:      name,
+		Role:      role,
+		Hash:      HashToken(token),
+		CreatedAt: time.Now(),
+	}
+
+	return key, token, nil
+}
+
+// Verify reports whether the token belongs to this key, in constant time
+func (k *APIKey) Verify(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(k.Hash), []byte(HashToken(token))) == 1
+}
+
+// Revoke marks the key as revoked. Revoking twice keeps the first revocation time.
+func (k *APIKey) Revoke(now time.Time) {
+	if k.RevokedAt == nil {
+		k.RevokedAt = &now
+	}
+}
+
+// IsRevoked reports whether the key has been revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Principal returns the principal authenticated by this key
+func (k *APIKey) Principal() *Principal {
+	return &Principal{Subject: k.ID, Name: k.Name, Role: k.Role, Method: MethodAPIKey}
+}
+
+// IsAPIKeyToken reports whether a token has the API key format rather than being a JWT
+func IsAPIKeyToken(token string) bool {
+	return strings.HasPrefix(token, tokenPrefix)
+}
+
+// KeyIDFromToken extracts the key ID embedded in an API key token
+func KeyIDFromToken(token string) (string, bool) {
+	if !IsAPIKeyToken(token) {
+		return "", false
+	}
+
+	id, _, found := strings.Cut(strings.TrimPrefix(token, tokenPrefix), ".")
+	if !found || id == "" {
+		return "", false
+	}
+
+	return "key_" + id, true
+}
+
+// HashToken returns the hex encoded SHA-256 hash stored for a token.
+// Tokens carry 256 bits of randomness, so a fast hash is sufficient.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) (string, error) {
+	buf := make([]byte, n)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// principalKey is the context key of the authenticated principal
+type principalKey struct{}
+
+// WithPrincipal returns a copy of ctx carrying the principal
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext returns the principal carried by ctx, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole(" Editor ")
+	assert.NoError(t, err)
+	assert.Equal(t, RoleEditor, role)
+
+	_, err = ParseRole("owner")
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "role", validationErr.Field)
+}
+
+func TestRoleAllows(t *testing.T) {
+	assert.True(t, RoleAdmin.Allows(RoleEditor))
+	assert.True(t, RoleEditor.Allows(RoleEditor))
+	assert.True(t, RoleEditor.Allows(RoleReader))
+	assert.False(t, RoleReader.Allows(RoleEditor))
+	assert.False(t, RoleEditor.Allows(RoleAdmin))
+	assert.False(t, Role("owner").Allows(RoleReader))
+}
+
+func TestNewAPIKey(t *testing.T) {
+	key, token, err := NewAPIKey(" ci pipeline ", RoleEditor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "ci pipeline", key.Name)
+	assert.True(t, strings.HasPrefix(key.ID, "key_"))
+	assert.True(t, IsAPIKeyToken(token))
+	assert.NotContains(t, key.Hash, token)
+	assert.Equal(t, HashToken(token), key.Hash)
+
+	id, ok := KeyIDFromToken(token)
+	assert.True(t, ok)
+	assert.Equal(t, key.ID, id)
+
+	assert.True(t, key.Verify(token))
+	assert.False(t, key.Verify(token+"x"))
+}
+
+func TestNewAPIKeyValidation(t *testing.T) {
+	_, _, err := NewAPIKey("", RoleReader)
+	assert.Equal(t, "name", err.(*beers.ValidationError).Field)
+
+	_, _, err = NewAPIKey("key", "owner")
+	assert.Equal(t, "role", err.(*beers.ValidationError).Field)
+}
+
+func TestKeyIDFromToken(t *testing.T) {
+	for _, token := range []string{"", "eyJhbGciOi.x.y", "bk_", "bk_.secret", "bk_abc"} {
+		_, ok := KeyIDFromToken(token)
+		assert.False(t, ok, token)
+	}
+}
+
+func TestRevoke(t *testing.T) {
+	key, _, _ := NewAPIKey("key", RoleReader)
+	first := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	key.Revoke(first)
+	key.Revoke(first.Add(time.Hour))
+
+	assert.True(t, key.IsRevoked())
+	assert.Equal(t, first, *key.RevokedAt)
+}
+
+func TestPrincipalContext(t *testing.T) {
+	_, ok := PrincipalFromContext(context.Background())
+	assert.False(t, ok)
+
+	key, _, _ := NewAPIKey("key", RoleAdmin)
+	ctx := WithPrincipal(context.Background(), key.Principal())
+
+	principal, ok := PrincipalFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, key.ID, principal.Subject)
+	assert.Equal(t, RoleAdmin, principal.Role)
+	assert.Equal(t, MethodAPIKey, principal.Method)
+}
@@ -13,6 +13,10 @@ const (
 	ErrCannotExceed100Chars  = "cannot exceed 100 characters"
 	ErrCannotBeNegative      = "cannot be negative"
 	ErrMustBe3Characters     = "must be exactly 3 characters (ISO 4217)"
+	ErrMustBeAPercentage     = "must be between 0 and 100"
+
+	// Largest number of units a box, cart line or quote line can be priced for
+	MaxBoxQuantity = 1000
 )
 
 // Beer represents the beer domain entity
@@ -23,6 +27,8 @@ type Beer struct {
 	Country   string    `json:"country"`
 	Price     float64   `json:"price"`
 	Currency  string    `json:"currency"`
+	ABV       float64   `json:"abv,omitempty"`
+	VolumeML  int       `json:"volume_ml,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -88,6 +94,30 @@ func (b *Beer) Validate() error {
 		return NewValidationError("currency", ErrMustBe3Characters)
 	}
 
+	if b.ABV < 0 || b.ABV > 100 {
+		return NewValidationError("abv", ErrMustBeAPercentage)
+	}
+
+	if b.VolumeML < 0 {
+		return NewValidationError("volume_ml", ErrCannotBeNegative)
+	}
+
+	return nil
+}
+
+// SetAttributes sets the alcohol by volume (percent) and container volume used by tax rules
+func (b *Beer) SetAttributes(abv float64, volumeML int) error {
+	if abv < 0 || abv > 100 {
+		return NewValidationError("abv", ErrMustBeAPercentage)
+	}
+
+	if volumeML < 0 {
+		return NewValidationError("volume_ml", ErrCannotBeNegative)
+	}
+
+	b.ABV = abv
+	b.VolumeML = volumeML
+
 	return nil
 }
 
@@ -97,6 +127,10 @@ func (b *Beer) CalculateBoxPrice(quantity int, exchangeRate float64) (float64, e
 		return 0, NewValidationError("quantity", ErrMustBeGreaterThanZero)
 	}
 
+	if quantity > MaxBoxQuantity {
+		return 0, NewValidationError("quantity", fmt.Sprintf("cannot exceed %d", MaxBoxQuantity))
+	}
+
 	if exchangeRate <= 0 {
 		return 0, NewValidationError("exchange_rate", ErrMustBeGreaterThanZero)
 	}
@@ -210,6 +210,26 @@ func TestBeerCalculateBoxPriceInvalidQuantity(t *testing.T) {
 	assert.Equal(t, "quantity", validationErr.Field)
 }
 
+func TestBeerCalculateBoxPriceQuantityTooLarge(t *testing.T) {
+	// Arrange
+	beer := &Beer{
+		ID:       validID,
+		Name:     validName,
+		Brewery:  validBrewery,
+		Country:  validCountry,
+		Price:    validPrice,
+		Currency: validCurrency,
+	}
+
+	// Act
+	_, err := beer.CalculateBoxPrice(MaxBoxQuantity+1, 1.0)
+
+	// Assert
+	validationErr, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "quantity", validationErr.Field)
+}
+
 func TestBeerCalculateBoxPriceInvalidExchangeRate(t *testing.T) {
 	// Arrange
 	beer := &Beer{
@@ -302,3 +322,21 @@ func TestDomainErrorWithCause(t *testing.T) {
 	assert.Contains(t, errorMsg, "caused by")
 	assert.Equal(t, cause, unwrappedErr)
 }
+
+func TestBeerSetAttributes(t *testing.T) {
+	beer, _ := NewBeer(validID, validName, validBrewery, validCountry, validPrice, validCurrency)
+
+	assert.NoError(t, beer.SetAttributes(4.5, 330))
+	assert.Equal(t, 4.5, beer.ABV)
+	assert.Equal(t, 330, beer.VolumeML)
+
+	err := beer.SetAttributes(120, 330)
+	validationErr, ok := err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "abv", validationErr.Field)
+
+	err = beer.SetAttributes(4.5, -1)
+	validationErr, ok = err.(*ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "volume_ml", validationErr.Field)
+}
@@ -0,0 +1,50 @@
+package correlation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+)
+
+// MaxRequestIDLength bounds request ids accepted from clients
+const MaxRequestIDLength = 128
+
+// requestIDKey is the context key of the request id
+type requestIDKey struct{}
+
+// NewRequestID generates a random 128-bit request id in hex
+func NewRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(id)
+}
+
+// IsValidRequestID reports whether a client supplied request id can be used as is:
+// non-empty, at most MaxRequestIDLength characters, and printable ASCII without
+// spaces, so it is safe to echo in headers and log lines
+func IsValidRequestID(id string) bool {
+	if id == "" || len(id) > MaxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WithRequestID returns a copy of ctx carrying the request id
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request id carried by ctx, if any
+func RequestIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDKey{}).(string)
+	return id, ok && id != ""
+}
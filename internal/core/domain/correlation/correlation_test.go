@@ -0,0 +1,37 @@
+package correlation
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRequestID(t *testing.T) {
+	first, second := NewRequestID(), NewRequestID()
+
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+	assert.True(t, IsValidRequestID(first))
+}
+
+func TestIsValidRequestID(t *testing.T) {
+	assert.True(t, IsValidRequestID("req-42"))
+	assert.True(t, IsValidRequestID("f47ac10b-58cc-4372-a567-0e02b2c3d479"))
+
+	assert.False(t, IsValidRequestID(""))
+	assert.False(t, IsValidRequestID("has space"))
+	assert.False(t, IsValidRequestID("line\nbreak"))
+	assert.False(t, IsValidRequestID("naïve"))
+	assert.False(t, IsValidRequestID(strings.Repeat("a", MaxRequestIDLength+1)))
+}
+
+func TestRequestIDContext(t *testing.T) {
+	_, ok := RequestIDFromContext(context.Background())
+	assert.False(t, ok)
+
+	id, ok := RequestIDFromContext(WithRequestID(context.Background(), "req-42"))
+	assert.True(t, ok)
+	assert.Equal(t, "req-42", id)
+}
@@ -0,0 +1,93 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// rateBatchKey is the context key of the rate batch
+type rateBatchKey struct{}
+
+// RateBatch shares exchange rate lookups between the resolvers of one request.
+// The first lookup of a currency pair fetches the rate; concurrent and later
+// lookups of the same pair wait for it and reuse its rate or error, unless the
+// fetch was cut short by the context of its caller: such a lookup is dropped and
+// whoever waits on it looks the pair up again with their own context.
+type RateBatch struct {
+	mu      sync.Mutex
+	lookups map[string]*rateLookup
+}
+
+// rateLookup is the outcome of fetching one currency pair
+type rateLookup struct {
+	done chan struct{}
+	rate float64
+	err  error
+	// settled is set once the outcome may be reused by other lookups
+	settled bool
+}
+
+// WithRateBatch returns a context whose exchange rate lookups share a new batch
+func WithRateBatch(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateBatchKey{}, &RateBatch{lookups: make(map[string]*rateLookup)})
+}
+
+// RateBatchFromContext returns the rate batch on the context, if any
+func RateBatchFromContext(ctx context.Context) (*RateBatch, bool) {
+	batch, ok := ctx.Value(rateBatchKey{}).(*RateBatch)
+	return batch, ok
+}
+
+// Rate returns the rate from one currency to another, calling fetch only for the
+// first lookup of the pair in the batch
+func (b *RateBatch) Rate(ctx context.Context, from, to string,
+	fetch func(ctx context.Context, from, to string) (float64, error)) (float64, error) {
+	key := from + "/" + to
+
+	for {
+		b.mu.Lock()
+		lookup, exists := b.lookups[key]
+		if !exists {
+			lookup = &rateLookup{done: make(chan struct{})}
+			b.lookups[key] = lookup
+		}
+		b.mu.Unlock()
+
+		if !exists {
+			return b.fetch(ctx, key, lookup, from, to, fetch)
+		}
+
+		select {
+		case <-lookup.done:
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+
+		if lookup.settled {
+			return lookup.rate, lookup.err
+		}
+	}
+}
+
+// fetch runs the lookup of a pair and releases its waiters, even when fetch
+// panics. Lookups that did not settle are dropped from the batch.
+func (b *RateBatch) fetch(ctx context.Context, key string, lookup *rateLookup, from, to string,
+	fetch func(ctx context.Context, from, to string) (float64, error)) (float64, error) {
+	defer func() {
+		if !lookup.settled {
+			b.mu.Lock()
+			delete(b.lookups, key)
+			b.mu.Unlock()
+		}
+		close(lookup.done)
+	}()
+
+	rate, err := fetch(ctx, from, to)
+	if err != nil && (ctx.Err() != nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)) {
+		return rate, err
+	}
+
+	lookup.rate, lookup.err, lookup.settled = rate, err, true
+	return rate, err
+}
@@ -0,0 +1,109 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateBatch(t *testing.T) {
+	_, ok := RateBatchFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithRateBatch(context.Background())
+	batch, ok := RateBatchFromContext(ctx)
+	assert.True(t, ok)
+
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	fetch := func(ctx context.Context, from, to string) (float64, error) {
+		fetches.Add(1)
+		<-release
+		if from == "XXX" {
+			return 0, errors.New(testErr)
+		}
+		return 0.85, nil
+	}
+
+	var wg sync.WaitGroup
+	rates := make([]float64, 5)
+	for i := range rates {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			rates[i], _ = batch.Rate(ctx, usd, eur, fetch)
+		}(i)
+	}
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), fetches.Load(), "concurrent lookups of a pair share one fetch")
+	assert.Equal(t, []float64{0.85, 0.85, 0.85, 0.85, 0.85}, rates)
+
+	_, err := batch.Rate(ctx, "XXX", eur, fetch)
+	assert.Error(t, err)
+	_, err = batch.Rate(ctx, "XXX", eur, fetch)
+	assert.Error(t, err, "failures are reused for the rest of the request")
+	assert.Equal(t, int32(2), fetches.Load())
+
+	_, _ = batch.Rate(ctx, eur, usd, fetch)
+	assert.Equal(t, int32(3), fetches.Load(), "each direction is a separate pair")
+}
+
+func TestRateBatchCancelledFetch(t *testing.T) {
+	batch, _ := RateBatchFromContext(WithRateBatch(context.Background()))
+
+	first, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	var fetches atomic.Int32
+	fetch := func(ctx context.Context, from, to string) (float64, error) {
+		if fetches.Add(1) == 1 {
+			close(started)
+			<-ctx.Done()
+			return 0, ctx.Err()
+		}
+		return 0.85, nil
+	}
+
+	firstErr := make(chan error)
+	go func() {
+		_, err := batch.Rate(first, usd, eur, fetch)
+		firstErr <- err
+	}()
+	<-started
+
+	waiter := make(chan float64)
+	go func() {
+		rate, _ := batch.Rate(context.Background(), usd, eur, fetch)
+		waiter <- rate
+	}()
+
+	cancel()
+	assert.ErrorIs(t, <-firstErr, context.Canceled)
+	assert.Equal(t, 0.85, <-waiter, "waiters look the pair up again with their own context")
+
+	rate, err := batch.Rate(context.Background(), usd, eur, fetch)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.85, rate)
+	assert.Equal(t, int32(2), fetches.Load(), "context errors are not reused")
+}
+
+func TestRateBatchPanickingFetch(t *testing.T) {
+	batch, _ := RateBatchFromContext(WithRateBatch(context.Background()))
+
+	assert.Panics(t, func() {
+		_, _ = batch.Rate(context.Background(), usd, eur, func(context.Context, string, string) (float64, error) {
+			panic("rate provider failed")
+		})
+	})
+
+	rate, err := batch.Rate(context.Background(), usd, eur, func(context.Context, string, string) (float64, error) {
+		return 0.85, nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 0.85, rate, "a panicking fetch leaves the pair to be looked up again")
+}
@@ -0,0 +1,64 @@
+package currency
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rateFreshnessKey is the context key of the rate freshness
+type rateFreshnessKey struct{}
+
+// RateFreshness collects how current the exchange rates a request was priced
+// with are, so its response can be cached no longer than its rates. Caches of
+// rates report every rate they serve to the freshness on the context.
+type RateFreshness struct {
+	mu        sync.Mutex
+	observed  bool
+	fetchedAt time.Time
+	expiresAt time.Time
+}
+
+// WithRateFreshness returns a context whose exchange rates are reported to a new
+// rate freshness, and that freshness
+func WithRateFreshness(ctx context.Context) (context.Context, *RateFreshness) {
+	freshness := &RateFreshness{}
+	return context.WithValue(ctx, rateFreshnessKey{}, freshness), freshness
+}
+
+// RateFreshnessFromContext returns the rate freshness on the context, if any
+func RateFreshnessFromContext(ctx context.Context) (*RateFreshness, bool) {
+	freshness, ok := ctx.Value(rateFreshnessKey{}).(*RateFreshness)
+	return freshness, ok
+}
+
+// ObserveRate reports a rate used by the request of ctx, fetched at fetchedAt and
+// current until expiresAt. It does nothing when ctx carries no rate freshness.
+func ObserveRate(ctx context.Context, fetchedAt, expiresAt time.Time) {
+	if freshness, ok := RateFreshnessFromContext(ctx); ok {
+		freshness.Observe(fetchedAt, expiresAt)
+	}
+}
+
+// Observe records a rate fetched at fetchedAt and current until expiresAt
+func (f *RateFreshness) Observe(fetchedAt, expiresAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.observed || fetchedAt.Before(f.fetchedAt) {
+		f.fetchedAt = fetchedAt
+	}
+	if !f.observed || expiresAt.Before(f.expiresAt) {
+		f.expiresAt = expiresAt
+	}
+	f.observed = true
+}
+
+// Observed returns when the oldest rate was fetched and when the first rate
+// expires; ok is false when no rate was observed
+func (f *RateFreshness) Observed() (fetchedAt, expiresAt time.Time, ok bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.fetchedAt, f.expiresAt, f.observed
+}
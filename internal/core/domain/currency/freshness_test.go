@@ -0,0 +1,33 @@
+package currency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateFreshness(t *testing.T) {
+	noon := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	// Without a freshness on the context, observations are dropped
+	ObserveRate(context.Background(), noon, noon.Add(time.Minute))
+
+	ctx, freshness := WithRateFreshness(context.Background())
+	_, _, ok := freshness.Observed()
+	assert.False(t, ok)
+
+	ObserveRate(ctx, noon, noon.Add(5*time.Minute))
+	ObserveRate(ctx, noon.Add(-time.Minute), noon.Add(4*time.Minute))
+	ObserveRate(ctx, noon.Add(time.Minute), noon.Add(6*time.Minute))
+
+	fetchedAt, expiresAt, ok := freshness.Observed()
+	assert.True(t, ok)
+	assert.Equal(t, noon.Add(-time.Minute), fetchedAt)
+	assert.Equal(t, noon.Add(4*time.Minute), expiresAt)
+
+	fromContext, ok := RateFreshnessFromContext(ctx)
+	assert.True(t, ok)
+	assert.Same(t, freshness, fromContext)
+}
@@ -0,0 +1,121 @@
+package events
+
+import (
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+// ErrCodeUnavailable is the error code used when events can no longer be subscribed to
+const ErrCodeUnavailable = "EVENTS_UNAVAILABLE"
+
+// Type names a kind of catalogue event
+type Type string
+
+const (
+	// TypeBeerCreated is published when a beer is added to the catalogue
+	TypeBeerCreated Type = "beer.created"
+	// TypeBeerUpdated is published whenever a beer is replaced, repriced or not
+	TypeBeerUpdated Type = "beer.updated"
+	// TypePriceChanged is published next to TypeBeerUpdated when the price or currency changed
+	TypePriceChanged Type = "beer.price_changed"
+)
+
+// Event is a change to the beer catalogue. Beer is the beer as it is after the
+// change. The ID is assigned when the event is published.
+type Event struct {
+	ID          string       `json:"id"`
+	Type        Type         `json:"type"`
+	BeerID      int          `json:"beer_id"`
+	OccurredAt  time.Time    `json:"occurred_at"`
+	Beer        *beers.Beer  `json:"beer"`
+	PriceChange *PriceChange `json:"price_change,omitempty"`
+}
+
+// PriceChange is the price of a beer before and after it was repriced
+type PriceChange struct {
+	OldPrice    float64 `json:"old_price"`
+	OldCurrency string  `json:"old_currency"`
+	NewPrice    float64 `json:"new_price"`
+	NewCurrency string  `json:"new_currency"`
+}
+
+// BeerCreated returns the event of a new beer
+func BeerCreated(beer *beers.Beer) Event {
+	return newEvent(TypeBeerCreated, beer)
+}
+
+// BeerUpdated returns the events of a beer replaced by updated: a BeerUpdated
+// event, followed by a PriceChanged event when the price or currency changed
+func BeerUpdated(previous, updated *beers.Beer) []Event {
+	result := []Event{newEvent(TypeBeerUpdated, updated)}
+
+	if previous.Price != updated.Price || previous.Currency != updated.Currency {
+		changed := newEvent(TypePriceChanged, updated)
+		changed.PriceChange = &PriceChange{
+			OldPrice:    previous.Price,
+			OldCurrency: previous.Currency,
+			NewPrice:    updated.Price,
+			NewCurrency: updated.Currency,
+		}
+		result = append(result, changed)
+	}
+
+	return result
+}
+
+// newEvent returns an event about a snapshot of beer
+func newEvent(eventType Type, beer *beers.Beer) Event {
+	snapshot := *beer
+	return Event{
+		Type:       eventType,
+		BeerID:     beer.ID,
+		OccurredAt: beer.UpdatedAt,
+		Beer:       &snapshot,
+	}
+}
+
+// Filter selects the events a subscriber receives. An empty list matches any value.
+type Filter struct {
+	BeerIDs    []int
+	Currencies []string
+}
+
+// Matches reports whether an event passes the filter. A price change matches
+// both its old and its new currency.
+func (f Filter) Matches(event Event) bool {
+	if len(f.BeerIDs) > 0 && !containsID(f.BeerIDs, event.BeerID) {
+		return false
+	}
+
+	if len(f.Currencies) == 0 {
+		return true
+	}
+
+	if event.Beer != nil && containsCurrency(f.Currencies, event.Beer.Currency) {
+		return true
+	}
+
+	return event.PriceChange != nil && containsCurrency(f.Currencies, event.PriceChange.OldCurrency)
+}
+
+// containsID reports whether ids holds id
+func containsID(ids []int, id int) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// containsCurrency reports whether currencies holds currency, ignoring case
+func containsCurrency(currencies []string, currency string) bool {
+	for _, candidate := range currencies {
+		if strings.EqualFold(candidate, currency) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,48 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+func TestBeerUpdated(t *testing.T) {
+	updatedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	previous := &beers.Beer{ID: 1, Name: "Torobayo", Price: 2500, Currency: "CLP"}
+	renamed := &beers.Beer{ID: 1, Name: "Kunstmann Torobayo", Price: 2500, Currency: "CLP", UpdatedAt: updatedAt}
+
+	result := BeerUpdated(previous, renamed)
+	assert.Len(t, result, 1)
+	assert.Equal(t, TypeBeerUpdated, result[0].Type)
+	assert.Equal(t, updatedAt, result[0].OccurredAt)
+	assert.Nil(t, result[0].PriceChange)
+
+	repriced := &beers.Beer{ID: 1, Name: "Torobayo", Price: 3.2, Currency: "USD", UpdatedAt: updatedAt}
+	result = BeerUpdated(previous, repriced)
+	assert.Len(t, result, 2)
+	assert.Equal(t, TypePriceChanged, result[1].Type)
+	assert.Equal(t, &PriceChange{OldPrice: 2500, OldCurrency: "CLP", NewPrice: 3.2, NewCurrency: "USD"}, result[1].PriceChange)
+
+	// Events keep a snapshot of the beer
+	repriced.Price = 4
+	assert.Equal(t, 3.2, result[0].Beer.Price)
+}
+
+func TestFilterMatches(t *testing.T) {
+	created := BeerCreated(&beers.Beer{ID: 1, Price: 2500, Currency: "CLP"})
+	repriced := BeerUpdated(&beers.Beer{ID: 2, Price: 1.5, Currency: "EUR"}, &beers.Beer{ID: 2, Price: 1.8, Currency: "USD"})[1]
+
+	assert.True(t, Filter{}.Matches(created))
+	assert.True(t, Filter{BeerIDs: []int{3, 1}}.Matches(created))
+	assert.False(t, Filter{BeerIDs: []int{2}}.Matches(created))
+	assert.True(t, Filter{Currencies: []string{"clp"}}.Matches(created))
+	assert.False(t, Filter{Currencies: []string{"USD"}}.Matches(created))
+
+	// A price change matches the currency it left as well as the one it moved to
+	assert.True(t, Filter{Currencies: []string{"EUR"}}.Matches(repriced))
+	assert.True(t, Filter{Currencies: []string{"USD"}}.Matches(repriced))
+	assert.False(t, Filter{BeerIDs: []int{2}, Currencies: []string{"CLP"}}.Matches(repriced))
+}
@@ -0,0 +1,53 @@
+package health
+
+// Status is the health of a component or of the whole service
+type Status string
+
+const (
+	// StatusUp means the component works
+	StatusUp Status = "up"
+	// StatusDegraded means a non-critical component is down; the service still works
+	StatusDegraded Status = "degraded"
+	// StatusDown means the component, or a critical component of the service, is down
+	StatusDown Status = "down"
+)
+
+// Component is the outcome of one health check
+type Component struct {
+	Name      string                 `json:"name"`
+	Status    Status                 `json:"status"`
+	Critical  bool                   `json:"critical"`
+	LatencyMs float64                `json:"latency_ms"`
+	Error     string                 `json:"error,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// Report is the health of the service and of each of its components
+type Report struct {
+	Status     Status      `json:"status"`
+	Components []Component `json:"components"`
+}
+
+// NewReport aggregates component results: the service is down when a critical
+// component is down, degraded when only non-critical ones are, and up otherwise
+func NewReport(components []Component) Report {
+	report := Report{Status: StatusUp, Components: components}
+
+	for _, component := range components {
+		if component.Status != StatusDown {
+			continue
+		}
+		if component.Critical {
+			report.Status = StatusDown
+			break
+		}
+		report.Status = StatusDegraded
+	}
+
+	return report
+}
+
+// IsReady reports whether the service can take traffic
+func (r Report) IsReady() bool {
+	return r.Status != StatusDown
+}
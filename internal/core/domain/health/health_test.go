@@ -0,0 +1,33 @@
+package health
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewReport(t *testing.T) {
+	database := Component{Name: "database", Status: StatusUp, Critical: true}
+	provider := Component{Name: "currency_provider", Status: StatusUp}
+
+	report := NewReport([]Component{database, provider})
+	assert.Equal(t, StatusUp, report.Status)
+	assert.True(t, report.IsReady())
+
+	provider.Status = StatusDown
+	report = NewReport([]Component{database, provider})
+	assert.Equal(t, StatusDegraded, report.Status)
+	assert.True(t, report.IsReady())
+
+	database.Status = StatusDown
+	report = NewReport([]Component{provider, database})
+	assert.Equal(t, StatusDown, report.Status)
+	assert.False(t, report.IsReady())
+}
+
+func TestNewReportWithoutComponents(t *testing.T) {
+	report := NewReport(nil)
+
+	assert.Equal(t, StatusUp, report.Status)
+	assert.True(t, report.IsReady())
+}
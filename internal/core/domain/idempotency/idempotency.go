@@ -0,0 +1,80 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// ErrCodeKeyReused is the domain error code used when a key is replayed with a different request
+	ErrCodeKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	// ErrCodeInProgress is the domain error code used when the first request with a key has not finished
+	ErrCodeInProgress = "IDEMPOTENCY_KEY_IN_PROGRESS"
+	// ErrCodeInvalidKey is the domain error code used when a key is empty or too long
+	ErrCodeInvalidKey = "INVALID_IDEMPOTENCY_KEY"
+)
+
+// MaxKeyLength is the longest Idempotency-Key accepted
+const MaxKeyLength = 255
+
+// Record remembers the request made with an idempotency key and, once it
+// completes, the response to replay for retries
+type Record struct {
+	Key         string    `json:"key"`
+	RequestHash string    `json:"request_hash"`
+	Completed   bool      `json:"completed"`
+	StatusCode  int       `json:"status_code,omitempty"`
+	ContentType string    `json:"content_type,omitempty"`
+	Body        []byte    `json:"body,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// NewRecord creates a pending record that expires after ttl
+func NewRecord(key, requestHash string, now time.Time, ttl time.Duration) *Record {
+	return &Record{
+		Key:         key,
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+}
+
+// Complete records the response of the request
+func (r *Record) Complete(statusCode int, contentType string, body []byte) {
+	r.Completed = true
+	r.StatusCode = statusCode
+	r.ContentType = contentType
+	r.Body = append([]byte(nil), body...)
+}
+
+// IsExpired reports whether the record should be forgotten
+func (r *Record) IsExpired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Matches reports whether a retry carries the same request as the original
+func (r *Record) Matches(requestHash string) bool {
+	return r.RequestHash == requestHash
+}
+
+// ScopedKey namespaces a client supplied key by caller and endpoint, so
+// different callers or endpoints never share a record
+func ScopedKey(caller, method, path, key string) string {
+	return Hash(caller, method, path, key)
+}
+
+// Hash returns the hex encoded SHA-256 hash of the parts, each length prefixed
+// so that different splits of the same bytes never collide
+func Hash(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		var size [8]byte
+		binary.LittleEndian.PutUint64(size[:], uint64(len(part)))
+		h.Write(size[:])
+		h.Write([]byte(part))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,38 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordLifecycle(t *testing.T) {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	record := NewRecord("key", Hash("POST", "/beers", "{}"), now, time.Hour)
+
+	assert.False(t, record.Completed)
+	assert.False(t, record.IsExpired(now.Add(59*time.Minute)))
+	assert.True(t, record.IsExpired(now.Add(time.Hour)))
+
+	body := []byte(`{"id":1}`)
+	record.Complete(201, "application/json", body)
+	body[0] = 'x'
+
+	assert.True(t, record.Completed)
+	assert.Equal(t, 201, record.StatusCode)
+	assert.Equal(t, `{"id":1}`, string(record.Body))
+}
+
+func TestMatches(t *testing.T) {
+	record := NewRecord("key", Hash("POST", "/beers", `{"id":1}`), time.Now(), time.Hour)
+
+	assert.True(t, record.Matches(Hash("POST", "/beers", `{"id":1}`)))
+	assert.False(t, record.Matches(Hash("POST", "/beers", `{"id":2}`)))
+}
+
+func TestHashIsUnambiguous(t *testing.T) {
+	assert.NotEqual(t, Hash("ab", "c"), Hash("a", "bc"))
+	assert.NotEqual(t, ScopedKey("key_a", "POST", "/beers", "k1"), ScopedKey("key_b", "POST", "/beers", "k1"))
+	assert.Len(t, Hash("x"), 64)
+}
@@ -0,0 +1,321 @@
+package orders
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const (
+	// Validation error messages
+	ErrMustBe3Characters = "must be exactly 3 characters (ISO 4217)"
+	ErrCartIsEmpty       = "cart has no items"
+)
+
+const (
+	// ErrCodeCartNotFound is the domain error code used when a cart does not exist
+	ErrCodeCartNotFound = "CART_NOT_FOUND"
+	// ErrCodeCartItemNotFound is the domain error code used when a beer is not in a cart
+	ErrCodeCartItemNotFound = "CART_ITEM_NOT_FOUND"
+	// ErrCodeCartCheckedOut is the domain error code used when a cart has already been turned into an order
+	ErrCodeCartCheckedOut = "CART_CHECKED_OUT"
+	// ErrCodeCartConflict is the domain error code used when a cart changed since it was read
+	ErrCodeCartConflict = "CART_CONFLICT"
+	// ErrCodeCartEmpty is the domain error code used when checking out a cart without items
+	ErrCodeCartEmpty = "CART_EMPTY"
+	// ErrCodeOrderNotFound is the domain error code used when an order does not exist
+	ErrCodeOrderNotFound = "ORDER_NOT_FOUND"
+	// ErrCodeInvalidStatusTransition is the domain error code used when an order cannot move to a status
+	ErrCodeInvalidStatusTransition = "INVALID_STATUS_TRANSITION"
+	// ErrCodeOrderStatusConflict is the domain error code used when an order's status changed concurrently
+	ErrCodeOrderStatusConflict = "ORDER_STATUS_CONFLICT"
+)
+
+// Status represents the lifecycle state of an order
+type Status string
+
+const (
+	StatusPlaced    Status = "placed"
+	StatusPaid      Status = "paid"
+	StatusShipped   Status = "shipped"
+	StatusCancelled Status = "cancelled"
+)
+
+// allowedTransitions lists the states an order can move to from each state
+var allowedTransitions = map[Status][]Status{
+	StatusPlaced:    {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusShipped, StatusCancelled},
+	StatusShipped:   {},
+	StatusCancelled: {},
+}
+
+// CartItem represents a beer and its quantity inside a cart
+type CartItem struct {
+	BeerID   int `json:"beer_id"`
+	Quantity int `json:"quantity"`
+}
+
+// Cart represents a set of beers priced in a single target currency
+type Cart struct {
+	ID        string     `json:"id"`
+	Currency  string     `json:"currency"`
+	Items     []CartItem `json:"items"`
+	OrderID   string     `json:"order_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	// Version counts the writes of a stored cart, 0 until it is first saved.
+	// Repositories only write a cart over the version it was read at.
+	Version int `json:"-"`
+}
+
+// NewCart creates a new empty cart for the given target currency
+func NewCart(currency string) (*Cart, error) {
+	cart := &Cart{
+		ID:        newID("cart"),
+		Currency:  strings.ToUpper(strings.TrimSpace(currency)),
+		Items:     []CartItem{},
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if len(cart.Currency) != 3 {
+		return nil, beers.NewValidationError("currency", ErrMustBe3Characters)
+	}
+
+	return cart, nil
+}
+
+// AddItem adds a quantity of a beer to the cart, merging with an existing line
+func (c *Cart) AddItem(beerID, quantity int) error {
+	if err := c.ensureOpen(); err != nil {
+		return err
+	}
+
+	if beerID < 1 {
+		return beers.NewValidationError("beer_id", beers.ErrMustBeGreaterThanZero)
+	}
+
+	if quantity < 1 {
+		return beers.NewValidationError("quantity", beers.ErrMustBeGreaterThanZero)
+	}
+
+	merged := quantity
+	for _, item := range c.Items {
+		if item.BeerID == beerID {
+			merged += item.Quantity
+		}
+	}
+	if merged > beers.MaxBoxQuantity {
+		return beers.NewValidationError("quantity", fmt.Sprintf("cannot exceed %d per beer", beers.MaxBoxQuantity))
+	}
+
+	for i := range c.Items {
+		if c.Items[i].BeerID == beerID {
+			c.Items[i].Quantity = merged
+			c.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	c.Items = append(c.Items, CartItem{BeerID: beerID, Quantity: quantity})
+	c.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// RemoveItem removes a beer from the cart
+func (c *Cart) RemoveItem(beerID int) error {
+	if err := c.ensureOpen(); err != nil {
+		return err
+	}
+
+	for i := range c.Items {
+		if c.Items[i].BeerID == beerID {
+			c.Items = append(c.Items[:i], c.Items[i+1:]...)
+			c.UpdatedAt = time.Now()
+			return nil
+		}
+	}
+
+	return beers.NewDomainError(ErrCodeCartItemNotFound,
+		fmt.Sprintf("Beer with ID %d is not in the cart", beerID), nil)
+}
+
+// MarkCheckedOut links the cart to the order created from it
+func (c *Cart) MarkCheckedOut(orderID string) error {
+	if err := c.ensureOpen(); err != nil {
+		return err
+	}
+
+	c.OrderID = orderID
+	c.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IsCheckedOut returns true if the cart has already been turned into an order
+func (c *Cart) IsCheckedOut() bool {
+	return c.OrderID != ""
+}
+
+// ensureOpen returns an error if the cart can no longer be modified
+func (c *Cart) ensureOpen() error {
+	if c.IsCheckedOut() {
+		return beers.NewDomainError(ErrCodeCartCheckedOut, "Cart has already been checked out", nil)
+	}
+	return nil
+}
+
+// OrderLine represents a priced line of an order, frozen at checkout time
+type OrderLine struct {
+	BeerID          int     `json:"beer_id"`
+	BeerName        string  `json:"beer_name"`
+	Quantity        int     `json:"quantity"`
+	SourceCurrency  string  `json:"source_currency"`
+	SourceUnitPrice float64 `json:"source_unit_price"`
+	ExchangeRate    float64 `json:"exchange_rate"`
+	UnitPrice       float64 `json:"unit_price"`
+	LineTotal       float64 `json:"line_total"`
+}
+
+// NewOrderLine creates an order line converting the beer price with the given rate.
+// lineTotal is the box price of the quantity, after packs, tiers and promotions.
+func NewOrderLine(beer *beers.Beer, quantity int, exchangeRate, lineTotal float64) (OrderLine, error) {
+	if _, err := beer.CalculateBoxPrice(quantity, exchangeRate); err != nil {
+		return OrderLine{}, err
+	}
+
+	if lineTotal < 0 {
+		return OrderLine{}, beers.NewValidationError("line_total", beers.ErrCannotBeNegative)
+	}
+
+	return OrderLine{
+		BeerID:          beer.ID,
+		BeerName:        beer.Name,
+		Quantity:        quantity,
+		SourceCurrency:  beer.Currency,
+		SourceUnitPrice: beer.Price,
+		ExchangeRate:    exchangeRate,
+		UnitPrice:       beer.Price * exchangeRate,
+		LineTotal:       lineTotal,
+	}, nil
+}
+
+// Order represents an immutable purchase created from a cart
+type Order struct {
+	ID          string      `json:"id"`
+	CartID      string      `json:"cart_id"`
+	Currency    string      `json:"currency"`
+	Lines       []OrderLine `json:"lines"`
+	Coupon      string      `json:"coupon,omitempty"`
+	Discount    float64     `json:"discount,omitempty"`
+	Total       float64     `json:"total"`
+	Status      Status      `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	PaidAt      *time.Time  `json:"paid_at,omitempty"`
+	ShippedAt   *time.Time  `json:"shipped_at,omitempty"`
+	CancelledAt *time.Time  `json:"cancelled_at,omitempty"`
+}
+
+// NewOrder creates a placed order from priced lines
+func NewOrder(cartID, currency string, lines []OrderLine) (*Order, error) {
+	if len(lines) == 0 {
+		return nil, beers.NewDomainError(ErrCodeCartEmpty, ErrCartIsEmpty, nil)
+	}
+
+	if len(currency) != 3 {
+		return nil, beers.NewValidationError("currency", ErrMustBe3Characters)
+	}
+
+	total := 0.0
+	for _, line := range lines {
+		total += line.LineTotal
+	}
+
+	now := time.Now()
+	return &Order{
+		ID:        newID("ord"),
+		CartID:    cartID,
+		Currency:  currency,
+		Lines:     append([]OrderLine(nil), lines...),
+		Total:     total,
+		Status:    StatusPlaced,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// ApplyCoupon takes the discount a coupon earned off the order total, never
+// taking the total below zero
+func (o *Order) ApplyCoupon(code string, discount float64) {
+	o.Coupon = code
+	o.ApplyDiscount(discount)
+}
+
+// ApplyDiscount takes an order-level discount off the order total, never taking
+// the total below zero
+func (o *Order) ApplyDiscount(discount float64) {
+	if discount > o.Total {
+		discount = o.Total
+	}
+
+	o.Discount += discount
+	o.Total -= discount
+}
+
+// TransitionTo moves the order to a new status if the lifecycle allows it
+func (o *Order) TransitionTo(status Status) error {
+	if !IsValidStatus(status) {
+		return beers.NewValidationError("status", fmt.Sprintf("unknown status '%s'", status))
+	}
+
+	if !o.CanTransitionTo(status) {
+		return beers.NewDomainError(ErrCodeInvalidStatusTransition,
+			fmt.Sprintf("Order cannot move from %s to %s", o.Status, status), nil)
+	}
+
+	now := time.Now()
+	switch status {
+	case StatusPaid:
+		o.PaidAt = &now
+	case StatusShipped:
+		o.ShippedAt = &now
+	case StatusCancelled:
+		o.CancelledAt = &now
+	}
+
+	o.Status = status
+	o.UpdatedAt = now
+
+	return nil
+}
+
+// CanTransitionTo returns true if the order may move to the given status
+func (o *Order) CanTransitionTo(status Status) bool {
+	for _, allowed := range allowedTransitions[o.Status] {
+		if allowed == status {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidStatus returns true if the status is a known order status
+func IsValidStatus(status Status) bool {
+	_, exists := allowedTransitions[status]
+	return exists
+}
+
+// newID generates a random identifier with the given prefix
+func newID(prefix string) string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	}
+	return prefix + "_" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,205 @@
+package orders
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const (
+	validCurrency = "USD"
+	validBeerID   = 1
+)
+
+func newTestLine(t *testing.T) OrderLine {
+	beer, err := beers.NewBeer(validBeerID, "Test Beer", "Test Brewery", "Chile", 1000, "CLP")
+	assert.NoError(t, err)
+
+	line, err := NewOrderLine(beer, 6, 0.00125, 7.5)
+	assert.NoError(t, err)
+	return line
+}
+
+func TestNewCartSuccess(t *testing.T) {
+	cart, err := NewCart(" usd ")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cart.ID)
+	assert.Equal(t, validCurrency, cart.Currency)
+	assert.Empty(t, cart.Items)
+	assert.False(t, cart.IsCheckedOut())
+}
+
+func TestNewCartInvalidCurrency(t *testing.T) {
+	cart, err := NewCart("US")
+
+	assert.Error(t, err)
+	assert.Nil(t, cart)
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "currency", validationErr.Field)
+}
+
+func TestCartAddItemMergesQuantities(t *testing.T) {
+	cart, _ := NewCart(validCurrency)
+
+	assert.NoError(t, cart.AddItem(validBeerID, 6))
+	assert.NoError(t, cart.AddItem(validBeerID, 6))
+	assert.NoError(t, cart.AddItem(2, 12))
+
+	assert.Len(t, cart.Items, 2)
+	assert.Equal(t, 12, cart.Items[0].Quantity)
+	assert.Equal(t, 12, cart.Items[1].Quantity)
+}
+
+func TestCartAddItemInvalidQuantity(t *testing.T) {
+	cart, _ := NewCart(validCurrency)
+
+	err := cart.AddItem(validBeerID, 0)
+
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "quantity", validationErr.Field)
+}
+
+func TestCartAddItemMergedQuantityTooLarge(t *testing.T) {
+	cart, _ := NewCart(validCurrency)
+	assert.NoError(t, cart.AddItem(validBeerID, beers.MaxBoxQuantity))
+
+	err := cart.AddItem(validBeerID, 1)
+
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "quantity", validationErr.Field)
+	assert.Equal(t, beers.MaxBoxQuantity, cart.Items[0].Quantity)
+}
+
+func TestCartRemoveItem(t *testing.T) {
+	cart, _ := NewCart(validCurrency)
+	cart.AddItem(validBeerID, 6)
+
+	assert.NoError(t, cart.RemoveItem(validBeerID))
+	assert.Empty(t, cart.Items)
+
+	err := cart.RemoveItem(validBeerID)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_ITEM_NOT_FOUND", domainErr.Code)
+}
+
+func TestCartCheckedOutIsReadOnly(t *testing.T) {
+	cart, _ := NewCart(validCurrency)
+	cart.AddItem(validBeerID, 6)
+
+	assert.NoError(t, cart.MarkCheckedOut("ord_1"))
+	assert.True(t, cart.IsCheckedOut())
+
+	err := cart.AddItem(2, 1)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CHECKED_OUT", domainErr.Code)
+}
+
+func TestNewOrderLineFreezesPrices(t *testing.T) {
+	line := newTestLine(t)
+
+	assert.Equal(t, validBeerID, line.BeerID)
+	assert.Equal(t, "CLP", line.SourceCurrency)
+	assert.Equal(t, 1000.0, line.SourceUnitPrice)
+	assert.InDelta(t, 1.25, line.UnitPrice, 0.0001)
+	assert.InDelta(t, 7.5, line.LineTotal, 0.0001)
+}
+
+func TestNewOrderLineNegativeTotal(t *testing.T) {
+	beer, _ := beers.NewBeer(validBeerID, "Test Beer", "Test Brewery", "Chile", 1000, "CLP")
+
+	_, err := NewOrderLine(beer, 6, 0.00125, -1)
+
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "line_total", validationErr.Field)
+}
+
+func TestNewOrderComputesTotal(t *testing.T) {
+	line := newTestLine(t)
+
+	order, err := NewOrder("cart_1", validCurrency, []OrderLine{line, line})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, order.ID)
+	assert.Equal(t, StatusPlaced, order.Status)
+	assert.InDelta(t, 15.0, order.Total, 0.0001)
+}
+
+func TestOrderApplyCoupon(t *testing.T) {
+	order, _ := NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t), newTestLine(t)})
+
+	order.ApplyCoupon("SAVE20", 3)
+	assert.Equal(t, "SAVE20", order.Coupon)
+	assert.InDelta(t, 3.0, order.Discount, 0.0001)
+	assert.InDelta(t, 12.0, order.Total, 0.0001)
+
+	// A discount larger than the order makes it free
+	order, _ = NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t)})
+	order.ApplyCoupon("FREE", 100)
+	assert.InDelta(t, 7.5, order.Discount, 0.0001)
+	assert.Zero(t, order.Total)
+}
+
+func TestOrderApplyDiscount(t *testing.T) {
+	order, _ := NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t), newTestLine(t)})
+
+	// Order-level discounts add up
+	order.ApplyDiscount(2)
+	order.ApplyCoupon("SAVE20", 3)
+	assert.InDelta(t, 5.0, order.Discount, 0.0001)
+	assert.InDelta(t, 10.0, order.Total, 0.0001)
+
+	order.ApplyDiscount(100)
+	assert.InDelta(t, 15.0, order.Discount, 0.0001)
+	assert.Zero(t, order.Total)
+}
+
+func TestNewOrderWithoutLines(t *testing.T) {
+	order, err := NewOrder("cart_1", validCurrency, nil)
+
+	assert.Nil(t, order)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_EMPTY", domainErr.Code)
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	order, _ := NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t)})
+
+	assert.NoError(t, order.TransitionTo(StatusPaid))
+	assert.NotNil(t, order.PaidAt)
+
+	assert.NoError(t, order.TransitionTo(StatusShipped))
+	assert.NotNil(t, order.ShippedAt)
+
+	err := order.TransitionTo(StatusCancelled)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", domainErr.Code)
+	assert.Equal(t, StatusShipped, order.Status)
+}
+
+func TestOrderCancelFromPlaced(t *testing.T) {
+	order, _ := NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t)})
+
+	assert.NoError(t, order.TransitionTo(StatusCancelled))
+	assert.NotNil(t, order.CancelledAt)
+	assert.False(t, order.CanTransitionTo(StatusPaid))
+}
+
+func TestOrderUnknownStatus(t *testing.T) {
+	order, _ := NewOrder("cart_1", validCurrency, []OrderLine{newTestLine(t)})
+
+	err := order.TransitionTo(Status("refunded"))
+
+	_, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+}
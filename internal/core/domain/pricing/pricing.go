@@ -0,0 +1,254 @@
+package pricing
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const (
+	// ErrCodeRuleNotFound is the domain error code used when a beer has no pricing rule
+	ErrCodeRuleNotFound = "PRICING_RULE_NOT_FOUND"
+
+	// Maximum quantity the pack optimiser accepts, which is the box price limit
+	MaxQuantity = beers.MaxBoxQuantity
+
+	// Line types used in a price breakdown
+	LineTypePack      = "pack"
+	LineTypeSingle    = "single"
+	LineTypeDiscount  = "discount"
+	LineTypePromotion = "promotion"
+
+	// Prices closer than this are considered equal when comparing combinations
+	priceEpsilon = 1e-9
+)
+
+// PackSKU represents a pack of a beer sold at a set price in the beer's currency
+type PackSKU struct {
+	SKU   string  `json:"sku"`
+	Size  int     `json:"size"`
+	Price float64 `json:"price"`
+}
+
+// DiscountTier represents a percentage discount applied from a minimum number of units
+type DiscountTier struct {
+	MinUnits int     `json:"min_units"`
+	Percent  float64 `json:"percent"`
+}
+
+// Rule holds the pack SKUs and quantity-break tiers of a beer
+type Rule struct {
+	BeerID    int            `json:"beer_id"`
+	Packs     []PackSKU      `json:"packs"`
+	Tiers     []DiscountTier `json:"tiers"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// NewRule creates a new pricing rule with validation
+func NewRule(beerID int, packs []PackSKU, tiers []DiscountTier) (*Rule, error) {
+	rule := &Rule{
+		BeerID:    beerID,
+		Packs:     append([]PackSKU{}, packs...),
+		Tiers:     append([]DiscountTier{}, tiers...),
+		UpdatedAt: time.Now(),
+	}
+
+	for i := range rule.Packs {
+		rule.Packs[i].SKU = strings.TrimSpace(rule.Packs[i].SKU)
+	}
+
+	sort.Slice(rule.Packs, func(i, j int) bool { return rule.Packs[i].Size > rule.Packs[j].Size })
+	sort.Slice(rule.Tiers, func(i, j int) bool { return rule.Tiers[i].MinUnits < rule.Tiers[j].MinUnits })
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// Validate validates the pricing rule
+func (r *Rule) Validate() error {
+	if r.BeerID < 1 {
+		return beers.NewValidationError("beer_id", beers.ErrMustBeGreaterThanZero)
+	}
+
+	sizes := map[int]bool{}
+	for i, pack := range r.Packs {
+		field := fmt.Sprintf("packs[%d]", i)
+
+		if len(pack.SKU) == 0 {
+			return beers.NewValidationError(field+".sku", beers.ErrCannotBeEmpty)
+		}
+
+		if pack.Size < 2 {
+			return beers.NewValidationError(field+".size", "must be at least 2")
+		}
+
+		if pack.Price < 0 {
+			return beers.NewValidationError(field+".price", beers.ErrCannotBeNegative)
+		}
+
+		if sizes[pack.Size] {
+			return beers.NewValidationError(field+".size", "duplicates another pack size")
+		}
+		sizes[pack.Size] = true
+	}
+
+	minUnits := map[int]bool{}
+	for i, tier := range r.Tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+
+		if tier.MinUnits < 1 {
+			return beers.NewValidationError(field+".min_units", beers.ErrMustBeGreaterThanZero)
+		}
+
+		if tier.Percent <= 0 || tier.Percent >= 100 {
+			return beers.NewValidationError(field+".percent", "must be between 0 and 100")
+		}
+
+		if minUnits[tier.MinUnits] {
+			return beers.NewValidationError(field+".min_units", "duplicates another tier")
+		}
+		minUnits[tier.MinUnits] = true
+	}
+
+	return nil
+}
+
+// Line represents one line of a price breakdown
+type Line struct {
+	Type        string  `json:"type"`
+	SKU         string  `json:"sku,omitempty"`
+	Description string  `json:"description"`
+	PackSize    int     `json:"pack_size,omitempty"`
+	Count       int     `json:"count,omitempty"`
+	Price       float64 `json:"price,omitempty"`
+	Amount      float64 `json:"amount"`
+}
+
+// Quote represents the cheapest way to sell a quantity of a beer under a rule
+type Quote struct {
+	Lines           []Line  `json:"lines"`
+	Subtotal        float64 `json:"subtotal"`
+	DiscountPercent float64 `json:"discount_percent,omitempty"`
+	Discount        float64 `json:"discount,omitempty"`
+	Total           float64 `json:"total"`
+}
+
+// Quote picks the cheapest combination of packs and single units that makes up
+// exactly the requested quantity, then applies the best matching discount tier.
+// Amounts are expressed in the beer's own currency.
+func (r *Rule) Quote(unitPrice float64, quantity int) (*Quote, error) {
+	if quantity < 1 {
+		return nil, beers.NewValidationError("quantity", beers.ErrMustBeGreaterThanZero)
+	}
+
+	if quantity > MaxQuantity {
+		return nil, beers.NewValidationError("quantity", fmt.Sprintf("cannot exceed %d", MaxQuantity))
+	}
+
+	if unitPrice < 0 {
+		return nil, beers.NewValidationError("price", beers.ErrCannotBeNegative)
+	}
+
+	// options[0] is always the single unit so every quantity is reachable
+	options := append([]PackSKU{{Size: 1, Price: unitPrice}}, r.Packs...)
+
+	// cost[q] is the cheapest price for exactly q units, pieces[q] the number of
+	// packages used to reach it (fewer packages win ties) and choice[q] the last option
+	cost := make([]float64, quantity+1)
+	pieces := make([]int, quantity+1)
+	choice := make([]int, quantity+1)
+
+	for q := 1; q <= quantity; q++ {
+		cost[q] = math.Inf(1)
+		for i, option := range options {
+			if option.Size > q {
+				continue
+			}
+
+			candidate := cost[q-option.Size] + option.Price
+			candidatePieces := pieces[q-option.Size] + 1
+
+			if candidate < cost[q]-priceEpsilon ||
+				(math.Abs(candidate-cost[q]) <= priceEpsilon && candidatePieces < pieces[q]) {
+				cost[q] = candidate
+				pieces[q] = candidatePieces
+				choice[q] = i
+			}
+		}
+	}
+
+	counts := make([]int, len(options))
+	for q := quantity; q > 0; q -= options[choice[q]].Size {
+		counts[choice[q]]++
+	}
+
+	quote := &Quote{Lines: []Line{}}
+
+	for i := 1; i < len(options); i++ {
+		if counts[i] == 0 {
+			continue
+		}
+		pack := options[i]
+		amount := pack.Price * float64(counts[i])
+		quote.Lines = append(quote.Lines, Line{
+			Type:        LineTypePack,
+			SKU:         pack.SKU,
+			Description: fmt.Sprintf("%d x %d-pack", counts[i], pack.Size),
+			PackSize:    pack.Size,
+			Count:       counts[i],
+			Price:       pack.Price,
+			Amount:      amount,
+		})
+		quote.Subtotal += amount
+	}
+
+	if counts[0] > 0 {
+		amount := unitPrice * float64(counts[0])
+		quote.Lines = append(quote.Lines, Line{
+			Type:        LineTypeSingle,
+			Description: fmt.Sprintf("%d x single unit", counts[0]),
+			PackSize:    1,
+			Count:       counts[0],
+			Price:       unitPrice,
+			Amount:      amount,
+		})
+		quote.Subtotal += amount
+	}
+
+	quote.Total = quote.Subtotal
+
+	if tier, ok := r.TierFor(quantity); ok {
+		quote.DiscountPercent = tier.Percent
+		quote.Discount = quote.Subtotal * tier.Percent / 100
+		quote.Total = quote.Subtotal - quote.Discount
+		quote.Lines = append(quote.Lines, Line{
+			Type:        LineTypeDiscount,
+			Description: fmt.Sprintf("%g%% volume discount from %d units", tier.Percent, tier.MinUnits),
+			Amount:      -quote.Discount,
+		})
+	}
+
+	return quote, nil
+}
+
+// TierFor returns the discount tier with the highest threshold reached by the quantity
+func (r *Rule) TierFor(quantity int) (DiscountTier, bool) {
+	best := DiscountTier{}
+	found := false
+
+	for _, tier := range r.Tiers {
+		if quantity >= tier.MinUnits && tier.MinUnits >= best.MinUnits {
+			best = tier
+			found = true
+		}
+	}
+
+	return best, found
+}
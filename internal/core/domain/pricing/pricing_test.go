@@ -0,0 +1,141 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const unitPrice = 1.0
+
+func newTestRule(t *testing.T) *Rule {
+	rule, err := NewRule(1,
+		[]PackSKU{
+			{SKU: "SIX", Size: 6, Price: 5.4},
+			{SKU: "TWENTYFOUR", Size: 24, Price: 19.2},
+			{SKU: "TWELVE", Size: 12, Price: 10.2},
+		},
+		[]DiscountTier{
+			{MinUnits: 96, Percent: 10},
+			{MinUnits: 48, Percent: 5},
+		},
+	)
+	assert.NoError(t, err)
+	return rule
+}
+
+func TestNewRuleSortsPacksAndTiers(t *testing.T) {
+	rule := newTestRule(t)
+
+	assert.Equal(t, 24, rule.Packs[0].Size)
+	assert.Equal(t, 6, rule.Packs[2].Size)
+	assert.Equal(t, 48, rule.Tiers[0].MinUnits)
+}
+
+func TestNewRuleValidation(t *testing.T) {
+	cases := map[string]struct {
+		packs []PackSKU
+		tiers []DiscountTier
+		field string
+	}{
+		"empty sku":       {packs: []PackSKU{{Size: 6, Price: 1}}, field: "packs[0].sku"},
+		"size too small":  {packs: []PackSKU{{SKU: "ONE", Size: 1, Price: 1}}, field: "packs[0].size"},
+		"negative price":  {packs: []PackSKU{{SKU: "SIX", Size: 6, Price: -1}}, field: "packs[0].price"},
+		"duplicate size":  {packs: []PackSKU{{SKU: "A", Size: 6, Price: 1}, {SKU: "B", Size: 6, Price: 2}}, field: "packs[1].size"},
+		"invalid percent": {tiers: []DiscountTier{{MinUnits: 10, Percent: 100}}, field: "tiers[0].percent"},
+		"invalid min":     {tiers: []DiscountTier{{MinUnits: 0, Percent: 5}}, field: "tiers[0].min_units"},
+		"duplicate min":   {tiers: []DiscountTier{{MinUnits: 10, Percent: 5}, {MinUnits: 10, Percent: 6}}, field: "tiers[1].min_units"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule, err := NewRule(1, tc.packs, tc.tiers)
+
+			assert.Nil(t, rule)
+			validationErr, ok := err.(*beers.ValidationError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestQuoteWithoutPacksIsLinear(t *testing.T) {
+	rule := &Rule{BeerID: 1}
+
+	quote, err := rule.Quote(1500, 6)
+
+	assert.NoError(t, err)
+	assert.Len(t, quote.Lines, 1)
+	assert.Equal(t, LineTypeSingle, quote.Lines[0].Type)
+	assert.Equal(t, 9000.0, quote.Total)
+}
+
+func TestQuotePicksCheapestCombination(t *testing.T) {
+	rule := newTestRule(t)
+
+	// 30 units: 24-pack + 6-pack (24.6) beats 2 x 12-pack + 6-pack (25.8)
+	quote, err := rule.Quote(unitPrice, 30)
+
+	assert.NoError(t, err)
+	assert.Len(t, quote.Lines, 2)
+	assert.Equal(t, "TWENTYFOUR", quote.Lines[0].SKU)
+	assert.Equal(t, 1, quote.Lines[0].Count)
+	assert.Equal(t, "SIX", quote.Lines[1].SKU)
+	assert.InDelta(t, 24.6, quote.Total, 0.0001)
+	assert.Zero(t, quote.Discount)
+}
+
+func TestQuoteFillsRemainderWithSingles(t *testing.T) {
+	rule := newTestRule(t)
+
+	quote, err := rule.Quote(unitPrice, 8)
+
+	assert.NoError(t, err)
+	assert.Len(t, quote.Lines, 2)
+	assert.Equal(t, LineTypePack, quote.Lines[0].Type)
+	assert.Equal(t, LineTypeSingle, quote.Lines[1].Type)
+	assert.Equal(t, 2, quote.Lines[1].Count)
+	assert.InDelta(t, 7.4, quote.Total, 0.0001)
+}
+
+func TestQuoteIgnoresPacksPricierThanSingles(t *testing.T) {
+	rule, _ := NewRule(1, []PackSKU{{SKU: "SIX", Size: 6, Price: 7}}, nil)
+
+	quote, err := rule.Quote(unitPrice, 6)
+
+	assert.NoError(t, err)
+	assert.Len(t, quote.Lines, 1)
+	assert.Equal(t, LineTypeSingle, quote.Lines[0].Type)
+	assert.Equal(t, 6.0, quote.Total)
+}
+
+func TestQuoteAppliesBestTier(t *testing.T) {
+	rule := newTestRule(t)
+
+	quote, err := rule.Quote(unitPrice, 48)
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 38.4, quote.Subtotal, 0.0001)
+	assert.Equal(t, 5.0, quote.DiscountPercent)
+	assert.InDelta(t, 1.92, quote.Discount, 0.0001)
+	assert.InDelta(t, 36.48, quote.Total, 0.0001)
+
+	last := quote.Lines[len(quote.Lines)-1]
+	assert.Equal(t, LineTypeDiscount, last.Type)
+	assert.InDelta(t, -1.92, last.Amount, 0.0001)
+
+	quote, _ = rule.Quote(unitPrice, 96)
+	assert.Equal(t, 10.0, quote.DiscountPercent)
+}
+
+func TestQuoteInvalidQuantity(t *testing.T) {
+	rule := newTestRule(t)
+
+	_, err := rule.Quote(unitPrice, 0)
+	assert.Error(t, err)
+
+	_, err = rule.Quote(unitPrice, MaxQuantity+1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,258 @@
+package promotions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+// Type represents how a promotion discounts a box
+type Type string
+
+const (
+	// TypePercentage takes a percentage off the box price
+	TypePercentage Type = "percentage"
+	// TypeFixed takes a fixed amount, in the promotion currency, off the box price
+	TypeFixed Type = "fixed"
+)
+
+const (
+	// ErrCodeNotFound is the domain error code used when a promotion does not exist
+	ErrCodeNotFound = "PROMOTION_NOT_FOUND"
+	// ErrCodeCouponExists is the domain error code used when a coupon code is already taken
+	ErrCodeCouponExists = "COUPON_ALREADY_EXISTS"
+	// ErrCodeUsageLimitReached is the domain error code used when a coupon has no redemptions left
+	ErrCodeUsageLimitReached = "PROMOTION_USAGE_LIMIT_REACHED"
+	// ErrCodeCouponNotApplicable is the domain error code used when a coupon applies to nothing being bought
+	ErrCodeCouponNotApplicable = "COUPON_NOT_APPLICABLE"
+)
+
+// Reasons a promotion did not apply to a box
+const (
+	ReasonNotStarted        = "not_started"
+	ReasonExpired           = "expired"
+	ReasonOutOfScope        = "out_of_scope"
+	ReasonCouponRequired    = "coupon_required"
+	ReasonUsageLimitReached = "usage_limit_reached"
+	ReasonNoDiscountLeft    = "no_discount_left"
+	ReasonRateUnavailable   = "exchange_rate_unavailable"
+	ReasonUnknownCoupon     = "unknown_coupon"
+)
+
+// Scope restricts a promotion to breweries, origin countries or specific beers.
+// A beer is in scope when it matches every non-empty list.
+type Scope struct {
+	Breweries []string `json:"breweries,omitempty"`
+	Countries []string `json:"countries,omitempty"`
+	BeerIDs   []int    `json:"beer_ids,omitempty"`
+}
+
+// Promotion represents a time-boxed discount, optionally unlocked by a coupon code
+type Promotion struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	Type        Type      `json:"type"`
+	Value       float64   `json:"value"`
+	Currency    string    `json:"currency,omitempty"`
+	Scope       Scope     `json:"scope"`
+	StartsAt    time.Time `json:"starts_at"`
+	EndsAt      time.Time `json:"ends_at"`
+	CouponCode  string    `json:"coupon_code,omitempty"`
+	UsageLimit  int       `json:"usage_limit,omitempty"`
+	UsageCount  int       `json:"usage_count"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// NewPromotion creates a new promotion with validation
+func NewPromotion(name string, promoType Type, value float64, currency string, startsAt, endsAt time.Time) (*Promotion, error) {
+	now := time.Now()
+	promotion := &Promotion{
+		ID:        newID("promo"),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := promotion.Update(name, promoType, value, currency, startsAt, endsAt); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// Update replaces the terms of the promotion with validation
+func (p *Promotion) Update(name string, promoType Type, value float64, currency string, startsAt, endsAt time.Time) error {
+	updated := *p
+	updated.Name = strings.TrimSpace(name)
+	updated.Type = promoType
+	updated.Value = value
+	updated.Currency = strings.ToUpper(strings.TrimSpace(currency))
+	updated.StartsAt = startsAt
+	updated.EndsAt = endsAt
+	updated.UpdatedAt = time.Now()
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+
+	*p = updated
+	return nil
+}
+
+// SetCoupon makes the promotion apply only when the code is given, up to limit times (0 for unlimited)
+func (p *Promotion) SetCoupon(code string, limit int) error {
+	code = NormalizeCode(code)
+
+	if limit < 0 {
+		return beers.NewValidationError("usage_limit", beers.ErrCannotBeNegative)
+	}
+
+	if limit > 0 && code == "" {
+		return beers.NewValidationError("coupon_code", "is required when usage_limit is set")
+	}
+
+	p.CouponCode = code
+	p.UsageLimit = limit
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Validate validates the promotion
+func (p *Promotion) Validate() error {
+	if len(p.Name) == 0 {
+		return beers.NewValidationError("name", beers.ErrCannotBeEmpty)
+	}
+
+	if len(p.Name) > 100 {
+		return beers.NewValidationError("name", beers.ErrCannotExceed100Chars)
+	}
+
+	switch p.Type {
+	case TypePercentage:
+		if p.Value <= 0 || p.Value > 100 {
+			return beers.NewValidationError("value", beers.ErrMustBeAPercentage)
+		}
+	case TypeFixed:
+		if p.Value <= 0 {
+			return beers.NewValidationError("value", beers.ErrMustBeGreaterThanZero)
+		}
+		if len(p.Currency) != 3 {
+			return beers.NewValidationError("currency", beers.ErrMustBe3Characters)
+		}
+	default:
+		return beers.NewValidationError("type", fmt.Sprintf("must be %s or %s", TypePercentage, TypeFixed))
+	}
+
+	if !p.StartsAt.IsZero() && !p.EndsAt.IsZero() && !p.EndsAt.After(p.StartsAt) {
+		return beers.NewValidationError("ends_at", "must be after starts_at")
+	}
+
+	return nil
+}
+
+// InScope reports whether the beer falls within the promotion scope
+func (p *Promotion) InScope(beer *beers.Beer) bool {
+	if len(p.Scope.BeerIDs) > 0 && !containsInt(p.Scope.BeerIDs, beer.ID) {
+		return false
+	}
+
+	if len(p.Scope.Breweries) > 0 && !containsFold(p.Scope.Breweries, beer.Brewery) {
+		return false
+	}
+
+	if len(p.Scope.Countries) > 0 && !containsFold(p.Scope.Countries, beer.Country) {
+		return false
+	}
+
+	return true
+}
+
+// Eligibility returns an empty reason when the promotion applies to the beer at the
+// given time with the given coupon code, or the reason it does not
+func (p *Promotion) Eligibility(beer *beers.Beer, now time.Time, coupon string) string {
+	if !p.StartsAt.IsZero() && now.Before(p.StartsAt) {
+		return ReasonNotStarted
+	}
+
+	if !p.EndsAt.IsZero() && !now.Before(p.EndsAt) {
+		return ReasonExpired
+	}
+
+	if !p.InScope(beer) {
+		return ReasonOutOfScope
+	}
+
+	if p.CouponCode != "" && p.CouponCode != NormalizeCode(coupon) {
+		return ReasonCouponRequired
+	}
+
+	if p.UsageLimit > 0 && p.UsageCount >= p.UsageLimit {
+		return ReasonUsageLimitReached
+	}
+
+	return ""
+}
+
+// Discount returns the amount taken off a box worth amount, never more than the amount.
+// rate converts the promotion currency into the currency of the amount.
+func (p *Promotion) Discount(amount, rate float64) float64 {
+	discount := p.Value * rate
+	if p.Type == TypePercentage {
+		discount = amount * p.Value / 100
+	}
+
+	if discount > amount {
+		return amount
+	}
+
+	return discount
+}
+
+// Outcome records whether a promotion applied to a box and why
+type Outcome struct {
+	PromotionID string  `json:"promotion_id"`
+	Name        string  `json:"name"`
+	Applied     bool    `json:"applied"`
+	Reason      string  `json:"reason,omitempty"`
+	Discount    float64 `json:"discount,omitempty"`
+}
+
+// NormalizeCode normalizes a coupon code for comparison
+func NormalizeCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// newID generates a random identifier with the given prefix
+func newID(prefix string) string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	}
+	return prefix + "_" + hex.EncodeToString(buf)
+}
+
+// containsInt reports whether the value is in the list
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether the value is in the list, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
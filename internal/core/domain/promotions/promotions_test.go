@@ -0,0 +1,112 @@
+package promotions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+var (
+	start = time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end   = time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func newTestBeer() *beers.Beer {
+	return &beers.Beer{ID: 1, Name: "Cerveza Cristal", Brewery: "CCU", Country: "Chile", Price: 1200, Currency: "CLP"}
+}
+
+func TestNewPromotion(t *testing.T) {
+	promotion, err := NewPromotion(" Oktoberfest ", TypeFixed, 500, "clp", start, end)
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(promotion.ID, "promo_"))
+	assert.Equal(t, "Oktoberfest", promotion.Name)
+	assert.Equal(t, "CLP", promotion.Currency)
+}
+
+func TestNewPromotionValidation(t *testing.T) {
+	cases := map[string]struct {
+		name     string
+		kind     Type
+		value    float64
+		currency string
+		endsAt   time.Time
+		field    string
+	}{
+		"empty name":       {"", TypePercentage, 10, "", end, "name"},
+		"unknown type":     {"Promo", "bogo", 10, "", end, "type"},
+		"percent too high": {"Promo", TypePercentage, 150, "", end, "value"},
+		"fixed zero":       {"Promo", TypeFixed, 0, "USD", end, "value"},
+		"fixed currency":   {"Promo", TypeFixed, 5, "", end, "currency"},
+		"ends before":      {"Promo", TypePercentage, 10, "", start.Add(-time.Hour), "ends_at"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			promotion, err := NewPromotion(tc.name, tc.kind, tc.value, tc.currency, start, tc.endsAt)
+
+			assert.Nil(t, promotion)
+			validationErr, ok := err.(*beers.ValidationError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestSetCoupon(t *testing.T) {
+	promotion, _ := NewPromotion("Promo", TypePercentage, 10, "", start, end)
+
+	assert.NoError(t, promotion.SetCoupon(" welcome10 ", 5))
+	assert.Equal(t, "WELCOME10", promotion.CouponCode)
+	assert.Equal(t, 5, promotion.UsageLimit)
+
+	assert.Error(t, promotion.SetCoupon("", 5))
+	assert.Error(t, promotion.SetCoupon("CODE", -1))
+}
+
+func TestEligibility(t *testing.T) {
+	beer := newTestBeer()
+	now := start.Add(24 * time.Hour)
+
+	promotion, _ := NewPromotion("Promo", TypePercentage, 10, "", start, end)
+	assert.Empty(t, promotion.Eligibility(beer, now, ""))
+	assert.Equal(t, ReasonNotStarted, promotion.Eligibility(beer, start.Add(-time.Second), ""))
+	assert.Equal(t, ReasonExpired, promotion.Eligibility(beer, end, ""))
+
+	promotion.Scope = Scope{Breweries: []string{"ccu"}, Countries: []string{"Chile"}}
+	assert.Empty(t, promotion.Eligibility(beer, now, ""))
+
+	promotion.Scope = Scope{BeerIDs: []int{2, 3}}
+	assert.Equal(t, ReasonOutOfScope, promotion.Eligibility(beer, now, ""))
+
+	promotion.Scope = Scope{}
+	_ = promotion.SetCoupon("WELCOME", 1)
+	assert.Equal(t, ReasonCouponRequired, promotion.Eligibility(beer, now, ""))
+	assert.Equal(t, ReasonCouponRequired, promotion.Eligibility(beer, now, "OTHER"))
+	assert.Empty(t, promotion.Eligibility(beer, now, "welcome"))
+
+	promotion.UsageCount = 1
+	assert.Equal(t, ReasonUsageLimitReached, promotion.Eligibility(beer, now, "WELCOME"))
+}
+
+func TestEligibilityWithoutDates(t *testing.T) {
+	promotion, err := NewPromotion("Always on", TypePercentage, 5, "", time.Time{}, time.Time{})
+
+	assert.NoError(t, err)
+	assert.Empty(t, promotion.Eligibility(newTestBeer(), time.Now(), ""))
+}
+
+func TestDiscount(t *testing.T) {
+	percentage, _ := NewPromotion("Promo", TypePercentage, 10, "", start, end)
+	assert.InDelta(t, 2.0, percentage.Discount(20, 1), 1e-9)
+
+	fixed, _ := NewPromotion("Promo", TypeFixed, 5, "USD", start, end)
+	assert.InDelta(t, 4.5, fixed.Discount(20, 0.9), 1e-9)
+
+	// A fixed discount never exceeds the amount it applies to
+	assert.InDelta(t, 3.0, fixed.Discount(3, 1), 1e-9)
+}
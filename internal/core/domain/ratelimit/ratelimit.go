@@ -0,0 +1,171 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrCodeRateLimited is the error code used when a client has used up its requests
+const ErrCodeRateLimited = "RATE_LIMITED"
+
+// Limit allows Requests per Period, refilled continuously. Requests is also
+// the burst size: an idle client may spend all of them at once.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// periods maps the unit of a limit spec to its duration
+var periods = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+}
+
+// ParseLimit parses a limit spec such as "60/m" (units s, m or h)
+func ParseLimit(spec string) (Limit, error) {
+	count, unit, found := strings.Cut(strings.TrimSpace(spec), "/")
+	if !found {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: expected <requests>/<s|m|h>", spec)
+	}
+
+	requests, err := strconv.Atoi(strings.TrimSpace(count))
+	if err != nil || requests <= 0 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: requests must be a positive integer", spec)
+	}
+
+	period, known := periods[strings.TrimSpace(unit)]
+	if !known {
+		return Limit{}, fmt.Errorf("invalid rate limit %q: unit must be s, m or h", spec)
+	}
+
+	return Limit{Requests: requests, Period: period}, nil
+}
+
+// IsZero reports whether the limit is unset, meaning unlimited
+func (l Limit) IsZero() bool {
+	return l.Requests == 0
+}
+
+// perSecond returns the refill rate in requests per second
+func (l Limit) perSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+// Decision is the outcome of taking a request from a bucket
+type Decision struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	ResetAfter time.Duration
+}
+
+// Bucket is a token bucket. A zero bucket is full.
+type Bucket struct {
+	Tokens    float64   `json:"tokens"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Take refills the bucket for the time elapsed since its last update and takes one request
+func (b *Bucket) Take(limit Limit, now time.Time) Decision {
+	b.refill(limit, now)
+
+	decision := Decision{Allowed: b.Tokens >= 1, Limit: limit.Requests}
+	if decision.Allowed {
+		b.Tokens--
+	} else {
+		decision.RetryAfter = limit.durationFor(1 - b.Tokens)
+	}
+
+	decision.Remaining = int(math.Floor(b.Tokens))
+	decision.ResetAfter = limit.durationFor(float64(limit.Requests) - b.Tokens)
+
+	return decision
+}
+
+// IsFull reports whether the bucket would be full at the given time, so it can be forgotten
+func (b Bucket) IsFull(limit Limit, now time.Time) bool {
+	b.refill(limit, now)
+	return b.Tokens >= float64(limit.Requests)
+}
+
+// refill adds the tokens earned since the last update, up to the bucket size
+func (b *Bucket) refill(limit Limit, now time.Time) {
+	capacity := float64(limit.Requests)
+
+	if b.UpdatedAt.IsZero() {
+		b.Tokens = capacity
+	} else if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(capacity, b.Tokens+elapsed*limit.perSecond())
+	}
+
+	if now.After(b.UpdatedAt) {
+		b.UpdatedAt = now
+	}
+}
+
+// durationFor returns how long the bucket takes to earn the given number of tokens
+func (l Limit) durationFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+	return time.Duration(tokens / l.perSecond() * float64(time.Second))
+}
+
+// Policy holds a default limit and per-route overrides keyed by "METHOD /route/:param"
+type Policy struct {
+	Default Limit
+	Routes  map[string]Limit
+}
+
+// ParsePolicy parses a default limit spec and a comma separated list of route
+// overrides such as "GET /api/v1/beers/:id/boxprice=30/m". An empty default
+// leaves routes without an override unlimited.
+func ParsePolicy(defaultSpec, routesSpec string) (*Policy, error) {
+	policy := &Policy{Routes: map[string]Limit{}}
+
+	if strings.TrimSpace(defaultSpec) != "" {
+		limit, err := ParseLimit(defaultSpec)
+		if err != nil {
+			return nil, err
+		}
+		policy.Default = limit
+	}
+
+	for _, entry := range strings.Split(routesSpec, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+
+		route, spec, found := strings.Cut(entry, "=")
+		method, path, hasMethod := strings.Cut(strings.TrimSpace(route), " ")
+		if !found || !hasMethod || strings.TrimSpace(path) == "" {
+			return nil, fmt.Errorf("invalid route rate limit %q: expected \"METHOD /path=<requests>/<unit>\"", entry)
+		}
+
+		limit, err := ParseLimit(spec)
+		if err != nil {
+			return nil, err
+		}
+		policy.Routes[RouteKey(method, path)] = limit
+	}
+
+	return policy, nil
+}
+
+// LimitFor returns the limit of a route, falling back to the default
+func (p *Policy) LimitFor(method, route string) Limit {
+	if limit, exists := p.Routes[RouteKey(method, route)]; exists {
+		return limit
+	}
+	return p.Default
+}
+
+// RouteKey normalizes a method and route pattern into a policy key
+func RouteKey(method, route string) string {
+	return strings.ToUpper(strings.TrimSpace(method)) + " " + strings.TrimSpace(route)
+}
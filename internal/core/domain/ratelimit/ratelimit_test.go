@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLimit(t *testing.T) {
+	limit, err := ParseLimit(" 60/m ")
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 60, Period: time.Minute}, limit)
+
+	for _, spec := range []string{"", "60", "0/s", "-1/s", "ten/s", "60/d"} {
+		_, err := ParseLimit(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestBucketTake(t *testing.T) {
+	limit := Limit{Requests: 2, Period: time.Second}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	var bucket Bucket
+
+	first := bucket.Take(limit, now)
+	assert.True(t, first.Allowed)
+	assert.Equal(t, 2, first.Limit)
+	assert.Equal(t, 1, first.Remaining)
+	assert.Equal(t, 500*time.Millisecond, first.ResetAfter)
+
+	assert.True(t, bucket.Take(limit, now).Allowed)
+
+	denied := bucket.Take(limit, now)
+	assert.False(t, denied.Allowed)
+	assert.Equal(t, 0, denied.Remaining)
+	assert.Equal(t, 500*time.Millisecond, denied.RetryAfter)
+	assert.Equal(t, time.Second, denied.ResetAfter)
+
+	// Half a second earns one request back
+	assert.True(t, bucket.Take(limit, now.Add(500*time.Millisecond)).Allowed)
+	assert.False(t, bucket.Take(limit, now.Add(500*time.Millisecond)).Allowed)
+}
+
+func TestBucketNeverExceedsCapacity(t *testing.T) {
+	limit := Limit{Requests: 3, Period: time.Minute}
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	bucket := Bucket{Tokens: 0, UpdatedAt: now}
+
+	assert.False(t, bucket.IsFull(limit, now.Add(30*time.Second)))
+	assert.True(t, bucket.IsFull(limit, now.Add(time.Hour)))
+
+	decision := bucket.Take(limit, now.Add(time.Hour))
+	assert.Equal(t, 2, decision.Remaining)
+}
+
+func TestParsePolicy(t *testing.T) {
+	policy, err := ParsePolicy("600/m", "GET /api/v1/beers/:id/boxprice=30/m, post /api/v1/quotes=10/s")
+
+	assert.NoError(t, err)
+	assert.Equal(t, Limit{Requests: 30, Period: time.Minute}, policy.LimitFor("GET", "/api/v1/beers/:id/boxprice"))
+	assert.Equal(t, Limit{Requests: 10, Period: time.Second}, policy.LimitFor("POST", "/api/v1/quotes"))
+	assert.Equal(t, Limit{Requests: 600, Period: time.Minute}, policy.LimitFor("GET", "/api/v1/beers"))
+
+	unlimited, err := ParsePolicy("", "")
+	assert.NoError(t, err)
+	assert.True(t, unlimited.LimitFor("GET", "/api/v1/beers").IsZero())
+
+	for _, routes := range []string{"/api/v1/quotes=10/s", "GET /api/v1/quotes", "GET /api/v1/quotes=fast"} {
+		_, err := ParsePolicy("", routes)
+		assert.Error(t, err, routes)
+	}
+}
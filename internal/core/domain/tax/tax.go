@@ -0,0 +1,249 @@
+package tax
+
+import (
+	"fmt"
+	"strings"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const (
+	// ErrCodeAttributesMissing is the domain error code used when a beer lacks
+	// the attributes a matching tax rule needs, such as its volume
+	ErrCodeAttributesMissing = "TAX_ATTRIBUTES_MISSING"
+	// ErrCodeRulesNotConfigured is the domain error code used when taxes are asked
+	// for but no tax rules are loaded at all
+	ErrCodeRulesNotConfigured = "TAX_RULES_NOT_CONFIGURED"
+
+	// KindPercentage is a tax charged as a percentage of the net amount, such as VAT or IVA
+	KindPercentage = "percentage"
+	// KindPerLitre is a fixed amount charged per litre of beer
+	KindPerLitre = "per_litre"
+	// KindPerLitreAlcohol is a fixed amount charged per litre of pure alcohol
+	KindPerLitreAlcohol = "per_litre_alcohol"
+)
+
+// Match selects the beers a rule applies to. Empty fields match every beer.
+type Match struct {
+	BeerIDs   []int    `json:"beer_ids,omitempty"`
+	Breweries []string `json:"breweries,omitempty"`
+	Origins   []string `json:"origins,omitempty"`
+	MinABV    float64  `json:"min_abv,omitempty"`
+	MaxABV    float64  `json:"max_abv,omitempty"`
+}
+
+// Rule represents a tax charged on beers shipped to a destination country
+type Rule struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Country  string  `json:"country"`
+	Kind     string  `json:"kind"`
+	Rate     float64 `json:"rate"`
+	Currency string  `json:"currency,omitempty"`
+	Compound bool    `json:"compound,omitempty"`
+	Match    Match   `json:"match"`
+}
+
+// Validate validates the tax rule
+func (r *Rule) Validate() error {
+	if len(strings.TrimSpace(r.ID)) == 0 {
+		return beers.NewValidationError("id", beers.ErrCannotBeEmpty)
+	}
+
+	if len(strings.TrimSpace(r.Name)) == 0 {
+		return beers.NewValidationError("name", beers.ErrCannotBeEmpty)
+	}
+
+	if len(r.Country) != 2 {
+		return beers.NewValidationError("country", "must be exactly 2 characters (ISO 3166-1 alpha-2)")
+	}
+
+	if r.Rate < 0 {
+		return beers.NewValidationError("rate", beers.ErrCannotBeNegative)
+	}
+
+	switch r.Kind {
+	case KindPercentage:
+		if r.Rate > 100 {
+			return beers.NewValidationError("rate", beers.ErrMustBeAPercentage)
+		}
+	case KindPerLitre, KindPerLitreAlcohol:
+		if len(r.Currency) != 3 {
+			return beers.NewValidationError("currency", beers.ErrMustBe3Characters)
+		}
+	default:
+		return beers.NewValidationError("kind", fmt.Sprintf("must be one of %s, %s, %s", KindPercentage, KindPerLitre, KindPerLitreAlcohol))
+	}
+
+	if r.Match.MaxABV > 0 && r.Match.MaxABV < r.Match.MinABV {
+		return beers.NewValidationError("match.max_abv", "cannot be lower than min_abv")
+	}
+
+	return nil
+}
+
+// Applies reports whether the rule matches the beer's attributes
+func (r *Rule) Applies(beer *beers.Beer) bool {
+	return r.matchesBeer(beer) && r.matchesABV(beer.ABV)
+}
+
+// matchesBeer reports whether the rule matches the beer's ID, brewery and origin
+func (r *Rule) matchesBeer(beer *beers.Beer) bool {
+	if len(r.Match.BeerIDs) > 0 && !containsInt(r.Match.BeerIDs, beer.ID) {
+		return false
+	}
+
+	if len(r.Match.Breweries) > 0 && !containsFold(r.Match.Breweries, beer.Brewery) {
+		return false
+	}
+
+	if len(r.Match.Origins) > 0 && !containsFold(r.Match.Origins, beer.Country) {
+		return false
+	}
+
+	return true
+}
+
+// matchesABV reports whether the alcohol by volume is within the rule's range
+func (r *Rule) matchesABV(abv float64) bool {
+	if abv < r.Match.MinABV {
+		return false
+	}
+
+	return r.Match.MaxABV == 0 || abv <= r.Match.MaxABV
+}
+
+// needsABV reports whether the rule cannot be assessed without the beer's ABV
+func (r *Rule) needsABV() bool {
+	return r.Kind == KindPerLitreAlcohol || r.Match.MinABV > 0 || r.Match.MaxABV > 0
+}
+
+// IsExcise reports whether the rule charges a fixed amount by volume
+func (r *Rule) IsExcise() bool {
+	return r.Kind == KindPerLitre || r.Kind == KindPerLitreAlcohol
+}
+
+// Line represents one itemised tax of a box price
+type Line struct {
+	RuleID string  `json:"rule_id"`
+	Name   string  `json:"name"`
+	Kind   string  `json:"kind"`
+	Rate   float64 `json:"rate"`
+	Base   float64 `json:"base"`
+	Amount float64 `json:"amount"`
+}
+
+// Assessment represents the taxes owed on a box shipped to a destination
+type Assessment struct {
+	Country string  `json:"country"`
+	Lines   []Line  `json:"lines"`
+	Total   float64 `json:"total"`
+}
+
+// RateFunc returns the exchange rate from a rule currency into the target currency
+type RateFunc func(currency string) (float64, error)
+
+// Calculate applies every matching rule to a box of the beer. Excise duties are
+// charged first so compound percentage taxes can include them in their base.
+// The net amount and every resulting amount are in the target currency.
+func Calculate(country string, rules []Rule, beer *beers.Beer, quantity int, net float64, rate RateFunc) (*Assessment, error) {
+	assessment := &Assessment{
+		Country: strings.ToUpper(country),
+		Lines:   []Line{},
+	}
+
+	litres := float64(beer.VolumeML*quantity) / 1000
+	excise := 0.0
+
+	for _, rule := range rules {
+		if !rule.IsExcise() || !rule.matchesBeer(beer) {
+			continue
+		}
+
+		// An ABV of 0 is unknown, as for beers created before it was recorded
+		if beer.ABV == 0 && rule.needsABV() {
+			return nil, beers.NewDomainError(ErrCodeAttributesMissing,
+				fmt.Sprintf("Tax rule %s requires the beer ABV", rule.ID), nil)
+		}
+
+		if !rule.matchesABV(beer.ABV) {
+			continue
+		}
+
+		if beer.VolumeML == 0 {
+			return nil, beers.NewDomainError(ErrCodeAttributesMissing,
+				fmt.Sprintf("Tax rule %s requires the beer volume", rule.ID), nil)
+		}
+
+		base := litres
+		if rule.Kind == KindPerLitreAlcohol {
+			base = litres * beer.ABV / 100
+		}
+
+		exchangeRate, err := rate(rule.Currency)
+		if err != nil {
+			return nil, err
+		}
+
+		amount := base * rule.Rate * exchangeRate
+		excise += amount
+		assessment.add(rule, base, amount)
+	}
+
+	for _, rule := range rules {
+		if rule.Kind != KindPercentage || !rule.matchesBeer(beer) {
+			continue
+		}
+
+		if beer.ABV == 0 && rule.needsABV() {
+			return nil, beers.NewDomainError(ErrCodeAttributesMissing,
+				fmt.Sprintf("Tax rule %s requires the beer ABV", rule.ID), nil)
+		}
+
+		if !rule.matchesABV(beer.ABV) {
+			continue
+		}
+
+		base := net
+		if rule.Compound {
+			base += excise
+		}
+
+		assessment.add(rule, base, base*rule.Rate/100)
+	}
+
+	return assessment, nil
+}
+
+// add appends a tax line and updates the total
+func (a *Assessment) add(rule Rule, base, amount float64) {
+	a.Lines = append(a.Lines, Line{
+		RuleID: rule.ID,
+		Name:   rule.Name,
+		Kind:   rule.Kind,
+		Rate:   rule.Rate,
+		Base:   base,
+		Amount: amount,
+	})
+	a.Total += amount
+}
+
+// containsInt reports whether the value is in the list
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// containsFold reports whether the value is in the list, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(value)) {
+			return true
+		}
+	}
+	return false
+}
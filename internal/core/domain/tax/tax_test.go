@@ -0,0 +1,165 @@
+package tax
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+func newTestBeer() *beers.Beer {
+	return &beers.Beer{
+		ID:       7,
+		Name:     "Guinness",
+		Brewery:  "Guinness Brewery",
+		Country:  "Ireland",
+		Price:    4.8,
+		Currency: "EUR",
+		ABV:      4.2,
+		VolumeML: 500,
+	}
+}
+
+func unitRate(string) (float64, error) { return 1.0, nil }
+
+func TestRuleValidate(t *testing.T) {
+	valid := Rule{ID: "cl-iva", Name: "IVA", Country: "CL", Kind: KindPercentage, Rate: 19}
+	assert.NoError(t, valid.Validate())
+
+	cases := map[string]struct {
+		mutate func(r *Rule)
+		field  string
+	}{
+		"empty id":          {func(r *Rule) { r.ID = "" }, "id"},
+		"invalid country":   {func(r *Rule) { r.Country = "CHL" }, "country"},
+		"unknown kind":      {func(r *Rule) { r.Kind = "flat" }, "kind"},
+		"percent above 100": {func(r *Rule) { r.Rate = 120 }, "rate"},
+		"excise currency":   {func(r *Rule) { r.Kind = KindPerLitre }, "currency"},
+		"abv range":         {func(r *Rule) { r.Match.MinABV = 5; r.Match.MaxABV = 4 }, "match.max_abv"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			rule := valid
+			tc.mutate(&rule)
+
+			validationErr, ok := rule.Validate().(*beers.ValidationError)
+			assert.True(t, ok)
+			assert.Equal(t, tc.field, validationErr.Field)
+		})
+	}
+}
+
+func TestRuleApplies(t *testing.T) {
+	beer := newTestBeer()
+
+	assert.True(t, (&Rule{}).Applies(beer))
+	assert.True(t, (&Rule{Match: Match{Origins: []string{"ireland"}}}).Applies(beer))
+	assert.False(t, (&Rule{Match: Match{Breweries: []string{"CCU"}}}).Applies(beer))
+	assert.False(t, (&Rule{Match: Match{BeerIDs: []int{1, 2}}}).Applies(beer))
+	assert.False(t, (&Rule{Match: Match{MinABV: 5}}).Applies(beer))
+	assert.False(t, (&Rule{Match: Match{MaxABV: 4}}).Applies(beer))
+}
+
+func TestCalculateExciseThenCompoundVAT(t *testing.T) {
+	rules := []Rule{
+		{ID: "ie-vat", Name: "VAT", Country: "IE", Kind: KindPercentage, Rate: 23, Compound: true},
+		{ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitreAlcohol, Rate: 22.55, Currency: "EUR"},
+	}
+
+	// 6 x 500ml at 4.2% ABV = 3 litres of beer, 0.126 litres of alcohol
+	assessment, err := Calculate("ie", rules, newTestBeer(), 6, 28.8, unitRate)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "IE", assessment.Country)
+	assert.Len(t, assessment.Lines, 2)
+
+	excise := assessment.Lines[0]
+	assert.Equal(t, "ie-excise", excise.RuleID)
+	assert.InDelta(t, 0.126, excise.Base, 1e-9)
+	assert.InDelta(t, 2.8413, excise.Amount, 1e-9)
+
+	vat := assessment.Lines[1]
+	assert.InDelta(t, 28.8+2.8413, vat.Base, 1e-9)
+	assert.InDelta(t, (28.8+2.8413)*0.23, vat.Amount, 1e-9)
+	assert.InDelta(t, excise.Amount+vat.Amount, assessment.Total, 1e-9)
+}
+
+func TestCalculateConvertsExciseCurrency(t *testing.T) {
+	rules := []Rule{{ID: "cl-ila", Name: "ILA", Country: "CL", Kind: KindPerLitre, Rate: 100, Currency: "CLP"}}
+
+	assessment, err := Calculate("CL", rules, newTestBeer(), 2, 10, func(currency string) (float64, error) {
+		assert.Equal(t, "CLP", currency)
+		return 0.001, nil
+	})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.1, assessment.Total, 1e-9)
+}
+
+func TestCalculateSkipsRulesThatDoNotMatch(t *testing.T) {
+	rules := []Rule{{ID: "strong", Name: "Strong beer duty", Country: "GB", Kind: KindPercentage, Rate: 10, Match: Match{MinABV: 7.5}}}
+
+	assessment, err := Calculate("GB", rules, newTestBeer(), 6, 28.8, unitRate)
+
+	assert.NoError(t, err)
+	assert.Empty(t, assessment.Lines)
+	assert.Zero(t, assessment.Total)
+}
+
+func TestCalculateMissingVolume(t *testing.T) {
+	beer := newTestBeer()
+	beer.VolumeML = 0
+	rules := []Rule{{ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitreAlcohol, Rate: 22.55, Currency: "EUR"}}
+
+	_, err := Calculate("IE", rules, beer, 6, 28.8, unitRate)
+
+	var domainErr *beers.DomainError
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, ErrCodeAttributesMissing, domainErr.Code)
+}
+
+func TestCalculateMissingABV(t *testing.T) {
+	cases := map[string]Rule{
+		"per litre of alcohol": {ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitreAlcohol, Rate: 22.55, Currency: "EUR"},
+		"minimum ABV":          {ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitre, Rate: 1, Currency: "EUR", Match: Match{MinABV: 2.8}},
+		"percentage by ABV":    {ID: "ie-strong", Name: "Strong beer tax", Country: "IE", Kind: KindPercentage, Rate: 10, Match: Match{MinABV: 7.5}},
+	}
+
+	for name, rule := range cases {
+		t.Run(name, func(t *testing.T) {
+			beer := newTestBeer()
+			beer.ABV = 0
+
+			_, err := Calculate("IE", []Rule{rule}, beer, 6, 28.8, unitRate)
+
+			var domainErr *beers.DomainError
+			assert.True(t, errors.As(err, &domainErr))
+			assert.Equal(t, ErrCodeAttributesMissing, domainErr.Code)
+		})
+	}
+}
+
+func TestCalculateMissingABVOfUnmatchedBeer(t *testing.T) {
+	beer := newTestBeer()
+	beer.ABV = 0
+	rules := []Rule{{ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitreAlcohol, Rate: 22.55,
+		Currency: "EUR", Match: Match{Origins: []string{"Belgium"}}}}
+
+	assessment, err := Calculate("IE", rules, beer, 6, 28.8, unitRate)
+
+	assert.NoError(t, err)
+	assert.Empty(t, assessment.Lines)
+}
+
+func TestCalculateRateError(t *testing.T) {
+	rules := []Rule{{ID: "ie-excise", Name: "Beer excise", Country: "IE", Kind: KindPerLitre, Rate: 1, Currency: "EUR"}}
+
+	_, err := Calculate("IE", rules, newTestBeer(), 6, 28.8, func(string) (float64, error) {
+		return 0, errors.New("rate unavailable")
+	})
+
+	assert.Error(t, err)
+}
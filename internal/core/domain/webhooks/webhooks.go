@@ -0,0 +1,268 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+)
+
+const (
+	// ErrCodeSubscriptionNotFound is the domain error code used when a subscription does not exist
+	ErrCodeSubscriptionNotFound = "WEBHOOK_SUBSCRIPTION_NOT_FOUND"
+	// ErrCodeDeliveryNotFound is the domain error code used when a delivery does not exist
+	ErrCodeDeliveryNotFound = "WEBHOOK_DELIVERY_NOT_FOUND"
+	// ErrCodeDeliveryNotDead is the domain error code used when replaying a delivery
+	// that has not been dead-lettered
+	ErrCodeDeliveryNotDead = "WEBHOOK_DELIVERY_NOT_DEAD"
+)
+
+// Headers of a webhook request. The signature is "t=<unix seconds>,v1=<hex>",
+// v1 being the HMAC-SHA256 of "<t>.<body>" keyed with the subscription secret.
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	EventIDHeader   = "X-Webhook-Event-Id"
+	EventTypeHeader = "X-Webhook-Event-Type"
+	DeliveryHeader  = "X-Webhook-Delivery"
+)
+
+// secretPrefix marks webhook signing secrets
+const secretPrefix = "whsec_"
+
+// Subscription asks for catalogue events to be posted to a URL. An empty list
+// of event types subscribes to every type.
+type Subscription struct {
+	ID         string        `json:"id"`
+	URL        string        `json:"url"`
+	EventTypes []events.Type `json:"event_types"`
+	Secret     string        `json:"-"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+// NewSubscription creates a subscription with a new signing secret. URLs naming
+// a loopback, private or link-local address are rejected, as deliveries would
+// post signed payloads to services inside the network; host names are checked
+// once resolved, when delivering.
+func NewSubscription(rawURL string, eventTypes []events.Type) (*Subscription, error) {
+	target, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+		return nil, beers.NewValidationError("url", "must be an absolute http or https URL")
+	}
+
+	if !isPublicHost(target.Hostname()) {
+		return nil, beers.NewValidationError("url", "must not point at a loopback, private or link-local address")
+	}
+
+	for _, eventType := range eventTypes {
+		if !IsKnownEventType(eventType) {
+			return nil, beers.NewValidationError("event_types", fmt.Sprintf("unknown event type %q", eventType))
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	if eventTypes == nil {
+		eventTypes = []events.Type{}
+	}
+
+	return &Subscription{
+		ID:         newID("whk"),
+		URL:        target.String(),
+		EventTypes: eventTypes,
+		Secret:     secretPrefix + hex.EncodeToString(secret),
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// IsPublicAddress reports whether an IP address may receive deliveries: it is
+// none of loopback, private, link-local, multicast or unspecified
+func IsPublicAddress(ip net.IP) bool {
+	return !(ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified())
+}
+
+// isPublicHost reports whether a URL host may receive deliveries, as far as can
+// be told without resolving it
+func isPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicAddress(ip)
+	}
+	return true
+}
+
+// IsKnownEventType reports whether events of a type are published
+func IsKnownEventType(eventType events.Type) bool {
+	switch eventType {
+	case events.TypeBeerCreated, events.TypeBeerUpdated, events.TypePriceChanged:
+		return true
+	default:
+		return false
+	}
+}
+
+// Accepts reports whether the subscription wants events of a type
+func (s *Subscription) Accepts(eventType events.Type) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+
+	for _, accepted := range s.EventTypes {
+		if accepted == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign returns the signature header value of a request body sent at timestamp
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// DeliveryStatus is the state of a delivery
+type DeliveryStatus string
+
+const (
+	// StatusPending deliveries are waiting for their next attempt
+	StatusPending DeliveryStatus = "pending"
+	// StatusDelivered deliveries were acknowledged with a 2xx response
+	StatusDelivered DeliveryStatus = "delivered"
+	// StatusDead deliveries used up their attempts and wait in the dead-letter list
+	StatusDead DeliveryStatus = "dead"
+)
+
+// ParseDeliveryStatus validates a delivery status
+func ParseDeliveryStatus(value string) (DeliveryStatus, error) {
+	switch status := DeliveryStatus(value); status {
+	case StatusPending, StatusDelivered, StatusDead:
+		return status, nil
+	default:
+		return "", beers.NewValidationError("status", "must be pending, delivered or dead")
+	}
+}
+
+// RetryPolicy spaces out the attempts of a delivery exponentially: the nth
+// failed attempt is retried after InitialBackoff * 2^(n-1), at most MaxBackoff
+type RetryPolicy struct {
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+}
+
+// Backoff returns how long to wait after a number of failed attempts
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	backoff := p.InitialBackoff
+	for i := 1; i < attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return backoff
+}
+
+// Delivery is one event on its way to one subscription
+type Delivery struct {
+	ID             string         `json:"id"`
+	SubscriptionID string         `json:"subscription_id"`
+	Event          events.Event   `json:"event"`
+	Status         DeliveryStatus `json:"status"`
+	Attempts       int            `json:"attempts"`
+	NextAttemptAt  time.Time      `json:"next_attempt_at"`
+	LastError      string         `json:"last_error,omitempty"`
+	LastStatusCode int            `json:"last_status_code,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// NewDelivery creates a delivery of an event, due at once
+func NewDelivery(subscription *Subscription, event events.Event, now time.Time) *Delivery {
+	return &Delivery{
+		ID:             newID("dlv"),
+		SubscriptionID: subscription.ID,
+		Event:          event,
+		Status:         StatusPending,
+		NextAttemptAt:  now,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// Succeed records an acknowledged attempt
+func (d *Delivery) Succeed(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = StatusDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.UpdatedAt = now
+}
+
+// Fail records a failed attempt, scheduling a retry or dead-lettering the
+// delivery once it has used up its attempts
+func (d *Delivery) Fail(reason string, statusCode int, policy RetryPolicy, now time.Time) {
+	d.Attempts++
+	d.LastError = reason
+	d.LastStatusCode = statusCode
+	d.UpdatedAt = now
+
+	if d.Attempts >= policy.MaxAttempts {
+		d.Status = StatusDead
+		return
+	}
+	d.NextAttemptAt = now.Add(policy.Backoff(d.Attempts))
+}
+
+// Abandon dead-letters a delivery that can no longer be attempted, such as one
+// whose subscription was deleted
+func (d *Delivery) Abandon(reason string, now time.Time) {
+	d.Status = StatusDead
+	d.LastError = reason
+	d.UpdatedAt = now
+}
+
+// Replay moves a dead-lettered delivery back to the queue with a fresh set of attempts
+func (d *Delivery) Replay(now time.Time) error {
+	if d.Status != StatusDead {
+		return beers.NewDomainError(ErrCodeDeliveryNotDead, "Only dead-lettered deliveries can be replayed", nil)
+	}
+
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.UpdatedAt = now
+
+	return nil
+}
+
+// newID generates a random identifier with the given prefix
+func newID(prefix string) string {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%s_%d", prefix, time.Now().UnixNano())
+	}
+	return prefix + "_" + hex.EncodeToString(buf)
+}
@@ -0,0 +1,122 @@
+package webhooks
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+)
+
+func TestNewSubscription(t *testing.T) {
+	subscription, err := NewSubscription(" https://partner.example.com/hooks ", []events.Type{events.TypePriceChanged})
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(subscription.ID, "whk_"))
+	assert.Equal(t, "https://partner.example.com/hooks", subscription.URL)
+	assert.True(t, strings.HasPrefix(subscription.Secret, "whsec_"))
+	assert.True(t, subscription.Accepts(events.TypePriceChanged))
+	assert.False(t, subscription.Accepts(events.TypeBeerCreated))
+
+	all, err := NewSubscription("http://hooks.example.com:9000", nil)
+	require.NoError(t, err)
+	assert.True(t, all.Accepts(events.TypeBeerCreated))
+	assert.NotEqual(t, subscription.Secret, all.Secret)
+
+	var validationErr *beers.ValidationError
+	for _, rawURL := range []string{"", "partner.example.com", "ftp://partner.example.com", "https://"} {
+		_, err := NewSubscription(rawURL, nil)
+		assert.True(t, errors.As(err, &validationErr), rawURL)
+		assert.Equal(t, "url", validationErr.Field)
+	}
+
+	for _, rawURL := range []string{
+		"http://localhost:9000",
+		"http://api.localhost/hooks",
+		"http://127.0.0.1/hooks",
+		"http://169.254.169.254/latest/meta-data",
+		"https://10.0.0.5/hooks",
+		"https://192.168.1.10/hooks",
+		"http://[::1]:8080/hooks",
+		"http://[fe80::1]/hooks",
+		"http://0.0.0.0/hooks",
+	} {
+		_, err := NewSubscription(rawURL, nil)
+		assert.True(t, errors.As(err, &validationErr), rawURL)
+		assert.Equal(t, "url", validationErr.Field)
+	}
+
+	_, err = NewSubscription("https://203.0.113.10/hooks", nil)
+	assert.NoError(t, err)
+
+	_, err = NewSubscription("https://partner.example.com", []events.Type{"beer.deleted"})
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "event_types", validationErr.Field)
+}
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1760000000, 0)
+
+	// Computed independently: printf '1760000000.{"id":"evt_1"}' | openssl dgst -sha256 -hmac whsec_test
+	assert.Equal(t, "t=1760000000,v1=66e880d7175fffb43ce10c4e14db1cfb230c8804b5aafb116affbc9a836c7690",
+		Sign("whsec_test", timestamp, []byte(`{"id":"evt_1"}`)))
+	assert.NotEqual(t, Sign("whsec_test", timestamp, []byte(`{}`)), Sign("whsec_other", timestamp, []byte(`{}`)))
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 8, InitialBackoff: 10 * time.Second, MaxBackoff: time.Minute}
+
+	assert.Equal(t, 10*time.Second, policy.Backoff(1))
+	assert.Equal(t, 20*time.Second, policy.Backoff(2))
+	assert.Equal(t, 40*time.Second, policy.Backoff(3))
+	assert.Equal(t, time.Minute, policy.Backoff(4))
+	assert.Equal(t, time.Minute, policy.Backoff(50))
+}
+
+func TestDeliveryLifecycle(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Second, MaxBackoff: time.Minute}
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	subscription := &Subscription{ID: "whk_1"}
+
+	delivery := NewDelivery(subscription, events.Event{ID: "evt_1", Type: events.TypeBeerCreated}, now)
+	assert.Equal(t, StatusPending, delivery.Status)
+	assert.Equal(t, now, delivery.NextAttemptAt)
+	assert.Error(t, delivery.Replay(now), "pending deliveries cannot be replayed")
+
+	delivery.Fail("connection refused", 0, policy, now)
+	assert.Equal(t, StatusPending, delivery.Status)
+	assert.Equal(t, now.Add(time.Second), delivery.NextAttemptAt)
+
+	delivery.Fail("unexpected status 500", 500, policy, now.Add(time.Second))
+	assert.Equal(t, StatusDead, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, 500, delivery.LastStatusCode)
+
+	later := now.Add(time.Hour)
+	require.NoError(t, delivery.Replay(later))
+	assert.Equal(t, StatusPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	assert.Equal(t, later, delivery.NextAttemptAt)
+
+	delivery.Succeed(204, later)
+	assert.Equal(t, StatusDelivered, delivery.Status)
+	assert.Empty(t, delivery.LastError)
+
+	abandoned := NewDelivery(subscription, events.Event{ID: "evt_2"}, now)
+	abandoned.Abandon("subscription deleted", now)
+	assert.Equal(t, StatusDead, abandoned.Status)
+	assert.Equal(t, 0, abandoned.Attempts)
+}
+
+func TestParseDeliveryStatus(t *testing.T) {
+	status, err := ParseDeliveryStatus("dead")
+	assert.NoError(t, err)
+	assert.Equal(t, StatusDead, status)
+
+	_, err = ParseDeliveryStatus("failed")
+	assert.Error(t, err)
+}
@@ -0,0 +1,34 @@
+package primary
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/auth"
+)
+
+// AuthService defines the primary port for authenticating callers and managing API keys
+type AuthService interface {
+	Authenticate(ctx context.Context, credentials Credentials) (*auth.Principal, error)
+	CreateAPIKey(ctx context.Context, req CreateAPIKeyRequest) (*CreatedAPIKey, error)
+	RevokeAPIKey(ctx context.Context, id string) (*auth.APIKey, error)
+	ListAPIKeys(ctx context.Context) ([]auth.APIKey, error)
+}
+
+// Credentials holds what a caller presented to authenticate.
+// APIKey takes precedence over BearerToken when both are set.
+type Credentials struct {
+	APIKey      string
+	BearerToken string
+}
+
+// CreateAPIKeyRequest represents the request to create an API key
+type CreateAPIKeyRequest struct {
+	Name string `json:"name" validate:"required,min=1,max=100"`
+	Role string `json:"role" validate:"required,oneof=reader editor admin"`
+}
+
+// CreatedAPIKey holds a new API key and its token, which is never shown again
+type CreatedAPIKey struct {
+	Key   *auth.APIKey `json:"key"`
+	Token string       `json:"token"`
+}
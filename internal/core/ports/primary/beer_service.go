@@ -4,12 +4,16 @@ import (
 	"context"
 
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/tax"
 )
 
 // BeerService defines the primary port for beer operations
 // This represents the use cases from the outside perspective
 type BeerService interface {
 	CreateBeer(ctx context.Context, req CreateBeerRequest) error
+	UpdateBeer(ctx context.Context, id int, req UpdateBeerRequest) (*beers.Beer, error)
 	FindBeerByID(ctx context.Context, id int) (*beers.Beer, error)
 	FindAllBeers(ctx context.Context) ([]beers.Beer, error)
 	CalculateBoxPrice(ctx context.Context, req CalculateBoxPriceRequest) (*BoxPriceResponse, error)
@@ -23,13 +27,28 @@ type CreateBeerRequest struct {
 	Country  string  `json:"country" validate:"required,min=1,max=100"`
 	Price    float64 `json:"price" validate:"required,min=0"`
 	Currency string  `json:"currency" validate:"required,len=3"`
+	ABV      float64 `json:"abv,omitempty" validate:"min=0,max=100"`
+	VolumeML int     `json:"volume_ml,omitempty" validate:"min=0"`
+}
+
+// UpdateBeerRequest represents the request to replace the details of a beer
+type UpdateBeerRequest struct {
+	Name     string  `json:"name" validate:"required,min=1,max=100"`
+	Brewery  string  `json:"brewery" validate:"required,min=1,max=100"`
+	Country  string  `json:"country" validate:"required,min=1,max=100"`
+	Price    float64 `json:"price" validate:"required,min=0"`
+	Currency string  `json:"currency" validate:"required,len=3"`
+	ABV      float64 `json:"abv,omitempty" validate:"min=0,max=100"`
+	VolumeML int     `json:"volume_ml,omitempty" validate:"min=0"`
 }
 
 // CalculateBoxPriceRequest represents the request to calculate box price
 type CalculateBoxPriceRequest struct {
-	BeerID   int    `json:"beer_id" validate:"required,min=1"`
-	Quantity int    `json:"quantity" validate:"required,min=1,max=1000"`
-	Currency string `json:"currency" validate:"required,len=3"`
+	BeerID      int    `json:"beer_id" validate:"required,min=1"`
+	Quantity    int    `json:"quantity" validate:"required,min=1,max=1000"`
+	Currency    string `json:"currency" validate:"required,len=3"`
+	Destination string `json:"destination,omitempty" validate:"omitempty,len=2"`
+	Coupon      string `json:"coupon,omitempty"`
 }
 
 // BoxPriceResponse represents the response for box price calculation
@@ -41,4 +60,20 @@ type BoxPriceResponse struct {
 	TotalPrice   float64 `json:"total_price"`
 	Currency     string  `json:"currency"`
 	ExchangeRate float64 `json:"exchange_rate,omitempty"`
+
+	// Pack and volume discount pricing, converted into the target currency
+	Subtotal        float64        `json:"subtotal,omitempty"`
+	DiscountPercent float64        `json:"discount_percent,omitempty"`
+	Discount        float64        `json:"discount,omitempty"`
+	Breakdown       []pricing.Line `json:"breakdown,omitempty"`
+
+	// Promotions considered for the box, applied or not, and their combined discount
+	PromotionDiscount float64              `json:"promotion_discount,omitempty"`
+	Promotions        []promotions.Outcome `json:"promotions,omitempty"`
+
+	// Itemised taxes of the destination country, converted into the target currency
+	Destination  string     `json:"destination,omitempty"`
+	Taxes        []tax.Line `json:"taxes,omitempty"`
+	TotalTax     float64    `json:"total_tax,omitempty"`
+	TotalWithTax float64    `json:"total_with_tax,omitempty"`
 }
@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/events"
+)
+
+// EventService defines the primary port for following catalogue events
+type EventService interface {
+	// Subscribe streams the events that match filter until ctx is done. With the
+	// ID of the last event a client saw, the events it missed are sent first.
+	// The channel is closed when the subscription ends, including when the
+	// subscriber falls too far behind; it can then resubscribe from its last event.
+	Subscribe(ctx context.Context, filter events.Filter, lastEventID string) (<-chan events.Event, error)
+}
@@ -0,0 +1,40 @@
+package primary
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/orders"
+)
+
+// OrderService defines the primary port for cart and order operations
+type OrderService interface {
+	CreateCart(ctx context.Context, req CreateCartRequest) (*orders.Cart, error)
+	GetCart(ctx context.Context, id string) (*orders.Cart, error)
+	AddCartItem(ctx context.Context, cartID string, req CartItemRequest) (*orders.Cart, error)
+	RemoveCartItem(ctx context.Context, cartID string, beerID int) (*orders.Cart, error)
+	Checkout(ctx context.Context, cartID string, req CheckoutRequest) (*orders.Order, error)
+	GetOrder(ctx context.Context, id string) (*orders.Order, error)
+	ListOrders(ctx context.Context) ([]orders.Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, req UpdateOrderStatusRequest) (*orders.Order, error)
+}
+
+// CreateCartRequest represents the request to create a cart
+type CreateCartRequest struct {
+	Currency string `json:"currency" validate:"required,len=3"`
+}
+
+// CartItemRequest represents the request to add a beer to a cart
+type CartItemRequest struct {
+	BeerID   int `json:"beer_id" validate:"required,min=1"`
+	Quantity int `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+// CheckoutRequest represents the request to check out a cart, optionally with a coupon
+type CheckoutRequest struct {
+	Coupon string `json:"coupon,omitempty"`
+}
+
+// UpdateOrderStatusRequest represents the request to move an order to a new status
+type UpdateOrderStatusRequest struct {
+	Status orders.Status `json:"status" validate:"required"`
+}
@@ -0,0 +1,20 @@
+package primary
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/pricing"
+)
+
+// PricingService defines the primary port for managing pack and tier pricing rules
+type PricingService interface {
+	SetPricingRule(ctx context.Context, beerID int, req PricingRuleRequest) (*pricing.Rule, error)
+	GetPricingRule(ctx context.Context, beerID int) (*pricing.Rule, error)
+	DeletePricingRule(ctx context.Context, beerID int) error
+}
+
+// PricingRuleRequest represents the request to set the pricing rule of a beer
+type PricingRuleRequest struct {
+	Packs []pricing.PackSKU      `json:"packs" validate:"dive"`
+	Tiers []pricing.DiscountTier `json:"tiers" validate:"dive"`
+}
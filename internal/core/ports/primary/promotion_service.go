@@ -0,0 +1,32 @@
+package primary
+
+import (
+	"context"
+	"time"
+
+	"beers-challenge/internal/core/domain/promotions"
+)
+
+// PromotionService defines the primary port for managing promotions and coupon codes
+type PromotionService interface {
+	CreatePromotion(ctx context.Context, req PromotionRequest) (*promotions.Promotion, error)
+	GetPromotion(ctx context.Context, id string) (*promotions.Promotion, error)
+	ListPromotions(ctx context.Context) ([]promotions.Promotion, error)
+	UpdatePromotion(ctx context.Context, id string, req PromotionRequest) (*promotions.Promotion, error)
+	DeletePromotion(ctx context.Context, id string) error
+}
+
+// PromotionRequest represents the request to create or replace a promotion.
+// Zero start or end times leave the promotion open-ended on that side.
+type PromotionRequest struct {
+	Name        string           `json:"name" validate:"required,min=1,max=100"`
+	Description string           `json:"description,omitempty"`
+	Type        promotions.Type  `json:"type" validate:"required,oneof=percentage fixed"`
+	Value       float64          `json:"value" validate:"required,gt=0"`
+	Currency    string           `json:"currency,omitempty" validate:"omitempty,len=3"`
+	Scope       promotions.Scope `json:"scope"`
+	StartsAt    time.Time        `json:"starts_at"`
+	EndsAt      time.Time        `json:"ends_at"`
+	CouponCode  string           `json:"coupon_code,omitempty"`
+	UsageLimit  int              `json:"usage_limit,omitempty" validate:"min=0"`
+}
@@ -0,0 +1,49 @@
+package primary
+
+import (
+	"context"
+)
+
+// QuoteService defines the primary port for pricing mixed boxes of beers
+type QuoteService interface {
+	CreateQuote(ctx context.Context, req QuoteRequest) (*QuoteResponse, error)
+}
+
+// QuoteRequest represents the request to price several beers in one currency
+type QuoteRequest struct {
+	Currency string             `json:"currency" validate:"required,len=3"`
+	Items    []QuoteItemRequest `json:"items" validate:"required,min=1,max=100"`
+}
+
+// QuoteItemRequest represents a single line of a quote request
+type QuoteItemRequest struct {
+	BeerID   int `json:"beer_id" validate:"required,min=1"`
+	Quantity int `json:"quantity" validate:"required,min=1,max=1000"`
+}
+
+// QuoteResponse represents a priced quote with per-line and grand totals
+type QuoteResponse struct {
+	Currency    string      `json:"currency"`
+	Lines       []QuoteLine `json:"lines"`
+	GrandTotal  float64     `json:"grand_total"`
+	PricedLines int         `json:"priced_lines"`
+	FailedLines int         `json:"failed_lines"`
+}
+
+// QuoteLine represents the outcome of pricing one line of a quote
+type QuoteLine struct {
+	BeerID         int             `json:"beer_id"`
+	BeerName       string          `json:"beer_name,omitempty"`
+	Quantity       int             `json:"quantity"`
+	SourceCurrency string          `json:"source_currency,omitempty"`
+	UnitPrice      float64         `json:"unit_price,omitempty"`
+	LineTotal      float64         `json:"line_total,omitempty"`
+	ExchangeRate   float64         `json:"exchange_rate,omitempty"`
+	Error          *QuoteLineError `json:"error,omitempty"`
+}
+
+// QuoteLineError explains why a quote line could not be priced
+type QuoteLineError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
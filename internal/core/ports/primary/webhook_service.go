@@ -0,0 +1,34 @@
+package primary
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+)
+
+// WebhookService defines the primary port for managing webhook subscriptions
+// and inspecting or replaying their deliveries
+type WebhookService interface {
+	CreateSubscription(ctx context.Context, req CreateWebhookRequest) (*CreatedWebhookSubscription, error)
+	GetSubscription(ctx context.Context, id string) (*webhooks.Subscription, error)
+	ListSubscriptions(ctx context.Context) ([]webhooks.Subscription, error)
+	DeleteSubscription(ctx context.Context, id string) error
+	// ListDeliveries returns the deliveries in a status, newest first; an empty status lists every delivery
+	ListDeliveries(ctx context.Context, status string) ([]webhooks.Delivery, error)
+	// ReplayDelivery queues a dead-lettered delivery again
+	ReplayDelivery(ctx context.Context, id string) (*webhooks.Delivery, error)
+}
+
+// CreateWebhookRequest represents the request to subscribe a URL to catalogue
+// events. No event types subscribes to every type.
+type CreateWebhookRequest struct {
+	URL        string        `json:"url" validate:"required,url"`
+	EventTypes []events.Type `json:"event_types,omitempty"`
+}
+
+// CreatedWebhookSubscription holds a new subscription and its signing secret, which is never shown again
+type CreatedWebhookSubscription struct {
+	Subscription *webhooks.Subscription `json:"subscription"`
+	Secret       string                 `json:"secret"`
+}
@@ -2,8 +2,18 @@ package secondary
 
 import (
 	"context"
+	"time"
 
+	"beers-challenge/internal/core/domain/auth"
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/domain/webhooks"
 )
 
 // BeerRepository defines the secondary port for beer persistence
@@ -15,6 +25,143 @@ type BeerRepository interface {
 	ExistsByID(ctx context.Context, id int) (bool, error)
 }
 
+// EventPublisher defines the secondary port for publishing catalogue events
+// once the change they describe has been saved
+type EventPublisher interface {
+	Publish(ctx context.Context, published ...events.Event)
+}
+
+// EventOutbox defines the secondary port for the transactional outbox: a beer
+// and the events about its change are saved atomically, and the events are
+// relayed to webhooks later, so a crash between the two cannot lose either
+type EventOutbox interface {
+	// SaveBeer saves a beer and records its events in the same transaction
+	SaveBeer(ctx context.Context, beer *beers.Beer, recorded []events.Event) error
+	// Relay hands up to limit undispatched events, oldest first and each with a
+	// durable ID, to handle, and marks them dispatched once handle succeeds.
+	// It returns how many events were dispatched.
+	Relay(ctx context.Context, limit int, handle func(ctx context.Context, pending []events.Event) error) (int, error)
+}
+
+// WebhookSubscriptionRepository defines the secondary port for webhook subscription persistence
+type WebhookSubscriptionRepository interface {
+	Save(ctx context.Context, subscription *webhooks.Subscription) error
+	FindByID(ctx context.Context, id string) (*webhooks.Subscription, error)
+	FindAll(ctx context.Context) ([]webhooks.Subscription, error)
+	Delete(ctx context.Context, id string) error
+}
+
+// WebhookDeliveryRepository defines the secondary port for webhook delivery persistence
+type WebhookDeliveryRepository interface {
+	Save(ctx context.Context, delivery *webhooks.Delivery) error
+	FindByID(ctx context.Context, id string) (*webhooks.Delivery, error)
+	// FindByStatus returns the deliveries in a status, newest first; an empty
+	// status returns every delivery
+	FindByStatus(ctx context.Context, status webhooks.DeliveryStatus) ([]webhooks.Delivery, error)
+	// ClaimDue atomically leases up to limit pending deliveries due at now by
+	// pushing their next attempt lease into the future, so concurrent
+	// dispatchers never attempt the same delivery at once
+	ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]webhooks.Delivery, error)
+}
+
+// WebhookSender defines the secondary port for posting webhook requests. It
+// returns the response status code, or an error when no response was received.
+type WebhookSender interface {
+	Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error)
+}
+
+// PricingRuleRepository defines the secondary port for pack and tier pricing rules
+type PricingRuleRepository interface {
+	Save(ctx context.Context, rule *pricing.Rule) error
+	FindByBeerID(ctx context.Context, beerID int) (*pricing.Rule, error)
+	Delete(ctx context.Context, beerID int) error
+}
+
+// TaxRuleRepository defines the secondary port for destination tax rules
+type TaxRuleRepository interface {
+	FindByCountry(ctx context.Context, country string) ([]tax.Rule, error)
+	FindAll(ctx context.Context) ([]tax.Rule, error)
+}
+
+// PromotionRepository defines the secondary port for promotion persistence
+type PromotionRepository interface {
+	Save(ctx context.Context, promotion *promotions.Promotion) error
+	FindByID(ctx context.Context, id string) (*promotions.Promotion, error)
+	FindByCouponCode(ctx context.Context, code string) (*promotions.Promotion, error)
+	FindAll(ctx context.Context) ([]promotions.Promotion, error)
+	Delete(ctx context.Context, id string) error
+	// Redeem atomically counts one use of a promotion, failing once its usage limit is reached
+	Redeem(ctx context.Context, id string) error
+	// Release gives back one use counted by Redeem, when the order it was
+	// redeemed for could not be saved or was cancelled
+	Release(ctx context.Context, id string) error
+}
+
+// CartRepository defines the secondary port for cart persistence
+type CartRepository interface {
+	// Save stores a new cart, or an open stored cart at the version it was read
+	// at, and advances the version of cart. A cart written by someone else since
+	// gets a CART_CONFLICT domain error, one checked out a CART_CHECKED_OUT.
+	Save(ctx context.Context, cart *orders.Cart) error
+	FindByID(ctx context.Context, id string) (*orders.Cart, error)
+	// MarkCheckedOut links cart id to orderID only if it is not checked out yet
+	// and still at version, as one atomic step, so of concurrent checkouts of a
+	// cart only one places an order, and only for the items it priced. The
+	// others get a CART_CHECKED_OUT or CART_CONFLICT domain error.
+	MarkCheckedOut(ctx context.Context, id string, version int, orderID string) error
+	// ClearCheckout unlinks cart id from orderID, undoing MarkCheckedOut when the
+	// order could not be saved
+	ClearCheckout(ctx context.Context, id, orderID string) error
+}
+
+// OrderRepository defines the secondary port for order persistence
+type OrderRepository interface {
+	Save(ctx context.Context, order *orders.Order) error
+	// UpdateStatus saves the status of an order only if it is still previous, as
+	// one atomic step, so of concurrent transitions of an order only one wins.
+	// The others get an ORDER_STATUS_CONFLICT domain error.
+	UpdateStatus(ctx context.Context, order *orders.Order, previous orders.Status) error
+	FindByID(ctx context.Context, id string) (*orders.Order, error)
+	FindAll(ctx context.Context) ([]orders.Order, error)
+}
+
+// APIKeyRepository defines the secondary port for API key persistence.
+// Keys are never deleted; revocation is saved on the key itself.
+type APIKeyRepository interface {
+	Save(ctx context.Context, key *auth.APIKey) error
+	FindByID(ctx context.Context, id string) (*auth.APIKey, error)
+	FindAll(ctx context.Context) ([]auth.APIKey, error)
+}
+
+// TokenVerifier defines the secondary port for verifying bearer tokens such as JWTs
+type TokenVerifier interface {
+	Verify(ctx context.Context, token string) (*auth.Principal, error)
+}
+
+// IdempotencyStore defines the secondary port for idempotency key records
+type IdempotencyStore interface {
+	// Reserve atomically stores a pending record unless an unexpired record with
+	// the same key exists, in which case that record is returned instead
+	Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error)
+	// Complete stores the response of a reserved record
+	Complete(ctx context.Context, record *idempotency.Record) error
+	// Release forgets a reserved record so the request can be retried
+	Release(ctx context.Context, key string) error
+}
+
+// RateLimitStore defines the secondary port for token bucket state. A shared
+// implementation lets every replica enforce the same limits.
+type RateLimitStore interface {
+	// Take atomically takes one request from the bucket of key
+	Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error)
+}
+
+// HealthChecker defines the secondary port of adapters that can report their
+// health. Details are optional diagnostics such as pool or cache sizes.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) (map[string]interface{}, error)
+}
+
 // CurrencyService defines the secondary port for currency operations
 type CurrencyService interface {
 	GetExchangeRate(ctx context.Context, from, to string) (float64, error)
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// AuthServiceImpl implements the AuthService primary port
+type AuthServiceImpl struct {
+	apiKeys       secondary.APIKeyRepository
+	tokenVerifier secondary.TokenVerifier
+	logger        secondary.Logger
+}
+
+// AuthServiceOption configures optional collaborators of the auth service
+type AuthServiceOption func(*AuthServiceImpl)
+
+// WithTokenVerifier enables bearer token (JWT) authentication
+func WithTokenVerifier(tokenVerifier secondary.TokenVerifier) AuthServiceOption {
+	return func(s *AuthServiceImpl) {
+		s.tokenVerifier = tokenVerifier
+	}
+}
+
+// NewAuthService creates a new auth service
+func NewAuthService(
+	apiKeys secondary.APIKeyRepository,
+	logger secondary.Logger,
+	opts ...AuthServiceOption,
+) primary.AuthService {
+	service := &AuthServiceImpl{
+		apiKeys: apiKeys,
+		logger:  logger,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// Authenticate resolves the principal behind an API key or bearer token.
+// Every failure is reported as UNAUTHENTICATED so callers cannot probe which keys exist.
+func (s *AuthServiceImpl) Authenticate(ctx context.Context, credentials primary.Credentials) (*auth.Principal, error) {
+	if credentials.APIKey != "" {
+		return s.authenticateAPIKey(ctx, credentials.APIKey)
+	}
+
+	if credentials.BearerToken == "" {
+		return nil, beers.NewDomainError(auth.ErrCodeUnauthenticated, "Missing credentials", nil)
+	}
+
+	if auth.IsAPIKeyToken(credentials.BearerToken) {
+		return s.authenticateAPIKey(ctx, credentials.BearerToken)
+	}
+
+	if s.tokenVerifier == nil {
+		return nil, beers.NewDomainError(auth.ErrCodeUnauthenticated, "Bearer tokens are not accepted", nil)
+	}
+
+	principal, err := s.tokenVerifier.Verify(ctx, credentials.BearerToken)
+	if err != nil {
+		s.logger.Warn(ctx, "Rejected bearer token", map[string]interface{}{
+			"reason": err.Error(),
+		})
+		return nil, beers.NewDomainError(auth.ErrCodeUnauthenticated, "Invalid bearer token", err)
+	}
+
+	return principal, nil
+}
+
+// authenticateAPIKey looks the key up by the ID embedded in the token and checks its hash
+func (s *AuthServiceImpl) authenticateAPIKey(ctx context.Context, token string) (*auth.Principal, error) {
+	invalid := beers.NewDomainError(auth.ErrCodeUnauthenticated, "Invalid API key", nil)
+
+	id, ok := auth.KeyIDFromToken(token)
+	if !ok {
+		return nil, invalid
+	}
+
+	key, err := s.apiKeys.FindByID(ctx, id)
+	if err != nil {
+		if !isDomainError(err, auth.ErrCodeAPIKeyNotFound) {
+			s.logger.Error(ctx, "Failed to look up API key", err, map[string]interface{}{
+				"key_id": id,
+			})
+			return nil, fmt.Errorf("failed to look up API key: %w", err)
+		}
+		return nil, invalid
+	}
+
+	if !key.Verify(token) || key.IsRevoked() {
+		return nil, invalid
+	}
+
+	return key.Principal(), nil
+}
+
+// CreateAPIKey creates an API key and returns its token, which is not stored
+func (s *AuthServiceImpl) CreateAPIKey(ctx context.Context, req primary.CreateAPIKeyRequest) (*primary.CreatedAPIKey, error) {
+	role, err := auth.ParseRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	key, token, err := auth.NewAPIKey(req.Name, role)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apiKeys.Save(ctx, key); err != nil {
+		s.logger.Error(ctx, "Failed to save API key", err, map[string]interface{}{
+			"key_id": key.ID,
+		})
+		return nil, fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	s.logger.Info(ctx, "API key created", map[string]interface{}{
+		"key_id": key.ID,
+		"name":   key.Name,
+		"role":   key.Role,
+	})
+
+	return &primary.CreatedAPIKey{Key: key, Token: token}, nil
+}
+
+// RevokeAPIKey revokes an API key; revoked keys stop authenticating immediately
+func (s *AuthServiceImpl) RevokeAPIKey(ctx context.Context, id string) (*auth.APIKey, error) {
+	key, err := s.apiKeys.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	key.Revoke(time.Now())
+	if err := s.apiKeys.Save(ctx, key); err != nil {
+		s.logger.Error(ctx, "Failed to revoke API key", err, map[string]interface{}{
+			"key_id": id,
+		})
+		return nil, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	s.logger.Info(ctx, "API key revoked", map[string]interface{}{
+		"key_id": id,
+	})
+
+	return key, nil
+}
+
+// ListAPIKeys returns every API key, including revoked ones
+func (s *AuthServiceImpl) ListAPIKeys(ctx context.Context) ([]auth.APIKey, error) {
+	keys, err := s.apiKeys.FindAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list API keys", err, nil)
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Save(ctx context.Context, key *auth.APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindAll(ctx context.Context) ([]auth.APIKey, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]auth.APIKey), args.Error(1)
+}
+
+type MockTokenVerifier struct {
+	mock.Mock
+}
+
+func (m *MockTokenVerifier) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*auth.Principal), args.Error(1)
+}
+
+func assertUnauthenticated(t *testing.T, err error) {
+	t.Helper()
+	assert.True(t, isDomainError(err, auth.ErrCodeUnauthenticated), "expected UNAUTHENTICATED, got %v", err)
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*auth.APIKey")).Return(nil).Once()
+
+	created, err := service.CreateAPIKey(ctx, primary.CreateAPIKeyRequest{Name: "ci", Role: "editor"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, auth.RoleEditor, created.Key.Role)
+	assert.True(t, created.Key.Verify(created.Token))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreateAPIKeyInvalidRole(t *testing.T) {
+	service := NewAuthService(new(MockAPIKeyRepository), logger.NewNoOpLogger())
+
+	_, err := service.CreateAPIKey(context.Background(), primary.CreateAPIKeyRequest{Name: "ci", Role: "owner"})
+
+	var validationErr *beers.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	ctx := context.Background()
+	key, token, _ := auth.NewAPIKey("ci", auth.RoleEditor)
+
+	t.Run("header", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).Return(key, nil).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		principal, err := service.Authenticate(ctx, primary.Credentials{APIKey: token})
+
+		assert.NoError(t, err)
+		assert.Equal(t, key.ID, principal.Subject)
+		assert.Equal(t, auth.MethodAPIKey, principal.Method)
+	})
+
+	t.Run("bearer", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).Return(key, nil).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		principal, err := service.Authenticate(ctx, primary.Credentials{BearerToken: token})
+
+		assert.NoError(t, err)
+		assert.Equal(t, auth.RoleEditor, principal.Role)
+	})
+
+	t.Run("wrong secret", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).Return(key, nil).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		// Flip the last character so the secret always differs
+		last := "0"
+		if strings.HasSuffix(token, last) {
+			last = "1"
+		}
+		_, err := service.Authenticate(ctx, primary.Credentials{APIKey: token[:len(token)-1] + last})
+
+		assertUnauthenticated(t, err)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).
+			Return(nil, beers.NewDomainError(auth.ErrCodeAPIKeyNotFound, "not found", nil)).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		_, err := service.Authenticate(ctx, primary.Credentials{APIKey: token})
+
+		assertUnauthenticated(t, err)
+	})
+
+	t.Run("revoked", func(t *testing.T) {
+		revoked := *key
+		revoked.Revoke(key.CreatedAt)
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).Return(&revoked, nil).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		_, err := service.Authenticate(ctx, primary.Credentials{APIKey: token})
+
+		assertUnauthenticated(t, err)
+	})
+
+	t.Run("repository failure", func(t *testing.T) {
+		mockRepo := new(MockAPIKeyRepository)
+		mockRepo.On("FindByID", ctx, key.ID).Return(nil, errors.New("connection refused")).Once()
+		service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+
+		_, err := service.Authenticate(ctx, primary.Credentials{APIKey: token})
+
+		assert.Error(t, err)
+		assert.False(t, isDomainError(err, auth.ErrCodeUnauthenticated))
+	})
+}
+
+func TestAuthenticateBearerToken(t *testing.T) {
+	ctx := context.Background()
+	jwtPrincipal := &auth.Principal{Subject: "user-1", Role: auth.RoleReader, Method: auth.MethodJWT}
+
+	t.Run("missing credentials", func(t *testing.T) {
+		service := NewAuthService(new(MockAPIKeyRepository), logger.NewNoOpLogger())
+
+		_, err := service.Authenticate(ctx, primary.Credentials{})
+
+		assertUnauthenticated(t, err)
+	})
+
+	t.Run("jwt disabled", func(t *testing.T) {
+		service := NewAuthService(new(MockAPIKeyRepository), logger.NewNoOpLogger())
+
+		_, err := service.Authenticate(ctx, primary.Credentials{BearerToken: "a.b.c"})
+
+		assertUnauthenticated(t, err)
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", ctx, "a.b.c").Return(jwtPrincipal, nil).Once()
+		service := NewAuthService(new(MockAPIKeyRepository), logger.NewNoOpLogger(), WithTokenVerifier(verifier))
+
+		principal, err := service.Authenticate(ctx, primary.Credentials{BearerToken: "a.b.c"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, jwtPrincipal, principal)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		verifier := new(MockTokenVerifier)
+		verifier.On("Verify", ctx, "a.b.c").Return(nil, errors.New("token is expired")).Once()
+		service := NewAuthService(new(MockAPIKeyRepository), logger.NewNoOpLogger(), WithTokenVerifier(verifier))
+
+		_, err := service.Authenticate(ctx, primary.Credentials{BearerToken: "a.b.c"})
+
+		assertUnauthenticated(t, err)
+	})
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+	key, _, _ := auth.NewAPIKey("ci", auth.RoleReader)
+
+	mockRepo.On("FindByID", ctx, key.ID).Return(key, nil).Once()
+	mockRepo.On("Save", ctx, key).Return(nil).Once()
+
+	revoked, err := service.RevokeAPIKey(ctx, key.ID)
+
+	assert.NoError(t, err)
+	assert.True(t, revoked.IsRevoked())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRevokeAPIKeyNotFound(t *testing.T) {
+	mockRepo := new(MockAPIKeyRepository)
+	service := NewAuthService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+	notFound := beers.NewDomainError(auth.ErrCodeAPIKeyNotFound, "not found", nil)
+
+	mockRepo.On("FindByID", ctx, "key_missing").Return(nil, notFound).Once()
+
+	_, err := service.RevokeAPIKey(ctx, "key_missing")
+
+	assert.Equal(t, notFound, err)
+}
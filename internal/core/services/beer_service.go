@@ -2,9 +2,13 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/tax"
 	"beers-challenge/internal/core/ports/primary"
 	"beers-challenge/internal/core/ports/secondary"
 )
@@ -13,20 +17,71 @@ import (
 type BeerServiceImpl struct {
 	beerRepo        secondary.BeerRepository
 	currencyService secondary.CurrencyService
+	pricingRules    secondary.PricingRuleRepository
+	taxRules        secondary.TaxRuleRepository
+	promotions      secondary.PromotionRepository
+	events          secondary.EventPublisher
+	outbox          secondary.EventOutbox
 	logger          secondary.Logger
 }
 
+// BeerServiceOption configures optional collaborators of the beer service
+type BeerServiceOption func(*BeerServiceImpl)
+
+// WithPricingRules enables pack and volume discount pricing in box price calculations
+func WithPricingRules(pricingRules secondary.PricingRuleRepository) BeerServiceOption {
+	return func(s *BeerServiceImpl) {
+		s.pricingRules = pricingRules
+	}
+}
+
+// WithTaxRules enables destination taxes in box price calculations
+func WithTaxRules(taxRules secondary.TaxRuleRepository) BeerServiceOption {
+	return func(s *BeerServiceImpl) {
+		s.taxRules = taxRules
+	}
+}
+
+// WithPromotions enables promotions and coupon codes in box price calculations
+func WithPromotions(promotionRepo secondary.PromotionRepository) BeerServiceOption {
+	return func(s *BeerServiceImpl) {
+		s.promotions = promotionRepo
+	}
+}
+
+// WithEventPublisher publishes an event for every beer created or updated
+func WithEventPublisher(publisher secondary.EventPublisher) BeerServiceOption {
+	return func(s *BeerServiceImpl) {
+		s.events = publisher
+	}
+}
+
+// WithOutbox saves beers through a transactional outbox, recording the events
+// of every change with it for webhook delivery
+func WithOutbox(outbox secondary.EventOutbox) BeerServiceOption {
+	return func(s *BeerServiceImpl) {
+		s.outbox = outbox
+	}
+}
+
 // NewBeerService creates a new beer service
 func NewBeerService(
 	beerRepo secondary.BeerRepository,
 	currencyService secondary.CurrencyService,
 	logger secondary.Logger,
+	opts ...BeerServiceOption,
 ) primary.BeerService {
-	return &BeerServiceImpl{
+	service := &BeerServiceImpl{
 		beerRepo:        beerRepo,
 		currencyService: currencyService,
 		logger:          logger,
 	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
 }
 
 // CreateBeer creates a new beer
@@ -50,20 +105,15 @@ func (s *BeerServiceImpl) CreateBeer(ctx context.Context, req primary.CreateBeer
 	}
 
 	// Validate currency
-	isValid, err := s.currencyService.IsValidCurrency(ctx, req.Currency)
-	if err != nil {
-		s.logger.Error(ctx, "Failed to validate currency", err, map[string]interface{}{
-			"currency": req.Currency,
-		})
-		return fmt.Errorf("failed to validate currency: %w", err)
-	}
-
-	if !isValid {
-		return beers.NewDomainError("INVALID_CURRENCY", "Invalid currency code", nil)
+	if err := s.validateCurrency(ctx, req.Currency); err != nil {
+		return err
 	}
 
 	// Create domain entity
 	beer, err := beers.NewBeer(req.ID, req.Name, req.Brewery, req.Country, req.Price, req.Currency)
+	if err == nil {
+		err = beer.SetAttributes(req.ABV, req.VolumeML)
+	}
 	if err != nil {
 		s.logger.Error(ctx, "Failed to create beer entity", err, map[string]interface{}{
 			"beer_id": req.ID,
@@ -72,13 +122,16 @@ func (s *BeerServiceImpl) CreateBeer(ctx context.Context, req primary.CreateBeer
 	}
 
 	// Save beer
-	if err := s.beerRepo.Save(ctx, beer); err != nil {
+	created := events.BeerCreated(beer)
+	if err := s.save(ctx, beer, created); err != nil {
 		s.logger.Error(ctx, "Failed to save beer", err, map[string]interface{}{
 			"beer_id": req.ID,
 		})
 		return fmt.Errorf("failed to save beer: %w", err)
 	}
 
+	s.publish(ctx, created)
+
 	s.logger.Info(ctx, "Beer created successfully", map[string]interface{}{
 		"beer_id": req.ID,
 	})
@@ -86,6 +139,79 @@ func (s *BeerServiceImpl) CreateBeer(ctx context.Context, req primary.CreateBeer
 	return nil
 }
 
+// UpdateBeer replaces the details of a beer, keeping its creation time
+func (s *BeerServiceImpl) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (*beers.Beer, error) {
+	s.logger.Info(ctx, "Updating beer", map[string]interface{}{
+		"beer_id": id,
+	})
+
+	previous, err := s.FindBeerByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validateCurrency(ctx, req.Currency); err != nil {
+		return nil, err
+	}
+
+	beer, err := beers.NewBeer(id, req.Name, req.Brewery, req.Country, req.Price, req.Currency)
+	if err == nil {
+		err = beer.SetAttributes(req.ABV, req.VolumeML)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update beer: %w", err)
+	}
+	beer.CreatedAt = previous.CreatedAt
+
+	updated := events.BeerUpdated(previous, beer)
+	if err := s.save(ctx, beer, updated...); err != nil {
+		s.logger.Error(ctx, "Failed to save beer", err, map[string]interface{}{
+			"beer_id": id,
+		})
+		return nil, fmt.Errorf("failed to save beer: %w", err)
+	}
+
+	s.publish(ctx, updated...)
+
+	s.logger.Info(ctx, "Beer updated successfully", map[string]interface{}{
+		"beer_id": id,
+	})
+
+	return beer, nil
+}
+
+// validateCurrency checks that beers can be priced in a currency
+func (s *BeerServiceImpl) validateCurrency(ctx context.Context, code string) error {
+	isValid, err := s.currencyService.IsValidCurrency(ctx, code)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to validate currency", err, map[string]interface{}{
+			"currency": code,
+		})
+		return fmt.Errorf("failed to validate currency: %w", err)
+	}
+
+	if !isValid {
+		return beers.NewDomainError("INVALID_CURRENCY", "Invalid currency code", nil)
+	}
+
+	return nil
+}
+
+// save saves a beer, together with the events of its change when an outbox is configured
+func (s *BeerServiceImpl) save(ctx context.Context, beer *beers.Beer, recorded ...events.Event) error {
+	if s.outbox != nil {
+		return s.outbox.SaveBeer(ctx, beer, recorded)
+	}
+	return s.beerRepo.Save(ctx, beer)
+}
+
+// publish hands events about saved changes to the event publisher, if any
+func (s *BeerServiceImpl) publish(ctx context.Context, published ...events.Event) {
+	if s.events != nil {
+		s.events.Publish(ctx, published...)
+	}
+}
+
 // FindBeerByID finds a beer by its ID
 func (s *BeerServiceImpl) FindBeerByID(ctx context.Context, id int) (*beers.Beer, error) {
 	s.logger.Debug(ctx, "Finding beer by ID", map[string]interface{}{
@@ -132,55 +258,115 @@ func (s *BeerServiceImpl) CalculateBoxPrice(ctx context.Context, req primary.Cal
 		"currency": req.Currency,
 	})
 
+	if req.Quantity < 1 {
+		return nil, beers.NewValidationError("quantity", beers.ErrMustBeGreaterThanZero)
+	}
+
+	destination := strings.ToUpper(strings.TrimSpace(req.Destination))
+	if req.Destination != "" && len(destination) != 2 {
+		return nil, beers.NewValidationError("destination", "must be exactly 2 characters (ISO 3166-1 alpha-2)")
+	}
+
 	// Find the beer
 	beer, err := s.FindBeerByID(ctx, req.BeerID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Get exchange rate
-	exchangeRate := 1.0
-	if beer.Currency != req.Currency {
-		rate, err := s.currencyService.GetExchangeRate(ctx, beer.Currency, req.Currency)
-		if err != nil {
-			s.logger.Error(ctx, "Failed to get exchange rate", err, map[string]interface{}{
-				"from": beer.Currency,
-				"to":   req.Currency,
-			})
-			return nil, fmt.Errorf("failed to get exchange rate: %w", err)
-		}
-		exchangeRate = rate
-	}
-
-	// Calculate total price
-	totalPrice, err := beer.CalculateBoxPrice(req.Quantity, exchangeRate)
+	// The beer, its promotions and its taxes are converted with one rate table,
+	// so each source currency is looked up once per box price
+	rates := newExchangeRates(s.currencyService, req.Currency)
+	exchangeRate, err := rates.Rate(ctx, beer.Currency)
 	if err != nil {
-		s.logger.Error(ctx, "Failed to calculate box price", err, map[string]interface{}{
-			"beer_id":       req.BeerID,
-			"quantity":      req.Quantity,
-			"exchange_rate": exchangeRate,
+		s.logger.Error(ctx, "Failed to get exchange rate", err, map[string]interface{}{
+			"from": beer.Currency,
+			"to":   req.Currency,
 		})
-		return nil, fmt.Errorf("failed to calculate box price: %w", err)
+		return nil, fmt.Errorf("failed to get exchange rate: %w", err)
 	}
 
-	response := &primary.BoxPriceResponse{
-		BeerID:     req.BeerID,
-		BeerName:   beer.Name,
-		Quantity:   req.Quantity,
-		UnitPrice:  beer.Price * exchangeRate,
-		TotalPrice: totalPrice,
-		Currency:   req.Currency,
+	// Pick the cheapest pack combination and discount tier, then take off promotions
+	response, err := s.pricer().price(ctx, rates, beer, req.Quantity, exchangeRate, req.Coupon)
+	if err != nil {
+		return nil, err
 	}
 
-	if beer.Currency != req.Currency {
-		response.ExchangeRate = exchangeRate
+	if destination != "" {
+		if err := s.applyTaxes(ctx, rates, response, beer, destination); err != nil {
+			return nil, err
+		}
 	}
 
 	s.logger.Info(ctx, "Box price calculated successfully", map[string]interface{}{
 		"beer_id":     req.BeerID,
-		"total_price": totalPrice,
+		"total_price": response.TotalPrice,
 		"currency":    req.Currency,
 	})
 
 	return response, nil
 }
+
+// pricer returns the box pricer shared with quotes and orders
+func (s *BeerServiceImpl) pricer() boxPricer {
+	return boxPricer{pricingRules: s.pricingRules, promotions: s.promotions, logger: s.logger}
+}
+
+// applyTaxes itemises the taxes owed when the box ships to the destination country.
+// A destination without rules owes no taxes, but when no rules are loaded at all
+// the taxes are unknown rather than zero.
+func (s *BeerServiceImpl) applyTaxes(ctx context.Context, rates *exchangeRates, response *primary.BoxPriceResponse, beer *beers.Beer, destination string) error {
+	response.Destination = destination
+	response.TotalWithTax = response.TotalPrice
+
+	if s.taxRules == nil {
+		return errTaxRulesNotConfigured()
+	}
+
+	rules, err := s.taxRules.FindByCountry(ctx, destination)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find tax rules", err, map[string]interface{}{
+			"destination": destination,
+		})
+		return fmt.Errorf("failed to find tax rules: %w", err)
+	}
+
+	if len(rules) == 0 {
+		all, err := s.taxRules.FindAll(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to find tax rules: %w", err)
+		}
+		if len(all) == 0 {
+			return errTaxRulesNotConfigured()
+		}
+	}
+
+	assessment, err := tax.Calculate(destination, rules, beer, response.Quantity, response.TotalPrice,
+		func(currency string) (float64, error) {
+			return rates.Rate(ctx, currency)
+		})
+	if err != nil {
+		s.logger.Error(ctx, "Failed to calculate taxes", err, map[string]interface{}{
+			"beer_id":     beer.ID,
+			"destination": destination,
+		})
+		return err
+	}
+
+	response.Taxes = assessment.Lines
+	response.TotalTax = assessment.Total
+	response.TotalWithTax = response.TotalPrice + assessment.Total
+
+	return nil
+}
+
+// errTaxRulesNotConfigured reports that taxes were asked for without any tax rules loaded
+func errTaxRulesNotConfigured() error {
+	return beers.NewDomainError(tax.ErrCodeRulesNotConfigured,
+		"No tax rules are configured, so taxes cannot be calculated for a destination", nil)
+}
+
+// isDomainError reports whether err wraps a domain error with the given code
+func isDomainError(err error, code string) bool {
+	var domainErr *beers.DomainError
+	return errors.As(err, &domainErr) && domainErr.Code == code
+}
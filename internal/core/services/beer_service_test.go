@@ -4,11 +4,14 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/tax"
 	"beers-challenge/internal/core/ports/primary"
 	"beers-challenge/internal/infrastructure/logger"
 )
@@ -527,3 +530,296 @@ func TestCalculateBoxPriceExchangeRateError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockCurrency.AssertExpectations(t)
 }
+
+type MockTaxRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaxRuleRepository) FindByCountry(ctx context.Context, country string) ([]tax.Rule, error) {
+	args := m.Called(ctx, country)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]tax.Rule), args.Error(1)
+}
+
+func (m *MockTaxRuleRepository) FindAll(ctx context.Context) ([]tax.Rule, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]tax.Rule), args.Error(1)
+}
+
+func TestCalculateBoxPriceWithDestinationTaxes(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockTaxRules := new(MockTaxRuleRepository)
+
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithTaxRules(mockTaxRules))
+
+	beer := &beers.Beer{
+		ID:       testBeerID,
+		Name:     testBeerName,
+		Brewery:  testBrewery,
+		Country:  testCountry,
+		Price:    testPrice,
+		Currency: testCurrency,
+		ABV:      5,
+		VolumeML: 500,
+	}
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockCurrency.On("GetExchangeRate", ctx, testCurrency, "USD").Return(0.001, nil).Once()
+	mockCurrency.On("GetExchangeRate", ctx, "EUR", "USD").Return(1.1, nil).Once()
+	mockTaxRules.On("FindByCountry", ctx, "IE").Return([]tax.Rule{
+		{ID: "ie-vat", Name: "VAT", Country: "IE", Kind: tax.KindPercentage, Rate: 20, Compound: true},
+		{ID: "ie-excise", Name: "Excise", Country: "IE", Kind: tax.KindPerLitreAlcohol, Rate: 20, Currency: "EUR"},
+	}, nil)
+
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID:      testBeerID,
+		Quantity:    4,
+		Currency:    "USD",
+		Destination: "ie",
+	})
+
+	// 4 x 500ml at 5% = 0.1 litres of alcohol, 20 EUR per litre = 2 EUR = 2.2 USD
+	assert.NoError(t, err)
+	assert.Equal(t, "IE", result.Destination)
+	assert.InDelta(t, 6.0, result.TotalPrice, 1e-9)
+	assert.Len(t, result.Taxes, 2)
+	assert.Equal(t, "ie-excise", result.Taxes[0].RuleID)
+	assert.InDelta(t, 2.2, result.Taxes[0].Amount, 1e-9)
+	assert.InDelta(t, 8.2*0.2, result.Taxes[1].Amount, 1e-9)
+	assert.InDelta(t, 2.2+1.64, result.TotalTax, 1e-9)
+	assert.InDelta(t, 6.0+2.2+1.64, result.TotalWithTax, 1e-9)
+	mockCurrency.AssertExpectations(t)
+}
+
+func TestCalculateBoxPriceLooksUpEachRateOnce(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockTaxRules := new(MockTaxRuleRepository)
+
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithTaxRules(mockTaxRules))
+
+	beer := &beers.Beer{
+		ID:       testBeerID,
+		Name:     testBeerName,
+		Brewery:  testBrewery,
+		Country:  testCountry,
+		Price:    testPrice,
+		Currency: testCurrency,
+		ABV:      5,
+		VolumeML: 500,
+	}
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockCurrency.On("GetExchangeRate", ctx, testCurrency, "USD").Return(0.001, nil).Once()
+	mockTaxRules.On("FindByCountry", ctx, "CL").Return([]tax.Rule{
+		{ID: "cl-ila", Name: "ILA", Country: "CL", Kind: tax.KindPerLitreAlcohol, Rate: 1000, Currency: testCurrency},
+	}, nil)
+
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID:      testBeerID,
+		Quantity:    4,
+		Currency:    "USD",
+		Destination: "CL",
+	})
+
+	// The tax in the beer's currency reuses the rate the beer was priced with
+	assert.NoError(t, err)
+	assert.InDelta(t, 0.1, result.TotalTax, 1e-9)
+	mockCurrency.AssertNumberOfCalls(t, "GetExchangeRate", 1)
+}
+
+func TestCalculateBoxPriceTaxAttributesMissing(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockTaxRules := new(MockTaxRuleRepository)
+
+	service := NewBeerService(mockRepo, new(MockCurrencyService), logger.NewNoOpLogger(), WithTaxRules(mockTaxRules))
+
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Price: testPrice, Currency: testCurrency}
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockTaxRules.On("FindByCountry", ctx, "CL").Return([]tax.Rule{
+		{ID: "cl-ila", Name: "ILA", Country: "CL", Kind: tax.KindPerLitre, Rate: 100, Currency: testCurrency},
+	}, nil)
+
+	_, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID:      testBeerID,
+		Quantity:    6,
+		Currency:    testCurrency,
+		Destination: "CL",
+	})
+
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, tax.ErrCodeAttributesMissing, domainErr.Code)
+}
+
+func TestCalculateBoxPriceTaxRulesNotConfigured(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockTaxRules := new(MockTaxRuleRepository)
+
+	service := NewBeerService(mockRepo, new(MockCurrencyService), logger.NewNoOpLogger(), WithTaxRules(mockTaxRules))
+
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Price: testPrice, Currency: testCurrency}
+	request := primary.CalculateBoxPriceRequest{
+		BeerID:      testBeerID,
+		Quantity:    6,
+		Currency:    testCurrency,
+		Destination: "US",
+	}
+
+	ctx := context.Background()
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockTaxRules.On("FindByCountry", ctx, "US").Return([]tax.Rule{}, nil)
+	mockTaxRules.On("FindAll", ctx).Return([]tax.Rule{}, nil).Once()
+
+	// Without any rules loaded the taxes are unknown, not zero
+	_, err := service.CalculateBoxPrice(ctx, request)
+
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, tax.ErrCodeRulesNotConfigured, domainErr.Code)
+
+	// A destination without rules of its own owes no taxes
+	mockTaxRules.On("FindAll", ctx).Return([]tax.Rule{
+		{ID: "cl-iva", Name: "IVA", Country: "CL", Kind: tax.KindPercentage, Rate: 19},
+	}, nil).Once()
+
+	result, err := service.CalculateBoxPrice(ctx, request)
+
+	assert.NoError(t, err)
+	assert.Empty(t, result.Taxes)
+	assert.Equal(t, result.TotalPrice, result.TotalWithTax)
+}
+
+func TestCalculateBoxPriceInvalidDestination(t *testing.T) {
+	service := NewBeerService(new(MockBeerRepository), new(MockCurrencyService), logger.NewNoOpLogger())
+
+	_, err := service.CalculateBoxPrice(context.Background(), primary.CalculateBoxPriceRequest{
+		BeerID:      testBeerID,
+		Quantity:    6,
+		Currency:    testCurrency,
+		Destination: "CHL",
+	})
+
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "destination", validationErr.Field)
+}
+
+// recordingPublisher keeps the events published to it
+type recordingPublisher struct {
+	published []events.Event
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, published ...events.Event) {
+	p.published = append(p.published, published...)
+}
+
+func TestCreateBeerPublishesEvent(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	publisher := &recordingPublisher{}
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithEventPublisher(publisher))
+	ctx := context.Background()
+
+	mockRepo.On("ExistsByID", ctx, testBeerID).Return(false, nil)
+	mockCurrency.On("IsValidCurrency", ctx, testCurrency).Return(true, nil)
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*beers.Beer")).Return(nil)
+
+	err := service.CreateBeer(ctx, primary.CreateBeerRequest{
+		ID: testBeerID, Name: testBeerName, Brewery: testBrewery, Country: testCountry, Price: testPrice, Currency: testCurrency,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, publisher.published, 1)
+	assert.Equal(t, events.TypeBeerCreated, publisher.published[0].Type)
+	assert.Equal(t, testBeerName, publisher.published[0].Beer.Name)
+}
+
+func TestUpdateBeerPublishesPriceChange(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	publisher := &recordingPublisher{}
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithEventPublisher(publisher))
+	ctx := context.Background()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := &beers.Beer{ID: testBeerID, Name: testBeerName, Brewery: testBrewery, Country: testCountry,
+		Price: testPrice, Currency: testCurrency, CreatedAt: createdAt, UpdatedAt: createdAt}
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(existing, nil)
+	mockCurrency.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*beers.Beer")).Return(nil)
+
+	beer, err := service.UpdateBeer(ctx, testBeerID, primary.UpdateBeerRequest{
+		Name: testBeerName, Brewery: testBrewery, Country: testCountry, Price: 2.5, Currency: "USD", ABV: 5,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", beer.Currency)
+	assert.Equal(t, 5.0, beer.ABV)
+	assert.Equal(t, createdAt, beer.CreatedAt)
+	assert.True(t, beer.UpdatedAt.After(createdAt))
+
+	assert.Len(t, publisher.published, 2)
+	assert.Equal(t, events.TypeBeerUpdated, publisher.published[0].Type)
+	assert.Equal(t, events.TypePriceChanged, publisher.published[1].Type)
+	assert.Equal(t, &events.PriceChange{OldPrice: testPrice, OldCurrency: testCurrency, NewPrice: 2.5, NewCurrency: "USD"},
+		publisher.published[1].PriceChange)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateBeerErrors(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	publisher := &recordingPublisher{}
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithEventPublisher(publisher))
+	ctx := context.Background()
+
+	req := primary.UpdateBeerRequest{Name: testBeerName, Brewery: testBrewery, Country: testCountry, Price: testPrice, Currency: "XXX"}
+
+	mockRepo.On("FindByID", ctx, 99).Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 99 not found", nil))
+	_, err := service.UpdateBeer(ctx, 99, req)
+	var domainErr *beers.DomainError
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "BEER_NOT_FOUND", domainErr.Code)
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(&beers.Beer{ID: testBeerID, Price: testPrice, Currency: testCurrency}, nil)
+	mockCurrency.On("IsValidCurrency", ctx, "XXX").Return(false, nil)
+	_, err = service.UpdateBeer(ctx, testBeerID, req)
+	assert.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, "INVALID_CURRENCY", domainErr.Code)
+
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	assert.Empty(t, publisher.published)
+}
+
+func TestUpdateBeerSavesThroughOutbox(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	outbox := &fakeOutbox{}
+	publisher := &recordingPublisher{}
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(),
+		WithEventPublisher(publisher), WithOutbox(outbox))
+	ctx := context.Background()
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(&beers.Beer{ID: testBeerID, Name: testBeerName, Brewery: testBrewery,
+		Country: testCountry, Price: testPrice, Currency: testCurrency}, nil)
+	mockCurrency.On("IsValidCurrency", ctx, testCurrency).Return(true, nil)
+
+	_, err := service.UpdateBeer(ctx, testBeerID, primary.UpdateBeerRequest{
+		Name: testBeerName, Brewery: testBrewery, Country: testCountry, Price: testPrice * 2, Currency: testCurrency,
+	})
+
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	assert.Len(t, outbox.pending, 2)
+	assert.Equal(t, events.TypePriceChanged, outbox.pending[1].Type)
+	assert.Equal(t, outbox.pending, publisher.published, "live subscribers get the same events")
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// boxPricer prices a quantity of a beer the same way for box prices, quotes and
+// order lines, so a cart is checked out at the price it was quoted
+type boxPricer struct {
+	pricingRules secondary.PricingRuleRepository
+	promotions   secondary.PromotionRepository
+	logger       secondary.Logger
+	// orderLevelFixed leaves fixed-amount promotions out of the box price, for
+	// orders that take them off their total once instead of off every line
+	orderLevelFixed bool
+}
+
+// price picks the cheapest pack combination and discount tier for the quantity,
+// converts it with exchangeRate into the target currency of rates and takes off
+// every eligible promotion. Coupon promotions only apply when their code is given.
+func (p boxPricer) price(
+	ctx context.Context,
+	rates *exchangeRates,
+	beer *beers.Beer,
+	quantity int,
+	exchangeRate float64,
+	coupon string,
+) (*primary.BoxPriceResponse, error) {
+	// Calculate the linear price, which also validates quantity and rate
+	if _, err := beer.CalculateBoxPrice(quantity, exchangeRate); err != nil {
+		p.logger.Error(ctx, "Failed to calculate box price", err, map[string]interface{}{
+			"beer_id":       beer.ID,
+			"quantity":      quantity,
+			"exchange_rate": exchangeRate,
+		})
+		return nil, fmt.Errorf("failed to calculate box price: %w", err)
+	}
+
+	rule, err := p.findPricingRule(ctx, beer.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	quote, err := rule.Quote(beer.Price, quantity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate box price: %w", err)
+	}
+
+	breakdown := make([]pricing.Line, len(quote.Lines))
+	for i, line := range quote.Lines {
+		line.Price *= exchangeRate
+		line.Amount *= exchangeRate
+		breakdown[i] = line
+	}
+
+	response := &primary.BoxPriceResponse{
+		BeerID:          beer.ID,
+		BeerName:        beer.Name,
+		Quantity:        quantity,
+		UnitPrice:       beer.Price * exchangeRate,
+		TotalPrice:      quote.Total * exchangeRate,
+		Currency:        rates.target,
+		Subtotal:        quote.Subtotal * exchangeRate,
+		DiscountPercent: quote.DiscountPercent,
+		Discount:        quote.Discount * exchangeRate,
+		Breakdown:       breakdown,
+	}
+
+	if beer.Currency != rates.target {
+		response.ExchangeRate = exchangeRate
+	}
+
+	if err := p.applyPromotions(ctx, rates, response, beer, coupon); err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// findPricingRule returns the pricing rule of a beer, or a rule without packs
+// or tiers when none is configured
+func (p boxPricer) findPricingRule(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	if p.pricingRules == nil {
+		return &pricing.Rule{BeerID: beerID}, nil
+	}
+
+	rule, err := p.pricingRules.FindByBeerID(ctx, beerID)
+	if err != nil {
+		if isDomainError(err, pricing.ErrCodeRuleNotFound) {
+			return &pricing.Rule{BeerID: beerID}, nil
+		}
+
+		p.logger.Error(ctx, "Failed to find pricing rule", err, map[string]interface{}{
+			"beer_id": beerID,
+		})
+		return nil, fmt.Errorf("failed to find pricing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// applyPromotions takes every eligible promotion off the box price, percentages
+// first, and records why the others did not apply. Pricing only checks that a
+// coupon has uses left; it is redeemed when an order is placed with it.
+func (p boxPricer) applyPromotions(ctx context.Context, rates *exchangeRates, response *primary.BoxPriceResponse, beer *beers.Beer, coupon string) error {
+	if p.promotions == nil {
+		return nil
+	}
+
+	candidates, err := p.promotions.FindAll(ctx)
+	if err != nil {
+		p.logger.Error(ctx, "Failed to find promotions", err, map[string]interface{}{
+			"beer_id": beer.ID,
+		})
+		return fmt.Errorf("failed to find promotions: %w", err)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].Type == promotions.TypePercentage && candidates[j].Type != promotions.TypePercentage
+	})
+
+	coupon = promotions.NormalizeCode(coupon)
+	couponFound := coupon == ""
+	now := time.Now()
+	total := 0.0
+
+	for i := range candidates {
+		promotion := &candidates[i]
+		if coupon != "" && promotion.CouponCode == coupon {
+			couponFound = true
+		}
+		if p.orderLevelFixed && promotion.Type == promotions.TypeFixed {
+			continue
+		}
+
+		outcome := promotions.Outcome{PromotionID: promotion.ID, Name: promotion.Name}
+		outcome.Reason = promotion.Eligibility(beer, now, coupon)
+
+		if outcome.Reason == "" {
+			outcome.Discount, outcome.Reason = p.promotionDiscount(ctx, rates, promotion, response.TotalPrice-total)
+		}
+
+		if outcome.Reason == "" {
+			outcome.Applied = true
+			total += outcome.Discount
+			response.Breakdown = append(response.Breakdown, pricing.Line{
+				Type:        pricing.LineTypePromotion,
+				Description: promotion.Name,
+				Amount:      -outcome.Discount,
+			})
+		}
+
+		response.Promotions = append(response.Promotions, outcome)
+	}
+
+	if !couponFound {
+		response.Promotions = append(response.Promotions, promotions.Outcome{
+			Name:   coupon,
+			Reason: promotions.ReasonUnknownCoupon,
+		})
+	}
+
+	response.PromotionDiscount = total
+	response.TotalPrice -= total
+
+	return nil
+}
+
+// promotionDiscount works out the discount of an eligible promotion on the remaining
+// amount, returning a reason instead when it cannot apply
+func (p boxPricer) promotionDiscount(ctx context.Context, rates *exchangeRates, promotion *promotions.Promotion, remaining float64) (float64, string) {
+	if remaining <= 0 {
+		return 0, promotions.ReasonNoDiscountLeft
+	}
+
+	rate := 1.0
+	if promotion.Type == promotions.TypeFixed {
+		var err error
+		if rate, err = rates.Rate(ctx, promotion.Currency); err != nil {
+			p.logger.Warn(ctx, "Promotion exchange rate unavailable", map[string]interface{}{
+				"promotion_id": promotion.ID,
+				"from":         promotion.Currency,
+				"to":           rates.target,
+				"error":        err.Error(),
+			})
+			return 0, promotions.ReasonRateUnavailable
+		}
+	}
+
+	return promotion.Discount(remaining, rate), ""
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func TestBoxPriceQuoteAndCheckoutAgree(t *testing.T) {
+	ctx := context.Background()
+	log := logger.NewNoOpLogger()
+
+	beerRepo := new(MockBeerRepository)
+	currencyService := new(MockCurrencyService)
+	rules := new(MockPricingRuleRepository)
+	promotionRepo := new(MockPromotionRepository)
+	carts := new(MockCartRepository)
+	orderRepo := new(MockOrderRepository)
+
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Brewery: "Kunstmann", Price: 1000, Currency: testCurrency}
+	rule, _ := pricing.NewRule(testBeerID,
+		[]pricing.PackSKU{{SKU: "SIX", Size: 6, Price: 5000}, {SKU: "TWELVE", Size: 12, Price: 9000}},
+		[]pricing.DiscountTier{{MinUnits: 48, Percent: 5}},
+	)
+	promotion := newTestPromotion("Kunstmann week", promotions.TypePercentage, 10, "")
+
+	cart, _ := orders.NewCart("USD")
+	assert.NoError(t, cart.AddItem(testBeerID, 50))
+
+	beerRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	currencyService.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	currencyService.On("GetExchangeRate", ctx, testCurrency, "USD").Return(0.001, nil)
+	rules.On("FindByBeerID", ctx, testBeerID).Return(rule, nil)
+	promotionRepo.On("FindAll", ctx).Return([]promotions.Promotion{promotion}, nil)
+	carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+	orderRepo.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(nil)
+
+	boxPrice, err := NewBeerService(beerRepo, currencyService, log,
+		WithPricingRules(rules), WithPromotions(promotionRepo),
+	).CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{BeerID: testBeerID, Quantity: 50, Currency: "USD"})
+	assert.NoError(t, err)
+
+	quote, err := NewQuoteService(beerRepo, currencyService, log,
+		WithQuotePricingRules(rules), WithQuotePromotions(promotionRepo),
+	).CreateQuote(ctx, primary.QuoteRequest{
+		Currency: "USD",
+		Items:    []primary.QuoteItemRequest{{BeerID: testBeerID, Quantity: 50}},
+	})
+	assert.NoError(t, err)
+
+	order, err := NewOrderService(carts, orderRepo, beerRepo, currencyService, log,
+		WithOrderPricingRules(rules), WithCoupons(promotionRepo),
+	).Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+	assert.NoError(t, err)
+
+	// 50 units = 4 x 12-pack + 2 singles = 38000 CLP, minus the 5% tier and the 10% promotion
+	assert.InDelta(t, 32.49, boxPrice.TotalPrice, 0.0001)
+	assert.InDelta(t, boxPrice.TotalPrice, quote.GrandTotal, 0.0001)
+	assert.InDelta(t, boxPrice.TotalPrice, order.Total, 0.0001)
+	assert.InDelta(t, boxPrice.TotalPrice, order.Lines[0].LineTotal, 0.0001)
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// subscriberBuffer is how many live events a subscriber may fall behind before
+// it is dropped
+const subscriberBuffer = 64
+
+// EventBus publishes catalogue events to in-process subscribers and keeps the
+// latest ones so reconnecting clients can resume where they stopped. It
+// implements both secondary.EventPublisher and primary.EventService.
+//
+// Event IDs are "<epoch>-<sequence>", the epoch being unique to the process: an
+// ID from another process or an earlier run cannot be resumed from, so its
+// holder is sent the whole history instead.
+type EventBus struct {
+	mu          sync.Mutex
+	epoch       string
+	sequence    uint64
+	history     []events.Event
+	historySize int
+	subscribers map[*subscriber]struct{}
+	closed      bool
+	logger      secondary.Logger
+}
+
+// subscriber is a live subscription
+type subscriber struct {
+	filter events.Filter
+	events chan events.Event
+}
+
+// NewEventBus creates an event bus that keeps the last historySize events
+func NewEventBus(historySize int, logger secondary.Logger) *EventBus {
+	return &EventBus{
+		epoch:       strconv.FormatInt(time.Now().UnixNano(), 36),
+		historySize: historySize,
+		subscribers: make(map[*subscriber]struct{}),
+		logger:      logger,
+	}
+}
+
+// Publish assigns IDs to events and sends them to every matching subscriber.
+// A subscriber whose buffer is full is dropped rather than holding up the
+// publisher; its channel is closed so the client reconnects and resumes.
+func (b *EventBus) Publish(ctx context.Context, published ...events.Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return
+	}
+
+	for _, event := range published {
+		b.sequence++
+		event.ID = b.epoch + "-" + strconv.FormatUint(b.sequence, 10)
+
+		b.history = append(b.history, event)
+		if len(b.history) > b.historySize {
+			b.history = b.history[len(b.history)-b.historySize:]
+		}
+
+		for sub := range b.subscribers {
+			if !sub.filter.Matches(event) {
+				continue
+			}
+
+			select {
+			case sub.events <- event:
+			default:
+				b.logger.Warn(ctx, "Dropping slow event subscriber", map[string]interface{}{
+					"event_id": event.ID,
+				})
+				b.remove(sub)
+			}
+		}
+	}
+}
+
+// Subscribe streams matching events until ctx is done, starting with those
+// published after lastEventID that are still in the history
+func (b *EventBus) Subscribe(ctx context.Context, filter events.Filter, lastEventID string) (<-chan events.Event, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, beers.NewDomainError(events.ErrCodeUnavailable, "The event stream is shutting down", nil)
+	}
+
+	missed := b.missedSince(lastEventID, filter)
+	sub := &subscriber{
+		filter: filter,
+		events: make(chan events.Event, len(missed)+subscriberBuffer),
+	}
+	for _, event := range missed {
+		sub.events <- event
+	}
+	b.subscribers[sub] = struct{}{}
+
+	go func() {
+		<-ctx.Done()
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.remove(sub)
+	}()
+
+	return sub.events, nil
+}
+
+// Close ends every subscription and stops accepting new ones, so streaming
+// connections finish before the server shuts down
+func (b *EventBus) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.closed = true
+	for sub := range b.subscribers {
+		b.remove(sub)
+	}
+}
+
+// missedSince returns the matching events of the history published after
+// lastEventID. Without an ID nothing was missed; an ID this bus did not issue
+// gets the whole history.
+func (b *EventBus) missedSince(lastEventID string, filter events.Filter) []events.Event {
+	if lastEventID == "" {
+		return nil
+	}
+
+	var after uint64
+	if epoch, sequence, found := strings.Cut(lastEventID, "-"); found && epoch == b.epoch {
+		after, _ = strconv.ParseUint(sequence, 10, 64)
+	}
+
+	var missed []events.Event
+	for _, event := range b.history {
+		_, sequence, _ := strings.Cut(event.ID, "-")
+		if n, _ := strconv.ParseUint(sequence, 10, 64); n > after && filter.Matches(event) {
+			missed = append(missed, event)
+		}
+	}
+
+	return missed
+}
+
+// remove ends a subscription; the caller holds the lock
+func (b *EventBus) remove(sub *subscriber) {
+	if _, ok := b.subscribers[sub]; ok {
+		delete(b.subscribers, sub)
+		close(sub.events)
+	}
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// receive reads the next event of a subscription
+func receive(t *testing.T, stream <-chan events.Event) events.Event {
+	t.Helper()
+	select {
+	case event, ok := <-stream:
+		require.True(t, ok, "subscription ended")
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("no event received")
+		return events.Event{}
+	}
+}
+
+func TestEventBusFiltersSubscriptions(t *testing.T) {
+	bus := NewEventBus(10, logger.NewNoOpLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clp, err := bus.Subscribe(ctx, events.Filter{Currencies: []string{"CLP"}}, "")
+	require.NoError(t, err)
+	beer2, err := bus.Subscribe(ctx, events.Filter{BeerIDs: []int{2}}, "")
+	require.NoError(t, err)
+
+	bus.Publish(ctx,
+		events.BeerCreated(&beers.Beer{ID: 1, Currency: "CLP"}),
+		events.BeerCreated(&beers.Beer{ID: 2, Currency: "EUR"}))
+
+	assert.Equal(t, 1, receive(t, clp).BeerID)
+	assert.Equal(t, 2, receive(t, beer2).BeerID)
+	assert.Empty(t, clp)
+	assert.Empty(t, beer2)
+}
+
+func TestEventBusResumesFromLastEventID(t *testing.T) {
+	bus := NewEventBus(2, logger.NewNoOpLogger())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for id := 1; id <= 3; id++ {
+		bus.Publish(ctx, events.BeerCreated(&beers.Beer{ID: id, Currency: "CLP"}))
+	}
+
+	// Without an ID only new events are sent
+	live, err := bus.Subscribe(ctx, events.Filter{}, "")
+	require.NoError(t, err)
+	assert.Empty(t, live)
+
+	// Events after the last one seen are replayed from the history
+	second := bus.history[0]
+	assert.Equal(t, 2, second.BeerID)
+	resumed, err := bus.Subscribe(ctx, events.Filter{}, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 3, receive(t, resumed).BeerID)
+	assert.Empty(t, resumed)
+
+	// An ID from another run gets the whole history
+	restarted, err := bus.Subscribe(ctx, events.Filter{}, "otherepoch-7")
+	require.NoError(t, err)
+	assert.Equal(t, 2, receive(t, restarted).BeerID)
+	assert.Equal(t, 3, receive(t, restarted).BeerID)
+
+	bus.Publish(ctx, events.BeerCreated(&beers.Beer{ID: 4, Currency: "CLP"}))
+	assert.Equal(t, 4, receive(t, resumed).BeerID)
+}
+
+func TestEventBusEndsSubscriptions(t *testing.T) {
+	bus := NewEventBus(10, logger.NewNoOpLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled, err := bus.Subscribe(ctx, events.Filter{}, "")
+	require.NoError(t, err)
+	cancel()
+	assert.Eventually(t, func() bool {
+		_, open := <-cancelled
+		return !open
+	}, time.Second, 10*time.Millisecond)
+
+	// A subscriber that stops reading is dropped
+	slow, err := bus.Subscribe(context.Background(), events.Filter{}, "")
+	require.NoError(t, err)
+	for id := 1; id <= subscriberBuffer+1; id++ {
+		bus.Publish(context.Background(), events.BeerCreated(&beers.Beer{ID: id}))
+	}
+	received := 0
+	for range slow {
+		received++
+	}
+	assert.Equal(t, subscriberBuffer, received)
+
+	open, err := bus.Subscribe(context.Background(), events.Filter{}, "")
+	require.NoError(t, err)
+	bus.Close()
+	_, ok := <-open
+	assert.False(t, ok)
+
+	_, err = bus.Subscribe(context.Background(), events.Filter{}, "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// exchangeRates resolves exchange rates into a single target currency and
+// remembers every answer, so each source currency hits the provider once
+type exchangeRates struct {
+	currencyService secondary.CurrencyService
+	target          string
+	rates           map[string]float64
+	errs            map[string]error
+}
+
+// newExchangeRates creates a rate table for the given target currency
+func newExchangeRates(currencyService secondary.CurrencyService, target string) *exchangeRates {
+	return &exchangeRates{
+		currencyService: currencyService,
+		target:          target,
+		rates:           map[string]float64{},
+		errs:            map[string]error{},
+	}
+}
+
+// Rate returns the exchange rate from the source currency to the target currency
+func (r *exchangeRates) Rate(ctx context.Context, from string) (float64, error) {
+	if from == r.target {
+		return 1.0, nil
+	}
+
+	if rate, ok := r.rates[from]; ok {
+		return rate, nil
+	}
+
+	if err, ok := r.errs[from]; ok {
+		return 0, err
+	}
+
+	rate, err := r.currencyService.GetExchangeRate(ctx, from, r.target)
+	if err != nil {
+		r.errs[from] = err
+		return 0, err
+	}
+
+	r.rates[from] = rate
+	return rate, nil
+}
@@ -0,0 +1,485 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// OrderServiceImpl implements the OrderService primary port
+type OrderServiceImpl struct {
+	cartRepo        secondary.CartRepository
+	orderRepo       secondary.OrderRepository
+	beerRepo        secondary.BeerRepository
+	currencyService secondary.CurrencyService
+	pricingRules    secondary.PricingRuleRepository
+	promotions      secondary.PromotionRepository
+	logger          secondary.Logger
+}
+
+// OrderServiceOption configures optional collaborators of the order service
+type OrderServiceOption func(*OrderServiceImpl)
+
+// WithOrderPricingRules prices order lines with the pack SKUs and discount tiers
+// of each beer, as box prices and quotes are
+func WithOrderPricingRules(pricingRules secondary.PricingRuleRepository) OrderServiceOption {
+	return func(s *OrderServiceImpl) {
+		s.pricingRules = pricingRules
+	}
+}
+
+// WithCoupons takes automatic promotions off order lines, as off box prices, and
+// lets carts be checked out with coupons, which are redeemed with the order they discount
+func WithCoupons(promotionRepo secondary.PromotionRepository) OrderServiceOption {
+	return func(s *OrderServiceImpl) {
+		s.promotions = promotionRepo
+	}
+}
+
+// NewOrderService creates a new order service
+func NewOrderService(
+	cartRepo secondary.CartRepository,
+	orderRepo secondary.OrderRepository,
+	beerRepo secondary.BeerRepository,
+	currencyService secondary.CurrencyService,
+	logger secondary.Logger,
+	opts ...OrderServiceOption,
+) primary.OrderService {
+	service := &OrderServiceImpl{
+		cartRepo:        cartRepo,
+		orderRepo:       orderRepo,
+		beerRepo:        beerRepo,
+		currencyService: currencyService,
+		logger:          logger,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// CreateCart creates a new empty cart
+func (s *OrderServiceImpl) CreateCart(ctx context.Context, req primary.CreateCartRequest) (*orders.Cart, error) {
+	s.logger.Info(ctx, "Creating cart", map[string]interface{}{
+		"currency": req.Currency,
+	})
+
+	cart, err := orders.NewCart(req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	isValid, err := s.currencyService.IsValidCurrency(ctx, cart.Currency)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to validate currency", err, map[string]interface{}{
+			"currency": cart.Currency,
+		})
+		return nil, fmt.Errorf("failed to validate currency: %w", err)
+	}
+
+	if !isValid {
+		return nil, beers.NewDomainError("INVALID_CURRENCY", "Invalid currency code", nil)
+	}
+
+	if err := s.cartRepo.Save(ctx, cart); err != nil {
+		s.logger.Error(ctx, "Failed to save cart", err, map[string]interface{}{
+			"cart_id": cart.ID,
+		})
+		return nil, fmt.Errorf("failed to save cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// GetCart finds a cart by its ID
+func (s *OrderServiceImpl) GetCart(ctx context.Context, id string) (*orders.Cart, error) {
+	cart, err := s.cartRepo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find cart", err, map[string]interface{}{
+			"cart_id": id,
+		})
+		return nil, err
+	}
+
+	return cart, nil
+}
+
+// AddCartItem adds a beer to a cart
+func (s *OrderServiceImpl) AddCartItem(ctx context.Context, cartID string, req primary.CartItemRequest) (*orders.Cart, error) {
+	s.logger.Info(ctx, "Adding item to cart", map[string]interface{}{
+		"cart_id":  cartID,
+		"beer_id":  req.BeerID,
+		"quantity": req.Quantity,
+	})
+
+	cart, err := s.GetCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.beerRepo.ExistsByID(ctx, req.BeerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check beer existence: %w", err)
+	}
+
+	if !exists {
+		return nil, beers.NewDomainError("BEER_NOT_FOUND", fmt.Sprintf("Beer with ID %d not found", req.BeerID), nil)
+	}
+
+	if err := cart.AddItem(req.BeerID, req.Quantity); err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.Save(ctx, cart); err != nil {
+		return nil, fmt.Errorf("failed to save cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// RemoveCartItem removes a beer from a cart
+func (s *OrderServiceImpl) RemoveCartItem(ctx context.Context, cartID string, beerID int) (*orders.Cart, error) {
+	cart, err := s.GetCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cart.RemoveItem(beerID); err != nil {
+		return nil, err
+	}
+
+	if err := s.cartRepo.Save(ctx, cart); err != nil {
+		return nil, fmt.Errorf("failed to save cart: %w", err)
+	}
+
+	return cart, nil
+}
+
+// Checkout prices every cart item like a box price and creates an order with the
+// frozen prices.
+// Automatic fixed-amount promotions and a coupon are taken once off the lines
+// they apply to, so they take their value off the order rather than off every
+// line. The coupon is redeemed once, together with the checkout of the cart.
+func (s *OrderServiceImpl) Checkout(ctx context.Context, cartID string, req primary.CheckoutRequest) (*orders.Order, error) {
+	s.logger.Info(ctx, "Checking out cart", map[string]interface{}{
+		"cart_id": cartID,
+	})
+
+	cart, err := s.GetCart(ctx, cartID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cart.IsCheckedOut() {
+		return nil, beers.NewDomainError(orders.ErrCodeCartCheckedOut, "Cart has already been checked out", nil)
+	}
+
+	coupon, err := s.findCoupon(ctx, req.Coupon)
+	if err != nil {
+		return nil, err
+	}
+
+	// Each source currency is converted once per checkout so that every line
+	// of the order is frozen with the same rate
+	rates := newExchangeRates(s.currencyService, cart.Currency)
+	lines := make([]orders.OrderLine, 0, len(cart.Items))
+	lineBeers := make([]*beers.Beer, 0, len(cart.Items))
+	eligible, couponReason := 0.0, ""
+	now := time.Now()
+
+	for _, item := range cart.Items {
+		beer, err := s.beerRepo.FindByID(ctx, item.BeerID)
+		if err != nil {
+			return nil, err
+		}
+
+		rate, err := rates.Rate(ctx, beer.Currency)
+		if err != nil {
+			s.logger.Error(ctx, "Failed to get exchange rate", err, map[string]interface{}{
+				"from": beer.Currency,
+				"to":   cart.Currency,
+			})
+			return nil, fmt.Errorf("failed to get exchange rate: %w", err)
+		}
+
+		price, err := s.orderPricer().price(ctx, rates, beer, item.Quantity, rate, "")
+		if err != nil {
+			return nil, err
+		}
+
+		line, err := orders.NewOrderLine(beer, item.Quantity, rate, price.TotalPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to price cart item: %w", err)
+		}
+		lines = append(lines, line)
+		lineBeers = append(lineBeers, beer)
+
+		if coupon == nil {
+			continue
+		}
+		if couponReason = coupon.Eligibility(beer, now, req.Coupon); couponReason != "" {
+			continue
+		}
+		eligible += line.LineTotal
+	}
+
+	order, err := orders.NewOrder(cart.ID, cart.Currency, lines)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyFixedPromotions(ctx, rates, order, lineBeers, now); err != nil {
+		return nil, err
+	}
+
+	if coupon != nil {
+		if eligible == 0 {
+			return nil, couponNotApplied(coupon, couponReason)
+		}
+		discount, err := s.couponDiscount(ctx, rates, coupon, eligible)
+		if err != nil {
+			return nil, err
+		}
+		order.ApplyCoupon(coupon.CouponCode, discount)
+	}
+
+	// The cart is claimed before the order is saved, so a concurrent checkout
+	// of the same cart fails here instead of placing a second order. The claim
+	// holds only for the version the lines were priced from, so items changed
+	// in the meantime fail it too.
+	if err := s.cartRepo.MarkCheckedOut(ctx, cart.ID, cart.Version, order.ID); err != nil {
+		return nil, err
+	}
+
+	if coupon != nil {
+		if err := s.promotions.Redeem(ctx, coupon.ID); err != nil {
+			s.logger.Error(ctx, "Failed to redeem coupon", err, map[string]interface{}{
+				"promotion_id": coupon.ID,
+				"order_id":     order.ID,
+			})
+			s.reopenCart(ctx, cart.ID, order.ID)
+			if isDomainError(err, promotions.ErrCodeUsageLimitReached) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("failed to redeem coupon: %w", err)
+		}
+	}
+
+	if err := s.orderRepo.Save(ctx, order); err != nil {
+		s.logger.Error(ctx, "Failed to save order", err, map[string]interface{}{
+			"order_id": order.ID,
+			"coupon":   order.Coupon,
+		})
+		if coupon != nil {
+			s.releaseCoupon(ctx, coupon.ID, order.ID)
+		}
+		s.reopenCart(ctx, cart.ID, order.ID)
+		return nil, fmt.Errorf("failed to save order: %w", err)
+	}
+
+	s.logger.Info(ctx, "Order placed successfully", map[string]interface{}{
+		"order_id": order.ID,
+		"total":    order.Total,
+		"currency": order.Currency,
+	})
+
+	return order, nil
+}
+
+// orderPricer returns the box pricer shared with box prices and quotes, leaving
+// fixed-amount promotions to the order total
+func (s *OrderServiceImpl) orderPricer() boxPricer {
+	return boxPricer{pricingRules: s.pricingRules, promotions: s.promotions, logger: s.logger, orderLevelFixed: true}
+}
+
+// applyFixedPromotions takes every automatic fixed-amount promotion once off the
+// total of the order lines it applies to. lineBeers holds the beer of each line.
+func (s *OrderServiceImpl) applyFixedPromotions(ctx context.Context, rates *exchangeRates, order *orders.Order, lineBeers []*beers.Beer, now time.Time) error {
+	if s.promotions == nil {
+		return nil
+	}
+
+	candidates, err := s.promotions.FindAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find promotions", err, map[string]interface{}{
+			"order_id": order.ID,
+		})
+		return fmt.Errorf("failed to find promotions: %w", err)
+	}
+
+	pricer := s.orderPricer()
+	for i := range candidates {
+		promotion := &candidates[i]
+		if promotion.Type != promotions.TypeFixed || promotion.CouponCode != "" {
+			continue
+		}
+
+		eligible := 0.0
+		for j, line := range order.Lines {
+			if promotion.Eligibility(lineBeers[j], now, "") == "" {
+				eligible += line.LineTotal
+			}
+		}
+		if eligible == 0 {
+			continue
+		}
+
+		if eligible > order.Total {
+			eligible = order.Total
+		}
+		if discount, reason := pricer.promotionDiscount(ctx, rates, promotion, eligible); reason == "" {
+			order.ApplyDiscount(discount)
+		}
+	}
+
+	return nil
+}
+
+// findCoupon returns the promotion unlocked by a coupon code, or nil when no
+// code is given
+func (s *OrderServiceImpl) findCoupon(ctx context.Context, code string) (*promotions.Promotion, error) {
+	if promotions.NormalizeCode(code) == "" {
+		return nil, nil
+	}
+
+	if s.promotions == nil {
+		return nil, beers.NewDomainError(promotions.ErrCodeNotFound,
+			fmt.Sprintf("Coupon code %s not found", promotions.NormalizeCode(code)), nil)
+	}
+
+	return s.promotions.FindByCouponCode(ctx, code)
+}
+
+// couponDiscount works out the discount of a coupon on the total of the order
+// lines it applies to
+func (s *OrderServiceImpl) couponDiscount(ctx context.Context, rates *exchangeRates, coupon *promotions.Promotion, eligible float64) (float64, error) {
+	rate := 1.0
+	if coupon.Type == promotions.TypeFixed {
+		var err error
+		if rate, err = rates.Rate(ctx, coupon.Currency); err != nil {
+			s.logger.Error(ctx, "Failed to get exchange rate", err, map[string]interface{}{
+				"from": coupon.Currency,
+				"to":   rates.target,
+			})
+			return 0, fmt.Errorf("failed to get exchange rate: %w", err)
+		}
+	}
+
+	return coupon.Discount(eligible, rate), nil
+}
+
+// couponNotApplied explains why a coupon took nothing off an order, given the
+// reason it did not apply to the last cart item
+func couponNotApplied(coupon *promotions.Promotion, reason string) error {
+	if reason == promotions.ReasonUsageLimitReached {
+		return beers.NewDomainError(promotions.ErrCodeUsageLimitReached, "Promotion has reached its usage limit", nil)
+	}
+
+	return beers.NewDomainError(promotions.ErrCodeCouponNotApplicable,
+		fmt.Sprintf("Coupon code %s does not apply to this cart (%s)", coupon.CouponCode, reason), nil)
+}
+
+// reopenCart undoes the checkout of a cart whose order could not be placed
+func (s *OrderServiceImpl) reopenCart(ctx context.Context, cartID, orderID string) {
+	if err := s.cartRepo.ClearCheckout(ctx, cartID, orderID); err != nil {
+		s.logger.Error(ctx, "Failed to reopen cart", err, map[string]interface{}{
+			"cart_id":  cartID,
+			"order_id": orderID,
+		})
+	}
+}
+
+// releaseCoupon gives back the coupon use redeemed for an order that could not be
+// saved or was cancelled
+func (s *OrderServiceImpl) releaseCoupon(ctx context.Context, promotionID, orderID string) {
+	if err := s.promotions.Release(ctx, promotionID); err != nil {
+		s.logger.Error(ctx, "Failed to release coupon", err, map[string]interface{}{
+			"promotion_id": promotionID,
+			"order_id":     orderID,
+		})
+	}
+}
+
+// GetOrder finds an order by its ID
+func (s *OrderServiceImpl) GetOrder(ctx context.Context, id string) (*orders.Order, error) {
+	order, err := s.orderRepo.FindByID(ctx, id)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find order", err, map[string]interface{}{
+			"order_id": id,
+		})
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// ListOrders finds all orders
+func (s *OrderServiceImpl) ListOrders(ctx context.Context) ([]orders.Order, error) {
+	ordersSlice, err := s.orderRepo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find all orders", err, nil)
+		return nil, fmt.Errorf("failed to find all orders: %w", err)
+	}
+
+	return ordersSlice, nil
+}
+
+// UpdateOrderStatus moves an order through its lifecycle. The new status is only
+// saved if the order is still in the status it was validated against, so of
+// concurrent transitions only one wins. Cancelling an order gives back the
+// coupon use it redeemed.
+func (s *OrderServiceImpl) UpdateOrderStatus(ctx context.Context, id string, req primary.UpdateOrderStatusRequest) (*orders.Order, error) {
+	s.logger.Info(ctx, "Updating order status", map[string]interface{}{
+		"order_id": id,
+		"status":   req.Status,
+	})
+
+	order, err := s.GetOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := order.Status
+	if err := order.TransitionTo(req.Status); err != nil {
+		return nil, err
+	}
+
+	if err := s.orderRepo.UpdateStatus(ctx, order, previous); err != nil {
+		if isDomainError(err, orders.ErrCodeOrderStatusConflict) || isDomainError(err, orders.ErrCodeOrderNotFound) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to save order: %w", err)
+	}
+
+	if order.Status == orders.StatusCancelled && order.Coupon != "" {
+		s.releaseOrderCoupon(ctx, order)
+	}
+
+	return order, nil
+}
+
+// releaseOrderCoupon gives back the coupon use redeemed for a cancelled order
+func (s *OrderServiceImpl) releaseOrderCoupon(ctx context.Context, order *orders.Order) {
+	if s.promotions == nil {
+		return
+	}
+
+	coupon, err := s.promotions.FindByCouponCode(ctx, order.Coupon)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to find coupon of cancelled order", err, map[string]interface{}{
+			"coupon":   order.Coupon,
+			"order_id": order.ID,
+		})
+		return
+	}
+
+	s.releaseCoupon(ctx, coupon.ID, order.ID)
+}
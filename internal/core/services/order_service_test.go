@@ -0,0 +1,471 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockCartRepository struct {
+	mock.Mock
+}
+
+func (m *MockCartRepository) Save(ctx context.Context, cart *orders.Cart) error {
+	args := m.Called(ctx, cart)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) FindByID(ctx context.Context, id string) (*orders.Cart, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Cart), args.Error(1)
+}
+
+func (m *MockCartRepository) MarkCheckedOut(ctx context.Context, id string, version int, orderID string) error {
+	args := m.Called(ctx, id, version, orderID)
+	return args.Error(0)
+}
+
+func (m *MockCartRepository) ClearCheckout(ctx context.Context, id, orderID string) error {
+	args := m.Called(ctx, id, orderID)
+	return args.Error(0)
+}
+
+type MockOrderRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrderRepository) Save(ctx context.Context, order *orders.Order) error {
+	args := m.Called(ctx, order)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) UpdateStatus(ctx context.Context, order *orders.Order, previous orders.Status) error {
+	args := m.Called(ctx, order, previous)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) FindByID(ctx context.Context, id string) (*orders.Order, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*orders.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) FindAll(ctx context.Context) ([]orders.Order, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]orders.Order), args.Error(1)
+}
+
+type orderServiceMocks struct {
+	carts      *MockCartRepository
+	orders     *MockOrderRepository
+	beers      *MockBeerRepository
+	currency   *MockCurrencyService
+	promotions *MockPromotionRepository
+}
+
+func newOrderServiceUnderTest() (primary.OrderService, orderServiceMocks) {
+	mocks := orderServiceMocks{
+		carts:      new(MockCartRepository),
+		orders:     new(MockOrderRepository),
+		beers:      new(MockBeerRepository),
+		currency:   new(MockCurrencyService),
+		promotions: new(MockPromotionRepository),
+	}
+
+	// No automatic promotions unless a test sets some up
+	mocks.promotions.On("FindAll", mock.Anything).Return([]promotions.Promotion{}, nil).Maybe()
+
+	service := NewOrderService(mocks.carts, mocks.orders, mocks.beers, mocks.currency, logger.NewNoOpLogger(),
+		WithCoupons(mocks.promotions))
+	return service, mocks
+}
+
+func TestCreateCartSuccess(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+
+	mocks.currency.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	mocks.carts.On("Save", ctx, mock.AnythingOfType("*orders.Cart")).Return(nil)
+
+	cart, err := service.CreateCart(ctx, primary.CreateCartRequest{Currency: "usd"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", cart.Currency)
+	mocks.carts.AssertExpectations(t)
+}
+
+func TestCreateCartInvalidCurrency(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+
+	mocks.currency.On("IsValidCurrency", ctx, "XXX").Return(false, nil)
+
+	cart, err := service.CreateCart(ctx, primary.CreateCartRequest{Currency: "XXX"})
+
+	assert.Nil(t, cart)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_CURRENCY", domainErr.Code)
+	mocks.carts.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestAddCartItemUnknownBeer(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("ExistsByID", ctx, 42).Return(false, nil)
+
+	_, err := service.AddCartItem(ctx, cart.ID, primary.CartItemRequest{BeerID: 42, Quantity: 6})
+
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "BEER_NOT_FOUND", domainErr.Code)
+}
+
+func TestCheckoutFreezesPricesAndFetchesEachRateOnce(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(1, 6)
+	cart.AddItem(2, 6)
+	cart.AddItem(3, 2)
+
+	cristal := &beers.Beer{ID: 1, Name: "Cristal", Price: 1000, Currency: "CLP"}
+	escudo := &beers.Beer{ID: 2, Name: "Escudo", Price: 800, Currency: "CLP"}
+	budweiser := &beers.Beer{ID: 3, Name: "Budweiser", Price: 4.5, Currency: "USD"}
+
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("FindByID", ctx, 1).Return(cristal, nil)
+	mocks.beers.On("FindByID", ctx, 2).Return(escudo, nil)
+	mocks.beers.On("FindByID", ctx, 3).Return(budweiser, nil)
+	mocks.currency.On("GetExchangeRate", ctx, "CLP", "USD").Return(0.001, nil).Once()
+	mocks.orders.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(nil)
+	mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, orders.StatusPlaced, order.Status)
+	assert.Len(t, order.Lines, 3)
+	assert.InDelta(t, 1.0, order.Lines[0].UnitPrice, 0.0001)
+	assert.Equal(t, 1.0, order.Lines[2].ExchangeRate)
+	assert.InDelta(t, 6+4.8+9, order.Total, 0.0001)
+	mocks.carts.AssertCalled(t, "MarkCheckedOut", ctx, cart.ID, cart.Version, order.ID)
+	mocks.currency.AssertNumberOfCalls(t, "GetExchangeRate", 1)
+}
+
+func TestCheckoutEmptyCart(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.Nil(t, order)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_EMPTY", domainErr.Code)
+	mocks.orders.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCheckoutExchangeRateError(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(1, 6)
+
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("FindByID", ctx, 1).Return(&beers.Beer{ID: 1, Price: 1000, Currency: "CLP"}, nil)
+	mocks.currency.On("GetExchangeRate", ctx, "CLP", "USD").Return(0.0, errors.New("api down"))
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.Nil(t, order)
+	assert.Error(t, err)
+	assert.False(t, cart.IsCheckedOut())
+}
+
+func TestCheckoutOfCartCheckedOutConcurrently(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(3, 2)
+
+	// The cart was still open when read, but another checkout claimed it first
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("FindByID", ctx, 3).Return(&beers.Beer{ID: 3, Price: 4.5, Currency: "USD"}, nil)
+	mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).
+		Return(beers.NewDomainError("CART_CHECKED_OUT", "Cart has already been checked out", nil))
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.Nil(t, order)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CHECKED_OUT", domainErr.Code)
+	mocks.orders.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCheckoutOfCartChangedSinceRead(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(3, 2)
+	cart.Version = 4
+
+	// An item was added after the lines were priced, so the claim of the
+	// version they were priced from fails
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("FindByID", ctx, 3).Return(&beers.Beer{ID: 3, Price: 4.5, Currency: "USD"}, nil)
+	mocks.carts.On("MarkCheckedOut", ctx, cart.ID, 4, mock.AnythingOfType("string")).
+		Return(beers.NewDomainError("CART_CONFLICT", "Cart has changed since it was read", nil))
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.Nil(t, order)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CONFLICT", domainErr.Code)
+	mocks.orders.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestCheckoutReopensCartWhenOrderIsNotSaved(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(3, 2)
+
+	mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	mocks.beers.On("FindByID", ctx, 3).Return(&beers.Beer{ID: 3, Price: 4.5, Currency: "USD"}, nil)
+	var claimedFor string
+	mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { claimedFor = args.String(3) }).
+		Return(nil)
+	mocks.orders.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(errors.New("connection reset"))
+	mocks.carts.On("ClearCheckout", ctx, cart.ID, mock.AnythingOfType("string")).Return(nil)
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	assert.Nil(t, order)
+	assert.Error(t, err)
+	mocks.carts.AssertCalled(t, "ClearCheckout", ctx, cart.ID, claimedFor)
+}
+
+func TestCheckoutWithCoupon(t *testing.T) {
+	ctx := context.Background()
+	stout := &beers.Beer{ID: 1, Name: "Stout", Brewery: "Kunstmann", Price: 10, Currency: "USD"}
+	lager := &beers.Beer{ID: 2, Name: "Lager", Brewery: "CCU", Price: 5, Currency: "USD"}
+
+	newCheckout := func(coupon promotions.Promotion) (primary.OrderService, orderServiceMocks, *orders.Cart) {
+		service, mocks := newOrderServiceUnderTest()
+		cart, _ := orders.NewCart("USD")
+		cart.AddItem(1, 2)
+		cart.AddItem(2, 4)
+
+		mocks.carts.On("FindByID", ctx, cart.ID).Return(cart, nil)
+		mocks.beers.On("FindByID", ctx, 1).Return(stout, nil)
+		mocks.beers.On("FindByID", ctx, 2).Return(lager, nil)
+		mocks.promotions.On("FindByCouponCode", ctx, "save20").Return(&coupon, nil)
+		return service, mocks, cart
+	}
+
+	t.Run("redeemed once with the order", func(t *testing.T) {
+		coupon := newTestPromotion("Kunstmann week", promotions.TypePercentage, 20, "")
+		coupon.Scope.Breweries = []string{"Kunstmann"}
+		_ = coupon.SetCoupon("SAVE20", 10)
+		service, mocks, cart := newCheckout(coupon)
+		mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+		mocks.promotions.On("Redeem", ctx, coupon.ID).Return(nil).Once()
+		mocks.orders.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(nil)
+
+		order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{Coupon: "save20"})
+
+		// Only the stout line is in scope: 20% of 20 USD
+		assert.NoError(t, err)
+		assert.Equal(t, "SAVE20", order.Coupon)
+		assert.InDelta(t, 4.0, order.Discount, 1e-9)
+		assert.InDelta(t, 36.0, order.Total, 1e-9)
+		mocks.promotions.AssertNumberOfCalls(t, "Redeem", 1)
+	})
+
+	t.Run("fixed coupon taken off the order once", func(t *testing.T) {
+		coupon := newTestPromotion("Two off", promotions.TypeFixed, 2, "USD")
+		_ = coupon.SetCoupon("SAVE20", 10)
+		service, mocks, cart := newCheckout(coupon)
+		mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+		mocks.promotions.On("Redeem", ctx, coupon.ID).Return(nil).Once()
+		mocks.orders.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(nil)
+
+		order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{Coupon: "save20"})
+
+		// Both lines are in scope, but 2 USD comes off the order, not off each line
+		assert.NoError(t, err)
+		assert.InDelta(t, 2.0, order.Discount, 1e-9)
+		assert.InDelta(t, 38.0, order.Total, 1e-9)
+	})
+
+	t.Run("released when the order is not saved", func(t *testing.T) {
+		coupon := newTestPromotion("Coupon", promotions.TypePercentage, 20, "")
+		_ = coupon.SetCoupon("SAVE20", 1)
+		service, mocks, cart := newCheckout(coupon)
+		mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+		mocks.promotions.On("Redeem", ctx, coupon.ID).Return(nil).Once()
+		mocks.orders.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(errors.New("database down"))
+		mocks.promotions.On("Release", ctx, coupon.ID).Return(nil).Once()
+		mocks.carts.On("ClearCheckout", ctx, cart.ID, mock.AnythingOfType("string")).Return(nil)
+
+		order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{Coupon: "save20"})
+
+		assert.Nil(t, order)
+		assert.Error(t, err)
+		mocks.promotions.AssertNumberOfCalls(t, "Release", 1)
+		mocks.carts.AssertNumberOfCalls(t, "ClearCheckout", 1)
+	})
+
+	t.Run("used up concurrently", func(t *testing.T) {
+		coupon := newTestPromotion("Coupon", promotions.TypePercentage, 20, "")
+		_ = coupon.SetCoupon("SAVE20", 1)
+		service, mocks, cart := newCheckout(coupon)
+		mocks.carts.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+		mocks.promotions.On("Redeem", ctx, coupon.ID).
+			Return(beers.NewDomainError(promotions.ErrCodeUsageLimitReached, "Promotion has reached its usage limit", nil))
+		mocks.carts.On("ClearCheckout", ctx, cart.ID, mock.AnythingOfType("string")).Return(nil)
+
+		order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{Coupon: "save20"})
+
+		assert.Nil(t, order)
+		assert.True(t, isDomainError(err, promotions.ErrCodeUsageLimitReached))
+		mocks.carts.AssertNumberOfCalls(t, "ClearCheckout", 1)
+		mocks.orders.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not applicable", func(t *testing.T) {
+		coupon := newTestPromotion("Other brewery", promotions.TypePercentage, 20, "")
+		coupon.Scope.Breweries = []string{"Austral"}
+		_ = coupon.SetCoupon("SAVE20", 0)
+		service, mocks, cart := newCheckout(coupon)
+
+		order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{Coupon: "save20"})
+
+		assert.Nil(t, order)
+		assert.True(t, isDomainError(err, promotions.ErrCodeCouponNotApplicable))
+		mocks.carts.AssertNotCalled(t, "MarkCheckedOut", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mocks.promotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	order, _ := orders.NewOrder("cart_1", "USD", []orders.OrderLine{{BeerID: 1, Quantity: 1, LineTotal: 1}})
+
+	mocks.orders.On("FindByID", ctx, order.ID).Return(order, nil)
+	mocks.orders.On("UpdateStatus", ctx, order, orders.StatusPlaced).Return(nil)
+
+	updated, err := service.UpdateOrderStatus(ctx, order.ID, primary.UpdateOrderStatusRequest{Status: orders.StatusPaid})
+
+	assert.NoError(t, err)
+	assert.Equal(t, orders.StatusPaid, updated.Status)
+
+	_, err = service.UpdateOrderStatus(ctx, order.ID, primary.UpdateOrderStatusRequest{Status: orders.StatusPlaced})
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_STATUS_TRANSITION", domainErr.Code)
+}
+
+func TestUpdateOrderStatusConflict(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	order, _ := orders.NewOrder("cart_1", "USD", []orders.OrderLine{{BeerID: 1, Quantity: 1, LineTotal: 1}})
+
+	// A concurrent request moved the order on after it was loaded
+	mocks.orders.On("FindByID", ctx, order.ID).Return(order, nil)
+	mocks.orders.On("UpdateStatus", ctx, order, orders.StatusPlaced).
+		Return(beers.NewDomainError("ORDER_STATUS_CONFLICT", "Order status changed concurrently", nil))
+
+	updated, err := service.UpdateOrderStatus(ctx, order.ID, primary.UpdateOrderStatusRequest{Status: orders.StatusCancelled})
+
+	assert.Nil(t, updated)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "ORDER_STATUS_CONFLICT", domainErr.Code)
+	mocks.promotions.AssertNotCalled(t, "Release", mock.Anything, mock.Anything)
+}
+
+func TestCancelOrderReleasesCoupon(t *testing.T) {
+	service, mocks := newOrderServiceUnderTest()
+	ctx := context.Background()
+	coupon := newTestPromotion("Coupon", promotions.TypePercentage, 20, "")
+	_ = coupon.SetCoupon("SAVE20", 1)
+	order, _ := orders.NewOrder("cart_1", "USD", []orders.OrderLine{{BeerID: 1, Quantity: 1, LineTotal: 10}})
+	order.ApplyCoupon("SAVE20", 2)
+
+	mocks.orders.On("FindByID", ctx, order.ID).Return(order, nil)
+	mocks.orders.On("UpdateStatus", ctx, order, orders.StatusPlaced).Return(nil)
+	mocks.promotions.On("FindByCouponCode", ctx, "SAVE20").Return(&coupon, nil)
+	mocks.promotions.On("Release", ctx, coupon.ID).Return(nil).Once()
+
+	updated, err := service.UpdateOrderStatus(ctx, order.ID, primary.UpdateOrderStatusRequest{Status: orders.StatusCancelled})
+
+	assert.NoError(t, err)
+	assert.Equal(t, orders.StatusCancelled, updated.Status)
+	mocks.promotions.AssertNumberOfCalls(t, "Release", 1)
+}
+
+func TestCheckoutWithAutomaticFixedPromotion(t *testing.T) {
+	ctx := context.Background()
+	cartRepo, orderRepo, beerRepo := new(MockCartRepository), new(MockOrderRepository), new(MockBeerRepository)
+	currencyService, promotionRepo := new(MockCurrencyService), new(MockPromotionRepository)
+	service := NewOrderService(cartRepo, orderRepo, beerRepo, currencyService, logger.NewNoOpLogger(),
+		WithCoupons(promotionRepo))
+
+	tenOff := newTestPromotion("Ten off", promotions.TypeFixed, 10, "USD")
+	tenOff.Scope.Breweries = []string{"Kunstmann"}
+	tenPercent := newTestPromotion("Ten percent", promotions.TypePercentage, 10, "")
+	promotionRepo.On("FindAll", ctx).Return([]promotions.Promotion{tenOff, tenPercent}, nil)
+
+	cart, _ := orders.NewCart("USD")
+	for id := 1; id <= 5; id++ {
+		brewery := "Kunstmann"
+		if id == 5 {
+			brewery = "CCU"
+		}
+		beerRepo.On("FindByID", ctx, id).
+			Return(&beers.Beer{ID: id, Name: "Beer", Brewery: brewery, Price: 10, Currency: "USD"}, nil)
+		_ = cart.AddItem(id, 2)
+	}
+	cartRepo.On("FindByID", ctx, cart.ID).Return(cart, nil)
+	cartRepo.On("MarkCheckedOut", ctx, cart.ID, cart.Version, mock.AnythingOfType("string")).Return(nil)
+	orderRepo.On("Save", ctx, mock.AnythingOfType("*orders.Order")).Return(nil)
+
+	order, err := service.Checkout(ctx, cart.ID, primary.CheckoutRequest{})
+
+	// Every line takes 10% off (18 USD each), then 10 USD comes off the four
+	// Kunstmann lines once rather than off each of them
+	assert.NoError(t, err)
+	for _, line := range order.Lines {
+		assert.InDelta(t, 18.0, line.LineTotal, 1e-9)
+	}
+	assert.InDelta(t, 10.0, order.Discount, 1e-9)
+	assert.InDelta(t, 80.0, order.Total, 1e-9)
+	assert.Empty(t, order.Coupon)
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PricingServiceImpl implements the PricingService primary port
+type PricingServiceImpl struct {
+	pricingRules secondary.PricingRuleRepository
+	beerRepo     secondary.BeerRepository
+	logger       secondary.Logger
+}
+
+// NewPricingService creates a new pricing service
+func NewPricingService(
+	pricingRules secondary.PricingRuleRepository,
+	beerRepo secondary.BeerRepository,
+	logger secondary.Logger,
+) primary.PricingService {
+	return &PricingServiceImpl{
+		pricingRules: pricingRules,
+		beerRepo:     beerRepo,
+		logger:       logger,
+	}
+}
+
+// SetPricingRule creates or replaces the pricing rule of a beer
+func (s *PricingServiceImpl) SetPricingRule(ctx context.Context, beerID int, req primary.PricingRuleRequest) (*pricing.Rule, error) {
+	s.logger.Info(ctx, "Setting pricing rule", map[string]interface{}{
+		"beer_id": beerID,
+		"packs":   len(req.Packs),
+		"tiers":   len(req.Tiers),
+	})
+
+	// Make sure the beer exists before attaching pricing to it
+	if _, err := s.beerRepo.FindByID(ctx, beerID); err != nil {
+		return nil, err
+	}
+
+	rule, err := pricing.NewRule(beerID, req.Packs, req.Tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.pricingRules.Save(ctx, rule); err != nil {
+		s.logger.Error(ctx, "Failed to save pricing rule", err, map[string]interface{}{
+			"beer_id": beerID,
+		})
+		return nil, fmt.Errorf("failed to save pricing rule: %w", err)
+	}
+
+	return rule, nil
+}
+
+// GetPricingRule finds the pricing rule of a beer
+func (s *PricingServiceImpl) GetPricingRule(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	return s.pricingRules.FindByBeerID(ctx, beerID)
+}
+
+// DeletePricingRule removes the pricing rule of a beer, reverting it to linear pricing
+func (s *PricingServiceImpl) DeletePricingRule(ctx context.Context, beerID int) error {
+	s.logger.Info(ctx, "Deleting pricing rule", map[string]interface{}{
+		"beer_id": beerID,
+	})
+
+	return s.pricingRules.Delete(ctx, beerID)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockPricingRuleRepository struct {
+	mock.Mock
+}
+
+func (m *MockPricingRuleRepository) Save(ctx context.Context, rule *pricing.Rule) error {
+	args := m.Called(ctx, rule)
+	return args.Error(0)
+}
+
+func (m *MockPricingRuleRepository) FindByBeerID(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	args := m.Called(ctx, beerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pricing.Rule), args.Error(1)
+}
+
+func (m *MockPricingRuleRepository) Delete(ctx context.Context, beerID int) error {
+	args := m.Called(ctx, beerID)
+	return args.Error(0)
+}
+
+func TestCalculateBoxPriceWithPackRule(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockRules := new(MockPricingRuleRepository)
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithPricingRules(mockRules))
+
+	ctx := context.Background()
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Price: 1000, Currency: testCurrency}
+	rule, _ := pricing.NewRule(testBeerID,
+		[]pricing.PackSKU{{SKU: "SIX", Size: 6, Price: 5000}, {SKU: "TWELVE", Size: 12, Price: 9000}},
+		[]pricing.DiscountTier{{MinUnits: 48, Percent: 5}},
+	)
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockCurrency.On("GetExchangeRate", ctx, testCurrency, "USD").Return(0.001, nil)
+	mockRules.On("FindByBeerID", ctx, testBeerID).Return(rule, nil)
+
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID: testBeerID, Quantity: 50, Currency: "USD",
+	})
+
+	// 50 units = 4 x 12-pack + 2 singles = 38000 CLP, minus 5% = 36100 CLP
+	assert.NoError(t, err)
+	assert.InDelta(t, 38.0, result.Subtotal, 0.0001)
+	assert.Equal(t, 5.0, result.DiscountPercent)
+	assert.InDelta(t, 1.9, result.Discount, 0.0001)
+	assert.InDelta(t, 36.1, result.TotalPrice, 0.0001)
+	assert.Len(t, result.Breakdown, 3)
+	assert.Equal(t, "TWELVE", result.Breakdown[0].SKU)
+	assert.Equal(t, 4, result.Breakdown[0].Count)
+	assert.InDelta(t, 9.0, result.Breakdown[0].Price, 0.0001)
+	assert.Equal(t, pricing.LineTypeSingle, result.Breakdown[1].Type)
+	assert.Equal(t, pricing.LineTypeDiscount, result.Breakdown[2].Type)
+}
+
+func TestCalculateBoxPriceWithoutRuleIsLinear(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockRules := new(MockPricingRuleRepository)
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithPricingRules(mockRules))
+
+	ctx := context.Background()
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Price: testPrice, Currency: testCurrency}
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockRules.On("FindByBeerID", ctx, testBeerID).
+		Return(nil, beers.NewDomainError(pricing.ErrCodeRuleNotFound, "no rule", nil))
+
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID: testBeerID, Quantity: 6, Currency: testCurrency,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testPrice*6, result.TotalPrice)
+	assert.Len(t, result.Breakdown, 1)
+	assert.Zero(t, result.Discount)
+}
+
+func TestSetPricingRule(t *testing.T) {
+	mockRules := new(MockPricingRuleRepository)
+	mockRepo := new(MockBeerRepository)
+	service := NewPricingService(mockRules, mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(&beers.Beer{ID: testBeerID}, nil)
+	mockRules.On("Save", ctx, mock.AnythingOfType("*pricing.Rule")).Return(nil)
+
+	rule, err := service.SetPricingRule(ctx, testBeerID, primary.PricingRuleRequest{
+		Packs: []pricing.PackSKU{{SKU: "SIX", Size: 6, Price: 5000}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testBeerID, rule.BeerID)
+	mockRules.AssertExpectations(t)
+}
+
+func TestSetPricingRuleInvalid(t *testing.T) {
+	mockRules := new(MockPricingRuleRepository)
+	mockRepo := new(MockBeerRepository)
+	service := NewPricingService(mockRules, mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(&beers.Beer{ID: testBeerID}, nil)
+
+	_, err := service.SetPricingRule(ctx, testBeerID, primary.PricingRuleRequest{
+		Packs: []pricing.PackSKU{{SKU: "ONE", Size: 1, Price: 1}},
+	})
+
+	_, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	mockRules.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PromotionServiceImpl implements the PromotionService primary port
+type PromotionServiceImpl struct {
+	promotionRepo secondary.PromotionRepository
+	logger        secondary.Logger
+}
+
+// NewPromotionService creates a new promotion service
+func NewPromotionService(
+	promotionRepo secondary.PromotionRepository,
+	logger secondary.Logger,
+) primary.PromotionService {
+	return &PromotionServiceImpl{
+		promotionRepo: promotionRepo,
+		logger:        logger,
+	}
+}
+
+// CreatePromotion creates a new promotion
+func (s *PromotionServiceImpl) CreatePromotion(ctx context.Context, req primary.PromotionRequest) (*promotions.Promotion, error) {
+	s.logger.Info(ctx, "Creating promotion", map[string]interface{}{
+		"name": req.Name,
+		"type": req.Type,
+	})
+
+	promotion, err := promotions.NewPromotion(req.Name, req.Type, req.Value, req.Currency, req.StartsAt, req.EndsAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := applyPromotionRequest(promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// GetPromotion finds a promotion by its ID
+func (s *PromotionServiceImpl) GetPromotion(ctx context.Context, id string) (*promotions.Promotion, error) {
+	return s.promotionRepo.FindByID(ctx, id)
+}
+
+// ListPromotions returns every promotion
+func (s *PromotionServiceImpl) ListPromotions(ctx context.Context) ([]promotions.Promotion, error) {
+	result, err := s.promotionRepo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list promotions", err, nil)
+		return nil, fmt.Errorf("failed to list promotions: %w", err)
+	}
+
+	return result, nil
+}
+
+// UpdatePromotion replaces the terms of a promotion, keeping its usage count
+func (s *PromotionServiceImpl) UpdatePromotion(ctx context.Context, id string, req primary.PromotionRequest) (*promotions.Promotion, error) {
+	s.logger.Info(ctx, "Updating promotion", map[string]interface{}{
+		"promotion_id": id,
+	})
+
+	promotion, err := s.promotionRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := promotion.Update(req.Name, req.Type, req.Value, req.Currency, req.StartsAt, req.EndsAt); err != nil {
+		return nil, err
+	}
+
+	if err := applyPromotionRequest(promotion, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, promotion); err != nil {
+		return nil, err
+	}
+
+	return promotion, nil
+}
+
+// DeletePromotion removes a promotion
+func (s *PromotionServiceImpl) DeletePromotion(ctx context.Context, id string) error {
+	s.logger.Info(ctx, "Deleting promotion", map[string]interface{}{
+		"promotion_id": id,
+	})
+
+	return s.promotionRepo.Delete(ctx, id)
+}
+
+// save persists a promotion, passing coupon conflicts through as domain errors
+func (s *PromotionServiceImpl) save(ctx context.Context, promotion *promotions.Promotion) error {
+	err := s.promotionRepo.Save(ctx, promotion)
+	if err == nil {
+		return nil
+	}
+
+	if isDomainError(err, promotions.ErrCodeCouponExists) {
+		return err
+	}
+
+	s.logger.Error(ctx, "Failed to save promotion", err, map[string]interface{}{
+		"promotion_id": promotion.ID,
+	})
+	return fmt.Errorf("failed to save promotion: %w", err)
+}
+
+// applyPromotionRequest copies the optional parts of a request onto a promotion
+func applyPromotionRequest(promotion *promotions.Promotion, req primary.PromotionRequest) error {
+	promotion.Description = req.Description
+	promotion.Scope = req.Scope
+
+	return promotion.SetCoupon(req.CouponCode, req.UsageLimit)
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockPromotionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPromotionRepository) Save(ctx context.Context, promotion *promotions.Promotion) error {
+	args := m.Called(ctx, promotion)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) FindByID(ctx context.Context, id string) (*promotions.Promotion, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindByCouponCode(ctx context.Context, code string) (*promotions.Promotion, error) {
+	args := m.Called(ctx, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) FindAll(ctx context.Context) ([]promotions.Promotion, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]promotions.Promotion), args.Error(1)
+}
+
+func (m *MockPromotionRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Redeem(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPromotionRepository) Release(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func newTestPromotion(name string, promoType promotions.Type, value float64, currency string) promotions.Promotion {
+	promotion, _ := promotions.NewPromotion(name, promoType, value, currency, time.Time{}, time.Time{})
+	return *promotion
+}
+
+func TestCreatePromotion(t *testing.T) {
+	mockRepo := new(MockPromotionRepository)
+	service := NewPromotionService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	mockRepo.On("Save", ctx, mock.AnythingOfType("*promotions.Promotion")).Return(nil).Once()
+
+	promotion, err := service.CreatePromotion(ctx, primary.PromotionRequest{
+		Name:       "Welcome",
+		Type:       promotions.TypePercentage,
+		Value:      10,
+		Scope:      promotions.Scope{Breweries: []string{"CCU"}},
+		CouponCode: "welcome10",
+		UsageLimit: 100,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "WELCOME10", promotion.CouponCode)
+	assert.Equal(t, []string{"CCU"}, promotion.Scope.Breweries)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestCreatePromotionErrors(t *testing.T) {
+	mockRepo := new(MockPromotionRepository)
+	service := NewPromotionService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	_, err := service.CreatePromotion(ctx, primary.PromotionRequest{Name: "Bad", Type: promotions.TypeFixed, Value: 5})
+	_, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+
+	conflict := beers.NewDomainError(promotions.ErrCodeCouponExists, "Coupon code WELCOME is already in use", nil)
+	mockRepo.On("Save", ctx, mock.Anything).Return(conflict).Once()
+
+	_, err = service.CreatePromotion(ctx, primary.PromotionRequest{
+		Name: "Dup", Type: promotions.TypePercentage, Value: 5, CouponCode: "WELCOME",
+	})
+	assert.Equal(t, conflict, err)
+
+	mockRepo.On("Save", ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+	_, err = service.CreatePromotion(ctx, primary.PromotionRequest{Name: "Promo", Type: promotions.TypePercentage, Value: 5})
+	assert.Contains(t, err.Error(), "failed to save promotion")
+}
+
+func TestUpdatePromotion(t *testing.T) {
+	mockRepo := new(MockPromotionRepository)
+	service := NewPromotionService(mockRepo, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	existing := newTestPromotion("Old", promotions.TypePercentage, 5, "")
+	existing.UsageCount = 3
+
+	mockRepo.On("FindByID", ctx, existing.ID).Return(&existing, nil).Once()
+	mockRepo.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+	promotion, err := service.UpdatePromotion(ctx, existing.ID, primary.PromotionRequest{
+		Name: "New", Type: promotions.TypeFixed, Value: 2, Currency: "usd",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, promotion.ID)
+	assert.Equal(t, "New", promotion.Name)
+	assert.Equal(t, "USD", promotion.Currency)
+	assert.Equal(t, 3, promotion.UsageCount)
+
+	notFound := beers.NewDomainError(promotions.ErrCodeNotFound, "Promotion not found", nil)
+	mockRepo.On("FindByID", ctx, "promo_missing").Return(nil, notFound).Once()
+
+	_, err = service.UpdatePromotion(ctx, "promo_missing", primary.PromotionRequest{})
+	assert.Equal(t, notFound, err)
+}
+
+func TestCalculateBoxPriceWithPromotions(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockPromotions := new(MockPromotionRepository)
+	service := NewBeerService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithPromotions(mockPromotions))
+
+	ctx := context.Background()
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Brewery: testBrewery, Country: testCountry, Price: 10, Currency: "USD"}
+
+	fixed := newTestPromotion("Two off", promotions.TypeFixed, 2, "EUR")
+	percentage := newTestPromotion("Brewery week", promotions.TypePercentage, 10, "")
+	percentage.Scope.Breweries = []string{testBrewery}
+	otherBrewery := newTestPromotion("Other brewery", promotions.TypePercentage, 50, "")
+	otherBrewery.Scope.Breweries = []string{"CCU"}
+	coupon := newTestPromotion("Coupon", promotions.TypePercentage, 20, "")
+	_ = coupon.SetCoupon("SAVE20", 0)
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockPromotions.On("FindAll", ctx).Return([]promotions.Promotion{fixed, percentage, otherBrewery, coupon}, nil)
+	mockCurrency.On("GetExchangeRate", ctx, "EUR", "USD").Return(1.1, nil).Once()
+
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID: testBeerID, Quantity: 6, Currency: "USD",
+	})
+
+	// 60 USD minus 10% = 54, minus 2 EUR (2.2 USD) = 51.8
+	assert.NoError(t, err)
+	assert.InDelta(t, 8.2, result.PromotionDiscount, 1e-9)
+	assert.InDelta(t, 51.8, result.TotalPrice, 1e-9)
+	assert.Len(t, result.Promotions, 4)
+
+	outcomes := map[string]promotions.Outcome{}
+	for _, outcome := range result.Promotions {
+		outcomes[outcome.PromotionID] = outcome
+	}
+	assert.True(t, outcomes[percentage.ID].Applied)
+	assert.InDelta(t, 6.0, outcomes[percentage.ID].Discount, 1e-9)
+	assert.True(t, outcomes[fixed.ID].Applied)
+	assert.Equal(t, promotions.ReasonOutOfScope, outcomes[otherBrewery.ID].Reason)
+	assert.Equal(t, promotions.ReasonCouponRequired, outcomes[coupon.ID].Reason)
+	mockCurrency.AssertExpectations(t)
+	mockPromotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything)
+}
+
+func TestCalculateBoxPriceWithCoupon(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockPromotions := new(MockPromotionRepository)
+	service := NewBeerService(mockRepo, new(MockCurrencyService), logger.NewNoOpLogger(), WithPromotions(mockPromotions))
+
+	ctx := context.Background()
+	beer := &beers.Beer{ID: testBeerID, Name: testBeerName, Price: 10, Currency: "USD"}
+
+	coupon := newTestPromotion("Coupon", promotions.TypePercentage, 20, "")
+	_ = coupon.SetCoupon("SAVE20", 1)
+	usedUp := newTestPromotion("Used up", promotions.TypePercentage, 50, "")
+	_ = usedUp.SetCoupon("HALF", 1)
+	usedUp.UsageCount = 1
+
+	mockRepo.On("FindByID", ctx, testBeerID).Return(beer, nil)
+	mockPromotions.On("FindAll", ctx).Return([]promotions.Promotion{coupon, usedUp}, nil)
+
+	// Quoting a price does not use the coupon up, however often it is asked
+	for i := 0; i < 2; i++ {
+		result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+			BeerID: testBeerID, Quantity: 1, Currency: "USD", Coupon: "save20",
+		})
+
+		assert.NoError(t, err)
+		assert.InDelta(t, 8.0, result.TotalPrice, 1e-9)
+		assert.True(t, result.Promotions[0].Applied)
+	}
+
+	// A coupon with no uses left no longer applies
+	result, err := service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID: testBeerID, Quantity: 1, Currency: "USD", Coupon: "HALF",
+	})
+
+	assert.NoError(t, err)
+	assert.InDelta(t, 10.0, result.TotalPrice, 1e-9)
+	assert.Equal(t, promotions.ReasonUsageLimitReached, result.Promotions[1].Reason)
+
+	result, err = service.CalculateBoxPrice(ctx, primary.CalculateBoxPriceRequest{
+		BeerID: testBeerID, Quantity: 1, Currency: "USD", Coupon: "NOPE",
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Promotions, 3)
+	assert.Equal(t, promotions.ReasonUnknownCoupon, result.Promotions[2].Reason)
+	mockPromotions.AssertNotCalled(t, "Redeem", mock.Anything, mock.Anything)
+}
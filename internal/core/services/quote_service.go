@@ -0,0 +1,198 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+const (
+	// Maximum number of lines accepted in a single quote
+	maxQuoteLines = 100
+)
+
+// QuoteServiceImpl implements the QuoteService primary port
+type QuoteServiceImpl struct {
+	beerRepo        secondary.BeerRepository
+	currencyService secondary.CurrencyService
+	pricingRules    secondary.PricingRuleRepository
+	promotions      secondary.PromotionRepository
+	logger          secondary.Logger
+}
+
+// QuoteServiceOption configures optional collaborators of the quote service
+type QuoteServiceOption func(*QuoteServiceImpl)
+
+// WithQuotePricingRules prices quote lines with the pack SKUs and discount tiers of each beer
+func WithQuotePricingRules(pricingRules secondary.PricingRuleRepository) QuoteServiceOption {
+	return func(s *QuoteServiceImpl) {
+		s.pricingRules = pricingRules
+	}
+}
+
+// WithQuotePromotions takes automatic promotions off quote lines, as off box prices
+func WithQuotePromotions(promotionRepo secondary.PromotionRepository) QuoteServiceOption {
+	return func(s *QuoteServiceImpl) {
+		s.promotions = promotionRepo
+	}
+}
+
+// NewQuoteService creates a new quote service
+func NewQuoteService(
+	beerRepo secondary.BeerRepository,
+	currencyService secondary.CurrencyService,
+	logger secondary.Logger,
+	opts ...QuoteServiceOption,
+) primary.QuoteService {
+	service := &QuoteServiceImpl{
+		beerRepo:        beerRepo,
+		currencyService: currencyService,
+		logger:          logger,
+	}
+
+	for _, opt := range opts {
+		opt(service)
+	}
+
+	return service
+}
+
+// CreateQuote prices every requested line in the target currency.
+// Lines that cannot be priced carry their own error instead of failing the quote.
+func (s *QuoteServiceImpl) CreateQuote(ctx context.Context, req primary.QuoteRequest) (*primary.QuoteResponse, error) {
+	currency := strings.ToUpper(strings.TrimSpace(req.Currency))
+
+	s.logger.Info(ctx, "Creating quote", map[string]interface{}{
+		"currency": currency,
+		"lines":    len(req.Items),
+	})
+
+	if len(currency) != 3 {
+		return nil, beers.NewValidationError("currency", beers.ErrMustBe3Characters)
+	}
+
+	if len(req.Items) == 0 {
+		return nil, beers.NewValidationError("items", beers.ErrCannotBeEmpty)
+	}
+
+	if len(req.Items) > maxQuoteLines {
+		return nil, beers.NewValidationError("items", fmt.Sprintf("cannot exceed %d lines", maxQuoteLines))
+	}
+
+	isValid, err := s.currencyService.IsValidCurrency(ctx, currency)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to validate currency", err, map[string]interface{}{
+			"currency": currency,
+		})
+		return nil, fmt.Errorf("failed to validate currency: %w", err)
+	}
+
+	if !isValid {
+		return nil, beers.NewDomainError("INVALID_CURRENCY", "Invalid currency code", nil)
+	}
+
+	rates := newExchangeRates(s.currencyService, currency)
+	response := &primary.QuoteResponse{
+		Currency: currency,
+		Lines:    make([]primary.QuoteLine, 0, len(req.Items)),
+	}
+
+	for _, item := range req.Items {
+		line := s.priceLine(ctx, rates, item)
+		if line.Error != nil {
+			response.FailedLines++
+		} else {
+			response.PricedLines++
+			response.GrandTotal += line.LineTotal
+		}
+		response.Lines = append(response.Lines, line)
+	}
+
+	s.logger.Info(ctx, "Quote created", map[string]interface{}{
+		"currency":     currency,
+		"grand_total":  response.GrandTotal,
+		"failed_lines": response.FailedLines,
+	})
+
+	return response, nil
+}
+
+// priceLine prices a single quote line, recording any failure on the line itself
+func (s *QuoteServiceImpl) priceLine(ctx context.Context, rates *exchangeRates, item primary.QuoteItemRequest) primary.QuoteLine {
+	line := primary.QuoteLine{
+		BeerID:   item.BeerID,
+		Quantity: item.Quantity,
+	}
+
+	if item.BeerID < 1 {
+		line.Error = quoteLineError(beers.NewValidationError("beer_id", beers.ErrMustBeGreaterThanZero))
+		return line
+	}
+
+	beer, err := s.beerRepo.FindByID(ctx, item.BeerID)
+	if err != nil {
+		s.logger.Warn(ctx, "Quote line beer lookup failed", map[string]interface{}{
+			"beer_id": item.BeerID,
+			"error":   err.Error(),
+		})
+		line.Error = quoteLineError(err)
+		return line
+	}
+
+	line.BeerName = beer.Name
+	line.SourceCurrency = beer.Currency
+
+	rate, err := rates.Rate(ctx, beer.Currency)
+	if err != nil {
+		s.logger.Warn(ctx, "Quote line exchange rate unavailable", map[string]interface{}{
+			"beer_id": item.BeerID,
+			"from":    beer.Currency,
+			"to":      rates.target,
+			"error":   err.Error(),
+		})
+		line.Error = &primary.QuoteLineError{
+			Code:    "EXCHANGE_RATE_UNAVAILABLE",
+			Message: fmt.Sprintf("Exchange rate from %s to %s is unavailable", beer.Currency, rates.target),
+		}
+		return line
+	}
+
+	price, err := s.pricer().price(ctx, rates, beer, item.Quantity, rate, "")
+	if err != nil {
+		line.Error = quoteLineError(err)
+		return line
+	}
+
+	line.UnitPrice = price.UnitPrice
+	line.LineTotal = price.TotalPrice
+	if beer.Currency != rates.target {
+		line.ExchangeRate = rate
+	}
+
+	return line
+}
+
+// pricer returns the box pricer shared with box prices and orders
+func (s *QuoteServiceImpl) pricer() boxPricer {
+	return boxPricer{pricingRules: s.pricingRules, promotions: s.promotions, logger: s.logger}
+}
+
+// quoteLineError converts a domain error into a quote line error
+func quoteLineError(err error) *primary.QuoteLineError {
+	var validationErr *beers.ValidationError
+	if errors.As(err, &validationErr) {
+		return &primary.QuoteLineError{Code: "VALIDATION_ERROR", Message: validationErr.Error()}
+	}
+
+	var domainErr *beers.DomainError
+	if errors.As(err, &domainErr) {
+		return &primary.QuoteLineError{Code: domainErr.Code, Message: domainErr.Message}
+	}
+
+	return &primary.QuoteLineError{Code: "PRICING_FAILED", Message: "Line could not be priced"}
+}
@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+func TestCreateQuoteMixedBox(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	service := NewQuoteService(mockRepo, mockCurrency, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	cristal := &beers.Beer{ID: 1, Name: "Cristal", Price: 1000, Currency: "CLP"}
+	escudo := &beers.Beer{ID: 2, Name: "Escudo", Price: 800, Currency: "CLP"}
+	heineken := &beers.Beer{ID: 3, Name: "Heineken", Price: 2.5, Currency: "EUR"}
+
+	mockCurrency.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	mockRepo.On("FindByID", ctx, 1).Return(cristal, nil)
+	mockRepo.On("FindByID", ctx, 2).Return(escudo, nil)
+	mockRepo.On("FindByID", ctx, 3).Return(heineken, nil)
+	mockCurrency.On("GetExchangeRate", ctx, "CLP", "USD").Return(0.001, nil).Once()
+	mockCurrency.On("GetExchangeRate", ctx, "EUR", "USD").Return(1.2, nil).Once()
+
+	response, err := service.CreateQuote(ctx, primary.QuoteRequest{
+		Currency: "usd",
+		Items: []primary.QuoteItemRequest{
+			{BeerID: 1, Quantity: 6},
+			{BeerID: 2, Quantity: 6},
+			{BeerID: 3, Quantity: 6},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "USD", response.Currency)
+	assert.Equal(t, 3, response.PricedLines)
+	assert.Equal(t, 0, response.FailedLines)
+	assert.InDelta(t, 6+4.8+18, response.GrandTotal, 0.0001)
+	assert.InDelta(t, 1.2, response.Lines[2].ExchangeRate, 0.0001)
+	mockCurrency.AssertNumberOfCalls(t, "GetExchangeRate", 2)
+}
+
+func TestCreateQuoteUsesPackPricing(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	mockRules := new(MockPricingRuleRepository)
+	service := NewQuoteService(mockRepo, mockCurrency, logger.NewNoOpLogger(), WithQuotePricingRules(mockRules))
+	ctx := context.Background()
+
+	cristal := &beers.Beer{ID: 1, Name: "Cristal", Price: 1000, Currency: "CLP"}
+	escudo := &beers.Beer{ID: 2, Name: "Escudo", Price: 800, Currency: "CLP"}
+	rule, _ := pricing.NewRule(1,
+		[]pricing.PackSKU{{SKU: "TWELVE", Size: 12, Price: 9000}},
+		[]pricing.DiscountTier{{MinUnits: 48, Percent: 5}},
+	)
+
+	mockCurrency.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	mockRepo.On("FindByID", ctx, 1).Return(cristal, nil)
+	mockRepo.On("FindByID", ctx, 2).Return(escudo, nil)
+	mockCurrency.On("GetExchangeRate", ctx, "CLP", "USD").Return(0.001, nil).Once()
+	mockRules.On("FindByBeerID", ctx, 1).Return(rule, nil)
+	mockRules.On("FindByBeerID", ctx, 2).
+		Return(nil, beers.NewDomainError(pricing.ErrCodeRuleNotFound, "no rule", nil))
+
+	response, err := service.CreateQuote(ctx, primary.QuoteRequest{
+		Currency: "USD",
+		Items: []primary.QuoteItemRequest{
+			{BeerID: 1, Quantity: 50},
+			{BeerID: 2, Quantity: 6},
+		},
+	})
+
+	// 50 units = 4 x 12-pack + 2 singles = 38000 CLP, minus 5% = 36100 CLP
+	assert.NoError(t, err)
+	assert.Equal(t, 2, response.PricedLines)
+	assert.InDelta(t, 36.1, response.Lines[0].LineTotal, 0.0001)
+	assert.InDelta(t, 1.0, response.Lines[0].UnitPrice, 0.0001)
+	assert.InDelta(t, 4.8, response.Lines[1].LineTotal, 0.0001)
+	assert.InDelta(t, 36.1+4.8, response.GrandTotal, 0.0001)
+}
+
+func TestCreateQuoteReportsLineErrors(t *testing.T) {
+	mockRepo := new(MockBeerRepository)
+	mockCurrency := new(MockCurrencyService)
+	service := NewQuoteService(mockRepo, mockCurrency, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	budweiser := &beers.Beer{ID: 1, Name: "Budweiser", Price: 4.5, Currency: "USD"}
+	heineken := &beers.Beer{ID: 3, Name: "Heineken", Price: 2.5, Currency: "EUR"}
+	stella := &beers.Beer{ID: 4, Name: "Stella", Price: 3.2, Currency: "EUR"}
+
+	mockCurrency.On("IsValidCurrency", ctx, "USD").Return(true, nil)
+	mockRepo.On("FindByID", ctx, 1).Return(budweiser, nil)
+	mockRepo.On("FindByID", ctx, 2).Return(nil, beers.NewDomainError("BEER_NOT_FOUND", "Beer with ID 2 not found", nil))
+	mockRepo.On("FindByID", ctx, 3).Return(heineken, nil)
+	mockRepo.On("FindByID", ctx, 4).Return(stella, nil)
+	mockCurrency.On("GetExchangeRate", ctx, "EUR", "USD").Return(0.0, errors.New("api down")).Once()
+
+	response, err := service.CreateQuote(ctx, primary.QuoteRequest{
+		Currency: "USD",
+		Items: []primary.QuoteItemRequest{
+			{BeerID: 1, Quantity: 2},
+			{BeerID: 2, Quantity: 6},
+			{BeerID: 3, Quantity: 6},
+			{BeerID: 4, Quantity: 6},
+			{BeerID: 1, Quantity: 0},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.PricedLines)
+	assert.Equal(t, 4, response.FailedLines)
+	assert.InDelta(t, 9.0, response.GrandTotal, 0.0001)
+	assert.Equal(t, "BEER_NOT_FOUND", response.Lines[1].Error.Code)
+	assert.Equal(t, "EXCHANGE_RATE_UNAVAILABLE", response.Lines[2].Error.Code)
+	assert.Equal(t, "EXCHANGE_RATE_UNAVAILABLE", response.Lines[3].Error.Code)
+	assert.Equal(t, "VALIDATION_ERROR", response.Lines[4].Error.Code)
+	mockCurrency.AssertNumberOfCalls(t, "GetExchangeRate", 1)
+}
+
+func TestCreateQuoteWithoutItems(t *testing.T) {
+	service := NewQuoteService(new(MockBeerRepository), new(MockCurrencyService), logger.NewNoOpLogger())
+
+	response, err := service.CreateQuote(context.Background(), primary.QuoteRequest{Currency: "USD"})
+
+	assert.Nil(t, response)
+	validationErr, ok := err.(*beers.ValidationError)
+	assert.True(t, ok)
+	assert.Equal(t, "items", validationErr.Field)
+}
+
+func TestCreateQuoteInvalidCurrency(t *testing.T) {
+	mockCurrency := new(MockCurrencyService)
+	service := NewQuoteService(new(MockBeerRepository), mockCurrency, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	mockCurrency.On("IsValidCurrency", ctx, "XXX").Return(false, nil)
+
+	_, err := service.CreateQuote(ctx, primary.QuoteRequest{
+		Currency: "XXX",
+		Items:    []primary.QuoteItemRequest{{BeerID: 1, Quantity: 1}},
+	})
+
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "INVALID_CURRENCY", domainErr.Code)
+}
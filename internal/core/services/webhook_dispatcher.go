@@ -0,0 +1,193 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// WebhookDispatcherSettings tunes the webhook dispatcher
+type WebhookDispatcherSettings struct {
+	// PollInterval is how often the outbox and due deliveries are checked
+	PollInterval time.Duration
+	// BatchSize bounds the events relayed and deliveries attempted per poll
+	BatchSize int
+	// Lease keeps a claimed delivery from being claimed again; it must outlast a send
+	Lease time.Duration
+	// Retry spaces out the attempts of failed deliveries
+	Retry webhooks.RetryPolicy
+}
+
+// WebhookDispatcher moves catalogue events from the outbox to the webhook
+// subscriptions that want them and posts them with signed requests.
+//
+// Delivery is at least once: a crash after a partner acknowledged an event but
+// before the delivery was saved sends it again, so partners deduplicate on the
+// event ID header.
+type WebhookDispatcher struct {
+	outbox        secondary.EventOutbox
+	subscriptions secondary.WebhookSubscriptionRepository
+	deliveries    secondary.WebhookDeliveryRepository
+	sender        secondary.WebhookSender
+	settings      WebhookDispatcherSettings
+	logger        secondary.Logger
+	now           func() time.Time
+}
+
+// NewWebhookDispatcher creates a new webhook dispatcher
+func NewWebhookDispatcher(
+	outbox secondary.EventOutbox,
+	subscriptions secondary.WebhookSubscriptionRepository,
+	deliveries secondary.WebhookDeliveryRepository,
+	sender secondary.WebhookSender,
+	settings WebhookDispatcherSettings,
+	logger secondary.Logger,
+) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		outbox:        outbox,
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		sender:        sender,
+		settings:      settings,
+		logger:        logger,
+		now:           time.Now,
+	}
+}
+
+// Run dispatches every poll interval until ctx is done
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.settings.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.DispatchOnce(ctx); err != nil && ctx.Err() == nil {
+			d.logger.Error(ctx, "Failed to dispatch webhooks", err, nil)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchOnce relays the pending outbox events into deliveries, then attempts
+// the deliveries that are due
+func (d *WebhookDispatcher) DispatchOnce(ctx context.Context) error {
+	if _, err := d.outbox.Relay(ctx, d.settings.BatchSize, d.enqueue); err != nil {
+		return fmt.Errorf("failed to relay outbox: %w", err)
+	}
+
+	due, err := d.deliveries.ClaimDue(ctx, d.now(), d.settings.BatchSize, d.settings.Lease)
+	if err != nil {
+		return fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+
+	// Partners are independent, so a slow one does not hold up the others
+	var wg sync.WaitGroup
+	for i := range due {
+		wg.Add(1)
+		go func(delivery *webhooks.Delivery) {
+			defer wg.Done()
+			d.attempt(ctx, delivery)
+		}(&due[i])
+	}
+	wg.Wait()
+
+	return nil
+}
+
+// enqueue creates a delivery of each event to every subscription accepting it
+func (d *WebhookDispatcher) enqueue(ctx context.Context, pending []events.Event) error {
+	subscriptions, err := d.subscriptions.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	now := d.now()
+	for _, event := range pending {
+		for i := range subscriptions {
+			if !subscriptions[i].Accepts(event.Type) {
+				continue
+			}
+
+			if err := d.deliveries.Save(ctx, webhooks.NewDelivery(&subscriptions[i], event, now)); err != nil {
+				return fmt.Errorf("failed to save webhook delivery: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// attempt posts a delivery to its subscription and records the outcome
+func (d *WebhookDispatcher) attempt(ctx context.Context, delivery *webhooks.Delivery) {
+	fields := map[string]interface{}{
+		"delivery_id":     delivery.ID,
+		"subscription_id": delivery.SubscriptionID,
+		"event_id":        delivery.Event.ID,
+	}
+
+	subscription, err := d.subscriptions.FindByID(ctx, delivery.SubscriptionID)
+	switch {
+	case isDomainError(err, webhooks.ErrCodeSubscriptionNotFound):
+		delivery.Abandon("subscription deleted", d.now())
+	case err != nil:
+		d.logger.Error(ctx, "Failed to load webhook subscription", err, fields)
+		return
+	default:
+		d.send(ctx, subscription, delivery)
+	}
+
+	if err := d.deliveries.Save(ctx, delivery); err != nil {
+		d.logger.Error(ctx, "Failed to save webhook delivery", err, fields)
+		return
+	}
+
+	fields["attempts"] = delivery.Attempts
+	fields["status_code"] = delivery.LastStatusCode
+	switch delivery.Status {
+	case webhooks.StatusDelivered:
+		d.logger.Debug(ctx, "Webhook delivered", fields)
+	case webhooks.StatusDead:
+		fields["error"] = delivery.LastError
+		d.logger.Warn(ctx, "Webhook delivery dead-lettered", fields)
+	default:
+		fields["error"] = delivery.LastError
+		fields["next_attempt_at"] = delivery.NextAttemptAt
+		d.logger.Info(ctx, "Webhook delivery failed, will retry", fields)
+	}
+}
+
+// send signs and posts the event of a delivery, updating the delivery with the result
+func (d *WebhookDispatcher) send(ctx context.Context, subscription *webhooks.Subscription, delivery *webhooks.Delivery) {
+	body, err := json.Marshal(delivery.Event)
+	if err != nil {
+		delivery.Abandon(fmt.Sprintf("failed to encode event: %v", err), d.now())
+		return
+	}
+
+	headers := map[string]string{
+		webhooks.SignatureHeader: webhooks.Sign(subscription.Secret, d.now(), body),
+		webhooks.EventIDHeader:   delivery.Event.ID,
+		webhooks.EventTypeHeader: string(delivery.Event.Type),
+		webhooks.DeliveryHeader:  delivery.ID,
+	}
+
+	statusCode, err := d.sender.Send(ctx, subscription.URL, headers, body)
+	switch {
+	case err != nil:
+		delivery.Fail(err.Error(), 0, d.settings.Retry, d.now())
+	case statusCode < 200 || statusCode > 299:
+		delivery.Fail(fmt.Sprintf("unexpected status %d", statusCode), statusCode, d.settings.Retry, d.now())
+	default:
+		delivery.Succeed(statusCode, d.now())
+	}
+}
@@ -0,0 +1,201 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockWebhookSender struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	args := m.Called(ctx, url, headers, body)
+	return args.Int(0), args.Error(1)
+}
+
+// fakeOutbox relays a prepared list of events once
+type fakeOutbox struct {
+	pending []events.Event
+}
+
+func (o *fakeOutbox) SaveBeer(ctx context.Context, beer *beers.Beer, recorded []events.Event) error {
+	o.pending = append(o.pending, recorded...)
+	return nil
+}
+
+func (o *fakeOutbox) Relay(ctx context.Context, limit int, handle func(ctx context.Context, pending []events.Event) error) (int, error) {
+	if len(o.pending) == 0 {
+		return 0, nil
+	}
+	if err := handle(ctx, o.pending); err != nil {
+		return 0, err
+	}
+	n := len(o.pending)
+	o.pending = nil
+	return n, nil
+}
+
+var testDispatcherSettings = WebhookDispatcherSettings{
+	PollInterval: time.Second,
+	BatchSize:    10,
+	Lease:        time.Minute,
+	Retry:        webhooks.RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+}
+
+type dispatcherFixture struct {
+	outbox        *fakeOutbox
+	subscriptions *MockWebhookSubscriptionRepository
+	deliveries    *MockWebhookDeliveryRepository
+	sender        *MockWebhookSender
+	dispatcher    *WebhookDispatcher
+	now           time.Time
+}
+
+func newDispatcherFixture() *dispatcherFixture {
+	f := &dispatcherFixture{
+		outbox:        &fakeOutbox{},
+		subscriptions: new(MockWebhookSubscriptionRepository),
+		deliveries:    new(MockWebhookDeliveryRepository),
+		sender:        new(MockWebhookSender),
+		now:           time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	f.dispatcher = NewWebhookDispatcher(f.outbox, f.subscriptions, f.deliveries, f.sender,
+		testDispatcherSettings, logger.NewNoOpLogger())
+	f.dispatcher.now = func() time.Time { return f.now }
+	return f
+}
+
+func TestWebhookDispatcherEnqueuesMatchingSubscriptions(t *testing.T) {
+	f := newDispatcherFixture()
+	ctx := context.Background()
+
+	beer := &beers.Beer{ID: 1, Name: "Golden", Price: 2500, Currency: "CLP"}
+	f.outbox.pending = []events.Event{events.BeerCreated(beer)}
+	f.outbox.pending[0].ID = "evt_1"
+
+	everything := webhooks.Subscription{ID: "whk_all"}
+	repricing := webhooks.Subscription{ID: "whk_price", EventTypes: []events.Type{events.TypePriceChanged}}
+	f.subscriptions.On("FindAll", ctx).Return([]webhooks.Subscription{everything, repricing}, nil)
+
+	var saved []*webhooks.Delivery
+	f.deliveries.On("Save", ctx, mock.AnythingOfType("*webhooks.Delivery")).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(*webhooks.Delivery))
+	}).Return(nil)
+	f.deliveries.On("ClaimDue", ctx, f.now, 10, time.Minute).Return([]webhooks.Delivery{}, nil)
+
+	require.NoError(t, f.dispatcher.DispatchOnce(ctx))
+
+	require.Len(t, saved, 1)
+	assert.Equal(t, "whk_all", saved[0].SubscriptionID)
+	assert.Equal(t, "evt_1", saved[0].Event.ID)
+	assert.Equal(t, webhooks.StatusPending, saved[0].Status)
+	assert.Empty(t, f.outbox.pending)
+}
+
+func TestWebhookDispatcherRelayFailureKeepsEvents(t *testing.T) {
+	f := newDispatcherFixture()
+	ctx := context.Background()
+	f.outbox.pending = []events.Event{{ID: "evt_1", Type: events.TypeBeerCreated}}
+	f.subscriptions.On("FindAll", ctx).Return(nil, errors.New("database unavailable"))
+
+	assert.Error(t, f.dispatcher.DispatchOnce(ctx))
+	assert.Len(t, f.outbox.pending, 1)
+	f.deliveries.AssertNotCalled(t, "ClaimDue", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestWebhookDispatcherSendsSignedRequests(t *testing.T) {
+	f := newDispatcherFixture()
+	ctx := context.Background()
+
+	subscription := &webhooks.Subscription{ID: "whk_1", URL: "https://partner.example.com/hooks", Secret: "whsec_test"}
+	event := events.Event{ID: "evt_1", Type: events.TypeBeerCreated, BeerID: 1}
+	delivery := webhooks.NewDelivery(subscription, event, f.now)
+	body, _ := json.Marshal(event)
+
+	f.deliveries.On("ClaimDue", ctx, f.now, 10, time.Minute).Return([]webhooks.Delivery{*delivery}, nil)
+	f.subscriptions.On("FindByID", ctx, "whk_1").Return(subscription, nil)
+	f.sender.On("Send", ctx, subscription.URL, map[string]string{
+		webhooks.SignatureHeader: webhooks.Sign("whsec_test", f.now, body),
+		webhooks.EventIDHeader:   "evt_1",
+		webhooks.EventTypeHeader: "beer.created",
+		webhooks.DeliveryHeader:  delivery.ID,
+	}, body).Return(204, nil)
+
+	var saved *webhooks.Delivery
+	f.deliveries.On("Save", ctx, mock.AnythingOfType("*webhooks.Delivery")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*webhooks.Delivery)
+	}).Return(nil)
+
+	require.NoError(t, f.dispatcher.DispatchOnce(ctx))
+
+	f.sender.AssertExpectations(t)
+	require.NotNil(t, saved)
+	assert.Equal(t, webhooks.StatusDelivered, saved.Status)
+	assert.Equal(t, 1, saved.Attempts)
+	assert.Equal(t, 204, saved.LastStatusCode)
+}
+
+func TestWebhookDispatcherRetriesThenDeadLetters(t *testing.T) {
+	f := newDispatcherFixture()
+	ctx := context.Background()
+
+	subscription := &webhooks.Subscription{ID: "whk_1", URL: "https://partner.example.com/hooks", Secret: "whsec_test"}
+	delivery := webhooks.NewDelivery(subscription, events.Event{ID: "evt_1", Type: events.TypeBeerCreated}, f.now)
+
+	f.subscriptions.On("FindByID", ctx, "whk_1").Return(subscription, nil)
+	f.sender.On("Send", ctx, subscription.URL, mock.Anything, mock.Anything).Return(503, nil).Once()
+	f.sender.On("Send", ctx, subscription.URL, mock.Anything, mock.Anything).Return(0, errors.New("connection refused")).Once()
+
+	var saved *webhooks.Delivery
+	f.deliveries.On("Save", ctx, mock.AnythingOfType("*webhooks.Delivery")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*webhooks.Delivery)
+	}).Return(nil)
+
+	f.deliveries.On("ClaimDue", ctx, f.now, 10, time.Minute).Return([]webhooks.Delivery{*delivery}, nil).Once()
+	require.NoError(t, f.dispatcher.DispatchOnce(ctx))
+	assert.Equal(t, webhooks.StatusPending, saved.Status)
+	assert.Equal(t, "unexpected status 503", saved.LastError)
+	assert.Equal(t, f.now.Add(time.Second), saved.NextAttemptAt)
+
+	retry := *saved
+	f.now = saved.NextAttemptAt
+	f.deliveries.On("ClaimDue", ctx, f.now, 10, time.Minute).Return([]webhooks.Delivery{retry}, nil).Once()
+	require.NoError(t, f.dispatcher.DispatchOnce(ctx))
+	assert.Equal(t, webhooks.StatusDead, saved.Status)
+	assert.Equal(t, 2, saved.Attempts)
+	assert.Contains(t, saved.LastError, "connection refused")
+}
+
+func TestWebhookDispatcherAbandonsDeletedSubscriptions(t *testing.T) {
+	f := newDispatcherFixture()
+	ctx := context.Background()
+
+	delivery := webhooks.NewDelivery(&webhooks.Subscription{ID: "whk_gone"}, events.Event{ID: "evt_1"}, f.now)
+	f.deliveries.On("ClaimDue", ctx, f.now, 10, time.Minute).Return([]webhooks.Delivery{*delivery}, nil)
+	f.subscriptions.On("FindByID", ctx, "whk_gone").
+		Return(nil, beers.NewDomainError(webhooks.ErrCodeSubscriptionNotFound, "Webhook subscription not found", nil))
+
+	var saved *webhooks.Delivery
+	f.deliveries.On("Save", ctx, mock.AnythingOfType("*webhooks.Delivery")).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*webhooks.Delivery)
+	}).Return(nil)
+
+	require.NoError(t, f.dispatcher.DispatchOnce(ctx))
+
+	assert.Equal(t, webhooks.StatusDead, saved.Status)
+	assert.Equal(t, "subscription deleted", saved.LastError)
+	f.sender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
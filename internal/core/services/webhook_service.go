@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// WebhookServiceImpl implements the WebhookService primary port
+type WebhookServiceImpl struct {
+	subscriptions secondary.WebhookSubscriptionRepository
+	deliveries    secondary.WebhookDeliveryRepository
+	logger        secondary.Logger
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	subscriptions secondary.WebhookSubscriptionRepository,
+	deliveries secondary.WebhookDeliveryRepository,
+	logger secondary.Logger,
+) primary.WebhookService {
+	return &WebhookServiceImpl{
+		subscriptions: subscriptions,
+		deliveries:    deliveries,
+		logger:        logger,
+	}
+}
+
+// CreateSubscription subscribes a URL and returns its signing secret
+func (s *WebhookServiceImpl) CreateSubscription(ctx context.Context, req primary.CreateWebhookRequest) (*primary.CreatedWebhookSubscription, error) {
+	subscription, err := webhooks.NewSubscription(req.URL, req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.subscriptions.Save(ctx, subscription); err != nil {
+		s.logger.Error(ctx, "Failed to save webhook subscription", err, map[string]interface{}{
+			"subscription_id": subscription.ID,
+		})
+		return nil, fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	s.logger.Info(ctx, "Webhook subscription created", map[string]interface{}{
+		"subscription_id": subscription.ID,
+		"url":             subscription.URL,
+		"event_types":     subscription.EventTypes,
+	})
+
+	return &primary.CreatedWebhookSubscription{Subscription: subscription, Secret: subscription.Secret}, nil
+}
+
+// GetSubscription finds a subscription by its ID
+func (s *WebhookServiceImpl) GetSubscription(ctx context.Context, id string) (*webhooks.Subscription, error) {
+	return s.subscriptions.FindByID(ctx, id)
+}
+
+// ListSubscriptions returns every subscription
+func (s *WebhookServiceImpl) ListSubscriptions(ctx context.Context) ([]webhooks.Subscription, error) {
+	subscriptions, err := s.subscriptions.FindAll(ctx)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list webhook subscriptions", err, nil)
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// DeleteSubscription removes a subscription. Its pending deliveries are
+// dead-lettered by the dispatcher when they come due.
+func (s *WebhookServiceImpl) DeleteSubscription(ctx context.Context, id string) error {
+	if err := s.subscriptions.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	s.logger.Info(ctx, "Webhook subscription deleted", map[string]interface{}{
+		"subscription_id": id,
+	})
+
+	return nil
+}
+
+// ListDeliveries returns the deliveries in a status, or every delivery
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, status string) ([]webhooks.Delivery, error) {
+	var parsed webhooks.DeliveryStatus
+	if status != "" {
+		var err error
+		if parsed, err = webhooks.ParseDeliveryStatus(status); err != nil {
+			return nil, err
+		}
+	}
+
+	deliveries, err := s.deliveries.FindByStatus(ctx, parsed)
+	if err != nil {
+		s.logger.Error(ctx, "Failed to list webhook deliveries", err, map[string]interface{}{
+			"status": status,
+		})
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery queues a dead-lettered delivery for immediate delivery
+func (s *WebhookServiceImpl) ReplayDelivery(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	delivery, err := s.deliveries.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := delivery.Replay(time.Now()); err != nil {
+		return nil, err
+	}
+
+	if err := s.deliveries.Save(ctx, delivery); err != nil {
+		s.logger.Error(ctx, "Failed to replay webhook delivery", err, map[string]interface{}{
+			"delivery_id": id,
+		})
+		return nil, fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+
+	s.logger.Info(ctx, "Webhook delivery replayed", map[string]interface{}{
+		"delivery_id":     id,
+		"subscription_id": delivery.SubscriptionID,
+	})
+
+	return delivery, nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+type MockWebhookSubscriptionRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookSubscriptionRepository) Save(ctx context.Context, subscription *webhooks.Subscription) error {
+	args := m.Called(ctx, subscription)
+	return args.Error(0)
+}
+
+func (m *MockWebhookSubscriptionRepository) FindByID(ctx context.Context, id string) (*webhooks.Subscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhooks.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookSubscriptionRepository) FindAll(ctx context.Context) ([]webhooks.Subscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhooks.Subscription), args.Error(1)
+}
+
+func (m *MockWebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockWebhookDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookDeliveryRepository) Save(ctx context.Context, delivery *webhooks.Delivery) error {
+	args := m.Called(ctx, delivery)
+	return args.Error(0)
+}
+
+func (m *MockWebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhooks.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) FindByStatus(ctx context.Context, status webhooks.DeliveryStatus) ([]webhooks.Delivery, error) {
+	args := m.Called(ctx, status)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhooks.Delivery), args.Error(1)
+}
+
+func (m *MockWebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]webhooks.Delivery, error) {
+	args := m.Called(ctx, now, limit, lease)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]webhooks.Delivery), args.Error(1)
+}
+
+func TestCreateWebhookSubscription(t *testing.T) {
+	subscriptions := new(MockWebhookSubscriptionRepository)
+	service := NewWebhookService(subscriptions, new(MockWebhookDeliveryRepository), logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	subscriptions.On("Save", ctx, mock.AnythingOfType("*webhooks.Subscription")).Return(nil)
+
+	created, err := service.CreateSubscription(ctx, primary.CreateWebhookRequest{
+		URL:        "https://partner.example.com/hooks",
+		EventTypes: []events.Type{events.TypePriceChanged},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Secret, "whsec_"))
+	assert.Equal(t, created.Subscription.Secret, created.Secret)
+	assert.Equal(t, []events.Type{events.TypePriceChanged}, created.Subscription.EventTypes)
+	subscriptions.AssertExpectations(t)
+
+	_, err = service.CreateSubscription(ctx, primary.CreateWebhookRequest{URL: "not a url"})
+	var validationErr *beers.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	subscriptions.AssertNumberOfCalls(t, "Save", 1)
+}
+
+func TestListWebhookDeliveries(t *testing.T) {
+	deliveries := new(MockWebhookDeliveryRepository)
+	service := NewWebhookService(new(MockWebhookSubscriptionRepository), deliveries, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	dead := []webhooks.Delivery{{ID: "dlv_1", Status: webhooks.StatusDead}}
+	deliveries.On("FindByStatus", ctx, webhooks.StatusDead).Return(dead, nil)
+	deliveries.On("FindByStatus", ctx, webhooks.DeliveryStatus("")).Return([]webhooks.Delivery{}, nil)
+
+	result, err := service.ListDeliveries(ctx, "dead")
+	require.NoError(t, err)
+	assert.Equal(t, dead, result)
+
+	result, err = service.ListDeliveries(ctx, "")
+	require.NoError(t, err)
+	assert.Empty(t, result)
+
+	_, err = service.ListDeliveries(ctx, "failed")
+	var validationErr *beers.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+}
+
+func TestReplayWebhookDelivery(t *testing.T) {
+	deliveries := new(MockWebhookDeliveryRepository)
+	service := NewWebhookService(new(MockWebhookSubscriptionRepository), deliveries, logger.NewNoOpLogger())
+	ctx := context.Background()
+
+	deliveries.On("FindByID", ctx, "dlv_dead").Return(&webhooks.Delivery{ID: "dlv_dead", Status: webhooks.StatusDead, Attempts: 8}, nil)
+	deliveries.On("FindByID", ctx, "dlv_pending").Return(&webhooks.Delivery{ID: "dlv_pending", Status: webhooks.StatusPending}, nil)
+	deliveries.On("Save", ctx, mock.AnythingOfType("*webhooks.Delivery")).Return(nil)
+
+	replayed, err := service.ReplayDelivery(ctx, "dlv_dead")
+	require.NoError(t, err)
+	assert.Equal(t, webhooks.StatusPending, replayed.Status)
+	assert.Equal(t, 0, replayed.Attempts)
+
+	_, err = service.ReplayDelivery(ctx, "dlv_pending")
+	assert.True(t, isDomainError(err, webhooks.ErrCodeDeliveryNotDead))
+	deliveries.AssertNumberOfCalls(t, "Save", 1)
+}
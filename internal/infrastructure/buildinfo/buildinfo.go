@@ -0,0 +1,87 @@
+package buildinfo
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+)
+
+// Build metadata, injected at build time with
+//
+//	go build -ldflags "-X beers-challenge/internal/infrastructure/buildinfo.Version=v1.4.0 \
+//	  -X beers-challenge/internal/infrastructure/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X beers-challenge/internal/infrastructure/buildinfo.BuildDate=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// The Makefile and Dockerfile set them. Commit and BuildDate fall back to the VCS
+// stamp the Go toolchain embeds when building inside a git checkout.
+var (
+	Version   = "dev"
+	Commit    = ""
+	BuildDate = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildDate string `json:"build_date"`
+	GoVersion string `json:"go_version"`
+	Platform  string `json:"platform"`
+}
+
+// Get returns the metadata of the running build
+func Get() Info {
+	info := Info{
+		Version:   Version,
+		Commit:    Commit,
+		BuildDate: BuildDate,
+		GoVersion: runtime.Version(),
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+	}
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		fillFromVCS(&info, build.Settings)
+	}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+	}
+	if info.BuildDate == "" {
+		info.BuildDate = "unknown"
+	}
+
+	return info
+}
+
+// fillFromVCS fills the commit and build date that were not injected from the
+// VCS settings of the build, marking commits built with uncommitted changes
+func fillFromVCS(info *Info, settings []debug.BuildSetting) {
+	var revision, modified, committedAt string
+	for _, setting := range settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		case "vcs.time":
+			committedAt = setting.Value
+		}
+	}
+
+	if info.Commit == "" && revision != "" {
+		info.Commit = revision
+		if modified == "true" {
+			info.Commit += "-dirty"
+		}
+	}
+
+	if info.BuildDate == "" {
+		info.BuildDate = committedAt
+	}
+}
+
+// String formats the metadata for humans, one field per line
+func (i Info) String() string {
+	return fmt.Sprintf("Version:    %s\nCommit:     %s\nBuild date: %s\nGo version: %s\nPlatform:   %s\n",
+		i.Version, i.Commit, i.BuildDate, i.GoVersion, i.Platform)
+}
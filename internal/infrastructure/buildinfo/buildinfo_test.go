@@ -0,0 +1,44 @@
+package buildinfo
+
+import (
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet(t *testing.T) {
+	defer func(version, commit, date string) {
+		Version, Commit, BuildDate = version, commit, date
+	}(Version, Commit, BuildDate)
+
+	Version, Commit, BuildDate = "v1.4.0", "0123abc", "2026-10-18T12:00:00Z"
+
+	info := Get()
+
+	assert.Equal(t, "v1.4.0", info.Version)
+	assert.Equal(t, "0123abc", info.Commit)
+	assert.Equal(t, "2026-10-18T12:00:00Z", info.BuildDate)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+	assert.Equal(t, runtime.GOOS+"/"+runtime.GOARCH, info.Platform)
+	assert.Contains(t, info.String(), "Version:    v1.4.0\n")
+}
+
+func TestFillFromVCS(t *testing.T) {
+	settings := []debug.BuildSetting{
+		{Key: "vcs.revision", Value: "0123abc"},
+		{Key: "vcs.time", Value: "2026-10-17T09:30:00Z"},
+		{Key: "vcs.modified", Value: "true"},
+	}
+
+	info := Info{}
+	fillFromVCS(&info, settings)
+	assert.Equal(t, "0123abc-dirty", info.Commit)
+	assert.Equal(t, "2026-10-17T09:30:00Z", info.BuildDate)
+
+	injected := Info{Commit: "fedcba9", BuildDate: "2026-10-18T12:00:00Z"}
+	fillFromVCS(&injected, settings)
+	assert.Equal(t, "fedcba9", injected.Commit, "injected values win")
+	assert.Equal(t, "2026-10-18T12:00:00Z", injected.BuildDate)
+}
@@ -0,0 +1,140 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// CurrencyCacheName labels the exchange rate cache in hit and miss reports
+const CurrencyCacheName = "exchange_rates"
+
+// Recorder is told about every cache lookup, for example to export a hit ratio
+type Recorder interface {
+	CacheHit(cache string)
+	CacheMiss(cache string)
+}
+
+// cachedRate is an exchange rate and when it was fetched
+type cachedRate struct {
+	rate      float64
+	fetchedAt time.Time
+}
+
+// CurrencyCache decorates a secondary.CurrencyService, remembering exchange rates
+// for a TTL so repeated conversions do not spend the provider's request quota.
+// Failures are not cached.
+type CurrencyCache struct {
+	next     secondary.CurrencyService
+	ttl      time.Duration
+	recorder Recorder
+	rates    map[string]cachedRate
+	mu       sync.RWMutex
+	now      func() time.Time
+}
+
+// CurrencyCacheOption configures optional collaborators of the currency cache
+type CurrencyCacheOption func(*CurrencyCache)
+
+// WithRecorder reports cache hits and misses to the recorder
+func WithRecorder(recorder Recorder) CurrencyCacheOption {
+	return func(c *CurrencyCache) {
+		c.recorder = recorder
+	}
+}
+
+// NewCurrencyCache wraps a currency service with an exchange rate cache
+func NewCurrencyCache(next secondary.CurrencyService, ttl time.Duration, opts ...CurrencyCacheOption) *CurrencyCache {
+	cache := &CurrencyCache{
+		next:  next,
+		ttl:   ttl,
+		rates: make(map[string]cachedRate),
+		now:   time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(cache)
+	}
+
+	return cache
+}
+
+// GetExchangeRate returns a cached rate younger than the TTL or fetches a fresh one.
+// Either way, when and until when the rate is current is reported to the
+// currency.RateFreshness of ctx, if any.
+func (c *CurrencyCache) GetExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	key := from + "/" + to
+	now := c.now()
+
+	c.mu.RLock()
+	cached, exists := c.rates[key]
+	c.mu.RUnlock()
+
+	if exists && now.Sub(cached.fetchedAt) < c.ttl {
+		c.record(true)
+		currency.ObserveRate(ctx, cached.fetchedAt, cached.fetchedAt.Add(c.ttl))
+		return cached.rate, nil
+	}
+	c.record(false)
+
+	rate, err := c.next.GetExchangeRate(ctx, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	c.rates[key] = cachedRate{rate: rate, fetchedAt: now}
+	c.mu.Unlock()
+
+	currency.ObserveRate(ctx, now, now.Add(c.ttl))
+
+	return rate, nil
+}
+
+// IsValidCurrency delegates to the wrapped service
+func (c *CurrencyCache) IsValidCurrency(ctx context.Context, currency string) (bool, error) {
+	return c.next.IsValidCurrency(ctx, currency)
+}
+
+// GetSupportedCurrencies delegates to the wrapped service
+func (c *CurrencyCache) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
+	return c.next.GetSupportedCurrencies(ctx)
+}
+
+// CheckHealth reports how many rates are cached and how many are still fresh.
+// The cache lives in memory, so it is always up.
+func (c *CurrencyCache) CheckHealth(ctx context.Context) (map[string]interface{}, error) {
+	now := c.now()
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	fresh := 0
+	for _, cached := range c.rates {
+		if now.Sub(cached.fetchedAt) < c.ttl {
+			fresh++
+		}
+	}
+
+	return map[string]interface{}{
+		"entries":     len(c.rates),
+		"fresh":       fresh,
+		"ttl_seconds": c.ttl.Seconds(),
+	}, nil
+}
+
+// record reports a lookup to the recorder, if any
+func (c *CurrencyCache) record(hit bool) {
+	if c.recorder == nil {
+		return
+	}
+
+	if hit {
+		c.recorder.CacheHit(CurrencyCacheName)
+	} else {
+		c.recorder.CacheMiss(CurrencyCacheName)
+	}
+}
@@ -0,0 +1,126 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/currency"
+)
+
+type stubCurrencyService struct {
+	calls int
+	err   error
+}
+
+func (s *stubCurrencyService) GetExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+	return 0.5, nil
+}
+
+func (s *stubCurrencyService) IsValidCurrency(ctx context.Context, currency string) (bool, error) {
+	return currency == "USD", nil
+}
+
+func (s *stubCurrencyService) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
+	return []string{"USD"}, nil
+}
+
+type countingRecorder struct {
+	hits, misses int
+}
+
+func (r *countingRecorder) CacheHit(string)  { r.hits++ }
+func (r *countingRecorder) CacheMiss(string) { r.misses++ }
+
+func TestCurrencyCache(t *testing.T) {
+	provider := &stubCurrencyService{}
+	recorder := &countingRecorder{}
+	cache := NewCurrencyCache(provider, time.Minute, WithRecorder(recorder))
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		rate, err := cache.GetExchangeRate(ctx, "EUR", "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5, rate)
+	}
+	assert.Equal(t, 1, provider.calls)
+
+	_, _ = cache.GetExchangeRate(ctx, "CLP", "USD")
+	assert.Equal(t, 2, provider.calls, "each currency pair is cached separately")
+
+	now = now.Add(time.Minute)
+	_, _ = cache.GetExchangeRate(ctx, "EUR", "USD")
+	assert.Equal(t, 3, provider.calls, "expired rates are fetched again")
+
+	assert.Equal(t, 2, recorder.hits)
+	assert.Equal(t, 3, recorder.misses)
+}
+
+func TestCurrencyCacheReportsRateFreshness(t *testing.T) {
+	cache := NewCurrencyCache(&stubCurrencyService{}, time.Minute)
+	fetched := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	now := fetched
+	cache.now = func() time.Time { return now }
+
+	_, _ = cache.GetExchangeRate(context.Background(), "EUR", "USD")
+
+	now = now.Add(20 * time.Second)
+	ctx, freshness := currency.WithRateFreshness(context.Background())
+	_, _ = cache.GetExchangeRate(ctx, "EUR", "USD")
+	_, _ = cache.GetExchangeRate(ctx, "CLP", "USD")
+
+	fetchedAt, expiresAt, ok := freshness.Observed()
+	assert.True(t, ok)
+	assert.Equal(t, fetched, fetchedAt, "the oldest rate is reported")
+	assert.Equal(t, fetched.Add(time.Minute), expiresAt, "the first rate to expire is reported")
+}
+
+func TestCurrencyCacheDoesNotCacheFailures(t *testing.T) {
+	provider := &stubCurrencyService{err: errors.New("quota exceeded")}
+	cache := NewCurrencyCache(provider, time.Minute)
+	ctx := context.Background()
+
+	_, err := cache.GetExchangeRate(ctx, "EUR", "USD")
+	assert.Error(t, err)
+
+	provider.err = nil
+	rate, err := cache.GetExchangeRate(ctx, "EUR", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.5, rate)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestCurrencyCacheDelegates(t *testing.T) {
+	cache := NewCurrencyCache(&stubCurrencyService{}, time.Minute)
+
+	valid, _ := cache.IsValidCurrency(context.Background(), "USD")
+	assert.True(t, valid)
+
+	supported, _ := cache.GetSupportedCurrencies(context.Background())
+	assert.Equal(t, []string{"USD"}, supported)
+}
+
+func TestCurrencyCacheCheckHealth(t *testing.T) {
+	cache := NewCurrencyCache(&stubCurrencyService{}, time.Minute)
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	_, _ = cache.GetExchangeRate(context.Background(), "EUR", "USD")
+	now = now.Add(time.Minute)
+	_, _ = cache.GetExchangeRate(context.Background(), "CLP", "USD")
+
+	details, err := cache.CheckHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, details["entries"])
+	assert.Equal(t, 1, details["fresh"])
+	assert.Equal(t, 60.0, details["ttl_seconds"])
+}
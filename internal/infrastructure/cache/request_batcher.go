@@ -0,0 +1,40 @@
+package cache
+
+import (
+	"context"
+
+	"beers-challenge/internal/core/domain/currency"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// RequestBatcher decorates a secondary.CurrencyService so that requests carrying a
+// currency.RateBatch, such as GraphQL queries pricing many beers at once, look each
+// currency pair up once however many resolvers ask for it. Other requests pass
+// straight through.
+type RequestBatcher struct {
+	next secondary.CurrencyService
+}
+
+// NewRequestBatcher wraps a currency service with per-request rate batching
+func NewRequestBatcher(next secondary.CurrencyService) *RequestBatcher {
+	return &RequestBatcher{next: next}
+}
+
+// GetExchangeRate shares the lookup with the rest of the request's batch, if any
+func (b *RequestBatcher) GetExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	if batch, ok := currency.RateBatchFromContext(ctx); ok {
+		return batch.Rate(ctx, from, to, b.next.GetExchangeRate)
+	}
+
+	return b.next.GetExchangeRate(ctx, from, to)
+}
+
+// IsValidCurrency delegates to the wrapped service
+func (b *RequestBatcher) IsValidCurrency(ctx context.Context, code string) (bool, error) {
+	return b.next.IsValidCurrency(ctx, code)
+}
+
+// GetSupportedCurrencies delegates to the wrapped service
+func (b *RequestBatcher) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
+	return b.next.GetSupportedCurrencies(ctx)
+}
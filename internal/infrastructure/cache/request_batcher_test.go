@@ -0,0 +1,30 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/currency"
+)
+
+func TestRequestBatcher(t *testing.T) {
+	provider := &stubCurrencyService{}
+	batcher := NewRequestBatcher(provider)
+
+	ctx := currency.WithRateBatch(context.Background())
+	for i := 0; i < 3; i++ {
+		rate, err := batcher.GetExchangeRate(ctx, "EUR", "USD")
+		assert.NoError(t, err)
+		assert.Equal(t, 0.5, rate)
+	}
+	assert.Equal(t, 1, provider.calls)
+
+	_, _ = batcher.GetExchangeRate(currency.WithRateBatch(context.Background()), "EUR", "USD")
+	assert.Equal(t, 2, provider.calls, "each request has its own batch")
+
+	_, _ = batcher.GetExchangeRate(context.Background(), "EUR", "USD")
+	_, _ = batcher.GetExchangeRate(context.Background(), "EUR", "USD")
+	assert.Equal(t, 4, provider.calls, "requests without a batch pass through")
+}
@@ -9,16 +9,34 @@ import (
 
 // Config holds the application configuration
 type Config struct {
-	Server   ServerConfig   `json:"server"`
-	Database DatabaseConfig `json:"database"`
-	Currency CurrencyConfig `json:"currency"`
-	Logger   LoggerConfig   `json:"logger"`
+	Server      ServerConfig      `json:"server"`
+	Database    DatabaseConfig    `json:"database"`
+	Currency    CurrencyConfig    `json:"currency"`
+	Logger      LoggerConfig      `json:"logger"`
+	Tax         TaxConfig         `json:"tax"`
+	Auth        AuthConfig        `json:"auth"`
+	Idempotency IdempotencyConfig `json:"idempotency"`
+	RateLimit   RateLimitConfig   `json:"rate_limit"`
+	Metrics     MetricsConfig     `json:"metrics"`
+	Tracing     TracingConfig     `json:"tracing"`
+	Health      HealthConfig      `json:"health"`
+	OpenAPI     OpenAPIConfig     `json:"openapi"`
+	GRPC        GRPCConfig        `json:"grpc"`
+	Events      EventsConfig      `json:"events"`
+	Webhooks    WebhooksConfig    `json:"webhooks"`
+	HTTPCache   HTTPCacheConfig   `json:"http_cache"`
+	Compression CompressionConfig `json:"compression"`
+	BodyLimit   BodyLimitConfig   `json:"body_limit"`
+	TLS         TLSConfig         `json:"tls"`
+	Legacy      LegacyConfig      `json:"legacy"`
 }
 
 // ServerConfig holds server configuration
 type ServerConfig struct {
-	Host string `json:"host"`
-	Port int    `json:"port"`
+	Host           string `json:"host"`
+	Port           int    `json:"port"`
+	ShutdownDelay  int    `json:"shutdown_delay"`
+	TrustedProxies string `json:"trusted_proxies"`
 }
 
 // DatabaseConfig holds database configuration
@@ -34,9 +52,10 @@ type DatabaseConfig struct {
 
 // CurrencyConfig holds currency service configuration
 type CurrencyConfig struct {
-	APIKey  string `json:"api_key"`
-	BaseURL string `json:"base_url"`
-	Timeout int    `json:"timeout"`
+	APIKey   string `json:"api_key"`
+	BaseURL  string `json:"base_url"`
+	Timeout  int    `json:"timeout"`
+	CacheTTL int    `json:"cache_ttl"`
 }
 
 // LoggerConfig holds logger configuration
@@ -45,6 +64,135 @@ type LoggerConfig struct {
 	Format string `json:"format"`
 }
 
+// TaxConfig holds tax rules configuration
+type TaxConfig struct {
+	RulesFile string `json:"rules_file"`
+}
+
+// AuthConfig holds authentication configuration.
+// JWT bearer tokens are accepted when an HMAC secret or an RSA public key file is set.
+type AuthConfig struct {
+	Enabled          bool   `json:"enabled"`
+	JWTHMACSecret    string `json:"jwt_hmac_secret"`
+	JWTPublicKeyFile string `json:"jwt_public_key_file"`
+	JWTIssuer        string `json:"jwt_issuer"`
+	JWTAudience      string `json:"jwt_audience"`
+}
+
+// IdempotencyConfig holds Idempotency-Key configuration
+type IdempotencyConfig struct {
+	TTLSeconds int `json:"ttl_seconds"`
+}
+
+// RateLimitConfig holds rate limiting configuration. Limits are written as
+// "<requests>/<s|m|h>"; Routes overrides them per "METHOD /route" pattern.
+type RateLimitConfig struct {
+	Enabled bool   `json:"enabled"`
+	Store   string `json:"store"`
+	Default string `json:"default"`
+	Routes  string `json:"routes"`
+}
+
+// MetricsConfig holds Prometheus metrics configuration
+type MetricsConfig struct {
+	Enabled bool `json:"enabled"`
+}
+
+// TracingConfig holds OpenTelemetry tracing configuration. The exporter is
+// "none", "stdout" or "otlp" (OTLP over HTTP).
+type TracingConfig struct {
+	Exporter     string  `json:"exporter"`
+	OTLPEndpoint string  `json:"otlp_endpoint"`
+	OTLPInsecure bool    `json:"otlp_insecure"`
+	SampleRatio  float64 `json:"sample_ratio"`
+}
+
+// HealthConfig holds dependency health check configuration
+type HealthConfig struct {
+	CheckTimeoutMs int `json:"check_timeout_ms"`
+}
+
+// OpenAPIConfig holds OpenAPI contract validation configuration. Validation is
+// "off", "requests" to reject requests not matching the contract, or "test" to
+// also flag responses that do not match it.
+type OpenAPIConfig struct {
+	Validation string `json:"validation"`
+}
+
+// GRPCConfig holds gRPC server configuration
+type GRPCConfig struct {
+	Enabled    bool `json:"enabled"`
+	Port       int  `json:"port"`
+	Reflection bool `json:"reflection"`
+}
+
+// EventsConfig holds catalogue event stream configuration. HistorySize events
+// are kept for clients resuming a stream.
+type EventsConfig struct {
+	HistorySize      int  `json:"history_size"`
+	KeepAliveSeconds int  `json:"keepalive_seconds"`
+	WebSocketEnabled bool `json:"websocket_enabled"`
+}
+
+// WebhooksConfig holds webhook delivery configuration. A delivery failing
+// MaxAttempts times is dead-lettered; retries wait InitialBackoffSeconds,
+// doubling up to MaxBackoffSeconds.
+type WebhooksConfig struct {
+	Enabled               bool `json:"enabled"`
+	PollIntervalMs        int  `json:"poll_interval_ms"`
+	BatchSize             int  `json:"batch_size"`
+	MaxAttempts           int  `json:"max_attempts"`
+	InitialBackoffSeconds int  `json:"initial_backoff_seconds"`
+	MaxBackoffSeconds     int  `json:"max_backoff_seconds"`
+	TimeoutSeconds        int  `json:"timeout_seconds"`
+}
+
+// HTTPCacheConfig holds HTTP caching configuration of beers and box prices.
+// CacheControl is sent with beers; box prices may be reused for
+// BoxPriceMaxAgeSeconds at most, and never past the expiry of their exchange rates.
+type HTTPCacheConfig struct {
+	Enabled               bool   `json:"enabled"`
+	CacheControl          string `json:"cache_control"`
+	BoxPriceMaxAgeSeconds int    `json:"box_price_max_age_seconds"`
+}
+
+// CompressionConfig holds response compression configuration. Bodies smaller
+// than MinSizeBytes are sent as they are.
+type CompressionConfig struct {
+	Enabled      bool `json:"enabled"`
+	MinSizeBytes int  `json:"min_size_bytes"`
+}
+
+// BodyLimitConfig holds request body size limits. Sizes are written as bytes or
+// with a KB or MB unit; Routes overrides them per "METHOD /route" pattern.
+type BodyLimitConfig struct {
+	Default string `json:"default"`
+	Routes  string `json:"routes"`
+}
+
+// TLSConfig holds TLS configuration of the HTTP and gRPC servers. TLS is on when
+// CertFile is set; a ClientCAFile turns on mutual TLS in the ClientAuth mode
+// ("require" or "optional"). The files are checked for changes every
+// ReloadIntervalSeconds.
+type TLSConfig struct {
+	CertFile              string `json:"cert_file"`
+	KeyFile               string `json:"key_file"`
+	ClientCAFile          string `json:"client_ca_file"`
+	ClientAuth            string `json:"client_auth"`
+	HTTP2                 bool   `json:"http2"`
+	ReloadIntervalSeconds int    `json:"reload_interval_seconds"`
+}
+
+// LegacyConfig holds the retirement of the legacy unversioned routes. Mode is
+// "serve", "redirect" (308 to /api/v1) or "gone" (410); DeprecatedAt and Sunset
+// are RFC 3339 timestamps, and DocsURL documents the migration.
+type LegacyConfig struct {
+	Mode         string `json:"mode"`
+	DeprecatedAt string `json:"deprecated_at"`
+	Sunset       string `json:"sunset"`
+	DocsURL      string `json:"docs_url"`
+}
+
 // ConfigProvider implements the secondary.ConfigProvider interface
 type ConfigProvider struct {
 	config *Config
@@ -62,6 +210,8 @@ func (c *ConfigProvider) GetString(key string) string {
 	switch key {
 	case "server.host":
 		return c.config.Server.Host
+	case "server.trusted_proxies":
+		return c.config.Server.TrustedProxies
 	case "database.type":
 		return c.config.Database.Type
 	case "database.host":
@@ -82,6 +232,50 @@ func (c *ConfigProvider) GetString(key string) string {
 		return c.config.Logger.Level
 	case "logger.format":
 		return c.config.Logger.Format
+	case "tax.rules_file":
+		return c.config.Tax.RulesFile
+	case "auth.jwt_hmac_secret":
+		return c.config.Auth.JWTHMACSecret
+	case "auth.jwt_public_key_file":
+		return c.config.Auth.JWTPublicKeyFile
+	case "auth.jwt_issuer":
+		return c.config.Auth.JWTIssuer
+	case "auth.jwt_audience":
+		return c.config.Auth.JWTAudience
+	case "ratelimit.store":
+		return c.config.RateLimit.Store
+	case "ratelimit.default":
+		return c.config.RateLimit.Default
+	case "ratelimit.routes":
+		return c.config.RateLimit.Routes
+	case "tracing.exporter":
+		return c.config.Tracing.Exporter
+	case "tracing.otlp_endpoint":
+		return c.config.Tracing.OTLPEndpoint
+	case "openapi.validation":
+		return c.config.OpenAPI.Validation
+	case "http_cache.cache_control":
+		return c.config.HTTPCache.CacheControl
+	case "body_limit.default":
+		return c.config.BodyLimit.Default
+	case "body_limit.routes":
+		return c.config.BodyLimit.Routes
+	case "tls.cert_file":
+		return c.config.TLS.CertFile
+	case "tls.key_file":
+		return c.config.TLS.KeyFile
+	case "tls.client_ca_file":
+		return c.config.TLS.ClientCAFile
+	case "tls.client_auth":
+		return c.config.TLS.ClientAuth
+	case "legacy.mode":
+		return c.config.Legacy.Mode
+	case "legacy.deprecated_at":
+		return c.config.Legacy.DeprecatedAt
+	case "legacy.sunset":
+		return c.config.Legacy.Sunset
+	case "legacy.docs_url":
+		return c.config.Legacy.DocsURL
 	default:
 		return ""
 	}
@@ -92,10 +286,42 @@ func (c *ConfigProvider) GetInt(key string) int {
 	switch key {
 	case "server.port":
 		return c.config.Server.Port
+	case "server.shutdown_delay":
+		return c.config.Server.ShutdownDelay
 	case "database.port":
 		return c.config.Database.Port
 	case "currency.timeout":
 		return c.config.Currency.Timeout
+	case "currency.cache_ttl":
+		return c.config.Currency.CacheTTL
+	case "idempotency.ttl_seconds":
+		return c.config.Idempotency.TTLSeconds
+	case "health.check_timeout_ms":
+		return c.config.Health.CheckTimeoutMs
+	case "grpc.port":
+		return c.config.GRPC.Port
+	case "events.history_size":
+		return c.config.Events.HistorySize
+	case "events.keepalive_seconds":
+		return c.config.Events.KeepAliveSeconds
+	case "webhooks.poll_interval_ms":
+		return c.config.Webhooks.PollIntervalMs
+	case "webhooks.batch_size":
+		return c.config.Webhooks.BatchSize
+	case "webhooks.max_attempts":
+		return c.config.Webhooks.MaxAttempts
+	case "webhooks.initial_backoff_seconds":
+		return c.config.Webhooks.InitialBackoffSeconds
+	case "webhooks.max_backoff_seconds":
+		return c.config.Webhooks.MaxBackoffSeconds
+	case "webhooks.timeout_seconds":
+		return c.config.Webhooks.TimeoutSeconds
+	case "http_cache.box_price_max_age_seconds":
+		return c.config.HTTPCache.BoxPriceMaxAgeSeconds
+	case "compression.min_size_bytes":
+		return c.config.Compression.MinSizeBytes
+	case "tls.reload_interval_seconds":
+		return c.config.TLS.ReloadIntervalSeconds
 	default:
 		return 0
 	}
@@ -103,14 +329,42 @@ func (c *ConfigProvider) GetInt(key string) int {
 
 // GetFloat64 returns a float64 configuration value
 func (c *ConfigProvider) GetFloat64(key string) float64 {
-	// No float64 configs currently, but method required by interface
-	return 0.0
+	switch key {
+	case "tracing.sample_ratio":
+		return c.config.Tracing.SampleRatio
+	default:
+		return 0.0
+	}
 }
 
 // GetBool returns a boolean configuration value
 func (c *ConfigProvider) GetBool(key string) bool {
-	// No boolean configs currently, but method required by interface
-	return false
+	switch key {
+	case "auth.enabled":
+		return c.config.Auth.Enabled
+	case "ratelimit.enabled":
+		return c.config.RateLimit.Enabled
+	case "metrics.enabled":
+		return c.config.Metrics.Enabled
+	case "tracing.otlp_insecure":
+		return c.config.Tracing.OTLPInsecure
+	case "grpc.enabled":
+		return c.config.GRPC.Enabled
+	case "grpc.reflection":
+		return c.config.GRPC.Reflection
+	case "events.websocket_enabled":
+		return c.config.Events.WebSocketEnabled
+	case "webhooks.enabled":
+		return c.config.Webhooks.Enabled
+	case "http_cache.enabled":
+		return c.config.HTTPCache.Enabled
+	case "compression.enabled":
+		return c.config.Compression.Enabled
+	case "tls.http2":
+		return c.config.TLS.HTTP2
+	default:
+		return false
+	}
 }
 
 // GetConfig returns the full configuration
@@ -124,6 +378,10 @@ func loadConfig() *Config {
 		Server: ServerConfig{
 			Host: getEnvString("SERVER_HOST", "0.0.0.0"),
 			Port: getEnvInt("SERVER_PORT", 8080),
+			// Seconds readiness fails before the listener closes on shutdown
+			ShutdownDelay: getEnvInt("SHUTDOWN_DELAY", 0),
+			// Comma separated proxy IPs or CIDRs whose X-Forwarded-For is believed; none by default
+			TrustedProxies: getEnvString("TRUSTED_PROXIES", ""),
 		},
 		Database: DatabaseConfig{
 			Type:     getEnvString("DB_TYPE", "inmemory"),
@@ -138,11 +396,105 @@ func loadConfig() *Config {
 			APIKey:  getEnvString("CURRENCY_API_KEY", ""),
 			BaseURL: getEnvString("CURRENCY_BASE_URL", "https://api.currencylayer.com"),
 			Timeout: getEnvInt("CURRENCY_TIMEOUT", 30),
+			// Exchange rate caching is opt-in, as cached prices may use rates up to the TTL old
+			CacheTTL: getEnvInt("CURRENCY_CACHE_TTL", 0),
 		},
 		Logger: LoggerConfig{
 			Level:  getEnvString("LOG_LEVEL", "info"),
 			Format: getEnvString("LOG_FORMAT", "json"),
 		},
+		Tax: TaxConfig{
+			RulesFile: getEnvString("TAX_RULES_FILE", ""),
+		},
+		Auth: AuthConfig{
+			// Authentication is opt-in, so deployments keep serving until API keys are issued
+			Enabled:          getEnvBool("AUTH_ENABLED", false),
+			JWTHMACSecret:    getEnvString("AUTH_JWT_HMAC_SECRET", ""),
+			JWTPublicKeyFile: getEnvString("AUTH_JWT_PUBLIC_KEY_FILE", ""),
+			JWTIssuer:        getEnvString("AUTH_JWT_ISSUER", ""),
+			JWTAudience:      getEnvString("AUTH_JWT_AUDIENCE", ""),
+		},
+		Idempotency: IdempotencyConfig{
+			TTLSeconds: getEnvInt("IDEMPOTENCY_TTL", 86400),
+		},
+		RateLimit: RateLimitConfig{
+			// Rate limiting is opt-in, so upgrading does not start answering existing clients with 429
+			Enabled: getEnvBool("RATE_LIMIT_ENABLED", false),
+			Store:   getEnvString("RATE_LIMIT_STORE", "memory"),
+			Default: getEnvString("RATE_LIMIT_DEFAULT", "600/m"),
+			// Box prices and quotes call the paid currency API, so they get a tighter limit
+			Routes: getEnvString("RATE_LIMIT_ROUTES",
+				"GET /api/v1/beers/:id/boxprice=60/m,GET /api/v2/beers/:id/boxprice=60/m,GET /beers/:id/boxprice=60/m,POST /api/v1/quotes=60/m"),
+		},
+		Metrics: MetricsConfig{
+			Enabled: getEnvBool("METRICS_ENABLED", true),
+		},
+		Tracing: TracingConfig{
+			Exporter:     getEnvString("TRACING_EXPORTER", "none"),
+			OTLPEndpoint: getEnvString("TRACING_OTLP_ENDPOINT", ""),
+			OTLPInsecure: getEnvBool("TRACING_OTLP_INSECURE", false),
+			SampleRatio:  getEnvFloat64("TRACING_SAMPLE_RATIO", 1.0),
+		},
+		Health: HealthConfig{
+			CheckTimeoutMs: getEnvInt("HEALTH_CHECK_TIMEOUT_MS", 2000),
+		},
+		OpenAPI: OpenAPIConfig{
+			Validation: getEnvString("OPENAPI_VALIDATION", "off"),
+		},
+		GRPC: GRPCConfig{
+			Enabled: getEnvBool("GRPC_ENABLED", false),
+			Port:    getEnvInt("GRPC_PORT", 9090),
+			// Reflection lets tools such as grpcurl discover the API; off in production
+			Reflection: getEnvBool("GRPC_REFLECTION", !isProductionEnvironment()),
+		},
+		Events: EventsConfig{
+			HistorySize: getEnvInt("EVENTS_HISTORY_SIZE", 1000),
+			// Proxies tend to close connections idle for a minute
+			KeepAliveSeconds: getEnvInt("EVENTS_KEEPALIVE_SECONDS", 15),
+			WebSocketEnabled: getEnvBool("EVENTS_WEBSOCKET_ENABLED", false),
+		},
+		Webhooks: WebhooksConfig{
+			Enabled:        getEnvBool("WEBHOOKS_ENABLED", false),
+			PollIntervalMs: getEnvInt("WEBHOOKS_POLL_INTERVAL_MS", 1000),
+			BatchSize:      getEnvInt("WEBHOOKS_BATCH_SIZE", 100),
+			// 10 attempts 10s apart, doubling up to an hour, span about 5 hours
+			MaxAttempts:           getEnvInt("WEBHOOKS_MAX_ATTEMPTS", 10),
+			InitialBackoffSeconds: getEnvInt("WEBHOOKS_INITIAL_BACKOFF_SECONDS", 10),
+			MaxBackoffSeconds:     getEnvInt("WEBHOOKS_MAX_BACKOFF_SECONDS", 3600),
+			TimeoutSeconds:        getEnvInt("WEBHOOKS_TIMEOUT_SECONDS", 10),
+		},
+		HTTPCache: HTTPCacheConfig{
+			Enabled: getEnvBool("HTTP_CACHE_ENABLED", true),
+			// Beers change at any time, so clients revalidate before every reuse
+			CacheControl:          getEnvString("HTTP_CACHE_CONTROL", "private, no-cache"),
+			BoxPriceMaxAgeSeconds: getEnvInt("HTTP_CACHE_BOX_PRICE_MAX_AGE", 60),
+		},
+		Compression: CompressionConfig{
+			Enabled: getEnvBool("COMPRESSION_ENABLED", true),
+			// Below about a packet, compression saves no round trip
+			MinSizeBytes: getEnvInt("COMPRESSION_MIN_SIZE", 1024),
+		},
+		BodyLimit: BodyLimitConfig{
+			Default: getEnvString("BODY_LIMIT_DEFAULT", "1MB"),
+			// A beer is a handful of fields, so creating or updating one needs little room
+			Routes: getEnvString("BODY_LIMIT_ROUTES",
+				"POST /api/v1/beers=16KB,PUT /api/v1/beers/:id=16KB,POST /api/v2/beers=16KB,PUT /api/v2/beers/:id=16KB,POST /beers=16KB"),
+		},
+		TLS: TLSConfig{
+			CertFile:     getEnvString("TLS_CERT_FILE", ""),
+			KeyFile:      getEnvString("TLS_KEY_FILE", ""),
+			ClientCAFile: getEnvString("TLS_CLIENT_CA_FILE", ""),
+			ClientAuth:   getEnvString("TLS_CLIENT_AUTH", ""),
+			HTTP2:        getEnvBool("TLS_HTTP2", true),
+			// Certificate managers renew well ahead of expiry, so a slow poll is enough
+			ReloadIntervalSeconds: getEnvInt("TLS_RELOAD_INTERVAL_SECONDS", 30),
+		},
+		Legacy: LegacyConfig{
+			Mode:         getEnvString("LEGACY_ROUTES", "serve"),
+			DeprecatedAt: getEnvString("LEGACY_DEPRECATED_AT", ""),
+			Sunset:       getEnvString("LEGACY_SUNSET", ""),
+			DocsURL:      getEnvString("LEGACY_DOCS_URL", ""),
+		},
 	}
 }
 
@@ -164,6 +516,26 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvFloat64 gets an environment variable as float64 with a default value
+func getEnvFloat64(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if floatValue, err := strconv.ParseFloat(value, 64); err == nil {
+			return floatValue
+		}
+	}
+	return defaultValue
+}
+
+// getEnvBool gets an environment variable as bool with a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	if value := os.Getenv(key); value != "" {
+		if boolValue, err := strconv.ParseBool(value); err == nil {
+			return boolValue
+		}
+	}
+	return defaultValue
+}
+
 // GetDatabaseConnectionString returns the database connection string
 func (c *ConfigProvider) GetDatabaseConnectionString() string {
 	if c.config.Database.Type == "postgres" {
@@ -188,6 +560,11 @@ func (c *ConfigProvider) IsDevelopment() bool {
 
 // IsProduction returns true if running in production mode
 func (c *ConfigProvider) IsProduction() bool {
+	return isProductionEnvironment()
+}
+
+// isProductionEnvironment reports whether ENVIRONMENT names production
+func isProductionEnvironment() bool {
 	env := strings.ToLower(getEnvString("ENVIRONMENT", "development"))
 	return env == "production" || env == "prod"
 }
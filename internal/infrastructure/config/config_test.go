@@ -19,7 +19,14 @@ func TestGetString(t *testing.T) {
 
 	provider := NewConfigProvider()
 	assert.Equal(t, "test_host", provider.GetString("server.host"))
-	assert.Equal(t, "inmemory", provider.GetString("database.type")) // Default
+	assert.Equal(t, "inmemory", provider.GetString("database.type"))                     // Default
+	assert.Equal(t, "off", provider.GetString("openapi.validation"))                     // Default
+	assert.Equal(t, "private, no-cache", provider.GetString("http_cache.cache_control")) // Default
+	assert.Equal(t, "1MB", provider.GetString("body_limit.default"))                     // Default
+	assert.Empty(t, provider.GetString("tls.client_auth"))                               // Default
+	assert.Equal(t, "serve", provider.GetString("legacy.mode"))                          // Default
+	assert.Empty(t, provider.GetString("legacy.sunset"))                                 // Default
+	assert.Empty(t, provider.GetString("server.trusted_proxies"))                        // Default
 }
 
 func TestGetInt(t *testing.T) {
@@ -28,7 +35,16 @@ func TestGetInt(t *testing.T) {
 
 	provider := NewConfigProvider()
 	assert.Equal(t, 9090, provider.GetInt("server.port"))
-	assert.Equal(t, 5432, provider.GetInt("database.port")) // Default
+	assert.Equal(t, 5432, provider.GetInt("database.port"))                      // Default
+	assert.Equal(t, 0, provider.GetInt("currency.cache_ttl"))                    // Default
+	assert.Equal(t, 2000, provider.GetInt("health.check_timeout_ms"))            // Default
+	assert.Equal(t, 9090, provider.GetInt("grpc.port"))                          // Default
+	assert.Equal(t, 1000, provider.GetInt("events.history_size"))                // Default
+	assert.Equal(t, 10, provider.GetInt("webhooks.max_attempts"))                // Default
+	assert.Equal(t, 10, provider.GetInt("webhooks.timeout_seconds"))             // Default
+	assert.Equal(t, 60, provider.GetInt("http_cache.box_price_max_age_seconds")) // Default
+	assert.Equal(t, 1024, provider.GetInt("compression.min_size_bytes"))         // Default
+	assert.Equal(t, 30, provider.GetInt("tls.reload_interval_seconds"))          // Default
 }
 
 func TestGetDatabaseConnectionString(t *testing.T) {
@@ -58,3 +74,37 @@ func TestIsProduction(t *testing.T) {
 	assert.False(t, provider.IsDevelopment())
 	assert.True(t, provider.IsProduction())
 }
+
+func TestGetBool(t *testing.T) {
+	os.Setenv("AUTH_ENABLED", "true")
+	defer os.Unsetenv("AUTH_ENABLED")
+
+	provider := NewConfigProvider()
+	assert.True(t, provider.GetBool("auth.enabled"))
+	assert.True(t, provider.GetBool("metrics.enabled"))           // Default
+	assert.False(t, provider.GetBool("grpc.enabled"))             // Default
+	assert.False(t, provider.GetBool("ratelimit.enabled"))        // Default
+	assert.False(t, provider.GetBool("events.websocket_enabled")) // Default
+	assert.False(t, provider.GetBool("webhooks.enabled"))         // Default
+	assert.True(t, provider.GetBool("http_cache.enabled"))        // Default
+	assert.True(t, provider.GetBool("compression.enabled"))       // Default
+	assert.True(t, provider.GetBool("tls.http2"))                 // Default
+	assert.False(t, provider.GetBool("unknown.key"))
+}
+
+func TestAuthDisabledByDefault(t *testing.T) {
+	os.Setenv("ENVIRONMENT", "production")
+	defer os.Unsetenv("ENVIRONMENT")
+
+	assert.False(t, NewConfigProvider().GetBool("auth.enabled"))
+}
+
+func TestGetFloat64(t *testing.T) {
+	assert.Equal(t, 1.0, NewConfigProvider().GetFloat64("tracing.sample_ratio")) // Default
+
+	os.Setenv("TRACING_SAMPLE_RATIO", "0.25")
+	defer os.Unsetenv("TRACING_SAMPLE_RATIO")
+
+	assert.Equal(t, 0.25, NewConfigProvider().GetFloat64("tracing.sample_ratio"))
+	assert.Equal(t, 0.0, NewConfigProvider().GetFloat64("unknown.key"))
+}
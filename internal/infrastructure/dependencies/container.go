@@ -3,15 +3,27 @@ package dependencies
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"beers-challenge/api"
+	graphqlAdapter "beers-challenge/internal/adapters/graphql"
+	grpcAdapter "beers-challenge/internal/adapters/grpc"
 	httpAdapter "beers-challenge/internal/adapters/http"
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/domain/webhooks"
 	"beers-challenge/internal/core/ports/primary"
 	"beers-challenge/internal/core/ports/secondary"
 	"beers-challenge/internal/core/services"
+	"beers-challenge/internal/infrastructure/cache"
 	"beers-challenge/internal/infrastructure/config"
 	"beers-challenge/internal/infrastructure/external/currencyLayer"
+	"beers-challenge/internal/infrastructure/external/webhook"
+	"beers-challenge/internal/infrastructure/healthcheck"
 	"beers-challenge/internal/infrastructure/logger"
+	"beers-challenge/internal/infrastructure/metrics"
+	"beers-challenge/internal/infrastructure/security"
 	"beers-challenge/internal/infrastructure/storage"
+	"beers-challenge/internal/infrastructure/tracing"
 )
 
 // Container holds all application dependencies
@@ -21,14 +33,40 @@ type Container struct {
 
 	// Infrastructure
 	logger          secondary.Logger
+	metrics         *metrics.Metrics
+	tracing         *tracing.Provider
+	health          *healthcheck.Registry
+	storage         *storage.RepositoryFactory
 	beerRepository  secondary.BeerRepository
+	pricingRules    secondary.PricingRuleRepository
+	taxRules        secondary.TaxRuleRepository
+	cartRepository  secondary.CartRepository
+	orderRepository secondary.OrderRepository
+	promotionRepo   secondary.PromotionRepository
+	apiKeyRepo      secondary.APIKeyRepository
+	idempotency     secondary.IdempotencyStore
+	rateLimits      secondary.RateLimitStore
+	tokenVerifier   secondary.TokenVerifier
 	currencyService secondary.CurrencyService
+	eventBus        *services.EventBus
+	outbox          secondary.EventOutbox
+	webhookSubs     secondary.WebhookSubscriptionRepository
+	deliveries      secondary.WebhookDeliveryRepository
+	certificates    *security.CertificateReloader
 
 	// Services
-	beerService primary.BeerService
+	beerService    primary.BeerService
+	orderService   primary.OrderService
+	quoteService   primary.QuoteService
+	pricingService primary.PricingService
+	promoService   primary.PromotionService
+	authService    primary.AuthService
+	webhookService primary.WebhookService
+	dispatcher     *services.WebhookDispatcher
 
 	// Adapters
 	httpServer *httpAdapter.Server
+	grpcServer *grpcAdapter.Server
 }
 
 // NewContainer creates and configures a new dependency injection container
@@ -72,38 +110,325 @@ func (c *Container) initInfrastructure() error {
 		c.config.GetString("logger.format"),
 	)
 
-	// Initialize repository
-	repositoryFactory := storage.NewRepositoryFactory(c.config)
 	var err error
-	c.beerRepository, err = repositoryFactory.CreateBeerRepository()
+	c.tracing, err = tracing.NewProvider(context.Background(), c.config)
+	if err != nil {
+		return fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
+	if c.config.GetBool("metrics.enabled") {
+		c.metrics = metrics.NewMetrics()
+	}
+
+	// Adapters register their health checks before they are wrapped by decorators
+	c.health = healthcheck.NewRegistry(time.Duration(c.config.GetInt("health.check_timeout_ms")) * time.Millisecond)
+
+	// Initialize repositories; they share the factory's database connection pool
+	c.storage = storage.NewRepositoryFactory(c.config)
+	c.beerRepository, err = c.storage.CreateBeerRepository()
 	if err != nil {
 		return fmt.Errorf("failed to create beer repository: %w", err)
 	}
+	if checker, ok := c.beerRepository.(secondary.HealthChecker); ok {
+		c.health.Register("database", checker)
+	}
+	if c.metrics != nil {
+		c.beerRepository = metrics.InstrumentBeerRepository(c.beerRepository, c.metrics)
+	}
+	c.beerRepository = tracing.TraceBeerRepository(c.beerRepository)
+
+	c.pricingRules, err = c.storage.CreatePricingRuleRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create pricing rule repository: %w", err)
+	}
+	c.pricingRules = tracing.TracePricingRuleRepository(c.pricingRules)
+
+	c.taxRules, err = c.storage.CreateTaxRuleRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create tax rule repository: %w", err)
+	}
+	c.taxRules = tracing.TraceTaxRuleRepository(c.taxRules)
+
+	c.cartRepository, err = c.storage.CreateCartRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create cart repository: %w", err)
+	}
+	c.cartRepository = tracing.TraceCartRepository(c.cartRepository)
+
+	c.orderRepository, err = c.storage.CreateOrderRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create order repository: %w", err)
+	}
+	c.orderRepository = tracing.TraceOrderRepository(c.orderRepository)
+
+	c.promotionRepo, err = c.storage.CreatePromotionRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create promotion repository: %w", err)
+	}
+	c.promotionRepo = tracing.TracePromotionRepository(c.promotionRepo)
+
+	c.apiKeyRepo, err = c.storage.CreateAPIKeyRepository()
+	if err != nil {
+		return fmt.Errorf("failed to create API key repository: %w", err)
+	}
+	c.apiKeyRepo = tracing.TraceAPIKeyRepository(c.apiKeyRepo)
+
+	c.idempotency, err = c.storage.CreateIdempotencyStore()
+	if err != nil {
+		return fmt.Errorf("failed to create idempotency store: %w", err)
+	}
+	c.idempotency = tracing.TraceIdempotencyStore(c.idempotency)
+
+	if c.config.GetBool("ratelimit.enabled") {
+		c.rateLimits, err = c.storage.CreateRateLimitStore()
+		if err != nil {
+			return fmt.Errorf("failed to create rate limit store: %w", err)
+		}
+	}
 
-	// Initialize currency service
+	if c.config.GetBool("webhooks.enabled") {
+		// The in-memory outbox saves through the decorated repository so beer saves stay instrumented
+		c.outbox, err = c.storage.CreateOutbox(c.beerRepository)
+		if err != nil {
+			return fmt.Errorf("failed to create outbox: %w", err)
+		}
+		c.outbox = tracing.TraceEventOutbox(c.outbox)
+
+		c.webhookSubs, err = c.storage.CreateWebhookSubscriptionRepository()
+		if err != nil {
+			return fmt.Errorf("failed to create webhook subscription repository: %w", err)
+		}
+		c.webhookSubs = tracing.TraceWebhookSubscriptionRepository(c.webhookSubs)
+
+		c.deliveries, err = c.storage.CreateWebhookDeliveryRepository()
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery repository: %w", err)
+		}
+		c.deliveries = tracing.TraceWebhookDeliveryRepository(c.deliveries)
+	}
+
+	c.tokenVerifier, err = security.NewJWTVerifier(c.config)
+	if err != nil {
+		return fmt.Errorf("failed to create JWT verifier: %w", err)
+	}
+
+	// Initialize currency service. Provider metrics sit inside the cache so
+	// they count the calls that actually reach the paid API.
 	c.currencyService = currencyLayer.NewCurrencyService(c.config)
+	if checker, ok := c.currencyService.(secondary.HealthChecker); ok {
+		c.health.Register("currency_provider", checker, healthcheck.NonCritical())
+	}
+	if c.metrics != nil {
+		c.currencyService = metrics.InstrumentCurrencyService(c.currencyService, c.metrics)
+	}
+	if ttl := c.config.GetInt("currency.cache_ttl"); ttl > 0 {
+		cacheOpts := []cache.CurrencyCacheOption{}
+		if c.metrics != nil {
+			cacheOpts = append(cacheOpts, cache.WithRecorder(c.metrics))
+		}
+		rateCache := cache.NewCurrencyCache(c.currencyService, time.Duration(ttl)*time.Second, cacheOpts...)
+		c.health.Register("exchange_rate_cache", rateCache, healthcheck.NonCritical())
+		c.currencyService = rateCache
+	}
+	// Requests pricing many beers at once, such as GraphQL queries, share their lookups
+	c.currencyService = cache.NewRequestBatcher(c.currencyService)
 
 	return nil
 }
 
 // initServices initializes business services
 func (c *Container) initServices() error {
-	c.beerService = services.NewBeerService(
+	c.eventBus = services.NewEventBus(c.config.GetInt("events.history_size"), c.logger)
+
+	beerOpts := []services.BeerServiceOption{
+		services.WithPricingRules(c.pricingRules),
+		services.WithTaxRules(c.taxRules),
+		services.WithPromotions(c.promotionRepo),
+		services.WithEventPublisher(c.eventBus),
+	}
+	if c.outbox != nil {
+		beerOpts = append(beerOpts, services.WithOutbox(c.outbox))
+	}
+	c.beerService = tracing.TraceBeerService(services.NewBeerService(
+		c.beerRepository,
+		c.currencyService,
+		c.logger,
+		beerOpts...,
+	))
+
+	c.pricingService = services.NewPricingService(
+		c.pricingRules,
+		c.beerRepository,
+		c.logger,
+	)
+
+	c.orderService = services.NewOrderService(
+		c.cartRepository,
+		c.orderRepository,
+		c.beerRepository,
+		c.currencyService,
+		c.logger,
+		services.WithOrderPricingRules(c.pricingRules),
+		services.WithCoupons(c.promotionRepo),
+	)
+
+	c.quoteService = services.NewQuoteService(
 		c.beerRepository,
 		c.currencyService,
 		c.logger,
+		services.WithQuotePricingRules(c.pricingRules),
+		services.WithQuotePromotions(c.promotionRepo),
 	)
 
+	c.promoService = services.NewPromotionService(
+		c.promotionRepo,
+		c.logger,
+	)
+
+	authOpts := []services.AuthServiceOption{}
+	if c.tokenVerifier != nil {
+		authOpts = append(authOpts, services.WithTokenVerifier(c.tokenVerifier))
+	}
+	c.authService = services.NewAuthService(c.apiKeyRepo, c.logger, authOpts...)
+
+	if c.outbox != nil {
+		c.webhookService = services.NewWebhookService(c.webhookSubs, c.deliveries, c.logger)
+
+		timeout := time.Duration(c.config.GetInt("webhooks.timeout_seconds")) * time.Second
+		c.dispatcher = services.NewWebhookDispatcher(
+			c.outbox,
+			c.webhookSubs,
+			c.deliveries,
+			webhook.NewSender(timeout),
+			services.WebhookDispatcherSettings{
+				PollInterval: time.Duration(c.config.GetInt("webhooks.poll_interval_ms")) * time.Millisecond,
+				BatchSize:    c.config.GetInt("webhooks.batch_size"),
+				// Every delivery of a batch is sent at once, so one timeout covers the batch
+				Lease: 2 * timeout,
+				Retry: webhooks.RetryPolicy{
+					MaxAttempts:    c.config.GetInt("webhooks.max_attempts"),
+					InitialBackoff: time.Duration(c.config.GetInt("webhooks.initial_backoff_seconds")) * time.Second,
+					MaxBackoff:     time.Duration(c.config.GetInt("webhooks.max_backoff_seconds")) * time.Second,
+				},
+			},
+			c.logger,
+		)
+	}
+
 	return nil
 }
 
 // initAdapters initializes adapters
 func (c *Container) initAdapters() error {
-	c.httpServer = httpAdapter.NewServer(
-		c.beerService,
-		c.config,
-		c.logger,
-	)
+	serverOpts := []httpAdapter.ServerOption{
+		httpAdapter.WithOrderService(c.orderService),
+		httpAdapter.WithQuoteService(c.quoteService),
+		httpAdapter.WithPricingService(c.pricingService),
+		httpAdapter.WithPromotionService(c.promoService),
+		httpAdapter.WithIdempotencyStore(c.idempotency,
+			time.Duration(c.config.GetInt("idempotency.ttl_seconds"))*time.Second),
+		httpAdapter.WithHealthChecks(c.health),
+		httpAdapter.WithGraphQL(graphqlAdapter.NewHandler(c.beerService, c.logger)),
+		httpAdapter.WithEventService(c.eventBus,
+			time.Duration(c.config.GetInt("events.keepalive_seconds"))*time.Second,
+			c.config.GetBool("events.websocket_enabled")),
+	}
+
+	if c.metrics != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithMetrics(c.metrics))
+	}
+
+	if c.webhookService != nil {
+		serverOpts = append(serverOpts, httpAdapter.WithWebhookService(c.webhookService))
+	}
+
+	if c.config.GetBool("http_cache.enabled") {
+		serverOpts = append(serverOpts, httpAdapter.WithHTTPCaching(httpAdapter.CachePolicy{
+			CacheControl:   c.config.GetString("http_cache.cache_control"),
+			BoxPriceMaxAge: time.Duration(c.config.GetInt("http_cache.box_price_max_age_seconds")) * time.Second,
+		}))
+	}
+
+	if c.config.GetBool("compression.enabled") {
+		serverOpts = append(serverOpts, httpAdapter.WithCompression(c.config.GetInt("compression.min_size_bytes")))
+	}
+
+	bodyLimits, err := httpAdapter.ParseBodyLimits(c.config.GetString("body_limit.default"), c.config.GetString("body_limit.routes"))
+	if err != nil {
+		return fmt.Errorf("failed to parse body limits: %w", err)
+	}
+	serverOpts = append(serverOpts, httpAdapter.WithBodyLimits(bodyLimits))
+
+	legacyPolicy, err := httpAdapter.ParseLegacyPolicy(c.config.GetString("legacy.mode"),
+		c.config.GetString("legacy.deprecated_at"),
+		c.config.GetString("legacy.sunset"),
+		c.config.GetString("legacy.docs_url"))
+	if err != nil {
+		return fmt.Errorf("failed to parse legacy route policy: %w", err)
+	}
+	serverOpts = append(serverOpts, httpAdapter.WithLegacyRoutes(legacyPolicy))
+
+	if c.rateLimits != nil {
+		policy, err := ratelimit.ParsePolicy(c.config.GetString("ratelimit.default"), c.config.GetString("ratelimit.routes"))
+		if err != nil {
+			return fmt.Errorf("failed to parse rate limits: %w", err)
+		}
+		serverOpts = append(serverOpts, httpAdapter.WithRateLimiter(c.rateLimits, policy))
+	}
+
+	var grpcOpts []grpcAdapter.ServerOption
+	if certFile := c.config.GetString("tls.cert_file"); certFile != "" {
+		var err error
+		c.certificates, err = security.NewCertificateReloader(certFile,
+			c.config.GetString("tls.key_file"),
+			c.config.GetString("tls.client_ca_file"),
+			time.Duration(c.config.GetInt("tls.reload_interval_seconds"))*time.Second,
+			c.logger)
+		if err != nil {
+			return err
+		}
+
+		clientAuth := c.config.GetString("tls.client_auth")
+		tlsConfig, err := c.certificates.ServerConfig(clientAuth)
+		if err != nil {
+			return err
+		}
+		mode, _ := c.certificates.ClientAuthMode(clientAuth)
+		c.logger.Info(context.Background(), "Serving TLS", map[string]interface{}{
+			"cert_file":   certFile,
+			"client_auth": mode,
+		})
+		serverOpts = append(serverOpts, httpAdapter.WithTLS(tlsConfig, c.config.GetBool("tls.http2")))
+		grpcOpts = append(grpcOpts, grpcAdapter.WithTLS(tlsConfig))
+	}
+
+	if c.config.GetBool("auth.enabled") {
+		serverOpts = append(serverOpts, httpAdapter.WithAuthService(c.authService))
+	} else {
+		c.logger.Warn(context.Background(), "Authentication is disabled; every route is open", nil)
+	}
+
+	switch mode := c.config.GetString("openapi.validation"); mode {
+	case httpAdapter.ContractValidationOff:
+	case httpAdapter.ContractValidationRequests, httpAdapter.ContractValidationTest:
+		contract, err := httpAdapter.LoadContract(api.OpenAPISpec)
+		if err != nil {
+			return err
+		}
+		serverOpts = append(serverOpts,
+			httpAdapter.WithContractValidation(contract, mode == httpAdapter.ContractValidationTest))
+	default:
+		return fmt.Errorf("unknown OpenAPI validation mode %q", mode)
+	}
+
+	c.httpServer = httpAdapter.NewServer(c.beerService, c.config, c.logger, serverOpts...)
+
+	if c.config.GetBool("grpc.enabled") {
+		if c.config.GetBool("auth.enabled") {
+			grpcOpts = append(grpcOpts, grpcAdapter.WithAuthService(c.authService))
+		}
+		c.grpcServer = grpcAdapter.NewServer(c.beerService, c.config, c.logger, grpcOpts...)
+	}
 
 	return nil
 }
@@ -113,6 +438,21 @@ func (c *Container) GetHTTPServer() *httpAdapter.Server {
 	return c.httpServer
 }
 
+// GetGRPCServer returns the gRPC server, or nil when gRPC is disabled
+func (c *Container) GetGRPCServer() *grpcAdapter.Server {
+	return c.grpcServer
+}
+
+// GetCertificateReloader returns the TLS certificate reloader, or nil when TLS is off
+func (c *Container) GetCertificateReloader() *security.CertificateReloader {
+	return c.certificates
+}
+
+// GetWebhookDispatcher returns the webhook dispatcher, or nil when webhooks are disabled
+func (c *Container) GetWebhookDispatcher() *services.WebhookDispatcher {
+	return c.dispatcher
+}
+
 // GetLogger returns the logger
 func (c *Container) GetLogger() secondary.Logger {
 	return c.logger
@@ -128,6 +468,31 @@ func (c *Container) GetBeerService() primary.BeerService {
 	return c.beerService
 }
 
+// GetOrderService returns the order service
+func (c *Container) GetOrderService() primary.OrderService {
+	return c.orderService
+}
+
+// GetQuoteService returns the quote service
+func (c *Container) GetQuoteService() primary.QuoteService {
+	return c.quoteService
+}
+
+// GetPricingService returns the pricing service
+func (c *Container) GetPricingService() primary.PricingService {
+	return c.pricingService
+}
+
+// GetPromotionService returns the promotion service
+func (c *Container) GetPromotionService() primary.PromotionService {
+	return c.promoService
+}
+
+// GetAuthService returns the auth service
+func (c *Container) GetAuthService() primary.AuthService {
+	return c.authService
+}
+
 // GetBeerRepository returns the beer repository
 func (c *Container) GetBeerRepository() secondary.BeerRepository {
 	return c.beerRepository
@@ -143,10 +508,18 @@ func (c *Container) Close() error {
 	ctx := context.TODO()
 	c.logger.Info(ctx, "Closing container resources", nil)
 
-	// Close repository if it has a Close method
-	if closer, ok := c.beerRepository.(interface{ Close() error }); ok {
-		if err := closer.Close(); err != nil {
-			c.logger.Error(ctx, "Failed to close repository", err, nil)
+	// Close the database connection pool shared by the repositories
+	if c.storage != nil {
+		if err := c.storage.Close(); err != nil {
+			c.logger.Error(ctx, "Failed to close database connections", err, nil)
+			return err
+		}
+	}
+
+	// Flush pending spans last, so spans of the shutdown itself are exported
+	if c.tracing != nil {
+		if err := c.tracing.Close(); err != nil {
+			c.logger.Error(ctx, "Failed to flush traces", err, nil)
 			return err
 		}
 	}
@@ -7,6 +7,8 @@ import (
 )
 
 func TestNewContainer(t *testing.T) {
+	t.Setenv("WEBHOOKS_ENABLED", "true")
+
 	container, err := NewContainer()
 	assert.NoError(t, err)
 	assert.NotNil(t, container)
@@ -17,11 +19,20 @@ func TestNewContainer(t *testing.T) {
 	assert.NotNil(t, container.GetCurrencyService())
 	assert.NotNil(t, container.GetBeerService())
 	assert.NotNil(t, container.GetHTTPServer())
+	assert.NotNil(t, container.GetWebhookDispatcher())
 
 	err = container.Close()
 	assert.NoError(t, err)
 }
 
+func TestWebhooksDisabledByDefault(t *testing.T) {
+	container, err := NewContainer()
+	assert.NoError(t, err)
+
+	assert.Nil(t, container.GetWebhookDispatcher())
+	assert.NoError(t, container.Close())
+}
+
 func TestGetters(t *testing.T) {
 	container, _ := NewContainer()
 
@@ -31,4 +42,5 @@ func TestGetters(t *testing.T) {
 	assert.Equal(t, container.currencyService, container.GetCurrencyService())
 	assert.Equal(t, container.beerService, container.GetBeerService())
 	assert.Equal(t, container.httpServer, container.GetHTTPServer())
+	assert.Equal(t, container.authService, container.GetAuthService())
 }
@@ -3,11 +3,16 @@ package currencyLayer
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"sync"
 	"time"
 
+	"beers-challenge/internal/core/domain/currency"
 	"beers-challenge/internal/core/ports/secondary"
+	"beers-challenge/internal/infrastructure/tracing"
 )
 
 // CurrencyService implements the secondary.CurrencyService interface
@@ -15,6 +20,12 @@ type CurrencyService struct {
 	apiKey  string
 	baseURL string
 	client  *http.Client
+
+	// Outcome of the latest provider call, reported by CheckHealth
+	statusMu    sync.RWMutex
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   error
 }
 
 // CurrencyLayerResponse represents the API response structure
@@ -41,6 +52,8 @@ func NewCurrencyService(config ConfigProvider) secondary.CurrencyService {
 		baseURL: "http://api.currencylayer.com/live",
 		client: &http.Client{
 			Timeout: 10 * time.Second,
+			// Each provider request gets a client span and carries the traceparent header
+			Transport: tracing.NewTransport(http.DefaultTransport),
 		},
 	}
 }
@@ -91,45 +104,60 @@ func (s *CurrencyService) GetExchangeRate(ctx context.Context, from, to string)
 }
 
 // getUSDRate gets the USD to target currency rate
-func (s *CurrencyService) getUSDRate(ctx context.Context, currency string) (float64, error) {
-	if currency == "USD" {
+func (s *CurrencyService) getUSDRate(ctx context.Context, code string) (float64, error) {
+	if code == "USD" {
 		return 1.0, nil
 	}
 
-	url := fmt.Sprintf("%s?access_key=%s&currencies=%s", s.baseURL, s.apiKey, currency)
+	rate, err := s.fetchUSDRate(ctx, code)
+	s.recordCall(ctx, err)
+
+	return rate, err
+}
+
+// fetchUSDRate asks the provider for the USD to target currency rate
+func (s *CurrencyService) fetchUSDRate(ctx context.Context, code string) (float64, error) {
+	requestURL := fmt.Sprintf("%s?access_key=%s&currencies=%s", s.baseURL, s.apiKey, code)
 
-	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL, nil)
 	if err != nil {
-		return 0, fmt.Errorf("failed to create request: %w", err)
+		return 0, currency.NewCurrencyError("REQUEST_CREATION_FAILED", "Failed to create request", nil)
 	}
 
 	resp, err := s.client.Do(req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to make request: %w", err)
+		// Transport errors quote the request URL, which carries the API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return 0, currency.NewCurrencyError("API_REQUEST_FAILED", "Failed to make API request", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return 0, currency.NewCurrencyError("API_ERROR", fmt.Sprintf("API returned status %d", resp.StatusCode), nil)
 	}
 
 	var response CurrencyLayerResponse
 	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return 0, fmt.Errorf("failed to decode response: %w", err)
+		return 0, currency.NewCurrencyError("JSON_DECODE_FAILED", "Failed to decode response", err)
 	}
 
 	if !response.Success {
 		if response.Error != nil {
-			return 0, fmt.Errorf("API error: %s (code: %d)", response.Error.Info, response.Error.Code)
+			return 0, currency.NewCurrencyError("API_ERROR",
+				fmt.Sprintf("%s (code: %d)", response.Error.Info, response.Error.Code), nil)
 		}
-		return 0, fmt.Errorf("API request failed")
+		return 0, currency.NewCurrencyError("API_ERROR", "Unknown API error", nil)
 	}
 
 	// CurrencyLayer returns rates as USD{CURRENCY}
-	quoteKey := "USD" + currency
+	quoteKey := "USD" + code
 	rate, exists := response.Quotes[quoteKey]
 	if !exists {
-		return 0, fmt.Errorf("rate not found for currency %s", currency)
+		return 0, currency.NewCurrencyError("RATE_NOT_FOUND",
+			fmt.Sprintf("Exchange rate not found for currency %s", code), nil)
 	}
 
 	return rate, nil
@@ -158,3 +186,61 @@ func (s *CurrencyService) GetSupportedCurrencies(ctx context.Context) ([]string,
 		"BRL", "INR", "RUB", "KRW", "CLP", "ARS", "COP", "PEN",
 	}, nil
 }
+
+// recordCall remembers whether the provider answered. Unknown currencies are an
+// answer, and calls abandoned by their caller say nothing about the provider.
+func (s *CurrencyService) recordCall(ctx context.Context, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	var currencyErr *currency.CurrencyError
+	if errors.As(err, &currencyErr) && currencyErr.Code == "RATE_NOT_FOUND" {
+		err = nil
+	}
+
+	s.statusMu.Lock()
+	defer s.statusMu.Unlock()
+
+	if err != nil {
+		s.lastFailure = time.Now()
+		s.lastError = reportedFailure(err)
+		return
+	}
+
+	s.lastSuccess = time.Now()
+	s.lastError = nil
+}
+
+// reportedFailure keeps only the code and message of a provider error. Health
+// reports are public, and causes such as transport errors are not meant for them.
+func reportedFailure(err error) error {
+	var currencyErr *currency.CurrencyError
+	if errors.As(err, &currencyErr) {
+		return currency.NewCurrencyError(currencyErr.Code, currencyErr.Message, nil)
+	}
+	return errors.New("provider call failed")
+}
+
+// CheckHealth reports the outcome of the latest provider call without making a
+// new one, as every CurrencyLayer request counts against the paid quota
+func (s *CurrencyService) CheckHealth(ctx context.Context) (map[string]interface{}, error) {
+	s.statusMu.RLock()
+	defer s.statusMu.RUnlock()
+
+	details := map[string]interface{}{
+		"api_key_configured": s.apiKey != "",
+	}
+	if !s.lastSuccess.IsZero() {
+		details["last_success_at"] = s.lastSuccess.UTC().Format(time.RFC3339)
+	}
+	if !s.lastFailure.IsZero() {
+		details["last_failure_at"] = s.lastFailure.UTC().Format(time.RFC3339)
+	}
+
+	if s.lastError != nil {
+		return details, fmt.Errorf("latest provider call failed: %w", s.lastError)
+	}
+
+	return details, nil
+}
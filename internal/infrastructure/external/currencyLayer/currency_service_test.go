@@ -0,0 +1,82 @@
+package currencyLayer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/infrastructure/healthcheck"
+)
+
+type stubConfig map[string]string
+
+func (c stubConfig) GetString(key string) string { return c[key] }
+
+// newTestService points a currency service at a fake provider answering with status and body
+func newTestService(t *testing.T, status *int, body *string) *CurrencyService {
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(*status)
+		fmt.Fprint(w, *body)
+	}))
+	t.Cleanup(provider.Close)
+
+	service := NewCurrencyService(stubConfig{"CURRENCY_API_KEY": "key"}).(*CurrencyService)
+	service.baseURL = provider.URL
+	return service
+}
+
+func TestCheckHealthReportsLatestCall(t *testing.T) {
+	status, body := http.StatusOK, `{"success":true,"quotes":{"USDCLP":900}}`
+	service := newTestService(t, &status, &body)
+	ctx := context.Background()
+
+	details, err := service.CheckHealth(ctx)
+	assert.NoError(t, err, "no call yet")
+	assert.Equal(t, true, details["api_key_configured"])
+
+	_, err = service.GetExchangeRate(ctx, "USD", "CLP")
+	assert.NoError(t, err)
+	details, err = service.CheckHealth(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, details, "last_success_at")
+
+	status = http.StatusServiceUnavailable
+	_, err = service.GetExchangeRate(ctx, "USD", "CLP")
+	assert.Error(t, err)
+	details, err = service.CheckHealth(ctx)
+	assert.Error(t, err)
+	assert.Contains(t, details, "last_failure_at")
+
+	status, body = http.StatusOK, `{"success":true,"quotes":{}}`
+	_, err = service.GetExchangeRate(ctx, "USD", "XXX")
+	assert.Error(t, err)
+	_, err = service.CheckHealth(ctx)
+	assert.NoError(t, err, "an unknown currency is an answer from the provider")
+}
+
+func TestCheckHealthDoesNotReportAPIKey(t *testing.T) {
+	provider := httptest.NewServer(http.NotFoundHandler())
+	provider.Close()
+
+	service := NewCurrencyService(stubConfig{"CURRENCY_API_KEY": "secret-key"}).(*CurrencyService)
+	service.baseURL = provider.URL
+	ctx := context.Background()
+
+	_, err := service.GetExchangeRate(ctx, "USD", "CLP")
+	assert.Error(t, err)
+	assert.NotContains(t, err.Error(), "secret-key")
+
+	registry := healthcheck.NewRegistry(time.Second)
+	registry.Register("currency_provider", service)
+	body, err := json.Marshal(registry.Check(ctx))
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), "API_REQUEST_FAILED: Failed to make API request")
+	assert.NotContains(t, string(body), "secret-key")
+}
@@ -0,0 +1,90 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/secondary"
+	"beers-challenge/internal/infrastructure/tracing"
+)
+
+// maxDrainBytes bounds how much of a response body is read so the connection can be reused
+const maxDrainBytes = 64 << 10
+
+// Sender implements the secondary.WebhookSender interface over HTTP
+type Sender struct {
+	client *http.Client
+}
+
+// NewSender creates a sender whose requests give up after timeout. It only
+// connects to public addresses, checked once host names are resolved, so a
+// subscribed host name cannot be pointed at an internal service later on.
+func NewSender(timeout time.Duration) secondary.WebhookSender {
+	return newSender(timeout, webhooks.IsPublicAddress)
+}
+
+// newSender creates a sender that only connects to addresses allowed returns true for
+func newSender(timeout time.Duration, allowed func(ip net.IP) bool) *Sender {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, conn syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !allowed(ip) {
+				return fmt.Errorf("webhook address %s is not public", host)
+			}
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	// A proxy would make the connection on the sender's behalf, past the address check
+	transport.Proxy = nil
+
+	return &Sender{
+		client: &http.Client{
+			Timeout: timeout,
+			// Each delivery gets a client span and carries the traceparent header
+			Transport: tracing.NewTransport(transport),
+			// A redirect would re-send the signed payload to a URL nobody subscribed
+			CheckRedirect: func(req *http.Request, via []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts a JSON body to url and returns the response status code
+func (s *Sender) Send(ctx context.Context, url string, headers map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "beers-challenge-webhooks/1")
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDrainBytes))
+
+	return resp.StatusCode, nil
+}
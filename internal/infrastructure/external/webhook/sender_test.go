@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestSender creates a sender allowed to reach the loopback test servers
+func newTestSender(timeout time.Duration) *Sender {
+	return newSender(timeout, func(net.IP) bool { return true })
+}
+
+func TestSend(t *testing.T) {
+	var received *http.Request
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := newTestSender(time.Second)
+	status, err := sender.Send(context.Background(), server.URL+"/hooks",
+		map[string]string{"X-Webhook-Signature": "t=1,v1=abc"}, []byte(`{"id":"evt_1"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, status)
+	assert.Equal(t, http.MethodPost, received.Method)
+	assert.Equal(t, "/hooks", received.URL.Path)
+	assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+	assert.Equal(t, "t=1,v1=abc", received.Header.Get("X-Webhook-Signature"))
+	assert.JSONEq(t, `{"id":"evt_1"}`, string(body))
+}
+
+func TestSendDoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "https://elsewhere.example.com", http.StatusFound)
+	}))
+	defer server.Close()
+
+	status, err := newTestSender(time.Second).Send(context.Background(), server.URL, nil, []byte(`{}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusFound, status)
+}
+
+func TestSendTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	_, err := newTestSender(50*time.Millisecond).Send(context.Background(), server.URL, nil, []byte(`{}`))
+
+	assert.Error(t, err)
+}
+
+func TestSendRefusesPrivateAddresses(t *testing.T) {
+	var called bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	// localhost resolves to the loopback address the server listens on, as a
+	// rebound DNS name would
+	url := strings.Replace(server.URL, "127.0.0.1", "localhost", 1)
+	_, err := NewSender(time.Second).Send(context.Background(), url, nil, []byte(`{}`))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not public")
+	assert.False(t, called)
+}
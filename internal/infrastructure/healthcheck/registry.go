@@ -0,0 +1,131 @@
+package healthcheck
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/health"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// CheckerFunc adapts a function to the secondary.HealthChecker port
+type CheckerFunc func(ctx context.Context) (map[string]interface{}, error)
+
+// CheckHealth calls f
+func (f CheckerFunc) CheckHealth(ctx context.Context) (map[string]interface{}, error) {
+	return f(ctx)
+}
+
+// registration is a named health check and how it is run
+type registration struct {
+	name     string
+	checker  secondary.HealthChecker
+	timeout  time.Duration
+	critical bool
+}
+
+// CheckOption configures how a registered check is run
+type CheckOption func(*registration)
+
+// WithTimeout overrides the default timeout of a check
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(r *registration) {
+		r.timeout = timeout
+	}
+}
+
+// NonCritical marks a check whose failure degrades the service without taking it
+// out of rotation, such as a third-party provider with a cache in front of it
+func NonCritical() CheckOption {
+	return func(r *registration) {
+		r.critical = false
+	}
+}
+
+// Registry runs the health checks that adapters register with it
+type Registry struct {
+	defaultTimeout time.Duration
+	checks         []registration
+	mu             sync.RWMutex
+}
+
+// NewRegistry creates a registry whose checks time out after defaultTimeout unless overridden
+func NewRegistry(defaultTimeout time.Duration) *Registry {
+	return &Registry{defaultTimeout: defaultTimeout}
+}
+
+// Register adds a check. Checks are critical unless registered as NonCritical.
+func (r *Registry) Register(name string, checker secondary.HealthChecker, opts ...CheckOption) {
+	check := registration{name: name, checker: checker, timeout: r.defaultTimeout, critical: true}
+	for _, opt := range opts {
+		opt(&check)
+	}
+
+	r.mu.Lock()
+	r.checks = append(r.checks, check)
+	r.mu.Unlock()
+}
+
+// Check runs every check concurrently and reports their outcomes in registration order
+func (r *Registry) Check(ctx context.Context) health.Report {
+	r.mu.RLock()
+	checks := append([]registration(nil), r.checks...)
+	r.mu.RUnlock()
+
+	components := make([]health.Component, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check registration) {
+			defer wg.Done()
+			components[i] = run(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	return health.NewReport(components)
+}
+
+// checkResult is what a checker returned
+type checkResult struct {
+	details map[string]interface{}
+	err     error
+}
+
+// run runs one check, giving up once its timeout expires even if the checker
+// ignores its context
+func run(ctx context.Context, check registration) health.Component {
+	ctx, cancel := context.WithTimeout(ctx, check.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan checkResult, 1)
+	go func() {
+		details, err := check.checker.CheckHealth(ctx)
+		done <- checkResult{details: details, err: err}
+	}()
+
+	var result checkResult
+	select {
+	case result = <-done:
+	case <-ctx.Done():
+		result.err = fmt.Errorf("timed out after %s", check.timeout)
+	}
+
+	component := health.Component{
+		Name:      check.name,
+		Status:    health.StatusUp,
+		Critical:  check.critical,
+		LatencyMs: math.Round(float64(time.Since(start).Microseconds())) / 1000,
+		Details:   result.details,
+	}
+	if result.err != nil {
+		component.Status = health.StatusDown
+		component.Error = result.err.Error()
+	}
+
+	return component
+}
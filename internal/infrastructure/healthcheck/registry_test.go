@@ -0,0 +1,77 @@
+package healthcheck
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/health"
+)
+
+func healthy(details map[string]interface{}) CheckerFunc {
+	return func(context.Context) (map[string]interface{}, error) { return details, nil }
+}
+
+func failing(err error) CheckerFunc {
+	return func(context.Context) (map[string]interface{}, error) { return nil, err }
+}
+
+func TestRegistryCheck(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	registry.Register("database", healthy(map[string]interface{}{"open_connections": 2}))
+	registry.Register("currency_provider", failing(errors.New("quota exceeded")), NonCritical())
+
+	report := registry.Check(context.Background())
+
+	assert.Equal(t, health.StatusDegraded, report.Status)
+	assert.Len(t, report.Components, 2)
+
+	database, provider := report.Components[0], report.Components[1]
+	assert.Equal(t, "database", database.Name)
+	assert.Equal(t, health.StatusUp, database.Status)
+	assert.True(t, database.Critical)
+	assert.Equal(t, 2, database.Details["open_connections"])
+
+	assert.Equal(t, "currency_provider", provider.Name)
+	assert.Equal(t, health.StatusDown, provider.Status)
+	assert.False(t, provider.Critical)
+	assert.Equal(t, "quota exceeded", provider.Error)
+}
+
+func TestRegistryCheckTimesOut(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	block := make(chan struct{})
+	defer close(block)
+	registry.Register("database", CheckerFunc(func(context.Context) (map[string]interface{}, error) {
+		<-block // ignores its context
+		return nil, nil
+	}), WithTimeout(20*time.Millisecond))
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, "timed out after 20ms", report.Components[0].Error)
+	assert.GreaterOrEqual(t, report.Components[0].LatencyMs, 20.0)
+}
+
+func TestRegistryRunsChecksConcurrently(t *testing.T) {
+	registry := NewRegistry(time.Second)
+	slow := CheckerFunc(func(context.Context) (map[string]interface{}, error) {
+		time.Sleep(50 * time.Millisecond)
+		return nil, nil
+	})
+	for _, name := range []string{"a", "b", "c", "d"} {
+		registry.Register(name, slow)
+	}
+
+	start := time.Now()
+	report := registry.Check(context.Background())
+
+	assert.Less(t, time.Since(start), 150*time.Millisecond)
+	assert.Equal(t, health.StatusUp, report.Status)
+}
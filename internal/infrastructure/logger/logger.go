@@ -8,6 +8,10 @@ import (
 	"os"
 	"time"
 
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/correlation"
 	"beers-challenge/internal/core/ports/secondary"
 )
 
@@ -28,6 +32,10 @@ type LogEntry struct {
 	Message   string                 `json:"message"`
 	Fields    map[string]interface{} `json:"fields,omitempty"`
 	Error     string                 `json:"error,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+	Principal string                 `json:"principal,omitempty"`
+	TraceID   string                 `json:"trace_id,omitempty"`
+	SpanID    string                 `json:"span_id,omitempty"`
 }
 
 // StructuredLogger implements the secondary.Logger interface
@@ -61,28 +69,28 @@ func NewStructuredLogger(level, format string) secondary.Logger {
 // Info logs an info message
 func (l *StructuredLogger) Info(ctx context.Context, msg string, fields map[string]interface{}) {
 	if l.shouldLog(LevelInfo) {
-		l.log(LevelInfo, msg, fields, nil)
+		l.log(ctx, LevelInfo, msg, fields, nil)
 	}
 }
 
 // Error logs an error message
 func (l *StructuredLogger) Error(ctx context.Context, msg string, err error, fields map[string]interface{}) {
 	if l.shouldLog(LevelError) {
-		l.log(LevelError, msg, fields, err)
+		l.log(ctx, LevelError, msg, fields, err)
 	}
 }
 
 // Debug logs a debug message
 func (l *StructuredLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
 	if l.shouldLog(LevelDebug) {
-		l.log(LevelDebug, msg, fields, nil)
+		l.log(ctx, LevelDebug, msg, fields, nil)
 	}
 }
 
 // Warn logs a warning message
 func (l *StructuredLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
 	if l.shouldLog(LevelWarn) {
-		l.log(LevelWarn, msg, fields, nil)
+		l.log(ctx, LevelWarn, msg, fields, nil)
 	}
 }
 
@@ -99,7 +107,7 @@ func (l *StructuredLogger) shouldLog(level LogLevel) bool {
 }
 
 // log writes the log entry
-func (l *StructuredLogger) log(level LogLevel, msg string, fields map[string]interface{}, err error) {
+func (l *StructuredLogger) log(ctx context.Context, level LogLevel, msg string, fields map[string]interface{}, err error) {
 	entry := LogEntry{
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Level:     string(level),
@@ -111,6 +119,8 @@ func (l *StructuredLogger) log(level LogLevel, msg string, fields map[string]int
 		entry.Error = err.Error()
 	}
 
+	entry.correlate(ctx)
+
 	var output string
 	if l.format == "json" {
 		jsonBytes, jsonErr := json.Marshal(entry)
@@ -130,11 +140,41 @@ func (l *StructuredLogger) log(level LogLevel, msg string, fields map[string]int
 		if entry.Error != "" {
 			output += fmt.Sprintf(" error=%s", entry.Error)
 		}
+		if entry.RequestID != "" {
+			output += fmt.Sprintf(" request_id=%s", entry.RequestID)
+		}
+		if entry.Principal != "" {
+			output += fmt.Sprintf(" principal=%s", entry.Principal)
+		}
+		if entry.TraceID != "" {
+			output += fmt.Sprintf(" trace_id=%s span_id=%s", entry.TraceID, entry.SpanID)
+		}
 	}
 
 	l.logger.Println(output)
 }
 
+// correlate adds the request id, principal and trace of the request that ctx
+// belongs to, so every entry of one request can be found together
+func (e *LogEntry) correlate(ctx context.Context) {
+	if ctx == nil {
+		return
+	}
+
+	if id, ok := correlation.RequestIDFromContext(ctx); ok {
+		e.RequestID = id
+	}
+
+	if principal, ok := auth.PrincipalFromContext(ctx); ok {
+		e.Principal = principal.Subject
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		e.TraceID = spanContext.TraceID().String()
+		e.SpanID = spanContext.SpanID().String()
+	}
+}
+
 // NoOpLogger is a logger that does nothing (useful for testing)
 type NoOpLogger struct{}
 
@@ -11,6 +11,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/correlation"
 )
 
 const (
@@ -113,6 +117,48 @@ func TestTextFormat(t *testing.T) {
 	assert.Contains(t, logOutput, "error="+testError)
 }
 
+func TestTraceIDs(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLogger("info", "json").(*StructuredLogger)
+	logger.logger.SetOutput(&buf)
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	logger.Info(ctx, testMessage, nil)
+
+	var entry LogEntry
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry.TraceID)
+	assert.Equal(t, "00f067aa0ba902b7", entry.SpanID)
+
+	buf.Reset()
+	logger.Info(context.Background(), testMessage, nil)
+	assert.NotContains(t, buf.String(), "trace_id")
+}
+
+func TestRequestContextFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStructuredLogger("info", "json").(*StructuredLogger)
+	logger.logger.SetOutput(&buf)
+
+	ctx := correlation.WithRequestID(context.Background(), "req-42")
+	ctx = auth.WithPrincipal(ctx, &auth.Principal{Subject: "key_0123456789abcdef", Role: auth.RoleReader})
+
+	logger.Error(ctx, testErrorMessage, errors.New(testError), nil)
+
+	var entry LogEntry
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "req-42", entry.RequestID)
+	assert.Equal(t, "key_0123456789abcdef", entry.Principal)
+	assert.Empty(t, entry.TraceID)
+}
+
 func TestNoOpLogger(t *testing.T) {
 	logger := NewNoOpLogger()
 	// These should not panic
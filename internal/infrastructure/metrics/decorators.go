@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// beerRepository decorates a secondary.BeerRepository with operation latency metrics
+type beerRepository struct {
+	next    secondary.BeerRepository
+	metrics *Metrics
+}
+
+// InstrumentBeerRepository wraps a beer repository so every call is timed
+func InstrumentBeerRepository(next secondary.BeerRepository, metrics *Metrics) secondary.BeerRepository {
+	return &beerRepository{next: next, metrics: metrics}
+}
+
+func (r *beerRepository) Save(ctx context.Context, beer *beers.Beer) error {
+	start := time.Now()
+	err := r.next.Save(ctx, beer)
+	r.observe("save", start, err)
+	return err
+}
+
+func (r *beerRepository) FindByID(ctx context.Context, id int) (*beers.Beer, error) {
+	start := time.Now()
+	beer, err := r.next.FindByID(ctx, id)
+	r.observe("find_by_id", start, err)
+	return beer, err
+}
+
+func (r *beerRepository) FindAll(ctx context.Context) ([]beers.Beer, error) {
+	start := time.Now()
+	all, err := r.next.FindAll(ctx)
+	r.observe("find_all", start, err)
+	return all, err
+}
+
+func (r *beerRepository) ExistsByID(ctx context.Context, id int) (bool, error) {
+	start := time.Now()
+	exists, err := r.next.ExistsByID(ctx, id)
+	r.observe("exists_by_id", start, err)
+	return exists, err
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *beerRepository) Close() error {
+	if closer, ok := r.next.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+func (r *beerRepository) observe(operation string, start time.Time, err error) {
+	r.metrics.ObserveRepositoryOperation("beers", operation, time.Since(start), err)
+}
+
+// currencyService decorates a secondary.CurrencyService with provider call metrics
+type currencyService struct {
+	next    secondary.CurrencyService
+	metrics *Metrics
+}
+
+// InstrumentCurrencyService wraps a currency provider so every call is counted and timed
+func InstrumentCurrencyService(next secondary.CurrencyService, metrics *Metrics) secondary.CurrencyService {
+	return &currencyService{next: next, metrics: metrics}
+}
+
+func (s *currencyService) GetExchangeRate(ctx context.Context, from, to string) (float64, error) {
+	start := time.Now()
+	rate, err := s.next.GetExchangeRate(ctx, from, to)
+	s.metrics.ObserveCurrencyCall("get_exchange_rate", time.Since(start), err)
+	return rate, err
+}
+
+func (s *currencyService) IsValidCurrency(ctx context.Context, currency string) (bool, error) {
+	start := time.Now()
+	valid, err := s.next.IsValidCurrency(ctx, currency)
+	s.metrics.ObserveCurrencyCall("is_valid_currency", time.Since(start), err)
+	return valid, err
+}
+
+func (s *currencyService) GetSupportedCurrencies(ctx context.Context) ([]string, error) {
+	start := time.Now()
+	supported, err := s.next.GetSupportedCurrencies(ctx)
+	s.metrics.ObserveCurrencyCall("get_supported_currencies", time.Since(start), err)
+	return supported, err
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+func TestInstrumentBeerRepository(t *testing.T) {
+	m := NewMetrics()
+	repo := InstrumentBeerRepository(inmemory.NewRepository(), m)
+	ctx := context.Background()
+
+	beer := &beers.Beer{ID: 1, Name: "Golden", Brewery: "Kross", Country: "Chile", Price: 10, Currency: "USD"}
+	assert.NoError(t, repo.Save(ctx, beer))
+
+	found, err := repo.FindByID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Golden", found.Name)
+
+	_, err = repo.FindByID(ctx, 99)
+	assert.Error(t, err)
+
+	assert.Equal(t, 3, testutil.CollectAndCount(m.repositoryDuration))
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, w.Body.String(),
+		`beers_repository_operation_duration_seconds_count{operation="find_by_id",outcome="not_found",repository="beers"} 1`)
+
+	closer, ok := repo.(interface{ Close() error })
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+}
+
+type stubCurrencyService struct {
+	err error
+}
+
+func (s stubCurrencyService) GetExchangeRate(context.Context, string, string) (float64, error) {
+	return 2, s.err
+}
+
+func (s stubCurrencyService) IsValidCurrency(context.Context, string) (bool, error) {
+	return true, s.err
+}
+
+func (s stubCurrencyService) GetSupportedCurrencies(context.Context) ([]string, error) {
+	return []string{"USD"}, s.err
+}
+
+func TestInstrumentCurrencyService(t *testing.T) {
+	m := NewMetrics()
+	ctx := context.Background()
+
+	rate, err := InstrumentCurrencyService(stubCurrencyService{}, m).GetExchangeRate(ctx, "EUR", "USD")
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, rate)
+
+	_, err = InstrumentCurrencyService(stubCurrencyService{err: errors.New("quota exceeded")}, m).GetExchangeRate(ctx, "EUR", "USD")
+	assert.Error(t, err)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.currencyCalls.WithLabelValues("get_exchange_rate", OutcomeOK)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.currencyCalls.WithLabelValues("get_exchange_rate", OutcomeError)))
+}
@@ -0,0 +1,165 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+const namespace = "beers"
+
+// Outcomes of an instrumented operation
+const (
+	OutcomeOK       = "ok"
+	OutcomeNotFound = "not_found"
+	OutcomeError    = "error"
+)
+
+// Metrics holds the Prometheus collectors of the service in a registry of its
+// own, so tests and several containers never clash on registration
+type Metrics struct {
+	registry *prometheus.Registry
+
+	httpRequests       *prometheus.CounterVec
+	httpErrors         *prometheus.CounterVec
+	httpDuration       *prometheus.HistogramVec
+	legacyRequests     *prometheus.CounterVec
+	repositoryDuration *prometheus.HistogramVec
+	currencyCalls      *prometheus.CounterVec
+	currencyDuration   *prometheus.HistogramVec
+	cacheRequests      *prometheus.CounterVec
+}
+
+// NewMetrics creates and registers the service metrics together with the Go
+// runtime and process collectors
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_request_errors_total",
+			Help:      "HTTP requests answered with a 5xx status, by method, route template and status code.",
+		}, []string{"method", "route", "status"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		legacyRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_legacy_requests_total",
+			Help:      "Requests to deprecated legacy routes by method, route template and API key, jwt or anonymous.",
+		}, []string{"method", "route", "client"}),
+		repositoryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_operation_duration_seconds",
+			Help:      "Repository operation latency by repository, operation and outcome.",
+			Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+		}, []string{"repository", "operation", "outcome"}),
+		currencyCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "currency_provider_calls_total",
+			Help:      "Calls to the currency provider by operation and outcome.",
+		}, []string{"operation", "outcome"}),
+		currencyDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "currency_provider_call_duration_seconds",
+			Help:      "Currency provider call latency by operation.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"operation"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "cache_requests_total",
+			Help:      "Cache lookups by cache and result (hit or miss).",
+		}, []string{"cache", "result"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.httpRequests,
+		m.httpErrors,
+		m.httpDuration,
+		m.legacyRequests,
+		m.repositoryDuration,
+		m.currencyCalls,
+		m.currencyDuration,
+		m.cacheRequests,
+	)
+
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveHTTPRequest records one HTTP request. route is the route template,
+// such as /api/v1/beers/:id, so the label set stays bounded.
+func (m *Metrics) ObserveHTTPRequest(method, route string, status int, duration time.Duration) {
+	code := strconv.Itoa(status)
+
+	m.httpRequests.WithLabelValues(method, route, code).Inc()
+	m.httpDuration.WithLabelValues(method, route, code).Observe(duration.Seconds())
+	if status >= http.StatusInternalServerError {
+		m.httpErrors.WithLabelValues(method, route, code).Inc()
+	}
+}
+
+// ObserveLegacyRequest counts one request to a deprecated legacy route. client
+// is the API key behind it, "jwt" or "anonymous", so the label set stays
+// bounded by the number of API keys.
+func (m *Metrics) ObserveLegacyRequest(method, route, client string) {
+	m.legacyRequests.WithLabelValues(method, route, client).Inc()
+}
+
+// ObserveRepositoryOperation records the latency of one repository call
+func (m *Metrics) ObserveRepositoryOperation(repository, operation string, duration time.Duration, err error) {
+	m.repositoryDuration.WithLabelValues(repository, operation, Outcome(err)).Observe(duration.Seconds())
+}
+
+// ObserveCurrencyCall records one call to the currency provider
+func (m *Metrics) ObserveCurrencyCall(operation string, duration time.Duration, err error) {
+	m.currencyCalls.WithLabelValues(operation, Outcome(err)).Inc()
+	m.currencyDuration.WithLabelValues(operation).Observe(duration.Seconds())
+}
+
+// CacheHit counts a cache lookup that was answered from the cache
+func (m *Metrics) CacheHit(cache string) {
+	m.cacheRequests.WithLabelValues(cache, "hit").Inc()
+}
+
+// CacheMiss counts a cache lookup that had to go to the source
+func (m *Metrics) CacheMiss(cache string) {
+	m.cacheRequests.WithLabelValues(cache, "miss").Inc()
+}
+
+// Outcome classifies an operation error. Not found domain errors are an
+// expected answer rather than a failure, so they get their own outcome.
+func Outcome(err error) string {
+	if err == nil {
+		return OutcomeOK
+	}
+
+	var domainErr *beers.DomainError
+	if errors.As(err, &domainErr) && strings.HasSuffix(domainErr.Code, "NOT_FOUND") {
+		return OutcomeNotFound
+	}
+
+	return OutcomeError
+}
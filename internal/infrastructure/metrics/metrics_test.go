@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/beers"
+)
+
+func TestObserveHTTPRequest(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/beers/:id", http.StatusOK, 10*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/beers/:id", http.StatusOK, 20*time.Millisecond)
+	m.ObserveHTTPRequest(http.MethodGet, "/api/v1/beers/:id", http.StatusServiceUnavailable, time.Second)
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.httpRequests.WithLabelValues("GET", "/api/v1/beers/:id", "200")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.httpErrors.WithLabelValues("GET", "/api/v1/beers/:id", "503")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(m.httpErrors.WithLabelValues("GET", "/api/v1/beers/:id", "200")))
+	assert.Equal(t, 2, testutil.CollectAndCount(m.httpDuration))
+}
+
+func TestObserveLegacyRequest(t *testing.T) {
+	m := NewMetrics()
+
+	m.ObserveLegacyRequest(http.MethodGet, "/beers", "api_key:key_reporting")
+	m.ObserveLegacyRequest(http.MethodGet, "/beers", "api_key:key_reporting")
+	m.ObserveLegacyRequest(http.MethodGet, "/beers", "anonymous")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.legacyRequests.WithLabelValues("GET", "/beers", "api_key:key_reporting")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.legacyRequests.WithLabelValues("GET", "/beers", "anonymous")))
+}
+
+func TestCacheCounters(t *testing.T) {
+	m := NewMetrics()
+
+	m.CacheHit("exchange_rates")
+	m.CacheHit("exchange_rates")
+	m.CacheMiss("exchange_rates")
+
+	assert.Equal(t, 2.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("exchange_rates", "hit")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.cacheRequests.WithLabelValues("exchange_rates", "miss")))
+}
+
+func TestOutcome(t *testing.T) {
+	assert.Equal(t, OutcomeOK, Outcome(nil))
+	assert.Equal(t, OutcomeNotFound, Outcome(beers.NewDomainError("BEER_NOT_FOUND", "missing", nil)))
+	assert.Equal(t, OutcomeError, Outcome(beers.NewDomainError("BEER_ALREADY_EXISTS", "duplicate", nil)))
+	assert.Equal(t, OutcomeError, Outcome(errors.New("connection refused")))
+}
+
+func TestHandlerExposesRuntimeAndServiceMetrics(t *testing.T) {
+	m := NewMetrics()
+	m.CacheMiss("exchange_rates")
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "go_goroutines")
+	assert.Contains(t, w.Body.String(), `beers_cache_requests_total{cache="exchange_rates",result="miss"} 1`)
+}
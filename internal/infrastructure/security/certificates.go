@@ -0,0 +1,242 @@
+package security
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// Client certificate modes of mutual TLS. An empty mode requires client
+// certificates when there is a client CA bundle, and turns them off otherwise.
+const (
+	// ClientAuthOff accepts connections without asking for a client certificate
+	ClientAuthOff = "off"
+	// ClientAuthRequire refuses connections without a client certificate signed by the CA bundle
+	ClientAuthRequire = "require"
+	// ClientAuthOptional accepts connections without a client certificate, but
+	// verifies the ones presented against the CA bundle
+	ClientAuthOptional = "optional"
+)
+
+// fileVersion tells whether a file changed since it was last loaded
+type fileVersion struct {
+	modTime time.Time
+	size    int64
+}
+
+// CertificateReloader serves a TLS certificate and client CA bundle loaded from
+// files, and reloads them when the files change. Every handshake picks up the
+// current files, so renewed certificates apply to new connections while open
+// ones carry on. When a reload fails, the last good files stay in use.
+type CertificateReloader struct {
+	certFile     string
+	keyFile      string
+	clientCAFile string
+	interval     time.Duration
+	logger       secondary.Logger
+
+	certificate atomic.Pointer[tls.Certificate]
+	clientCAs   atomic.Pointer[x509.CertPool]
+
+	mu       sync.Mutex
+	versions map[string]fileVersion
+}
+
+// NewCertificateReloader loads a certificate and its key and, with a clientCAFile,
+// the CA bundle client certificates are verified against. Run checks the files
+// for changes every interval.
+func NewCertificateReloader(certFile, keyFile, clientCAFile string, interval time.Duration, logger secondary.Logger) (*CertificateReloader, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, errors.New("a TLS certificate needs both a certificate and a key file")
+	}
+
+	reloader := &CertificateReloader{
+		certFile:     certFile,
+		keyFile:      keyFile,
+		clientCAFile: clientCAFile,
+		interval:     interval,
+		logger:       logger,
+		versions:     make(map[string]fileVersion),
+	}
+
+	if _, err := reloader.reloadIfChanged(); err != nil {
+		return nil, err
+	}
+
+	return reloader, nil
+}
+
+// ClientAuthMode returns the client auth mode in effect for a configured mode.
+// A mode set without a client CA bundle is an error rather than silently
+// accepting every client.
+func (r *CertificateReloader) ClientAuthMode(clientAuth string) (string, error) {
+	if r.clientCAFile == "" {
+		if clientAuth != "" && clientAuth != ClientAuthOff {
+			return "", fmt.Errorf("TLS client auth mode %q needs a client CA bundle", clientAuth)
+		}
+		return ClientAuthOff, nil
+	}
+
+	switch clientAuth {
+	case "":
+		return ClientAuthRequire, nil
+	case ClientAuthRequire, ClientAuthOptional:
+		return clientAuth, nil
+	default:
+		return "", fmt.Errorf("unknown TLS client auth mode %q: expected %s or %s", clientAuth, ClientAuthRequire, ClientAuthOptional)
+	}
+}
+
+// ServerConfig returns a TLS configuration serving the current certificate. With a
+// client CA bundle, client certificates are verified against its current contents
+// in the client auth mode in effect for clientAuth.
+func (r *CertificateReloader) ServerConfig(clientAuth string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: r.GetCertificate,
+	}
+
+	mode, err := r.ClientAuthMode(clientAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	// The CA bundle can change at any time, so client certificates are verified
+	// here rather than against a ClientCAs pool fixed at startup
+	switch mode {
+	case ClientAuthOff:
+		return config, nil
+	case ClientAuthRequire:
+		config.ClientAuth = tls.RequireAnyClientCert
+	case ClientAuthOptional:
+		config.ClientAuth = tls.RequestClientCert
+	}
+	config.VerifyConnection = r.verifyClient
+
+	return config, nil
+}
+
+// GetCertificate returns the current certificate
+func (r *CertificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return r.certificate.Load(), nil
+}
+
+// verifyClient verifies the client certificate of a connection, if any, against
+// the current CA bundle. Whether one is required is left to the client auth mode.
+func (r *CertificateReloader) verifyClient(state tls.ConnectionState) error {
+	if len(state.PeerCertificates) == 0 {
+		return nil
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         r.clientCAs.Load(),
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("client certificate rejected: %w", err)
+	}
+
+	return nil
+}
+
+// Run checks the files for changes every interval until ctx is done
+func (r *CertificateReloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.reloadIfChanged()
+			if err != nil {
+				r.logger.Error(ctx, "Failed to reload TLS certificates, keeping the current ones", err, map[string]interface{}{
+					"cert_file": r.certFile,
+				})
+				continue
+			}
+			if reloaded {
+				fields := map[string]interface{}{"cert_file": r.certFile}
+				if leaf := r.certificate.Load().Leaf; leaf != nil {
+					fields["not_after"] = leaf.NotAfter.UTC().Format(time.RFC3339)
+				}
+				r.logger.Info(ctx, "Reloaded TLS certificates", fields)
+			}
+		}
+	}
+}
+
+// reloadIfChanged loads the files when any of them changed since they were last
+// loaded, and reports whether it did. A certificate and key written one after
+// the other may not match in between; later polls load them again until they do.
+func (r *CertificateReloader) reloadIfChanged() (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	files := []string{r.certFile, r.keyFile}
+	if r.clientCAFile != "" {
+		files = append(files, r.clientCAFile)
+	}
+
+	changed := false
+	versions := make(map[string]fileVersion, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return false, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+
+		version := fileVersion{modTime: info.ModTime(), size: info.Size()}
+		versions[file] = version
+		if r.versions[file] != version {
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("failed to load TLS certificate: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.clientCAFile != "" {
+		bundle, err := os.ReadFile(r.clientCAFile)
+		if err != nil {
+			return false, fmt.Errorf("failed to read client CA bundle: %w", err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(bundle) {
+			return false, fmt.Errorf("client CA bundle %s holds no PEM certificates", r.clientCAFile)
+		}
+	}
+
+	r.certificate.Store(&certificate)
+	if clientCAs != nil {
+		r.clientCAs.Store(clientCAs)
+	}
+	// Versions are only recorded once loaded, so files that failed to load are
+	// tried again at the next poll even if they do not change in between
+	r.versions = versions
+
+	return true, nil
+}
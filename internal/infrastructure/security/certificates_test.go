@@ -0,0 +1,254 @@
+package security
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/infrastructure/logger"
+)
+
+// testAuthority issues certificates for tests
+type testAuthority struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestAuthority(t *testing.T, name string) *testAuthority {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testAuthority{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a PEM certificate and key for the common name
+func (a *testAuthority) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, a.cert, &key.PublicKey, a.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+}
+
+// writeFile writes a file with a modification time distinct from its last one
+func writeFile(t *testing.T, path string, data []byte, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// serveTLS serves empty responses over TLS until the test ends, and returns the server URL
+func serveTLS(t *testing.T, tlsConfig *tls.Config) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	// Refused handshakes are expected; keep them out of the test output
+	server := &http.Server{
+		Handler:  http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}),
+		ErrorLog: log.New(io.Discard, "", 0),
+	}
+	go func() { _ = server.Serve(tls.NewListener(listener, tlsConfig)) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	return "https://" + listener.Addr().String()
+}
+
+func serverCommonName(t *testing.T, reloader *CertificateReloader) string {
+	cert, err := reloader.GetCertificate(nil)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return leaf.Subject.CommonName
+}
+
+func TestCertificateReloaderReloadsChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	authority := newTestAuthority(t, "Test CA")
+	start := time.Now().Add(-time.Minute)
+
+	cert, key := authority.issue(t, "first", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, start)
+	writeFile(t, keyFile, key, start)
+
+	reloader, err := NewCertificateReloader(certFile, keyFile, "", time.Second, logger.NewNoOpLogger())
+	require.NoError(t, err)
+	assert.Equal(t, "first", serverCommonName(t, reloader))
+
+	reloaded, err := reloader.reloadIfChanged()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "unchanged files are not loaded again")
+
+	// Renewal writes the certificate first; until the key follows they do not match
+	renewed, renewedKey := authority.issue(t, "second", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, renewed, start.Add(time.Second))
+	_, err = reloader.reloadIfChanged()
+	assert.Error(t, err)
+	assert.Equal(t, "first", serverCommonName(t, reloader))
+
+	// A failed load is retried at every poll, not only when a file changes again
+	_, err = reloader.reloadIfChanged()
+	assert.Error(t, err)
+
+	writeFile(t, keyFile, renewedKey, start.Add(time.Second))
+	reloaded, err = reloader.reloadIfChanged()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "second", serverCommonName(t, reloader))
+}
+
+func TestNewCertificateReloaderErrors(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	cert, key := newTestAuthority(t, "Test CA").issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, time.Now())
+	writeFile(t, keyFile, key, time.Now())
+	notPEM := filepath.Join(dir, "ca.txt")
+	writeFile(t, notPEM, []byte("not a certificate"), time.Now())
+
+	_, err := NewCertificateReloader(certFile, "", "", time.Second, logger.NewNoOpLogger())
+	assert.Error(t, err)
+
+	_, err = NewCertificateReloader(certFile, filepath.Join(dir, "missing.key"), "", time.Second, logger.NewNoOpLogger())
+	assert.Error(t, err)
+
+	_, err = NewCertificateReloader(certFile, keyFile, notPEM, time.Second, logger.NewNoOpLogger())
+	assert.Error(t, err)
+
+	reloader, err := NewCertificateReloader(certFile, keyFile, certFile, time.Second, logger.NewNoOpLogger())
+	require.NoError(t, err)
+	_, err = reloader.ServerConfig("sometimes")
+	assert.Error(t, err)
+	mode, err := reloader.ClientAuthMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ClientAuthRequire, mode)
+
+	// Without a CA bundle client certificates cannot be checked, so asking for
+	// them fails instead of letting every client in
+	reloader, err = NewCertificateReloader(certFile, keyFile, "", time.Second, logger.NewNoOpLogger())
+	require.NoError(t, err)
+	_, err = reloader.ServerConfig(ClientAuthRequire)
+	assert.Error(t, err)
+	tlsConfig, err := reloader.ServerConfig("")
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, tlsConfig.ClientAuth)
+	mode, err = reloader.ClientAuthMode("")
+	require.NoError(t, err)
+	assert.Equal(t, ClientAuthOff, mode)
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile, caFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key"), filepath.Join(dir, "ca.crt")
+	authority := newTestAuthority(t, "Test CA")
+	clientAuthority := newTestAuthority(t, "Client CA")
+	start := time.Now().Add(-time.Minute)
+
+	cert, key := authority.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	writeFile(t, certFile, cert, start)
+	writeFile(t, keyFile, key, start)
+	writeFile(t, caFile, clientAuthority.pem, start)
+
+	trustedPEM, trustedKey := clientAuthority.issue(t, "client", x509.ExtKeyUsageClientAuth)
+	trusted, err := tls.X509KeyPair(trustedPEM, trustedKey)
+	require.NoError(t, err)
+	strangerPEM, strangerKey := authority.issue(t, "stranger", x509.ExtKeyUsageClientAuth)
+	stranger, err := tls.X509KeyPair(strangerPEM, strangerKey)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(authority.cert)
+	get := func(url string, clientCert *tls.Certificate) error {
+		tlsConfig := &tls.Config{RootCAs: roots}
+		if clientCert != nil {
+			tlsConfig.Certificates = []tls.Certificate{*clientCert}
+		}
+		client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+		resp, err := client.Get(url)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	reloader, err := NewCertificateReloader(certFile, keyFile, caFile, time.Second, logger.NewNoOpLogger())
+	require.NoError(t, err)
+
+	for _, mode := range []string{ClientAuthRequire, ClientAuthOptional} {
+		t.Run(mode, func(t *testing.T) {
+			tlsConfig, err := reloader.ServerConfig(mode)
+			require.NoError(t, err)
+			url := serveTLS(t, tlsConfig)
+
+			assert.NoError(t, get(url, &trusted))
+			assert.Error(t, get(url, &stranger))
+			if mode == ClientAuthRequire {
+				assert.Error(t, get(url, nil))
+			} else {
+				assert.NoError(t, get(url, nil))
+			}
+		})
+	}
+
+	t.Run("reloaded CA bundle", func(t *testing.T) {
+		tlsConfig, err := reloader.ServerConfig(ClientAuthRequire)
+		require.NoError(t, err)
+		url := serveTLS(t, tlsConfig)
+
+		writeFile(t, caFile, authority.pem, start.Add(time.Second))
+		_, err = reloader.reloadIfChanged()
+		require.NoError(t, err)
+
+		assert.NoError(t, get(url, &stranger))
+		assert.Error(t, get(url, &trusted))
+	})
+}
@@ -0,0 +1,109 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/golang-jwt/jwt/v4"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/ports/secondary"
+	"beers-challenge/internal/infrastructure/config"
+)
+
+// Claims are the JWT claims accepted by the API. The role claim carries one of
+// the auth roles; the standard subject claim identifies the caller.
+type Claims struct {
+	Role string `json:"role"`
+	Name string `json:"name,omitempty"`
+	jwt.RegisteredClaims
+}
+
+// JWTVerifier implements the secondary.TokenVerifier interface for JWTs signed
+// with a locally configured HMAC secret or RSA key pair
+type JWTVerifier struct {
+	key      interface{}
+	methods  []string
+	issuer   string
+	audience string
+}
+
+// NewJWTVerifier creates a JWT verifier from the auth configuration.
+// It returns nil when neither an HMAC secret nor an RSA public key is configured.
+func NewJWTVerifier(configProvider *config.ConfigProvider) (secondary.TokenVerifier, error) {
+	secret := configProvider.GetString("auth.jwt_hmac_secret")
+	publicKeyFile := configProvider.GetString("auth.jwt_public_key_file")
+
+	verifier := &JWTVerifier{
+		issuer:   configProvider.GetString("auth.jwt_issuer"),
+		audience: configProvider.GetString("auth.jwt_audience"),
+	}
+
+	switch {
+	case secret != "" && publicKeyFile != "":
+		return nil, fmt.Errorf("configure either a JWT HMAC secret or an RSA public key, not both")
+	case secret != "":
+		verifier.key = []byte(secret)
+		verifier.methods = []string{"HS256", "HS384", "HS512"}
+	case publicKeyFile != "":
+		pem, err := os.ReadFile(publicKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWT public key: %w", err)
+		}
+
+		publicKey, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+		}
+
+		verifier.key = publicKey
+		verifier.methods = []string{"RS256", "RS384", "RS512"}
+	default:
+		return nil, nil
+	}
+
+	return verifier, nil
+}
+
+// Verify checks the token signature, expiry, issuer and audience, and returns
+// the principal named by its subject and role claims
+func (v *JWTVerifier) Verify(ctx context.Context, token string) (*auth.Principal, error) {
+	claims := &Claims{}
+	parser := jwt.NewParser(jwt.WithValidMethods(v.methods))
+
+	if _, err := parser.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) {
+		return v.key, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, errors.New("token has no expiry")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("token has no subject")
+	}
+
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return nil, errors.New("token issuer is not accepted")
+	}
+
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return nil, errors.New("token audience is not accepted")
+	}
+
+	role, err := auth.ParseRole(claims.Role)
+	if err != nil {
+		return nil, fmt.Errorf("token role claim is invalid: %w", err)
+	}
+
+	return &auth.Principal{
+		Subject: claims.Subject,
+		Name:    claims.Name,
+		Role:    role,
+		Method:  auth.MethodJWT,
+	}, nil
+}
@@ -0,0 +1,125 @@
+package security
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/infrastructure/config"
+)
+
+const testSecret = "test-secret-with-enough-entropy"
+
+func newTestConfig(mutate func(cfg *config.AuthConfig)) *config.ConfigProvider {
+	cfg := config.NewConfigProvider()
+	mutate(&cfg.GetConfig().Auth)
+	return cfg
+}
+
+func newClaims(role string) Claims {
+	return Claims{
+		Role: role,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user-1",
+			Issuer:    "beer-auth",
+			Audience:  jwt.ClaimStrings{"beer-api"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims Claims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	assert.NoError(t, err)
+	return token
+}
+
+func TestNewJWTVerifierDisabled(t *testing.T) {
+	verifier, err := NewJWTVerifier(newTestConfig(func(cfg *config.AuthConfig) {}))
+
+	assert.NoError(t, err)
+	assert.Nil(t, verifier)
+}
+
+func TestNewJWTVerifierRejectsBothKeys(t *testing.T) {
+	_, err := NewJWTVerifier(newTestConfig(func(cfg *config.AuthConfig) {
+		cfg.JWTHMACSecret = testSecret
+		cfg.JWTPublicKeyFile = "key.pem"
+	}))
+
+	assert.Error(t, err)
+}
+
+func TestVerifyHMAC(t *testing.T) {
+	verifier, err := NewJWTVerifier(newTestConfig(func(cfg *config.AuthConfig) {
+		cfg.JWTHMACSecret = testSecret
+		cfg.JWTIssuer = "beer-auth"
+		cfg.JWTAudience = "beer-api"
+	}))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	principal, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte(testSecret), newClaims("editor")))
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Principal{Subject: "user-1", Role: auth.RoleEditor, Method: auth.MethodJWT}, principal)
+
+	cases := map[string]func(c *Claims){
+		"expired":        func(c *Claims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute)) },
+		"no expiry":      func(c *Claims) { c.ExpiresAt = nil },
+		"no subject":     func(c *Claims) { c.Subject = "" },
+		"wrong issuer":   func(c *Claims) { c.Issuer = "someone-else" },
+		"wrong audience": func(c *Claims) { c.Audience = jwt.ClaimStrings{"other-api"} },
+		"unknown role":   func(c *Claims) { c.Role = "owner" },
+	}
+
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			claims := newClaims("reader")
+			mutate(&claims)
+
+			_, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte(testSecret), claims))
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("wrong secret", func(t *testing.T) {
+		_, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodHS256, []byte("other"), newClaims("reader")))
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyRSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	publicDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	assert.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwt.pem")
+	assert.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}), 0o600))
+
+	verifier, err := NewJWTVerifier(newTestConfig(func(cfg *config.AuthConfig) {
+		cfg.JWTPublicKeyFile = path
+	}))
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	principal, err := verifier.Verify(ctx, sign(t, jwt.SigningMethodRS256, privateKey, newClaims("admin")))
+	assert.NoError(t, err)
+	assert.Equal(t, auth.RoleAdmin, principal.Role)
+
+	// An HMAC token must not be accepted by an RSA verifier, whatever its key
+	_, err = verifier.Verify(ctx, sign(t, jwt.SigningMethodHS256, publicDER, newClaims("admin")))
+	assert.Error(t, err)
+}
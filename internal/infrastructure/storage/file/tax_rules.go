@@ -0,0 +1,94 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// taxRulesDocument is the layout of a tax rules file
+type taxRulesDocument struct {
+	Rules []tax.Rule `json:"rules"`
+}
+
+// TaxRuleRepository implements the secondary.TaxRuleRepository interface from a JSON file.
+// Rules are loaded once at startup and are read-only afterwards.
+type TaxRuleRepository struct {
+	rules     []tax.Rule
+	byCountry map[string][]tax.Rule
+}
+
+// NewTaxRuleRepository loads the tax rules from the given file.
+// An empty path yields a repository without rules.
+func NewTaxRuleRepository(path string) (secondary.TaxRuleRepository, error) {
+	if path == "" {
+		return newTaxRuleRepository(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tax rules file: %w", err)
+	}
+
+	var document taxRulesDocument
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed to parse tax rules file: %w", err)
+	}
+
+	return newTaxRuleRepository(document.Rules)
+}
+
+// newTaxRuleRepository validates and indexes the rules by destination country
+func newTaxRuleRepository(rules []tax.Rule) (*TaxRuleRepository, error) {
+	repo := &TaxRuleRepository{
+		rules:     make([]tax.Rule, 0, len(rules)),
+		byCountry: make(map[string][]tax.Rule),
+	}
+
+	ids := map[string]bool{}
+	for i, rule := range rules {
+		rule.Country = strings.ToUpper(strings.TrimSpace(rule.Country))
+		rule.Currency = strings.ToUpper(strings.TrimSpace(rule.Currency))
+
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid tax rule at index %d: %w", i, err)
+		}
+
+		if ids[rule.ID] {
+			return nil, fmt.Errorf("duplicate tax rule id: %s", rule.ID)
+		}
+		ids[rule.ID] = true
+
+		repo.rules = append(repo.rules, rule)
+		repo.byCountry[rule.Country] = append(repo.byCountry[rule.Country], rule)
+	}
+
+	return repo, nil
+}
+
+// FindByCountry returns the rules of a destination country, in file order
+func (r *TaxRuleRepository) FindByCountry(ctx context.Context, country string) ([]tax.Rule, error) {
+	return copyTaxRules(r.byCountry[strings.ToUpper(country)]), nil
+}
+
+// FindAll returns every rule, in file order
+func (r *TaxRuleRepository) FindAll(ctx context.Context) ([]tax.Rule, error) {
+	return copyTaxRules(r.rules), nil
+}
+
+// copyTaxRules creates a deep copy to avoid external modifications
+func copyTaxRules(rules []tax.Rule) []tax.Rule {
+	result := make([]tax.Rule, len(rules))
+	for i, rule := range rules {
+		rule.Match.BeerIDs = append([]int(nil), rule.Match.BeerIDs...)
+		rule.Match.Breweries = append([]string(nil), rule.Match.Breweries...)
+		rule.Match.Origins = append([]string(nil), rule.Match.Origins...)
+		result[i] = rule
+	}
+	return result
+}
@@ -0,0 +1,73 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeRulesFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "tax_rules.json")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewTaxRuleRepository(t *testing.T) {
+	path := writeRulesFile(t, `{"rules": [
+		{"id": "cl-iva", "name": "IVA", "country": "cl", "kind": "percentage", "rate": 19},
+		{"id": "ie-excise", "name": "Excise", "country": "IE", "kind": "per_litre_alcohol", "rate": 22.55, "currency": "eur"}
+	]}`)
+
+	repo, err := NewTaxRuleRepository(path)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	rules, err := repo.FindByCountry(ctx, "cl")
+	assert.NoError(t, err)
+	assert.Len(t, rules, 1)
+	assert.Equal(t, "CL", rules[0].Country)
+
+	rules, _ = repo.FindByCountry(ctx, "IE")
+	assert.Equal(t, "EUR", rules[0].Currency)
+
+	rules, _ = repo.FindByCountry(ctx, "US")
+	assert.Empty(t, rules)
+
+	rules, _ = repo.FindAll(ctx)
+	assert.Len(t, rules, 2)
+}
+
+func TestNewTaxRuleRepositoryWithoutFile(t *testing.T) {
+	repo, err := NewTaxRuleRepository("")
+	assert.NoError(t, err)
+
+	rules, err := repo.FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestNewTaxRuleRepositoryErrors(t *testing.T) {
+	_, err := NewTaxRuleRepository(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+
+	_, err = NewTaxRuleRepository(writeRulesFile(t, `{"rules": [`))
+	assert.Error(t, err)
+
+	_, err = NewTaxRuleRepository(writeRulesFile(t, `{"rules": [{"id": "x", "name": "X", "country": "CL", "kind": "flat", "rate": 1}]}`))
+	assert.Error(t, err)
+
+	_, err = NewTaxRuleRepository(writeRulesFile(t, `{"rules": [
+		{"id": "x", "name": "X", "country": "CL", "kind": "percentage", "rate": 1},
+		{"id": "x", "name": "Y", "country": "AR", "kind": "percentage", "rate": 2}
+	]}`))
+	assert.Error(t, err)
+}
+
+func TestSampleTaxRulesFile(t *testing.T) {
+	_, err := NewTaxRuleRepository(filepath.Join("..", "..", "..", "..", "config", "tax_rules.json"))
+	assert.NoError(t, err)
+}
@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// APIKeyRepository implements the secondary.APIKeyRepository interface for in-memory storage
+type APIKeyRepository struct {
+	data map[string]*auth.APIKey
+	mu   sync.RWMutex
+}
+
+// NewAPIKeyRepository creates a new in-memory API key repository
+func NewAPIKeyRepository() secondary.APIKeyRepository {
+	return &APIKeyRepository{
+		data: make(map[string]*auth.APIKey),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves an API key to memory
+func (r *APIKeyRepository) Save(ctx context.Context, key *auth.APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[key.ID] = copyAPIKey(key)
+	return nil
+}
+
+// FindByID finds an API key by its ID
+func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	key, exists := r.data[id]
+	if !exists {
+		return nil, beers.NewDomainError(auth.ErrCodeAPIKeyNotFound, fmt.Sprintf("API key with ID %s not found", id), nil)
+	}
+
+	return copyAPIKey(key), nil
+}
+
+// FindAll returns every API key, oldest first
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]auth.APIKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]auth.APIKey, 0, len(r.data))
+	for _, key := range r.data {
+		result = append(result, *copyAPIKey(key))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// copyAPIKey creates a deep copy to avoid external modifications
+func copyAPIKey(key *auth.APIKey) *auth.APIKey {
+	keyCopy := *key
+	if key.RevokedAt != nil {
+		revokedAt := *key.RevokedAt
+		keyCopy.RevokedAt = &revokedAt
+	}
+	return &keyCopy
+}
@@ -0,0 +1,48 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyRepository(t *testing.T) {
+	repo := NewAPIKeyRepository()
+	ctx := context.Background()
+	key, token, err := auth.NewAPIKey("ci", auth.RoleEditor)
+	assert.NoError(t, err)
+
+	assert.NoError(t, repo.Save(ctx, key))
+
+	saved, err := repo.FindByID(ctx, key.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, key, saved)
+	assert.True(t, saved.Verify(token))
+
+	saved.Revoke(time.Now())
+	stored, _ := repo.FindByID(ctx, key.ID)
+	assert.False(t, stored.IsRevoked(), "changes must not leak into the repository before Save")
+
+	assert.NoError(t, repo.Save(ctx, saved))
+	stored, _ = repo.FindByID(ctx, key.ID)
+	assert.True(t, stored.IsRevoked())
+
+	all, err := repo.FindAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+}
+
+func TestAPIKeyRepositoryNotFound(t *testing.T) {
+	repo := NewAPIKeyRepository()
+
+	_, err := repo.FindByID(context.Background(), "key_missing")
+
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, auth.ErrCodeAPIKeyNotFound, domainErr.Code)
+}
@@ -0,0 +1,85 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// IdempotencyStore implements the secondary.IdempotencyStore interface for in-memory storage.
+// Expired records are dropped when their key is reserved again or during periodic sweeps.
+type IdempotencyStore struct {
+	data      map[string]*idempotency.Record
+	mu        sync.Mutex
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// idempotencySweepInterval is how often expired records are swept from memory
+const idempotencySweepInterval = time.Minute
+
+// NewIdempotencyStore creates a new in-memory idempotency store
+func NewIdempotencyStore() secondary.IdempotencyStore {
+	return &IdempotencyStore{
+		data: make(map[string]*idempotency.Record),
+		now:  time.Now,
+	}
+}
+
+// Reserve stores a pending record unless an unexpired record with the same key exists
+func (s *IdempotencyStore) Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	if existing, exists := s.data[record.Key]; exists && !existing.IsExpired(now) {
+		return copyRecord(existing), nil
+	}
+
+	s.data[record.Key] = copyRecord(record)
+	return nil, nil
+}
+
+// Complete stores the response of a reserved record
+func (s *IdempotencyStore) Complete(ctx context.Context, record *idempotency.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.data[record.Key] = copyRecord(record)
+	return nil
+}
+
+// Release forgets a reserved record
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.data, key)
+	return nil
+}
+
+// sweep removes expired records at most once per sweep interval. Callers hold the lock.
+func (s *IdempotencyStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < idempotencySweepInterval {
+		return
+	}
+
+	for key, record := range s.data {
+		if record.IsExpired(now) {
+			delete(s.data, key)
+		}
+	}
+	s.lastSweep = now
+}
+
+// copyRecord creates a deep copy to avoid external modifications
+func copyRecord(record *idempotency.Record) *idempotency.Record {
+	recordCopy := *record
+	recordCopy.Body = append([]byte(nil), record.Body...)
+	return &recordCopy
+}
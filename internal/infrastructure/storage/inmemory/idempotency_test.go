@@ -0,0 +1,54 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beers-challenge/internal/core/domain/idempotency"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyStore(t *testing.T) {
+	store := NewIdempotencyStore()
+	ctx := context.Background()
+	record := idempotency.NewRecord("key", "hash", time.Now(), time.Hour)
+
+	existing, err := store.Reserve(ctx, record)
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+
+	existing, err = store.Reserve(ctx, idempotency.NewRecord("key", "other", time.Now(), time.Hour))
+	assert.NoError(t, err)
+	assert.False(t, existing.Completed)
+	assert.Equal(t, "hash", existing.RequestHash)
+
+	record.Complete(201, "application/json", []byte(`{}`))
+	assert.NoError(t, store.Complete(ctx, record))
+
+	existing, _ = store.Reserve(ctx, idempotency.NewRecord("key", "hash", time.Now(), time.Hour))
+	assert.True(t, existing.Completed)
+	assert.Equal(t, 201, existing.StatusCode)
+
+	assert.NoError(t, store.Release(ctx, "key"))
+	existing, _ = store.Reserve(ctx, idempotency.NewRecord("key", "hash", time.Now(), time.Hour))
+	assert.Nil(t, existing)
+}
+
+func TestIdempotencyStoreExpiry(t *testing.T) {
+	store := NewIdempotencyStore().(*IdempotencyStore)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+
+	_, _ = store.Reserve(ctx, idempotency.NewRecord("old", "hash", now, time.Minute))
+	_, _ = store.Reserve(ctx, idempotency.NewRecord("key", "hash", now, time.Minute))
+
+	now = now.Add(2 * time.Minute)
+	existing, err := store.Reserve(ctx, idempotency.NewRecord("key", "hash", now, time.Minute))
+
+	assert.NoError(t, err)
+	assert.Nil(t, existing)
+	assert.NotContains(t, store.data, "old")
+}
@@ -73,3 +73,14 @@ func (r *Repository) ExistsByID(ctx context.Context, id int) (bool, error) {
 	_, exists := r.data[id]
 	return exists, nil
 }
+
+// CheckHealth always succeeds: memory needs no connection
+func (r *Repository) CheckHealth(ctx context.Context) (map[string]interface{}, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return map[string]interface{}{
+		"backend": "inmemory",
+		"beers":   len(r.data),
+	}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/secondary"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -54,3 +55,13 @@ func TestExistsByID(t *testing.T) {
 	assert.NoError(t, err)
 	assert.False(t, exists)
 }
+
+func TestCheckHealth(t *testing.T) {
+	repo := NewRepository()
+	repo.Save(context.Background(), &beers.Beer{ID: 1, Name: "Test Beer"})
+
+	details, err := repo.(secondary.HealthChecker).CheckHealth(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "inmemory", details["backend"])
+	assert.Equal(t, 1, details["beers"])
+}
@@ -0,0 +1,201 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// CartRepository implements the secondary.CartRepository interface for in-memory storage
+type CartRepository struct {
+	data map[string]*orders.Cart
+	mu   sync.RWMutex
+}
+
+// NewCartRepository creates a new in-memory cart repository
+func NewCartRepository() secondary.CartRepository {
+	return &CartRepository{
+		data: make(map[string]*orders.Cart),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves a cart to memory. A stored cart that has been checked out is never
+// overwritten, so a copy loaded before MarkCheckedOut cannot change its items,
+// and nor is one written since the cart was read.
+func (r *CartRepository) Save(ctx context.Context, cart *orders.Cart) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.checkWritable(cart.ID, cart.Version); err != nil {
+		return err
+	}
+	cart.Version++
+	r.data[cart.ID] = copyCart(cart)
+
+	return nil
+}
+
+// MarkCheckedOut links a stored cart to an order unless it is already checked
+// out or was written since version
+func (r *CartRepository) MarkCheckedOut(ctx context.Context, id string, version int, orderID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	cart, exists := r.data[id]
+	if !exists {
+		return beers.NewDomainError(orders.ErrCodeCartNotFound, fmt.Sprintf("Cart with ID %s not found", id), nil)
+	}
+	if err := r.checkWritable(id, version); err != nil {
+		return err
+	}
+
+	if err := cart.MarkCheckedOut(orderID); err != nil {
+		return err
+	}
+	cart.Version++
+
+	return nil
+}
+
+// checkWritable returns an error unless the stored cart id, if any, is open and
+// still at version
+func (r *CartRepository) checkWritable(id string, version int) error {
+	stored, exists := r.data[id]
+	if !exists {
+		stored = &orders.Cart{}
+	}
+
+	if stored.IsCheckedOut() {
+		return beers.NewDomainError(orders.ErrCodeCartCheckedOut, "Cart has already been checked out", nil)
+	}
+	if stored.Version != version {
+		return beers.NewDomainError(orders.ErrCodeCartConflict, "Cart has changed since it was read", nil)
+	}
+	return nil
+}
+
+// ClearCheckout unlinks a stored cart from an order it was checked out into
+func (r *CartRepository) ClearCheckout(ctx context.Context, id, orderID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if cart, exists := r.data[id]; exists && cart.OrderID == orderID {
+		cart.OrderID = ""
+		cart.UpdatedAt = time.Now()
+		cart.Version++
+	}
+
+	return nil
+}
+
+// FindByID finds a cart by its ID
+func (r *CartRepository) FindByID(ctx context.Context, id string) (*orders.Cart, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	cart, exists := r.data[id]
+	if !exists {
+		return nil, beers.NewDomainError(orders.ErrCodeCartNotFound, fmt.Sprintf("Cart with ID %s not found", id), nil)
+	}
+
+	return copyCart(cart), nil
+}
+
+// copyCart creates a deep copy to avoid external modifications
+func copyCart(cart *orders.Cart) *orders.Cart {
+	cartCopy := *cart
+	cartCopy.Items = append([]orders.CartItem{}, cart.Items...)
+	return &cartCopy
+}
+
+// OrderRepository implements the secondary.OrderRepository interface for in-memory storage
+type OrderRepository struct {
+	data map[string]*orders.Order
+	mu   sync.RWMutex
+}
+
+// NewOrderRepository creates a new in-memory order repository
+func NewOrderRepository() secondary.OrderRepository {
+	return &OrderRepository{
+		data: make(map[string]*orders.Order),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves an order to memory
+func (r *OrderRepository) Save(ctx context.Context, order *orders.Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[order.ID] = copyOrder(order)
+
+	return nil
+}
+
+// UpdateStatus saves the status of a stored order unless it has moved on from previous
+func (r *OrderRepository) UpdateStatus(ctx context.Context, order *orders.Order, previous orders.Status) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, exists := r.data[order.ID]
+	if !exists {
+		return beers.NewDomainError(orders.ErrCodeOrderNotFound, fmt.Sprintf("Order with ID %s not found", order.ID), nil)
+	}
+
+	if stored.Status != previous {
+		return beers.NewDomainError(orders.ErrCodeOrderStatusConflict,
+			fmt.Sprintf("Order status changed from %s to %s concurrently", previous, stored.Status), nil)
+	}
+
+	stored.Status = order.Status
+	stored.UpdatedAt = order.UpdatedAt
+	stored.PaidAt = order.PaidAt
+	stored.ShippedAt = order.ShippedAt
+	stored.CancelledAt = order.CancelledAt
+
+	return nil
+}
+
+// FindByID finds an order by its ID
+func (r *OrderRepository) FindByID(ctx context.Context, id string) (*orders.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	order, exists := r.data[id]
+	if !exists {
+		return nil, beers.NewDomainError(orders.ErrCodeOrderNotFound, fmt.Sprintf("Order with ID %s not found", id), nil)
+	}
+
+	return copyOrder(order), nil
+}
+
+// FindAll finds all orders, oldest first
+func (r *OrderRepository) FindAll(ctx context.Context) ([]orders.Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]orders.Order, 0, len(r.data))
+	for _, order := range r.data {
+		result = append(result, *copyOrder(order))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// copyOrder creates a deep copy to avoid external modifications
+func copyOrder(order *orders.Order) *orders.Order {
+	orderCopy := *order
+	orderCopy.Lines = append([]orders.OrderLine{}, order.Lines...)
+	return &orderCopy
+}
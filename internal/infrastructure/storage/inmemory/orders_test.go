@@ -0,0 +1,154 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartRepositorySaveAndFind(t *testing.T) {
+	repo := NewCartRepository()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(1, 6)
+
+	err := repo.Save(context.Background(), cart)
+	assert.NoError(t, err)
+
+	savedCart, err := repo.FindByID(context.Background(), cart.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, cart, savedCart)
+
+	// Mutating the returned cart must not change the stored one
+	savedCart.Items[0].Quantity = 99
+	reloaded, _ := repo.FindByID(context.Background(), cart.ID)
+	assert.Equal(t, 6, reloaded.Items[0].Quantity)
+}
+
+func TestCartRepositoryNotFound(t *testing.T) {
+	repo := NewCartRepository()
+	_, err := repo.FindByID(context.Background(), "missing")
+	assert.Error(t, err)
+}
+
+func TestCartRepositoryMarkCheckedOut(t *testing.T) {
+	repo := NewCartRepository()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	cart.AddItem(1, 6)
+	assert.NoError(t, repo.Save(ctx, cart))
+
+	assert.NoError(t, repo.MarkCheckedOut(ctx, cart.ID, cart.Version, "order_1"))
+	err := repo.MarkCheckedOut(ctx, cart.ID, cart.Version, "order_2")
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CHECKED_OUT", domainErr.Code)
+
+	// A stale copy saved after the checkout cannot change the checked out cart
+	assert.NoError(t, cart.AddItem(2, 6))
+	err = repo.Save(ctx, cart)
+	domainErr, ok = err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CHECKED_OUT", domainErr.Code)
+	saved, _ := repo.FindByID(ctx, cart.ID)
+	assert.Equal(t, "order_1", saved.OrderID)
+	assert.Len(t, saved.Items, 1)
+
+	// Only the order the cart was checked out into can reopen it
+	assert.NoError(t, repo.ClearCheckout(ctx, cart.ID, "order_2"))
+	saved, _ = repo.FindByID(ctx, cart.ID)
+	assert.Equal(t, "order_1", saved.OrderID)
+
+	assert.NoError(t, repo.ClearCheckout(ctx, cart.ID, "order_1"))
+	reopened, _ := repo.FindByID(ctx, cart.ID)
+	assert.NoError(t, repo.MarkCheckedOut(ctx, cart.ID, reopened.Version, "order_2"))
+
+	assert.Error(t, repo.MarkCheckedOut(ctx, "missing", 1, "order_3"))
+}
+
+func TestCartRepositoryConflict(t *testing.T) {
+	repo := NewCartRepository()
+	ctx := context.Background()
+	cart, _ := orders.NewCart("USD")
+	assert.NoError(t, repo.Save(ctx, cart))
+
+	// Two requests read the cart and add a beer each; the second save would
+	// lose the first beer
+	first, _ := repo.FindByID(ctx, cart.ID)
+	second, _ := repo.FindByID(ctx, cart.ID)
+	assert.NoError(t, first.AddItem(1, 6))
+	assert.NoError(t, repo.Save(ctx, first))
+	assert.NoError(t, second.AddItem(2, 6))
+	err := repo.Save(ctx, second)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CONFLICT", domainErr.Code)
+
+	// A checkout priced from the cart before the first save cannot claim it
+	err = repo.MarkCheckedOut(ctx, cart.ID, second.Version, "order_1")
+	domainErr, ok = err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "CART_CONFLICT", domainErr.Code)
+
+	saved, _ := repo.FindByID(ctx, cart.ID)
+	assert.Equal(t, []orders.CartItem{{BeerID: 1, Quantity: 6}}, saved.Items)
+	assert.False(t, saved.IsCheckedOut())
+	assert.NoError(t, repo.MarkCheckedOut(ctx, cart.ID, saved.Version, "order_1"))
+
+	// A new cart is only stored once
+	assert.Error(t, repo.Save(ctx, &orders.Cart{ID: cart.ID, Currency: "USD"}))
+}
+
+func TestOrderRepositorySaveAndFindAll(t *testing.T) {
+	repo := NewOrderRepository()
+	line := orders.OrderLine{BeerID: 1, Quantity: 6, UnitPrice: 1, LineTotal: 6}
+	order1, _ := orders.NewOrder("cart_1", "USD", []orders.OrderLine{line})
+	order2, _ := orders.NewOrder("cart_2", "USD", []orders.OrderLine{line})
+
+	assert.NoError(t, repo.Save(context.Background(), order1))
+	assert.NoError(t, repo.Save(context.Background(), order2))
+
+	savedOrder, err := repo.FindByID(context.Background(), order1.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, order1, savedOrder)
+
+	allOrders, err := repo.FindAll(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, allOrders, 2)
+}
+
+func TestOrderRepositoryUpdateStatus(t *testing.T) {
+	repo := NewOrderRepository()
+	ctx := context.Background()
+	line := orders.OrderLine{BeerID: 1, Quantity: 6, UnitPrice: 1, LineTotal: 6}
+	order, _ := orders.NewOrder("cart_1", "USD", []orders.OrderLine{line})
+	assert.NoError(t, repo.Save(ctx, order))
+
+	paid, _ := repo.FindByID(ctx, order.ID)
+	cancelled, _ := repo.FindByID(ctx, order.ID)
+	assert.NoError(t, paid.TransitionTo(orders.StatusPaid))
+	assert.NoError(t, cancelled.TransitionTo(orders.StatusCancelled))
+
+	assert.NoError(t, repo.UpdateStatus(ctx, paid, orders.StatusPlaced))
+
+	// The second transition was validated against a status that is gone
+	err := repo.UpdateStatus(ctx, cancelled, orders.StatusPlaced)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, "ORDER_STATUS_CONFLICT", domainErr.Code)
+
+	saved, _ := repo.FindByID(ctx, order.ID)
+	assert.Equal(t, orders.StatusPaid, saved.Status)
+	assert.Nil(t, saved.CancelledAt)
+
+	assert.Error(t, repo.UpdateStatus(ctx, &orders.Order{ID: "missing"}, orders.StatusPlaced))
+}
+
+func TestOrderRepositoryNotFound(t *testing.T) {
+	repo := NewOrderRepository()
+	_, err := repo.FindByID(context.Background(), "missing")
+	assert.Error(t, err)
+}
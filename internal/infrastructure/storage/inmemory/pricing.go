@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PricingRuleRepository implements the secondary.PricingRuleRepository interface for in-memory storage
+type PricingRuleRepository struct {
+	data map[int]*pricing.Rule
+	mu   sync.RWMutex
+}
+
+// NewPricingRuleRepository creates a new in-memory pricing rule repository
+func NewPricingRuleRepository() secondary.PricingRuleRepository {
+	return &PricingRuleRepository{
+		data: make(map[int]*pricing.Rule),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves a pricing rule to memory
+func (r *PricingRuleRepository) Save(ctx context.Context, rule *pricing.Rule) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[rule.BeerID] = copyRule(rule)
+
+	return nil
+}
+
+// FindByBeerID finds the pricing rule of a beer
+func (r *PricingRuleRepository) FindByBeerID(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	rule, exists := r.data[beerID]
+	if !exists {
+		return nil, beers.NewDomainError(pricing.ErrCodeRuleNotFound,
+			fmt.Sprintf("Beer with ID %d has no pricing rule", beerID), nil)
+	}
+
+	return copyRule(rule), nil
+}
+
+// Delete removes the pricing rule of a beer
+func (r *PricingRuleRepository) Delete(ctx context.Context, beerID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.data[beerID]; !exists {
+		return beers.NewDomainError(pricing.ErrCodeRuleNotFound,
+			fmt.Sprintf("Beer with ID %d has no pricing rule", beerID), nil)
+	}
+
+	delete(r.data, beerID)
+	return nil
+}
+
+// copyRule creates a deep copy to avoid external modifications
+func copyRule(rule *pricing.Rule) *pricing.Rule {
+	ruleCopy := *rule
+	ruleCopy.Packs = append([]pricing.PackSKU{}, rule.Packs...)
+	ruleCopy.Tiers = append([]pricing.DiscountTier{}, rule.Tiers...)
+	return &ruleCopy
+}
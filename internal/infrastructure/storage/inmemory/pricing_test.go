@@ -0,0 +1,28 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"beers-challenge/internal/core/domain/pricing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPricingRuleRepository(t *testing.T) {
+	repo := NewPricingRuleRepository()
+	ctx := context.Background()
+	rule, _ := pricing.NewRule(1, []pricing.PackSKU{{SKU: "SIX", Size: 6, Price: 5}}, nil)
+
+	assert.NoError(t, repo.Save(ctx, rule))
+
+	savedRule, err := repo.FindByBeerID(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, rule, savedRule)
+
+	assert.NoError(t, repo.Delete(ctx, 1))
+
+	_, err = repo.FindByBeerID(ctx, 1)
+	assert.Error(t, err)
+	assert.Error(t, repo.Delete(ctx, 1))
+}
@@ -0,0 +1,158 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PromotionRepository implements the secondary.PromotionRepository interface for in-memory storage
+type PromotionRepository struct {
+	data map[string]*promotions.Promotion
+	mu   sync.RWMutex
+}
+
+// NewPromotionRepository creates a new in-memory promotion repository
+func NewPromotionRepository() secondary.PromotionRepository {
+	return &PromotionRepository{
+		data: make(map[string]*promotions.Promotion),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves a promotion to memory. The usage count of an existing promotion
+// is owned by Redeem and is left untouched.
+func (r *PromotionRepository) Save(ctx context.Context, promotion *promotions.Promotion) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if promotion.CouponCode != "" {
+		for id, existing := range r.data {
+			if id != promotion.ID && existing.CouponCode == promotion.CouponCode {
+				return beers.NewDomainError(promotions.ErrCodeCouponExists,
+					fmt.Sprintf("Coupon code %s is already in use", promotion.CouponCode), nil)
+			}
+		}
+	}
+
+	promotionCopy := copyPromotion(promotion)
+	if existing, exists := r.data[promotion.ID]; exists {
+		promotionCopy.UsageCount = existing.UsageCount
+	}
+	r.data[promotion.ID] = promotionCopy
+
+	return nil
+}
+
+// FindByID finds a promotion by its ID
+func (r *PromotionRepository) FindByID(ctx context.Context, id string) (*promotions.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	promotion, exists := r.data[id]
+	if !exists {
+		return nil, promotionNotFound(id)
+	}
+
+	return copyPromotion(promotion), nil
+}
+
+// FindByCouponCode finds the promotion unlocked by a coupon code
+func (r *PromotionRepository) FindByCouponCode(ctx context.Context, code string) (*promotions.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	code = promotions.NormalizeCode(code)
+	for _, promotion := range r.data {
+		if code != "" && promotion.CouponCode == code {
+			return copyPromotion(promotion), nil
+		}
+	}
+
+	return nil, beers.NewDomainError(promotions.ErrCodeNotFound,
+		fmt.Sprintf("Coupon code %s not found", code), nil)
+}
+
+// FindAll returns every promotion, oldest first
+func (r *PromotionRepository) FindAll(ctx context.Context) ([]promotions.Promotion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]promotions.Promotion, 0, len(r.data))
+	for _, promotion := range r.data {
+		result = append(result, *copyPromotion(promotion))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// Delete removes a promotion
+func (r *PromotionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.data[id]; !exists {
+		return promotionNotFound(id)
+	}
+
+	delete(r.data, id)
+	return nil
+}
+
+// Redeem counts one use of a promotion, failing once its usage limit is reached
+func (r *PromotionRepository) Redeem(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promotion, exists := r.data[id]
+	if !exists {
+		return promotionNotFound(id)
+	}
+
+	if promotion.UsageLimit > 0 && promotion.UsageCount >= promotion.UsageLimit {
+		return beers.NewDomainError(promotions.ErrCodeUsageLimitReached,
+			fmt.Sprintf("Promotion %s has reached its usage limit", id), nil)
+	}
+
+	promotion.UsageCount++
+	return nil
+}
+
+// Release gives back one use counted by Redeem
+func (r *PromotionRepository) Release(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	promotion, exists := r.data[id]
+	if !exists {
+		return promotionNotFound(id)
+	}
+
+	if promotion.UsageCount > 0 {
+		promotion.UsageCount--
+	}
+	return nil
+}
+
+// promotionNotFound builds the error returned for unknown promotion IDs
+func promotionNotFound(id string) error {
+	return beers.NewDomainError(promotions.ErrCodeNotFound, fmt.Sprintf("Promotion with ID %s not found", id), nil)
+}
+
+// copyPromotion creates a deep copy to avoid external modifications
+func copyPromotion(promotion *promotions.Promotion) *promotions.Promotion {
+	promotionCopy := *promotion
+	promotionCopy.Scope.Breweries = append([]string(nil), promotion.Scope.Breweries...)
+	promotionCopy.Scope.Countries = append([]string(nil), promotion.Scope.Countries...)
+	promotionCopy.Scope.BeerIDs = append([]int(nil), promotion.Scope.BeerIDs...)
+	return &promotionCopy
+}
@@ -0,0 +1,97 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/promotions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestPromotion(t *testing.T, coupon string, limit int) *promotions.Promotion {
+	promotion, err := promotions.NewPromotion("Promo", promotions.TypePercentage, 10, "", time.Time{}, time.Time{})
+	assert.NoError(t, err)
+	assert.NoError(t, promotion.SetCoupon(coupon, limit))
+	return promotion
+}
+
+func TestPromotionRepository(t *testing.T) {
+	repo := NewPromotionRepository()
+	ctx := context.Background()
+	promotion := newTestPromotion(t, "WELCOME", 0)
+	promotion.Scope.Breweries = []string{"CCU"}
+
+	assert.NoError(t, repo.Save(ctx, promotion))
+
+	saved, err := repo.FindByID(ctx, promotion.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, promotion, saved)
+
+	byCoupon, err := repo.FindByCouponCode(ctx, "welcome")
+	assert.NoError(t, err)
+	assert.Equal(t, promotion.ID, byCoupon.ID)
+
+	all, err := repo.FindAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	assert.NoError(t, repo.Delete(ctx, promotion.ID))
+	_, err = repo.FindByID(ctx, promotion.ID)
+	assert.Error(t, err)
+	assert.Error(t, repo.Delete(ctx, promotion.ID))
+}
+
+func TestPromotionRepositoryDuplicateCoupon(t *testing.T) {
+	repo := NewPromotionRepository()
+	ctx := context.Background()
+
+	assert.NoError(t, repo.Save(ctx, newTestPromotion(t, "WELCOME", 0)))
+
+	err := repo.Save(ctx, newTestPromotion(t, "WELCOME", 0))
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, promotions.ErrCodeCouponExists, domainErr.Code)
+}
+
+func TestPromotionRepositoryRedeem(t *testing.T) {
+	repo := NewPromotionRepository()
+	ctx := context.Background()
+	promotion := newTestPromotion(t, "ONCE", 1)
+	assert.NoError(t, repo.Save(ctx, promotion))
+
+	assert.NoError(t, repo.Redeem(ctx, promotion.ID))
+
+	err := repo.Redeem(ctx, promotion.ID)
+	domainErr, ok := err.(*beers.DomainError)
+	assert.True(t, ok)
+	assert.Equal(t, promotions.ErrCodeUsageLimitReached, domainErr.Code)
+
+	// Saving the promotion again keeps the redemptions already counted
+	assert.NoError(t, repo.Save(ctx, promotion))
+	saved, _ := repo.FindByID(ctx, promotion.ID)
+	assert.Equal(t, 1, saved.UsageCount)
+
+	assert.Error(t, repo.Redeem(ctx, "promo_missing"))
+}
+
+func TestPromotionRepositoryRelease(t *testing.T) {
+	repo := NewPromotionRepository()
+	ctx := context.Background()
+	promotion := newTestPromotion(t, "ONCE", 1)
+	assert.NoError(t, repo.Save(ctx, promotion))
+	assert.NoError(t, repo.Redeem(ctx, promotion.ID))
+
+	assert.NoError(t, repo.Release(ctx, promotion.ID))
+	assert.NoError(t, repo.Redeem(ctx, promotion.ID), "the released use can be redeemed again")
+
+	// Releasing more than was redeemed never goes below zero
+	assert.NoError(t, repo.Release(ctx, promotion.ID))
+	assert.NoError(t, repo.Release(ctx, promotion.ID))
+	saved, _ := repo.FindByID(ctx, promotion.ID)
+	assert.Equal(t, 0, saved.UsageCount)
+
+	assert.Error(t, repo.Release(ctx, "promo_missing"))
+}
@@ -0,0 +1,67 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// rateLimitSweepInterval is how often buckets that have refilled are dropped
+const rateLimitSweepInterval = time.Minute
+
+// rateLimitEntry is a bucket along with the limit it was last used with
+type rateLimitEntry struct {
+	bucket ratelimit.Bucket
+	limit  ratelimit.Limit
+}
+
+// RateLimitStore implements the secondary.RateLimitStore interface for a single process
+type RateLimitStore struct {
+	buckets   map[string]*rateLimitEntry
+	mu        sync.Mutex
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+// NewRateLimitStore creates a new in-memory rate limit store
+func NewRateLimitStore() secondary.RateLimitStore {
+	return &RateLimitStore{
+		buckets: make(map[string]*rateLimitEntry),
+		now:     time.Now,
+	}
+}
+
+// Take takes one request from the bucket of key
+func (s *RateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.now()
+	s.sweep(now)
+
+	entry, exists := s.buckets[key]
+	if !exists {
+		entry = &rateLimitEntry{}
+		s.buckets[key] = entry
+	}
+	entry.limit = limit
+
+	return entry.bucket.Take(limit, now), nil
+}
+
+// sweep drops full buckets, which are indistinguishable from new ones. Callers hold the lock.
+func (s *RateLimitStore) sweep(now time.Time) {
+	if now.Sub(s.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+
+	for key, entry := range s.buckets {
+		if entry.bucket.IsFull(entry.limit, now) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,37 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"beers-challenge/internal/core/domain/ratelimit"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitStore(t *testing.T) {
+	store := NewRateLimitStore().(*RateLimitStore)
+	ctx := context.Background()
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	store.now = func() time.Time { return now }
+	limit := ratelimit.Limit{Requests: 2, Period: time.Minute}
+
+	first, err := store.Take(ctx, "ip:10.0.0.1", limit)
+	assert.NoError(t, err)
+	assert.True(t, first.Allowed)
+
+	second, _ := store.Take(ctx, "ip:10.0.0.1", limit)
+	assert.True(t, second.Allowed)
+
+	third, _ := store.Take(ctx, "ip:10.0.0.1", limit)
+	assert.False(t, third.Allowed)
+
+	other, _ := store.Take(ctx, "ip:10.0.0.2", limit)
+	assert.True(t, other.Allowed, "buckets are per key")
+
+	now = now.Add(2 * time.Minute)
+	refilled, _ := store.Take(ctx, "ip:10.0.0.3", limit)
+	assert.True(t, refilled.Allowed)
+	assert.NotContains(t, store.buckets, "ip:10.0.0.1", "full buckets are swept")
+}
@@ -0,0 +1,261 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// Outbox implements the secondary.EventOutbox interface in process. Beers are
+// saved to the wrapped repository and their events recorded under one lock, so
+// no reader of the outbox sees one without the other.
+type Outbox struct {
+	beerRepo secondary.BeerRepository
+	pending  []events.Event
+	sequence uint64
+	mu       sync.Mutex
+	relayMu  sync.Mutex
+}
+
+// NewOutbox creates a new in-memory outbox saving beers to beerRepo
+func NewOutbox(beerRepo secondary.BeerRepository) secondary.EventOutbox {
+	return &Outbox{beerRepo: beerRepo}
+}
+
+// SaveBeer saves a beer and records its events
+func (o *Outbox) SaveBeer(ctx context.Context, beer *beers.Beer, recorded []events.Event) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if err := o.beerRepo.Save(ctx, beer); err != nil {
+		return err
+	}
+
+	for _, event := range recorded {
+		o.sequence++
+		event = copyEvent(event)
+		event.ID = fmt.Sprintf("evt_%d", o.sequence)
+		o.pending = append(o.pending, event)
+	}
+
+	return nil
+}
+
+// Relay hands the oldest undispatched events to handle and forgets them once
+// it succeeds. Relays run one at a time; saves are not held up meanwhile.
+func (o *Outbox) Relay(ctx context.Context, limit int, handle func(ctx context.Context, pending []events.Event) error) (int, error) {
+	o.relayMu.Lock()
+	defer o.relayMu.Unlock()
+
+	o.mu.Lock()
+	batch := make([]events.Event, 0, limit)
+	for i := 0; i < len(o.pending) && i < limit; i++ {
+		batch = append(batch, copyEvent(o.pending[i]))
+	}
+	o.mu.Unlock()
+
+	if len(batch) == 0 {
+		return 0, nil
+	}
+
+	if err := handle(ctx, batch); err != nil {
+		return 0, err
+	}
+
+	// Events saved while handling were appended behind the batch
+	o.mu.Lock()
+	o.pending = o.pending[len(batch):]
+	o.mu.Unlock()
+
+	return len(batch), nil
+}
+
+// WebhookSubscriptionRepository implements the secondary.WebhookSubscriptionRepository interface for in-memory storage
+type WebhookSubscriptionRepository struct {
+	data map[string]*webhooks.Subscription
+	mu   sync.RWMutex
+}
+
+// NewWebhookSubscriptionRepository creates a new in-memory webhook subscription repository
+func NewWebhookSubscriptionRepository() secondary.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{
+		data: make(map[string]*webhooks.Subscription),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves a subscription to memory
+func (r *WebhookSubscriptionRepository) Save(ctx context.Context, subscription *webhooks.Subscription) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[subscription.ID] = copySubscription(subscription)
+	return nil
+}
+
+// FindByID finds a subscription by its ID
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id string) (*webhooks.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subscription, exists := r.data[id]
+	if !exists {
+		return nil, subscriptionNotFound(id)
+	}
+
+	return copySubscription(subscription), nil
+}
+
+// FindAll returns every subscription, oldest first
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]webhooks.Subscription, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]webhooks.Subscription, 0, len(r.data))
+	for _, subscription := range r.data {
+		result = append(result, *copySubscription(subscription))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.Before(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// Delete removes a subscription
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.data[id]; !exists {
+		return subscriptionNotFound(id)
+	}
+
+	delete(r.data, id)
+	return nil
+}
+
+// WebhookDeliveryRepository implements the secondary.WebhookDeliveryRepository interface for in-memory storage
+type WebhookDeliveryRepository struct {
+	data map[string]*webhooks.Delivery
+	mu   sync.RWMutex
+}
+
+// NewWebhookDeliveryRepository creates a new in-memory webhook delivery repository
+func NewWebhookDeliveryRepository() secondary.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		data: make(map[string]*webhooks.Delivery),
+		mu:   sync.RWMutex{},
+	}
+}
+
+// Save saves a delivery to memory
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *webhooks.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.data[delivery.ID] = copyDelivery(delivery)
+	return nil
+}
+
+// FindByID finds a delivery by its ID
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	delivery, exists := r.data[id]
+	if !exists {
+		return nil, beers.NewDomainError(webhooks.ErrCodeDeliveryNotFound,
+			fmt.Sprintf("Webhook delivery with ID %s not found", id), nil)
+	}
+
+	return copyDelivery(delivery), nil
+}
+
+// FindByStatus returns the deliveries in a status, newest first
+func (r *WebhookDeliveryRepository) FindByStatus(ctx context.Context, status webhooks.DeliveryStatus) ([]webhooks.Delivery, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := []webhooks.Delivery{}
+	for _, delivery := range r.data {
+		if status == "" || delivery.Status == status {
+			result = append(result, *copyDelivery(delivery))
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].CreatedAt.After(result[j].CreatedAt)
+	})
+
+	return result, nil
+}
+
+// ClaimDue leases the pending deliveries due at now, oldest due first
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]webhooks.Delivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	due := []*webhooks.Delivery{}
+	for _, delivery := range r.data {
+		if delivery.Status == webhooks.StatusPending && !delivery.NextAttemptAt.After(now) {
+			due = append(due, delivery)
+		}
+	}
+
+	sort.Slice(due, func(i, j int) bool {
+		return due[i].NextAttemptAt.Before(due[j].NextAttemptAt)
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	result := make([]webhooks.Delivery, 0, len(due))
+	for _, delivery := range due {
+		result = append(result, *copyDelivery(delivery))
+		delivery.NextAttemptAt = now.Add(lease)
+	}
+
+	return result, nil
+}
+
+// subscriptionNotFound returns the error of a missing subscription
+func subscriptionNotFound(id string) error {
+	return beers.NewDomainError(webhooks.ErrCodeSubscriptionNotFound,
+		fmt.Sprintf("Webhook subscription with ID %s not found", id), nil)
+}
+
+// copySubscription creates a deep copy to avoid external modifications
+func copySubscription(subscription *webhooks.Subscription) *webhooks.Subscription {
+	subscriptionCopy := *subscription
+	subscriptionCopy.EventTypes = append([]events.Type{}, subscription.EventTypes...)
+	return &subscriptionCopy
+}
+
+// copyDelivery creates a deep copy to avoid external modifications
+func copyDelivery(delivery *webhooks.Delivery) *webhooks.Delivery {
+	deliveryCopy := *delivery
+	deliveryCopy.Event = copyEvent(delivery.Event)
+	return &deliveryCopy
+}
+
+// copyEvent creates a deep copy to avoid external modifications
+func copyEvent(event events.Event) events.Event {
+	if event.Beer != nil {
+		beer := *event.Beer
+		event.Beer = &beer
+	}
+	if event.PriceChange != nil {
+		change := *event.PriceChange
+		event.PriceChange = &change
+	}
+	return event
+}
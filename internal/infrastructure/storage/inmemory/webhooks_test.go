@@ -0,0 +1,125 @@
+package inmemory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+)
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	beerRepo := NewRepository()
+	outbox := NewOutbox(beerRepo)
+
+	beer, _ := beers.NewBeer(1, "Golden", "Kunstmann", "Chile", 2500, "CLP")
+	require.NoError(t, outbox.SaveBeer(ctx, beer, []events.Event{events.BeerCreated(beer)}))
+
+	saved, err := beerRepo.FindByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "Golden", saved.Name)
+
+	// A failed relay keeps the events for the next one
+	failure := errors.New("database unavailable")
+	_, err = outbox.Relay(ctx, 10, func(ctx context.Context, pending []events.Event) error {
+		return failure
+	})
+	assert.ErrorIs(t, err, failure)
+
+	repriced := *beer
+	repriced.Price = 2800
+	require.NoError(t, outbox.SaveBeer(ctx, &repriced, events.BeerUpdated(beer, &repriced)))
+
+	var relayed []events.Event
+	handle := func(ctx context.Context, pending []events.Event) error {
+		relayed = append(relayed, pending...)
+		return nil
+	}
+
+	n, err := outbox.Relay(ctx, 2, handle)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	n, err = outbox.Relay(ctx, 2, handle)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, _ = outbox.Relay(ctx, 2, handle)
+	assert.Equal(t, 0, n, "dispatched events are relayed once")
+
+	require.Len(t, relayed, 3)
+	assert.Equal(t, []string{"evt_1", "evt_2", "evt_3"}, []string{relayed[0].ID, relayed[1].ID, relayed[2].ID})
+	assert.Equal(t, events.TypeBeerCreated, relayed[0].Type)
+	assert.Equal(t, events.TypePriceChanged, relayed[2].Type)
+}
+
+func TestWebhookSubscriptionRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWebhookSubscriptionRepository()
+	subscription, err := webhooks.NewSubscription("https://partner.example.com/hooks", []events.Type{events.TypeBeerCreated})
+	require.NoError(t, err)
+
+	require.NoError(t, repo.Save(ctx, subscription))
+
+	saved, err := repo.FindByID(ctx, subscription.ID)
+	require.NoError(t, err)
+	assert.Equal(t, subscription, saved)
+
+	saved.EventTypes[0] = events.TypePriceChanged
+	stored, _ := repo.FindByID(ctx, subscription.ID)
+	assert.Equal(t, events.TypeBeerCreated, stored.EventTypes[0], "changes must not leak into the repository")
+
+	all, err := repo.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	require.NoError(t, repo.Delete(ctx, subscription.ID))
+	_, err = repo.FindByID(ctx, subscription.ID)
+	var domainErr *beers.DomainError
+	require.True(t, errors.As(err, &domainErr))
+	assert.Equal(t, webhooks.ErrCodeSubscriptionNotFound, domainErr.Code)
+	assert.Error(t, repo.Delete(ctx, subscription.ID))
+}
+
+func TestWebhookDeliveryRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := NewWebhookDeliveryRepository()
+	subscription := &webhooks.Subscription{ID: "whk_1"}
+	now := time.Now()
+
+	first := webhooks.NewDelivery(subscription, events.Event{ID: "evt_1"}, now.Add(-time.Minute))
+	second := webhooks.NewDelivery(subscription, events.Event{ID: "evt_2"}, now)
+	later := webhooks.NewDelivery(subscription, events.Event{ID: "evt_3"}, now.Add(time.Minute))
+	for _, delivery := range []*webhooks.Delivery{first, second, later} {
+		require.NoError(t, repo.Save(ctx, delivery))
+	}
+
+	claimed, err := repo.ClaimDue(ctx, now, 10, 30*time.Second)
+	require.NoError(t, err)
+	require.Len(t, claimed, 2)
+	assert.Equal(t, first.ID, claimed[0].ID)
+	assert.Equal(t, second.ID, claimed[1].ID)
+
+	claimed, _ = repo.ClaimDue(ctx, now, 10, 30*time.Second)
+	assert.Empty(t, claimed, "leased deliveries are not claimed twice")
+
+	first.Status = webhooks.StatusDead
+	require.NoError(t, repo.Save(ctx, first))
+
+	dead, err := repo.FindByStatus(ctx, webhooks.StatusDead)
+	require.NoError(t, err)
+	require.Len(t, dead, 1)
+	assert.Equal(t, first.ID, dead[0].ID)
+
+	all, _ := repo.FindByStatus(ctx, "")
+	require.Len(t, all, 3)
+	assert.Equal(t, later.ID, all[0].ID, "newest first")
+
+	_, err = repo.FindByID(ctx, "dlv_missing")
+	assert.Error(t, err)
+}
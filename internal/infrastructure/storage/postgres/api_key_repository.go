@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// apiKeyColumns lists the columns read by scanAPIKey, in order
+const apiKeyColumns = `id, name, role, hash, created_at, revoked_at`
+
+// APIKeyRepository implements the secondary.APIKeyRepository interface
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL API key repository
+func NewAPIKeyRepository(db *sql.DB) secondary.APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Save inserts an API key or records its revocation. The hash, role and
+// creation time of an existing key never change.
+func (r *APIKeyRepository) Save(ctx context.Context, key *auth.APIKey) error {
+	query := `
+		INSERT INTO api_key (id, name, role, hash, created_at, revoked_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			revoked_at = EXCLUDED.revoked_at
+	`
+
+	var revokedAt sql.NullTime
+	if key.RevokedAt != nil {
+		revokedAt = sql.NullTime{Time: *key.RevokedAt, Valid: true}
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		key.ID,
+		key.Name,
+		string(key.Role),
+		key.Hash,
+		key.CreatedAt,
+		revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save API key: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds an API key by its ID
+func (r *APIKeyRepository) FindByID(ctx context.Context, id string) (*auth.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key WHERE id = $1`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(auth.ErrCodeAPIKeyNotFound, "API key not found", err)
+		}
+		return nil, fmt.Errorf("failed to find API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// FindAll finds all API keys, oldest first
+func (r *APIKeyRepository) FindAll(ctx context.Context) ([]auth.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_key ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query API keys: %w", err)
+	}
+	defer rows.Close()
+
+	result := []auth.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		result = append(result, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// scanAPIKey scans an API key row selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (*auth.APIKey, error) {
+	var (
+		key       auth.APIKey
+		role      string
+		revokedAt sql.NullTime
+	)
+
+	if err := row.Scan(&key.ID, &key.Name, &role, &key.Hash, &key.CreatedAt, &revokedAt); err != nil {
+		return nil, err
+	}
+
+	key.Role = auth.Role(role)
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
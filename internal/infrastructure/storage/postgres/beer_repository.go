@@ -4,81 +4,30 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"time"
-
-	_ "github.com/lib/pq"
 
 	"beers-challenge/internal/core/domain/beers"
 	"beers-challenge/internal/core/ports/secondary"
-	"beers-challenge/internal/infrastructure/config"
 )
 
 // Repository implements the secondary.BeerRepository interface
 type Repository struct {
-	db     *sql.DB
-	config *config.ConfigProvider
+	db *sql.DB
 }
 
-// NewRepository creates a new PostgreSQL repository
-func NewRepository(configProvider *config.ConfigProvider) (secondary.BeerRepository, error) {
-	connStr := configProvider.GetDatabaseConnectionString()
-
-	db, err := sql.Open("postgres", connStr)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database connection: %w", err)
-	}
-
-	if err := db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
-	}
-
-	// Set connection pool settings
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(25)
-	db.SetConnMaxLifetime(5 * time.Minute)
-
-	return &Repository{
-		db:     db,
-		config: configProvider,
-	}, nil
+// NewRepository creates a new PostgreSQL repository on the shared connection pool
+func NewRepository(db *sql.DB) secondary.BeerRepository {
+	return &Repository{db: db}
 }
 
 // Save saves a beer to the database
 func (r *Repository) Save(ctx context.Context, beer *beers.Beer) error {
-	query := `
-		INSERT INTO beer (id, name, brewery, country, price, currency, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (id) DO UPDATE SET
-			name = EXCLUDED.name,
-			brewery = EXCLUDED.brewery,
-			country = EXCLUDED.country,
-			price = EXCLUDED.price,
-			currency = EXCLUDED.currency,
-			updated_at = EXCLUDED.updated_at
-	`
-
-	_, err := r.db.ExecContext(ctx, query,
-		beer.ID,
-		beer.Name,
-		beer.Brewery,
-		beer.Country,
-		beer.Price,
-		beer.Currency,
-		beer.CreatedAt,
-		beer.UpdatedAt,
-	)
-
-	if err != nil {
-		return fmt.Errorf("failed to save beer: %w", err)
-	}
-
-	return nil
+	return saveBeer(ctx, r.db, beer)
 }
 
 // FindByID finds a beer by its ID
 func (r *Repository) FindByID(ctx context.Context, id int) (*beers.Beer, error) {
 	query := `
-		SELECT id, name, brewery, country, price, currency, created_at, updated_at
+		SELECT id, name, brewery, country, price, currency, abv, volume_ml, created_at, updated_at
 		FROM beer
 		WHERE id = $1
 	`
@@ -93,6 +42,8 @@ func (r *Repository) FindByID(ctx context.Context, id int) (*beers.Beer, error)
 		&beer.Country,
 		&beer.Price,
 		&beer.Currency,
+		&beer.ABV,
+		&beer.VolumeML,
 		&beer.CreatedAt,
 		&beer.UpdatedAt,
 	)
@@ -110,7 +61,7 @@ func (r *Repository) FindByID(ctx context.Context, id int) (*beers.Beer, error)
 // FindAll finds all beers
 func (r *Repository) FindAll(ctx context.Context) ([]beers.Beer, error) {
 	query := `
-		SELECT id, name, brewery, country, price, currency, created_at, updated_at
+		SELECT id, name, brewery, country, price, currency, abv, volume_ml, created_at, updated_at
 		FROM beer
 		ORDER BY id
 	`
@@ -131,6 +82,8 @@ func (r *Repository) FindAll(ctx context.Context) ([]beers.Beer, error) {
 			&beer.Country,
 			&beer.Price,
 			&beer.Currency,
+			&beer.ABV,
+			&beer.VolumeML,
 			&beer.CreatedAt,
 			&beer.UpdatedAt,
 		)
@@ -160,7 +113,60 @@ func (r *Repository) ExistsByID(ctx context.Context, id int) (bool, error) {
 	return exists, nil
 }
 
-// Close closes the database connection
-func (r *Repository) Close() error {
-	return r.db.Close()
+// CheckHealth pings the database and reports the connection pool usage
+func (r *Repository) CheckHealth(ctx context.Context) (map[string]interface{}, error) {
+	stats := r.db.Stats()
+	details := map[string]interface{}{
+		"backend":          "postgres",
+		"open_connections": stats.OpenConnections,
+		"in_use":           stats.InUse,
+		"idle":             stats.Idle,
+	}
+
+	if err := r.db.PingContext(ctx); err != nil {
+		return details, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	return details, nil
+}
+
+// execer abstracts *sql.DB and *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// saveBeer upserts a beer, on its own or as part of a transaction
+func saveBeer(ctx context.Context, db execer, beer *beers.Beer) error {
+	query := `
+		INSERT INTO beer (id, name, brewery, country, price, currency, abv, volume_ml, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			brewery = EXCLUDED.brewery,
+			country = EXCLUDED.country,
+			price = EXCLUDED.price,
+			currency = EXCLUDED.currency,
+			abv = EXCLUDED.abv,
+			volume_ml = EXCLUDED.volume_ml,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := db.ExecContext(ctx, query,
+		beer.ID,
+		beer.Name,
+		beer.Brewery,
+		beer.Country,
+		beer.Price,
+		beer.Currency,
+		beer.ABV,
+		beer.VolumeML,
+		beer.CreatedAt,
+		beer.UpdatedAt,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save beer: %w", err)
+	}
+
+	return nil
 }
@@ -0,0 +1,34 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"beers-challenge/internal/infrastructure/config"
+)
+
+// OpenDatabase opens and verifies the PostgreSQL connection pool. It is opened
+// once and shared by every repository, so the pool limits bound the service as
+// a whole rather than each table.
+func OpenDatabase(configProvider *config.ConfigProvider) (*sql.DB, error) {
+	connStr := configProvider.GetDatabaseConnectionString()
+
+	db, err := sql.Open("postgres", connStr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(25)
+	db.SetMaxIdleConns(25)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	return db, nil
+}
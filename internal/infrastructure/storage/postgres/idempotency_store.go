@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// idempotencyPurgeInterval is how often expired records are deleted
+const idempotencyPurgeInterval = 10 * time.Minute
+
+// IdempotencyStore implements the secondary.IdempotencyStore interface
+type IdempotencyStore struct {
+	db         *sql.DB
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// NewIdempotencyStore creates a new PostgreSQL idempotency store
+func NewIdempotencyStore(db *sql.DB) secondary.IdempotencyStore {
+	return &IdempotencyStore{db: db}
+}
+
+// Reserve inserts a pending record in one statement, replacing an expired record
+// with the same key, so concurrent requests with one key cannot both proceed
+func (s *IdempotencyStore) Reserve(ctx context.Context, record *idempotency.Record) (*idempotency.Record, error) {
+	s.purgeExpired(ctx, record.CreatedAt)
+
+	query := `
+		INSERT INTO idempotency_key (key, request_hash, completed, created_at, expires_at)
+		VALUES ($1, $2, FALSE, $3, $4)
+		ON CONFLICT (key) DO UPDATE SET
+			request_hash = EXCLUDED.request_hash,
+			completed = FALSE,
+			status_code = 0,
+			content_type = '',
+			body = NULL,
+			created_at = EXCLUDED.created_at,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_key.expires_at <= EXCLUDED.created_at
+	`
+
+	result, err := s.db.ExecContext(ctx, query, record.Key, record.RequestHash, record.CreatedAt, record.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return nil, nil
+	}
+
+	existing := &idempotency.Record{Key: record.Key}
+	err = s.db.QueryRowContext(ctx, `
+		SELECT request_hash, completed, status_code, content_type, body, created_at, expires_at
+		FROM idempotency_key WHERE key = $1
+	`, record.Key).Scan(
+		&existing.RequestHash,
+		&existing.Completed,
+		&existing.StatusCode,
+		&existing.ContentType,
+		&existing.Body,
+		&existing.CreatedAt,
+		&existing.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+
+	return existing, nil
+}
+
+// Complete stores the response of a reserved record
+func (s *IdempotencyStore) Complete(ctx context.Context, record *idempotency.Record) error {
+	query := `
+		UPDATE idempotency_key
+		SET completed = TRUE, status_code = $2, content_type = $3, body = $4
+		WHERE key = $1
+	`
+
+	if _, err := s.db.ExecContext(ctx, query, record.Key, record.StatusCode, record.ContentType, record.Body); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// Release forgets a reserved record
+func (s *IdempotencyStore) Release(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM idempotency_key WHERE key = $1`, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+// purgeExpired deletes expired records at most once per purge interval. Failures
+// are ignored: expired records are still replaced when their key is reused.
+func (s *IdempotencyStore) purgeExpired(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurged) < idempotencyPurgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurged = now
+	s.mu.Unlock()
+
+	_, _ = s.db.ExecContext(ctx, `DELETE FROM idempotency_key WHERE expires_at <= $1`, now)
+}
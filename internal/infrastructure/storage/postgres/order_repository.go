@@ -0,0 +1,452 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// CartRepository implements the secondary.CartRepository interface
+type CartRepository struct {
+	db *sql.DB
+}
+
+// NewCartRepository creates a new PostgreSQL cart repository
+func NewCartRepository(db *sql.DB) secondary.CartRepository {
+	return &CartRepository{db: db}
+}
+
+// Save saves a cart and replaces its items in a single transaction. A stored
+// cart row is only written while it is still open and at the version the cart
+// was read at, and holds its lock until the items are replaced, so neither a
+// cart loaded before MarkCheckedOut nor one loaded before another save can
+// overwrite the items.
+func (r *CartRepository) Save(ctx context.Context, cart *orders.Cart) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var version int
+	if cart.Version == 0 {
+		err = tx.QueryRowContext(ctx, `
+			INSERT INTO cart (id, currency, order_id, created_at, updated_at, version)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5, 1)
+			ON CONFLICT (id) DO NOTHING
+			RETURNING version
+		`, cart.ID, cart.Currency, cart.OrderID, cart.CreatedAt, cart.UpdatedAt).Scan(&version)
+	} else {
+		err = tx.QueryRowContext(ctx, `
+			UPDATE cart SET updated_at = $2, version = version + 1
+			WHERE id = $1 AND version = $3 AND order_id IS NULL
+			RETURNING version
+		`, cart.ID, cart.UpdatedAt, cart.Version).Scan(&version)
+	}
+	if err == sql.ErrNoRows {
+		return cartWriteConflict(ctx, tx, cart.ID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save cart: %w", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM cart_item WHERE cart_id = $1`, cart.ID); err != nil {
+		return fmt.Errorf("failed to clear cart items: %w", err)
+	}
+
+	for position, item := range cart.Items {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO cart_item (cart_id, position, beer_id, quantity) VALUES ($1, $2, $3, $4)`,
+			cart.ID, position, item.BeerID, item.Quantity,
+		); err != nil {
+			return fmt.Errorf("failed to save cart item: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit cart: %w", err)
+	}
+
+	cart.Version = version
+	return nil
+}
+
+// rowQueryer runs a query returning one row, on its own or as part of a transaction
+type rowQueryer interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// cartWriteConflict tells why a conditional write of cart id matched no row:
+// the cart does not exist, has been checked out or was written since it was read
+func cartWriteConflict(ctx context.Context, db rowQueryer, id string) error {
+	var checkedOut bool
+	err := db.QueryRowContext(ctx, `SELECT order_id IS NOT NULL FROM cart WHERE id = $1`, id).Scan(&checkedOut)
+	if err == sql.ErrNoRows {
+		return beers.NewDomainError(orders.ErrCodeCartNotFound, "Cart not found", err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	if checkedOut {
+		return beers.NewDomainError(orders.ErrCodeCartCheckedOut, "Cart has already been checked out", nil)
+	}
+	return beers.NewDomainError(orders.ErrCodeCartConflict, "Cart has changed since it was read", nil)
+}
+
+// FindByID finds a cart by its ID
+func (r *CartRepository) FindByID(ctx context.Context, id string) (*orders.Cart, error) {
+	query := `
+		SELECT id, currency, COALESCE(order_id, ''), created_at, updated_at, version
+		FROM cart
+		WHERE id = $1
+	`
+
+	var cart orders.Cart
+	err := r.db.QueryRowContext(ctx, query, id).Scan(
+		&cart.ID,
+		&cart.Currency,
+		&cart.OrderID,
+		&cart.CreatedAt,
+		&cart.UpdatedAt,
+		&cart.Version,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(orders.ErrCodeCartNotFound, "Cart not found", err)
+		}
+		return nil, fmt.Errorf("failed to find cart: %w", err)
+	}
+
+	rows, err := r.db.QueryContext(ctx,
+		`SELECT beer_id, quantity FROM cart_item WHERE cart_id = $1 ORDER BY position`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cart items: %w", err)
+	}
+	defer rows.Close()
+
+	cart.Items = []orders.CartItem{}
+	for rows.Next() {
+		var item orders.CartItem
+		if err := rows.Scan(&item.BeerID, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan cart item: %w", err)
+		}
+		cart.Items = append(cart.Items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return &cart, nil
+}
+
+// MarkCheckedOut links a cart to an order with a conditional update, so only one
+// of concurrent checkouts finds the cart still open, and only while the cart is
+// at the version its items were priced from
+func (r *CartRepository) MarkCheckedOut(ctx context.Context, id string, version int, orderID string) error {
+	result, err := r.db.ExecContext(ctx,
+		`UPDATE cart SET order_id = $2, updated_at = NOW(), version = version + 1
+		WHERE id = $1 AND version = $3 AND order_id IS NULL`,
+		id, orderID, version)
+	if err != nil {
+		return fmt.Errorf("failed to check out cart: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check out cart: %w", err)
+	}
+	if updated == 0 {
+		return cartWriteConflict(ctx, r.db, id)
+	}
+
+	return nil
+}
+
+// ClearCheckout unlinks a cart from the order it was checked out into
+func (r *CartRepository) ClearCheckout(ctx context.Context, id, orderID string) error {
+	if _, err := r.db.ExecContext(ctx,
+		`UPDATE cart SET order_id = NULL, updated_at = NOW(), version = version + 1 WHERE id = $1 AND order_id = $2`,
+		id, orderID); err != nil {
+		return fmt.Errorf("failed to reopen cart: %w", err)
+	}
+
+	return nil
+}
+
+// OrderRepository implements the secondary.OrderRepository interface
+type OrderRepository struct {
+	db *sql.DB
+}
+
+// NewOrderRepository creates a new PostgreSQL order repository
+func NewOrderRepository(db *sql.DB) secondary.OrderRepository {
+	return &OrderRepository{db: db}
+}
+
+// Save inserts a new order with its lines or updates the status of an existing one.
+// Order lines are immutable once written.
+func (r *OrderRepository) Save(ctx context.Context, order *orders.Order) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO purchase_order (id, cart_id, currency, coupon, discount, total, status, created_at, updated_at,
+			paid_at, shipped_at, cancelled_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			updated_at = EXCLUDED.updated_at,
+			paid_at = EXCLUDED.paid_at,
+			shipped_at = EXCLUDED.shipped_at,
+			cancelled_at = EXCLUDED.cancelled_at
+		RETURNING (xmax = 0)
+	`
+
+	var inserted bool
+	if err := tx.QueryRowContext(ctx, query,
+		order.ID,
+		order.CartID,
+		order.Currency,
+		order.Coupon,
+		order.Discount,
+		order.Total,
+		string(order.Status),
+		order.CreatedAt,
+		order.UpdatedAt,
+		order.PaidAt,
+		order.ShippedAt,
+		order.CancelledAt,
+	).Scan(&inserted); err != nil {
+		return fmt.Errorf("failed to save order: %w", err)
+	}
+
+	if inserted {
+		lineQuery := `
+			INSERT INTO order_line (order_id, position, beer_id, beer_name, quantity, source_currency,
+				source_unit_price, exchange_rate, unit_price, line_total)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		`
+		for position, line := range order.Lines {
+			if _, err := tx.ExecContext(ctx, lineQuery,
+				order.ID,
+				position,
+				line.BeerID,
+				line.BeerName,
+				line.Quantity,
+				line.SourceCurrency,
+				line.SourceUnitPrice,
+				line.ExchangeRate,
+				line.UnitPrice,
+				line.LineTotal,
+			); err != nil {
+				return fmt.Errorf("failed to save order line: %w", err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit order: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateStatus saves the status of an order with a conditional update on the
+// previous status, so only one of concurrent transitions finds it unchanged
+func (r *OrderRepository) UpdateStatus(ctx context.Context, order *orders.Order, previous orders.Status) error {
+	query := `
+		UPDATE purchase_order
+		SET status = $2, updated_at = $3, paid_at = $4, shipped_at = $5, cancelled_at = $6
+		WHERE id = $1 AND status = $7
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		order.ID,
+		string(order.Status),
+		order.UpdatedAt,
+		order.PaidAt,
+		order.ShippedAt,
+		order.CancelledAt,
+		string(previous),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	updated, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	if updated == 0 {
+		// Either the order does not exist or its status was changed first
+		if _, err := r.FindByID(ctx, order.ID); err != nil {
+			return err
+		}
+		return beers.NewDomainError(orders.ErrCodeOrderStatusConflict,
+			fmt.Sprintf("Order status changed from %s concurrently", previous), nil)
+	}
+
+	return nil
+}
+
+// FindByID finds an order by its ID
+func (r *OrderRepository) FindByID(ctx context.Context, id string) (*orders.Order, error) {
+	query := `
+		SELECT id, cart_id, currency, COALESCE(coupon, ''), discount, total, status, created_at, updated_at,
+			paid_at, shipped_at, cancelled_at
+		FROM purchase_order
+		WHERE id = $1
+	`
+
+	order, err := scanOrder(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(orders.ErrCodeOrderNotFound, "Order not found", err)
+		}
+		return nil, fmt.Errorf("failed to find order: %w", err)
+	}
+
+	lines, err := r.findLines(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	order.Lines = lines[order.ID]
+
+	return order, nil
+}
+
+// FindAll finds all orders, oldest first
+func (r *OrderRepository) FindAll(ctx context.Context) ([]orders.Order, error) {
+	query := `
+		SELECT id, cart_id, currency, COALESCE(coupon, ''), discount, total, status, created_at, updated_at,
+			paid_at, shipped_at, cancelled_at
+		FROM purchase_order
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]orders.Order, 0)
+	ids := []string{}
+	for rows.Next() {
+		order, err := scanOrder(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		result = append(result, *order)
+		ids = append(ids, order.ID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if len(result) == 0 {
+		return result, nil
+	}
+
+	lines, err := r.findLines(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result {
+		result[i].Lines = lines[result[i].ID]
+	}
+
+	return result, nil
+}
+
+// findLines loads the lines of orders in one query, by order ID. Every order
+// gets a list, empty when it has no lines.
+func (r *OrderRepository) findLines(ctx context.Context, orderIDs ...string) (map[string][]orders.OrderLine, error) {
+	query := `
+		SELECT order_id, beer_id, beer_name, quantity, source_currency, source_unit_price, exchange_rate, unit_price,
+			line_total
+		FROM order_line
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, position
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, pq.Array(orderIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order lines: %w", err)
+	}
+	defer rows.Close()
+
+	lines := make(map[string][]orders.OrderLine, len(orderIDs))
+	for _, id := range orderIDs {
+		lines[id] = []orders.OrderLine{}
+	}
+	for rows.Next() {
+		var orderID string
+		var line orders.OrderLine
+		if err := rows.Scan(
+			&orderID,
+			&line.BeerID,
+			&line.BeerName,
+			&line.Quantity,
+			&line.SourceCurrency,
+			&line.SourceUnitPrice,
+			&line.ExchangeRate,
+			&line.UnitPrice,
+			&line.LineTotal,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan order line: %w", err)
+		}
+		lines[orderID] = append(lines[orderID], line)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return lines, nil
+}
+
+// rowScanner abstracts *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanOrder scans an order header row
+func scanOrder(row rowScanner) (*orders.Order, error) {
+	var order orders.Order
+	var status string
+
+	err := row.Scan(
+		&order.ID,
+		&order.CartID,
+		&order.Currency,
+		&order.Coupon,
+		&order.Discount,
+		&order.Total,
+		&status,
+		&order.CreatedAt,
+		&order.UpdatedAt,
+		&order.PaidAt,
+		&order.ShippedAt,
+		&order.CancelledAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	order.Status = orders.Status(status)
+	return &order, nil
+}
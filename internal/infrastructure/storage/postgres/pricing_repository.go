@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// PricingRuleRepository implements the secondary.PricingRuleRepository interface
+type PricingRuleRepository struct {
+	db *sql.DB
+}
+
+// NewPricingRuleRepository creates a new PostgreSQL pricing rule repository
+func NewPricingRuleRepository(db *sql.DB) secondary.PricingRuleRepository {
+	return &PricingRuleRepository{db: db}
+}
+
+// Save replaces the packs and tiers of a beer in a single transaction
+func (r *PricingRuleRepository) Save(ctx context.Context, rule *pricing.Rule) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := deletePricingRule(ctx, tx, rule.BeerID); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO beer_pricing_rule (beer_id, updated_at)
+		VALUES ($1, $2)
+	`
+	if _, err := tx.ExecContext(ctx, query, rule.BeerID, rule.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to save pricing rule: %w", err)
+	}
+
+	for _, pack := range rule.Packs {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO beer_pack (beer_id, sku, size, price) VALUES ($1, $2, $3, $4)`,
+			rule.BeerID, pack.SKU, pack.Size, pack.Price,
+		); err != nil {
+			return fmt.Errorf("failed to save pack: %w", err)
+		}
+	}
+
+	for _, tier := range rule.Tiers {
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO beer_discount_tier (beer_id, min_units, percent) VALUES ($1, $2, $3)`,
+			rule.BeerID, tier.MinUnits, tier.Percent,
+		); err != nil {
+			return fmt.Errorf("failed to save discount tier: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit pricing rule: %w", err)
+	}
+
+	return nil
+}
+
+// FindByBeerID finds the pricing rule of a beer
+func (r *PricingRuleRepository) FindByBeerID(ctx context.Context, beerID int) (*pricing.Rule, error) {
+	rule := &pricing.Rule{
+		BeerID: beerID,
+		Packs:  []pricing.PackSKU{},
+		Tiers:  []pricing.DiscountTier{},
+	}
+
+	var updatedAt time.Time
+	err := r.db.QueryRowContext(ctx,
+		`SELECT updated_at FROM beer_pricing_rule WHERE beer_id = $1`, beerID,
+	).Scan(&updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(pricing.ErrCodeRuleNotFound, "Beer has no pricing rule", err)
+		}
+		return nil, fmt.Errorf("failed to find pricing rule: %w", err)
+	}
+	rule.UpdatedAt = updatedAt
+
+	packRows, err := r.db.QueryContext(ctx,
+		`SELECT sku, size, price FROM beer_pack WHERE beer_id = $1 ORDER BY size DESC`, beerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query packs: %w", err)
+	}
+	defer packRows.Close()
+
+	for packRows.Next() {
+		var pack pricing.PackSKU
+		if err := packRows.Scan(&pack.SKU, &pack.Size, &pack.Price); err != nil {
+			return nil, fmt.Errorf("failed to scan pack: %w", err)
+		}
+		rule.Packs = append(rule.Packs, pack)
+	}
+
+	if err := packRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	tierRows, err := r.db.QueryContext(ctx,
+		`SELECT min_units, percent FROM beer_discount_tier WHERE beer_id = $1 ORDER BY min_units`, beerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query discount tiers: %w", err)
+	}
+	defer tierRows.Close()
+
+	for tierRows.Next() {
+		var tier pricing.DiscountTier
+		if err := tierRows.Scan(&tier.MinUnits, &tier.Percent); err != nil {
+			return nil, fmt.Errorf("failed to scan discount tier: %w", err)
+		}
+		rule.Tiers = append(rule.Tiers, tier)
+	}
+
+	if err := tierRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return rule, nil
+}
+
+// Delete removes the pricing rule of a beer
+func (r *PricingRuleRepository) Delete(ctx context.Context, beerID int) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `DELETE FROM beer_pricing_rule WHERE beer_id = $1`, beerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pricing rule: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return beers.NewDomainError(pricing.ErrCodeRuleNotFound, "Beer has no pricing rule", nil)
+	}
+
+	if err := deletePricingRule(ctx, tx, beerID); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// deletePricingRule removes every row of a beer's pricing rule inside a transaction
+func deletePricingRule(ctx context.Context, tx *sql.Tx, beerID int) error {
+	statements := []string{
+		`DELETE FROM beer_pack WHERE beer_id = $1`,
+		`DELETE FROM beer_discount_tier WHERE beer_id = $1`,
+		`DELETE FROM beer_pricing_rule WHERE beer_id = $1`,
+	}
+
+	for _, statement := range statements {
+		if _, err := tx.ExecContext(ctx, statement, beerID); err != nil {
+			return fmt.Errorf("failed to clear pricing rule: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// uniqueViolation is the PostgreSQL error code for unique constraint violations
+const uniqueViolation = "23505"
+
+// promotionColumns lists the columns read by scanPromotion, in order
+const promotionColumns = `
+	id, name, description, type, value, currency, breweries, countries, beer_ids,
+	starts_at, ends_at, COALESCE(coupon_code, ''), usage_limit, usage_count, created_at, updated_at
+`
+
+// PromotionRepository implements the secondary.PromotionRepository interface
+type PromotionRepository struct {
+	db *sql.DB
+}
+
+// NewPromotionRepository creates a new PostgreSQL promotion repository
+func NewPromotionRepository(db *sql.DB) secondary.PromotionRepository {
+	return &PromotionRepository{db: db}
+}
+
+// Save inserts or updates a promotion. The usage count is owned by Redeem and
+// is never overwritten.
+func (r *PromotionRepository) Save(ctx context.Context, promotion *promotions.Promotion) error {
+	query := `
+		INSERT INTO promotion (id, name, description, type, value, currency, breweries, countries, beer_ids,
+			starts_at, ends_at, coupon_code, usage_limit, usage_count, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NULLIF($12, ''), $13, $14, $15, $16)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			type = EXCLUDED.type,
+			value = EXCLUDED.value,
+			currency = EXCLUDED.currency,
+			breweries = EXCLUDED.breweries,
+			countries = EXCLUDED.countries,
+			beer_ids = EXCLUDED.beer_ids,
+			starts_at = EXCLUDED.starts_at,
+			ends_at = EXCLUDED.ends_at,
+			coupon_code = EXCLUDED.coupon_code,
+			usage_limit = EXCLUDED.usage_limit,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, query,
+		promotion.ID,
+		promotion.Name,
+		promotion.Description,
+		string(promotion.Type),
+		promotion.Value,
+		promotion.Currency,
+		pq.Array(nonNilStrings(promotion.Scope.Breweries)),
+		pq.Array(nonNilStrings(promotion.Scope.Countries)),
+		pq.Array(toInt64s(promotion.Scope.BeerIDs)),
+		nullTime(promotion.StartsAt),
+		nullTime(promotion.EndsAt),
+		promotion.CouponCode,
+		promotion.UsageLimit,
+		promotion.UsageCount,
+		promotion.CreatedAt,
+		promotion.UpdatedAt,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+			return beers.NewDomainError(promotions.ErrCodeCouponExists,
+				fmt.Sprintf("Coupon code %s is already in use", promotion.CouponCode), err)
+		}
+		return fmt.Errorf("failed to save promotion: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a promotion by its ID
+func (r *PromotionRepository) FindByID(ctx context.Context, id string) (*promotions.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotion WHERE id = $1`
+
+	promotion, err := scanPromotion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(promotions.ErrCodeNotFound, "Promotion not found", err)
+		}
+		return nil, fmt.Errorf("failed to find promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// FindByCouponCode finds the promotion unlocked by a coupon code
+func (r *PromotionRepository) FindByCouponCode(ctx context.Context, code string) (*promotions.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotion WHERE coupon_code = $1`
+
+	promotion, err := scanPromotion(r.db.QueryRowContext(ctx, query, promotions.NormalizeCode(code)))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(promotions.ErrCodeNotFound, "Coupon code not found", err)
+		}
+		return nil, fmt.Errorf("failed to find promotion: %w", err)
+	}
+
+	return promotion, nil
+}
+
+// FindAll finds all promotions, oldest first
+func (r *PromotionRepository) FindAll(ctx context.Context) ([]promotions.Promotion, error) {
+	query := `SELECT ` + promotionColumns + ` FROM promotion ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query promotions: %w", err)
+	}
+	defer rows.Close()
+
+	result := []promotions.Promotion{}
+	for rows.Next() {
+		promotion, err := scanPromotion(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan promotion: %w", err)
+		}
+		result = append(result, *promotion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete removes a promotion
+func (r *PromotionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM promotion WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete promotion: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return beers.NewDomainError(promotions.ErrCodeNotFound, "Promotion not found", nil)
+	}
+
+	return nil
+}
+
+// Redeem counts one use of a promotion in a single statement, so concurrent
+// redemptions cannot exceed the usage limit
+func (r *PromotionRepository) Redeem(ctx context.Context, id string) error {
+	query := `
+		UPDATE promotion SET usage_count = usage_count + 1
+		WHERE id = $1 AND (usage_limit = 0 OR usage_count < usage_limit)
+	`
+
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to redeem promotion: %w", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected > 0 {
+		return nil
+	}
+
+	if _, err := r.FindByID(ctx, id); err != nil {
+		return err
+	}
+
+	return beers.NewDomainError(promotions.ErrCodeUsageLimitReached, "Promotion has reached its usage limit", nil)
+}
+
+// Release gives back one use counted by Redeem
+func (r *PromotionRepository) Release(ctx context.Context, id string) error {
+	query := `UPDATE promotion SET usage_count = usage_count - 1 WHERE id = $1 AND usage_count > 0`
+
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to release promotion: %w", err)
+	}
+
+	return nil
+}
+
+// scanPromotion scans a promotion row selected with promotionColumns
+func scanPromotion(row rowScanner) (*promotions.Promotion, error) {
+	var (
+		promotion promotions.Promotion
+		promoType string
+		beerIDs   pq.Int64Array
+		startsAt  sql.NullTime
+		endsAt    sql.NullTime
+	)
+
+	if err := row.Scan(
+		&promotion.ID,
+		&promotion.Name,
+		&promotion.Description,
+		&promoType,
+		&promotion.Value,
+		&promotion.Currency,
+		pq.Array(&promotion.Scope.Breweries),
+		pq.Array(&promotion.Scope.Countries),
+		&beerIDs,
+		&startsAt,
+		&endsAt,
+		&promotion.CouponCode,
+		&promotion.UsageLimit,
+		&promotion.UsageCount,
+		&promotion.CreatedAt,
+		&promotion.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	promotion.Type = promotions.Type(promoType)
+	promotion.StartsAt = startsAt.Time
+	promotion.EndsAt = endsAt.Time
+	for _, id := range beerIDs {
+		promotion.Scope.BeerIDs = append(promotion.Scope.BeerIDs, int(id))
+	}
+
+	return &promotion, nil
+}
+
+// nullTime stores zero times as NULL
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// nonNilStrings avoids storing NULL for empty lists
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+// toInt64s converts ints for pq array encoding
+func toInt64s(values []int) []int64 {
+	result := make([]int64, len(values))
+	for i, v := range values {
+		result[i] = int64(v)
+	}
+	return result
+}
@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"time"
+
+	"beers-challenge/internal/core/domain/ratelimit"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// rateLimitPurgeInterval is how often buckets that have refilled are deleted
+const rateLimitPurgeInterval = time.Minute
+
+// RateLimitStore implements the secondary.RateLimitStore interface, sharing
+// token buckets between every replica that uses the same database
+type RateLimitStore struct {
+	db         *sql.DB
+	mu         sync.Mutex
+	lastPurged time.Time
+}
+
+// NewRateLimitStore creates a new PostgreSQL rate limit store
+func NewRateLimitStore(db *sql.DB) secondary.RateLimitStore {
+	return &RateLimitStore{db: db}
+}
+
+// Take takes one request from the bucket of key. The bucket row is locked for
+// the duration of the transaction so concurrent requests are counted exactly.
+func (s *RateLimitStore) Take(ctx context.Context, key string, limit ratelimit.Limit) (ratelimit.Decision, error) {
+	now := time.Now()
+	s.purgeFull(ctx, now)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Create the bucket full if it does not exist yet, or lock the existing one.
+	// Doing both in one statement keeps a concurrent purge from deleting it in between.
+	var bucket ratelimit.Bucket
+	if err := tx.QueryRowContext(ctx, `
+		INSERT INTO rate_limit_bucket (key, tokens, updated_at, full_at) VALUES ($1, $2, $3, $3)
+		ON CONFLICT (key) DO UPDATE SET key = rate_limit_bucket.key
+		RETURNING tokens, updated_at
+	`, key, float64(limit.Requests), now).Scan(&bucket.Tokens, &bucket.UpdatedAt); err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to load rate limit bucket: %w", err)
+	}
+
+	decision := bucket.Take(limit, now)
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE rate_limit_bucket SET tokens = $2, updated_at = $3, full_at = $4 WHERE key = $1`,
+		key, bucket.Tokens, bucket.UpdatedAt, bucket.UpdatedAt.Add(decision.ResetAfter),
+	); err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to update rate limit bucket: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return ratelimit.Decision{}, fmt.Errorf("failed to commit rate limit bucket: %w", err)
+	}
+
+	return decision, nil
+}
+
+// purgeFull deletes buckets that have refilled, which are indistinguishable from
+// new ones, at most once per purge interval. Failures are ignored: a full bucket
+// left behind still allows every request.
+func (s *RateLimitStore) purgeFull(ctx context.Context, now time.Time) {
+	s.mu.Lock()
+	if now.Sub(s.lastPurged) < rateLimitPurgeInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastPurged = now
+	s.mu.Unlock()
+
+	_, _ = s.db.ExecContext(ctx, `DELETE FROM rate_limit_bucket WHERE full_at <= $1`, now)
+}
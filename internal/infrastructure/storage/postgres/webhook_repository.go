@@ -0,0 +1,402 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/lib/pq"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+// webhookSubscriptionColumns lists the columns read by scanWebhookSubscription, in order
+const webhookSubscriptionColumns = `id, url, event_types, secret, created_at`
+
+// webhookDeliveryColumns lists the columns read by scanWebhookDelivery, in order
+const webhookDeliveryColumns = `id, subscription_id, event, status, attempts, next_attempt_at,
+	last_error, last_status_code, created_at, updated_at`
+
+// Outbox implements the secondary.EventOutbox interface with the outbox_event
+// table, written in the same transaction as the beer
+type Outbox struct {
+	db *sql.DB
+}
+
+// NewOutbox creates a new PostgreSQL outbox
+func NewOutbox(db *sql.DB) secondary.EventOutbox {
+	return &Outbox{db: db}
+}
+
+// SaveBeer saves a beer and inserts its events in one transaction
+func (o *Outbox) SaveBeer(ctx context.Context, beer *beers.Beer, recorded []events.Event) error {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := saveBeer(ctx, tx, beer); err != nil {
+		return err
+	}
+
+	for _, event := range recorded {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx,
+			`INSERT INTO outbox_event (event_type, beer_id, payload) VALUES ($1, $2, $3)`,
+			string(event.Type), event.BeerID, payload,
+		); err != nil {
+			return fmt.Errorf("failed to record event: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit beer: %w", err)
+	}
+
+	return nil
+}
+
+// Relay hands the oldest undispatched events to handle. The rows stay locked
+// until they are marked dispatched, and locked rows are skipped, so replicas
+// relaying at the same time never hand out the same event twice.
+func (o *Outbox) Relay(ctx context.Context, limit int, handle func(ctx context.Context, pending []events.Event) error) (int, error) {
+	tx, err := o.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, payload FROM outbox_event
+		WHERE dispatched_at IS NULL
+		ORDER BY id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED
+	`, limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query outbox: %w", err)
+	}
+
+	var (
+		ids     []int64
+		pending []events.Event
+	)
+	for rows.Next() {
+		var (
+			id      int64
+			payload []byte
+			event   events.Event
+		)
+		if err := rows.Scan(&id, &payload); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan outbox event: %w", err)
+		}
+		if err := json.Unmarshal(payload, &event); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to decode outbox event %d: %w", id, err)
+		}
+
+		event.ID = "evt_" + strconv.FormatInt(id, 10)
+		ids = append(ids, id)
+		pending = append(pending, event)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	if err := handle(ctx, pending); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx,
+		`UPDATE outbox_event SET dispatched_at = NOW() WHERE id = ANY($1)`, pq.Array(ids),
+	); err != nil {
+		return 0, fmt.Errorf("failed to mark events dispatched: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit outbox: %w", err)
+	}
+
+	return len(pending), nil
+}
+
+// WebhookSubscriptionRepository implements the secondary.WebhookSubscriptionRepository interface
+type WebhookSubscriptionRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookSubscriptionRepository creates a new PostgreSQL webhook subscription repository
+func NewWebhookSubscriptionRepository(db *sql.DB) secondary.WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+// Save inserts or replaces a subscription
+func (r *WebhookSubscriptionRepository) Save(ctx context.Context, subscription *webhooks.Subscription) error {
+	query := `
+		INSERT INTO webhook_subscription (id, url, event_types, secret, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (id) DO UPDATE SET
+			url = EXCLUDED.url,
+			event_types = EXCLUDED.event_types
+	`
+
+	eventTypes := make([]string, len(subscription.EventTypes))
+	for i, eventType := range subscription.EventTypes {
+		eventTypes[i] = string(eventType)
+	}
+
+	_, err := r.db.ExecContext(ctx, query,
+		subscription.ID,
+		subscription.URL,
+		pq.Array(eventTypes),
+		subscription.Secret,
+		subscription.CreatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a subscription by its ID
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id string) (*webhooks.Subscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription WHERE id = $1`
+
+	subscription, err := scanWebhookSubscription(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(webhooks.ErrCodeSubscriptionNotFound, "Webhook subscription not found", err)
+		}
+		return nil, fmt.Errorf("failed to find webhook subscription: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// FindAll finds all subscriptions, oldest first
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]webhooks.Subscription, error) {
+	query := `SELECT ` + webhookSubscriptionColumns + ` FROM webhook_subscription ORDER BY created_at`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	result := []webhooks.Subscription{}
+	for rows.Next() {
+		subscription, err := scanWebhookSubscription(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook subscription: %w", err)
+		}
+		result = append(result, *subscription)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// Delete removes a subscription. Its deliveries are kept for the dead-letter list.
+func (r *WebhookSubscriptionRepository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscription WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+		return beers.NewDomainError(webhooks.ErrCodeSubscriptionNotFound, "Webhook subscription not found", nil)
+	}
+
+	return nil
+}
+
+// WebhookDeliveryRepository implements the secondary.WebhookDeliveryRepository interface
+type WebhookDeliveryRepository struct {
+	db *sql.DB
+}
+
+// NewWebhookDeliveryRepository creates a new PostgreSQL webhook delivery repository
+func NewWebhookDeliveryRepository(db *sql.DB) secondary.WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+// Save inserts or updates a delivery
+func (r *WebhookDeliveryRepository) Save(ctx context.Context, delivery *webhooks.Delivery) error {
+	query := `
+		INSERT INTO webhook_delivery (id, subscription_id, event, status, attempts, next_attempt_at,
+			last_error, last_status_code, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			attempts = EXCLUDED.attempts,
+			next_attempt_at = EXCLUDED.next_attempt_at,
+			last_error = EXCLUDED.last_error,
+			last_status_code = EXCLUDED.last_status_code,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	event, err := json.Marshal(delivery.Event)
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	_, err = r.db.ExecContext(ctx, query,
+		delivery.ID,
+		delivery.SubscriptionID,
+		event,
+		string(delivery.Status),
+		delivery.Attempts,
+		delivery.NextAttemptAt,
+		delivery.LastError,
+		delivery.LastStatusCode,
+		delivery.CreatedAt,
+		delivery.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID finds a delivery by its ID
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id string) (*webhooks.Delivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery WHERE id = $1`
+
+	delivery, err := scanWebhookDelivery(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, beers.NewDomainError(webhooks.ErrCodeDeliveryNotFound, "Webhook delivery not found", err)
+		}
+		return nil, fmt.Errorf("failed to find webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// FindByStatus finds the deliveries in a status, newest first
+func (r *WebhookDeliveryRepository) FindByStatus(ctx context.Context, status webhooks.DeliveryStatus) ([]webhooks.Delivery, error) {
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_delivery
+		WHERE $1 = '' OR status = $1
+		ORDER BY created_at DESC`
+
+	return r.queryDeliveries(ctx, query, string(status))
+}
+
+// ClaimDue leases the pending deliveries due at now, oldest due first. Rows
+// locked by another dispatcher are skipped.
+func (r *WebhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) ([]webhooks.Delivery, error) {
+	query := `
+		UPDATE webhook_delivery SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT id FROM webhook_delivery
+			WHERE status = 'pending' AND next_attempt_at <= $1
+			ORDER BY next_attempt_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + webhookDeliveryColumns
+
+	return r.queryDeliveries(ctx, query, now, now.Add(lease), limit)
+}
+
+// queryDeliveries runs a query returning webhookDeliveryColumns
+func (r *WebhookDeliveryRepository) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]webhooks.Delivery, error) {
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	result := []webhooks.Delivery{}
+	for rows.Next() {
+		delivery, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		result = append(result, *delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// scanWebhookSubscription scans a subscription row selected with webhookSubscriptionColumns
+func scanWebhookSubscription(row rowScanner) (*webhooks.Subscription, error) {
+	var (
+		subscription webhooks.Subscription
+		eventTypes   []string
+	)
+
+	if err := row.Scan(
+		&subscription.ID,
+		&subscription.URL,
+		pq.Array(&eventTypes),
+		&subscription.Secret,
+		&subscription.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	subscription.EventTypes = make([]events.Type, len(eventTypes))
+	for i, eventType := range eventTypes {
+		subscription.EventTypes[i] = events.Type(eventType)
+	}
+
+	return &subscription, nil
+}
+
+// scanWebhookDelivery scans a delivery row selected with webhookDeliveryColumns
+func scanWebhookDelivery(row rowScanner) (*webhooks.Delivery, error) {
+	var (
+		delivery webhooks.Delivery
+		event    []byte
+		status   string
+	)
+
+	if err := row.Scan(
+		&delivery.ID,
+		&delivery.SubscriptionID,
+		&event,
+		&status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.LastError,
+		&delivery.LastStatusCode,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(event, &delivery.Event); err != nil {
+		return nil, fmt.Errorf("failed to decode delivery event: %w", err)
+	}
+	delivery.Status = webhooks.DeliveryStatus(status)
+
+	return &delivery, nil
+}
@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"database/sql"
 	"fmt"
 
 	"beers-challenge/internal/core/ports/secondary"
 	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/storage/file"
 	"beers-challenge/internal/infrastructure/storage/inmemory"
 	"beers-challenge/internal/infrastructure/storage/postgres"
 )
@@ -19,9 +21,12 @@ const (
 	MySQL      RepositoryType = "mysql"   // Placeholder for future implementation
 )
 
-// RepositoryFactory creates repositories based on configuration
+// RepositoryFactory creates repositories based on configuration. PostgreSQL
+// repositories share one connection pool, opened with the first of them and
+// closed by Close.
 type RepositoryFactory struct {
 	config *config.ConfigProvider
+	db     *sql.DB
 }
 
 // NewRepositoryFactory creates a new repository factory
@@ -37,7 +42,11 @@ func (f *RepositoryFactory) CreateBeerRepository() (secondary.BeerRepository, er
 
 	switch RepositoryType(dbType) {
 	case PostgreSQL:
-		return postgres.NewRepository(f.config)
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewRepository(db), nil
 	case MongoDB:
 		return nil, fmt.Errorf("mongodb repository not implemented")
 	case MySQL:
@@ -50,6 +59,234 @@ func (f *RepositoryFactory) CreateBeerRepository() (secondary.BeerRepository, er
 	}
 }
 
+// CreatePricingRuleRepository creates a pricing rule repository based on the configured database type
+func (f *RepositoryFactory) CreatePricingRuleRepository() (secondary.PricingRuleRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewPricingRuleRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewPricingRuleRepository(), nil
+	}
+}
+
+// CreateCartRepository creates a cart repository based on the configured database type
+func (f *RepositoryFactory) CreateCartRepository() (secondary.CartRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewCartRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewCartRepository(), nil
+	}
+}
+
+// CreateOrderRepository creates an order repository based on the configured database type
+func (f *RepositoryFactory) CreateOrderRepository() (secondary.OrderRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewOrderRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewOrderRepository(), nil
+	}
+}
+
+// CreatePromotionRepository creates a promotion repository based on the configured database type
+func (f *RepositoryFactory) CreatePromotionRepository() (secondary.PromotionRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewPromotionRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewPromotionRepository(), nil
+	}
+}
+
+// CreateAPIKeyRepository creates an API key repository based on the configured database type
+func (f *RepositoryFactory) CreateAPIKeyRepository() (secondary.APIKeyRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewAPIKeyRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewAPIKeyRepository(), nil
+	}
+}
+
+// CreateIdempotencyStore creates an idempotency key store based on the configured database type
+func (f *RepositoryFactory) CreateIdempotencyStore() (secondary.IdempotencyStore, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewIdempotencyStore(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewIdempotencyStore(), nil
+	}
+}
+
+// CreateOutbox creates the transactional outbox beers are saved through. The
+// in-memory outbox saves to beerRepo, which must be the in-memory beer repository.
+func (f *RepositoryFactory) CreateOutbox(beerRepo secondary.BeerRepository) (secondary.EventOutbox, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewOutbox(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewOutbox(beerRepo), nil
+	}
+}
+
+// CreateWebhookSubscriptionRepository creates a webhook subscription repository based on the configured database type
+func (f *RepositoryFactory) CreateWebhookSubscriptionRepository() (secondary.WebhookSubscriptionRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewWebhookSubscriptionRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewWebhookSubscriptionRepository(), nil
+	}
+}
+
+// CreateWebhookDeliveryRepository creates a webhook delivery repository based on the configured database type
+func (f *RepositoryFactory) CreateWebhookDeliveryRepository() (secondary.WebhookDeliveryRepository, error) {
+	dbType := f.config.GetString("database.type")
+
+	switch RepositoryType(dbType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewWebhookDeliveryRepository(db), nil
+	case MongoDB:
+		return nil, fmt.Errorf("mongodb repository not implemented")
+	case MySQL:
+		return nil, fmt.Errorf("mysql repository not implemented")
+	default:
+		return inmemory.NewWebhookDeliveryRepository(), nil
+	}
+}
+
+// CreateRateLimitStore creates the token bucket store named by ratelimit.store.
+// Buckets are kept in memory unless "postgres" is configured, which shares them
+// between replicas at the cost of a database round trip per request.
+func (f *RepositoryFactory) CreateRateLimitStore() (secondary.RateLimitStore, error) {
+	storeType := f.config.GetString("ratelimit.store")
+
+	switch RepositoryType(storeType) {
+	case PostgreSQL:
+		db, err := f.database()
+		if err != nil {
+			return nil, err
+		}
+		return postgres.NewRateLimitStore(db), nil
+	case InMemory, "memory", "":
+		return inmemory.NewRateLimitStore(), nil
+	default:
+		return nil, fmt.Errorf("unsupported rate limit store: %s", storeType)
+	}
+}
+
+// CreateTaxRuleRepository creates a tax rule repository from the configured rules file.
+// Tax rules are configuration rather than data, so they do not depend on the database type.
+func (f *RepositoryFactory) CreateTaxRuleRepository() (secondary.TaxRuleRepository, error) {
+	return file.NewTaxRuleRepository(f.config.GetString("tax.rules_file"))
+}
+
+// Close closes the PostgreSQL connection pool, if one was opened
+func (f *RepositoryFactory) Close() error {
+	if f.db == nil {
+		return nil
+	}
+	return f.db.Close()
+}
+
+// database returns the PostgreSQL connection pool shared by every repository,
+// opening it on first use
+func (f *RepositoryFactory) database() (*sql.DB, error) {
+	if f.db == nil {
+		db, err := postgres.OpenDatabase(f.config)
+		if err != nil {
+			return nil, err
+		}
+		f.db = db
+	}
+	return f.db, nil
+}
+
 // GetSupportedRepositoryTypes returns the supported repository types
 func GetSupportedRepositoryTypes() []RepositoryType {
 	return []RepositoryType{InMemory, PostgreSQL}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"beers-challenge/internal/infrastructure/config"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -62,3 +63,99 @@ func TestValidateRepositoryType(t *testing.T) {
 	assert.NoError(t, ValidateRepositoryType("postgres"))
 	assert.Error(t, ValidateRepositoryType("mongodb"))
 }
+
+func TestCreateOrderRepositories(t *testing.T) {
+	t.Run("inmemory", func(t *testing.T) {
+		cfg := config.NewConfigProvider()
+		cfg.GetConfig().Database.Type = "inmemory"
+		factory := NewRepositoryFactory(cfg)
+
+		cartRepo, err := factory.CreateCartRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, cartRepo)
+
+		orderRepo, err := factory.CreateOrderRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, orderRepo)
+
+		promotionRepo, err := factory.CreatePromotionRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, promotionRepo)
+
+		apiKeyRepo, err := factory.CreateAPIKeyRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, apiKeyRepo)
+
+		idempotencyStore, err := factory.CreateIdempotencyStore()
+		assert.NoError(t, err)
+		assert.NotNil(t, idempotencyStore)
+
+		outbox, err := factory.CreateOutbox(inmemory.NewRepository())
+		assert.NoError(t, err)
+		assert.NotNil(t, outbox)
+
+		subscriptionRepo, err := factory.CreateWebhookSubscriptionRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, subscriptionRepo)
+
+		deliveryRepo, err := factory.CreateWebhookDeliveryRepository()
+		assert.NoError(t, err)
+		assert.NotNil(t, deliveryRepo)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		cfg := config.NewConfigProvider()
+		cfg.GetConfig().Database.Type = "mysql"
+		factory := NewRepositoryFactory(cfg)
+
+		_, err := factory.CreateCartRepository()
+		assert.Error(t, err)
+
+		_, err = factory.CreateOrderRepository()
+		assert.Error(t, err)
+
+		_, err = factory.CreatePromotionRepository()
+		assert.Error(t, err)
+
+		_, err = factory.CreateAPIKeyRepository()
+		assert.Error(t, err)
+
+		_, err = factory.CreateIdempotencyStore()
+		assert.Error(t, err)
+
+		_, err = factory.CreateOutbox(nil)
+		assert.Error(t, err)
+
+		_, err = factory.CreateWebhookSubscriptionRepository()
+		assert.Error(t, err)
+
+		_, err = factory.CreateWebhookDeliveryRepository()
+		assert.Error(t, err)
+	})
+}
+
+func TestCreateRateLimitStore(t *testing.T) {
+	cfg := config.NewConfigProvider()
+	factory := NewRepositoryFactory(cfg)
+
+	store, err := factory.CreateRateLimitStore()
+	assert.NoError(t, err)
+	assert.NotNil(t, store)
+
+	cfg.GetConfig().RateLimit.Store = "redis"
+	_, err = factory.CreateRateLimitStore()
+	assert.Error(t, err)
+}
+
+func TestCreateTaxRuleRepository(t *testing.T) {
+	cfg := config.NewConfigProvider()
+	factory := NewRepositoryFactory(cfg)
+
+	repo, err := factory.CreateTaxRuleRepository()
+	assert.NoError(t, err)
+	assert.NotNil(t, repo)
+
+	cfg.GetConfig().Tax.RulesFile = "does-not-exist.json"
+	_, err = factory.CreateTaxRuleRepository()
+	assert.Error(t, err)
+}
@@ -0,0 +1,444 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/core/domain/auth"
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/events"
+	"beers-challenge/internal/core/domain/idempotency"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/core/domain/pricing"
+	"beers-challenge/internal/core/domain/promotions"
+	"beers-challenge/internal/core/domain/tax"
+	"beers-challenge/internal/core/domain/webhooks"
+	"beers-challenge/internal/core/ports/secondary"
+)
+
+const instrumentationName = "beers-challenge/internal/infrastructure/tracing"
+
+// startSpan starts a client span for one repository operation. The tracer is looked
+// up on every call so a provider installed after the decorators are built is used.
+func startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+}
+
+// closeNext closes the wrapped repository if it holds resources
+func closeNext(next interface{}) error {
+	if closer, ok := next.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// beerRepository decorates a secondary.BeerRepository with a span per operation
+type beerRepository struct {
+	next secondary.BeerRepository
+}
+
+// TraceBeerRepository wraps a beer repository so every call gets a span
+func TraceBeerRepository(next secondary.BeerRepository) secondary.BeerRepository {
+	return &beerRepository{next: next}
+}
+
+func (r *beerRepository) Save(ctx context.Context, beer *beers.Beer) (err error) {
+	ctx, span := startSpan(ctx, "BeerRepository.Save", attribute.Int("beer.id", beer.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, beer)
+}
+
+func (r *beerRepository) FindByID(ctx context.Context, id int) (_ *beers.Beer, err error) {
+	ctx, span := startSpan(ctx, "BeerRepository.FindByID", attribute.Int("beer.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *beerRepository) FindAll(ctx context.Context) (_ []beers.Beer, err error) {
+	ctx, span := startSpan(ctx, "BeerRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *beerRepository) ExistsByID(ctx context.Context, id int) (_ bool, err error) {
+	ctx, span := startSpan(ctx, "BeerRepository.ExistsByID", attribute.Int("beer.id", id))
+	defer func() { End(span, err) }()
+	return r.next.ExistsByID(ctx, id)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *beerRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// pricingRuleRepository decorates a secondary.PricingRuleRepository with a span per operation
+type pricingRuleRepository struct {
+	next secondary.PricingRuleRepository
+}
+
+// TracePricingRuleRepository wraps a pricing rule repository so every call gets a span
+func TracePricingRuleRepository(next secondary.PricingRuleRepository) secondary.PricingRuleRepository {
+	return &pricingRuleRepository{next: next}
+}
+
+func (r *pricingRuleRepository) Save(ctx context.Context, rule *pricing.Rule) (err error) {
+	ctx, span := startSpan(ctx, "PricingRuleRepository.Save", attribute.Int("beer.id", rule.BeerID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, rule)
+}
+
+func (r *pricingRuleRepository) FindByBeerID(ctx context.Context, beerID int) (_ *pricing.Rule, err error) {
+	ctx, span := startSpan(ctx, "PricingRuleRepository.FindByBeerID", attribute.Int("beer.id", beerID))
+	defer func() { End(span, err) }()
+	return r.next.FindByBeerID(ctx, beerID)
+}
+
+func (r *pricingRuleRepository) Delete(ctx context.Context, beerID int) (err error) {
+	ctx, span := startSpan(ctx, "PricingRuleRepository.Delete", attribute.Int("beer.id", beerID))
+	defer func() { End(span, err) }()
+	return r.next.Delete(ctx, beerID)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *pricingRuleRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// taxRuleRepository decorates a secondary.TaxRuleRepository with a span per operation
+type taxRuleRepository struct {
+	next secondary.TaxRuleRepository
+}
+
+// TraceTaxRuleRepository wraps a tax rule repository so every call gets a span
+func TraceTaxRuleRepository(next secondary.TaxRuleRepository) secondary.TaxRuleRepository {
+	return &taxRuleRepository{next: next}
+}
+
+func (r *taxRuleRepository) FindByCountry(ctx context.Context, country string) (_ []tax.Rule, err error) {
+	ctx, span := startSpan(ctx, "TaxRuleRepository.FindByCountry", attribute.String("tax.country", country))
+	defer func() { End(span, err) }()
+	return r.next.FindByCountry(ctx, country)
+}
+
+func (r *taxRuleRepository) FindAll(ctx context.Context) (_ []tax.Rule, err error) {
+	ctx, span := startSpan(ctx, "TaxRuleRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+// promotionRepository decorates a secondary.PromotionRepository with a span per operation
+type promotionRepository struct {
+	next secondary.PromotionRepository
+}
+
+// TracePromotionRepository wraps a promotion repository so every call gets a span
+func TracePromotionRepository(next secondary.PromotionRepository) secondary.PromotionRepository {
+	return &promotionRepository{next: next}
+}
+
+func (r *promotionRepository) Save(ctx context.Context, promotion *promotions.Promotion) (err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.Save", attribute.String("promotion.id", promotion.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, promotion)
+}
+
+func (r *promotionRepository) FindByID(ctx context.Context, id string) (_ *promotions.Promotion, err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.FindByID", attribute.String("promotion.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *promotionRepository) FindByCouponCode(ctx context.Context, code string) (_ *promotions.Promotion, err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.FindByCouponCode")
+	defer func() { End(span, err) }()
+	return r.next.FindByCouponCode(ctx, code)
+}
+
+func (r *promotionRepository) FindAll(ctx context.Context) (_ []promotions.Promotion, err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *promotionRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.Delete", attribute.String("promotion.id", id))
+	defer func() { End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+func (r *promotionRepository) Redeem(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.Redeem", attribute.String("promotion.id", id))
+	defer func() { End(span, err) }()
+	return r.next.Redeem(ctx, id)
+}
+
+func (r *promotionRepository) Release(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "PromotionRepository.Release", attribute.String("promotion.id", id))
+	defer func() { End(span, err) }()
+	return r.next.Release(ctx, id)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *promotionRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// cartRepository decorates a secondary.CartRepository with a span per operation
+type cartRepository struct {
+	next secondary.CartRepository
+}
+
+// TraceCartRepository wraps a cart repository so every call gets a span
+func TraceCartRepository(next secondary.CartRepository) secondary.CartRepository {
+	return &cartRepository{next: next}
+}
+
+func (r *cartRepository) Save(ctx context.Context, cart *orders.Cart) (err error) {
+	ctx, span := startSpan(ctx, "CartRepository.Save", attribute.String("cart.id", cart.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, cart)
+}
+
+func (r *cartRepository) FindByID(ctx context.Context, id string) (_ *orders.Cart, err error) {
+	ctx, span := startSpan(ctx, "CartRepository.FindByID", attribute.String("cart.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *cartRepository) MarkCheckedOut(ctx context.Context, id string, version int, orderID string) (err error) {
+	ctx, span := startSpan(ctx, "CartRepository.MarkCheckedOut",
+		attribute.String("cart.id", id), attribute.String("order.id", orderID))
+	defer func() { End(span, err) }()
+	return r.next.MarkCheckedOut(ctx, id, version, orderID)
+}
+
+func (r *cartRepository) ClearCheckout(ctx context.Context, id, orderID string) (err error) {
+	ctx, span := startSpan(ctx, "CartRepository.ClearCheckout",
+		attribute.String("cart.id", id), attribute.String("order.id", orderID))
+	defer func() { End(span, err) }()
+	return r.next.ClearCheckout(ctx, id, orderID)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *cartRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// orderRepository decorates a secondary.OrderRepository with a span per operation
+type orderRepository struct {
+	next secondary.OrderRepository
+}
+
+// TraceOrderRepository wraps an order repository so every call gets a span
+func TraceOrderRepository(next secondary.OrderRepository) secondary.OrderRepository {
+	return &orderRepository{next: next}
+}
+
+func (r *orderRepository) Save(ctx context.Context, order *orders.Order) (err error) {
+	ctx, span := startSpan(ctx, "OrderRepository.Save", attribute.String("order.id", order.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, order)
+}
+
+func (r *orderRepository) UpdateStatus(ctx context.Context, order *orders.Order, previous orders.Status) (err error) {
+	ctx, span := startSpan(ctx, "OrderRepository.UpdateStatus",
+		attribute.String("order.id", order.ID), attribute.String("order.status", string(order.Status)))
+	defer func() { End(span, err) }()
+	return r.next.UpdateStatus(ctx, order, previous)
+}
+
+func (r *orderRepository) FindByID(ctx context.Context, id string) (_ *orders.Order, err error) {
+	ctx, span := startSpan(ctx, "OrderRepository.FindByID", attribute.String("order.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *orderRepository) FindAll(ctx context.Context) (_ []orders.Order, err error) {
+	ctx, span := startSpan(ctx, "OrderRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *orderRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// apiKeyRepository decorates a secondary.APIKeyRepository with a span per operation
+type apiKeyRepository struct {
+	next secondary.APIKeyRepository
+}
+
+// TraceAPIKeyRepository wraps an API key repository so every call gets a span
+func TraceAPIKeyRepository(next secondary.APIKeyRepository) secondary.APIKeyRepository {
+	return &apiKeyRepository{next: next}
+}
+
+func (r *apiKeyRepository) Save(ctx context.Context, key *auth.APIKey) (err error) {
+	ctx, span := startSpan(ctx, "APIKeyRepository.Save", attribute.String("api_key.id", key.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, key)
+}
+
+func (r *apiKeyRepository) FindByID(ctx context.Context, id string) (_ *auth.APIKey, err error) {
+	ctx, span := startSpan(ctx, "APIKeyRepository.FindByID", attribute.String("api_key.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *apiKeyRepository) FindAll(ctx context.Context) (_ []auth.APIKey, err error) {
+	ctx, span := startSpan(ctx, "APIKeyRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *apiKeyRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// idempotencyStore decorates a secondary.IdempotencyStore with a span per
+// operation. Keys are chosen by clients, so they are not recorded.
+type idempotencyStore struct {
+	next secondary.IdempotencyStore
+}
+
+// TraceIdempotencyStore wraps an idempotency store so every call gets a span
+func TraceIdempotencyStore(next secondary.IdempotencyStore) secondary.IdempotencyStore {
+	return &idempotencyStore{next: next}
+}
+
+func (s *idempotencyStore) Reserve(ctx context.Context, record *idempotency.Record) (_ *idempotency.Record, err error) {
+	ctx, span := startSpan(ctx, "IdempotencyStore.Reserve")
+	defer func() { End(span, err) }()
+	return s.next.Reserve(ctx, record)
+}
+
+func (s *idempotencyStore) Complete(ctx context.Context, record *idempotency.Record) (err error) {
+	ctx, span := startSpan(ctx, "IdempotencyStore.Complete")
+	defer func() { End(span, err) }()
+	return s.next.Complete(ctx, record)
+}
+
+func (s *idempotencyStore) Release(ctx context.Context, key string) (err error) {
+	ctx, span := startSpan(ctx, "IdempotencyStore.Release")
+	defer func() { End(span, err) }()
+	return s.next.Release(ctx, key)
+}
+
+// Close closes the wrapped store if it holds resources
+func (s *idempotencyStore) Close() error {
+	return closeNext(s.next)
+}
+
+// eventOutbox decorates a secondary.EventOutbox with a span per operation
+type eventOutbox struct {
+	next secondary.EventOutbox
+}
+
+// TraceEventOutbox wraps an event outbox so every call gets a span
+func TraceEventOutbox(next secondary.EventOutbox) secondary.EventOutbox {
+	return &eventOutbox{next: next}
+}
+
+func (o *eventOutbox) SaveBeer(ctx context.Context, beer *beers.Beer, recorded []events.Event) (err error) {
+	ctx, span := startSpan(ctx, "EventOutbox.SaveBeer",
+		attribute.Int("beer.id", beer.ID), attribute.Int("outbox.events", len(recorded)))
+	defer func() { End(span, err) }()
+	return o.next.SaveBeer(ctx, beer, recorded)
+}
+
+func (o *eventOutbox) Relay(ctx context.Context, limit int, handle func(ctx context.Context, pending []events.Event) error) (_ int, err error) {
+	ctx, span := startSpan(ctx, "EventOutbox.Relay", attribute.Int("outbox.limit", limit))
+	defer func() { End(span, err) }()
+	return o.next.Relay(ctx, limit, handle)
+}
+
+// Close closes the wrapped outbox if it holds resources
+func (o *eventOutbox) Close() error {
+	return closeNext(o.next)
+}
+
+// webhookSubscriptionRepository decorates a secondary.WebhookSubscriptionRepository with a span per operation
+type webhookSubscriptionRepository struct {
+	next secondary.WebhookSubscriptionRepository
+}
+
+// TraceWebhookSubscriptionRepository wraps a webhook subscription repository so every call gets a span
+func TraceWebhookSubscriptionRepository(next secondary.WebhookSubscriptionRepository) secondary.WebhookSubscriptionRepository {
+	return &webhookSubscriptionRepository{next: next}
+}
+
+func (r *webhookSubscriptionRepository) Save(ctx context.Context, subscription *webhooks.Subscription) (err error) {
+	ctx, span := startSpan(ctx, "WebhookSubscriptionRepository.Save", attribute.String("webhook.subscription.id", subscription.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, subscription)
+}
+
+func (r *webhookSubscriptionRepository) FindByID(ctx context.Context, id string) (_ *webhooks.Subscription, err error) {
+	ctx, span := startSpan(ctx, "WebhookSubscriptionRepository.FindByID", attribute.String("webhook.subscription.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *webhookSubscriptionRepository) FindAll(ctx context.Context) (_ []webhooks.Subscription, err error) {
+	ctx, span := startSpan(ctx, "WebhookSubscriptionRepository.FindAll")
+	defer func() { End(span, err) }()
+	return r.next.FindAll(ctx)
+}
+
+func (r *webhookSubscriptionRepository) Delete(ctx context.Context, id string) (err error) {
+	ctx, span := startSpan(ctx, "WebhookSubscriptionRepository.Delete", attribute.String("webhook.subscription.id", id))
+	defer func() { End(span, err) }()
+	return r.next.Delete(ctx, id)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *webhookSubscriptionRepository) Close() error {
+	return closeNext(r.next)
+}
+
+// webhookDeliveryRepository decorates a secondary.WebhookDeliveryRepository with a span per operation
+type webhookDeliveryRepository struct {
+	next secondary.WebhookDeliveryRepository
+}
+
+// TraceWebhookDeliveryRepository wraps a webhook delivery repository so every call gets a span
+func TraceWebhookDeliveryRepository(next secondary.WebhookDeliveryRepository) secondary.WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{next: next}
+}
+
+func (r *webhookDeliveryRepository) Save(ctx context.Context, delivery *webhooks.Delivery) (err error) {
+	ctx, span := startSpan(ctx, "WebhookDeliveryRepository.Save", attribute.String("webhook.delivery.id", delivery.ID))
+	defer func() { End(span, err) }()
+	return r.next.Save(ctx, delivery)
+}
+
+func (r *webhookDeliveryRepository) FindByID(ctx context.Context, id string) (_ *webhooks.Delivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookDeliveryRepository.FindByID", attribute.String("webhook.delivery.id", id))
+	defer func() { End(span, err) }()
+	return r.next.FindByID(ctx, id)
+}
+
+func (r *webhookDeliveryRepository) FindByStatus(ctx context.Context, status webhooks.DeliveryStatus) (_ []webhooks.Delivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookDeliveryRepository.FindByStatus", attribute.String("webhook.delivery.status", string(status)))
+	defer func() { End(span, err) }()
+	return r.next.FindByStatus(ctx, status)
+}
+
+func (r *webhookDeliveryRepository) ClaimDue(ctx context.Context, now time.Time, limit int, lease time.Duration) (_ []webhooks.Delivery, err error) {
+	ctx, span := startSpan(ctx, "WebhookDeliveryRepository.ClaimDue", attribute.Int("webhook.claim.limit", limit))
+	defer func() { End(span, err) }()
+	return r.next.ClaimDue(ctx, now, limit, lease)
+}
+
+// Close closes the wrapped repository if it holds resources
+func (r *webhookDeliveryRepository) Close() error {
+	return closeNext(r.next)
+}
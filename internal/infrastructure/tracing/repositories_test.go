@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/domain/orders"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+func TestTraceBeerRepository(t *testing.T) {
+	recorder := recordSpans(t)
+	repo := TraceBeerRepository(inmemory.NewRepository())
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	beer := &beers.Beer{ID: 1, Name: "Golden", Brewery: "Kross", Country: "Chile", Price: 10, Currency: "USD"}
+	assert.NoError(t, repo.Save(ctx, beer))
+	_, err := repo.FindByID(ctx, 99)
+	assert.Error(t, err)
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 3)
+
+	assert.Equal(t, "BeerRepository.Save", spans[0].Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	assert.Equal(t, "BeerRepository.FindByID", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("beer.id", 99))
+
+	closer, ok := repo.(interface{ Close() error })
+	assert.True(t, ok)
+	assert.NoError(t, closer.Close())
+}
+
+func TestTracePricingRuleRepository(t *testing.T) {
+	recorder := recordSpans(t)
+	repo := TracePricingRuleRepository(inmemory.NewPricingRuleRepository())
+
+	_, _ = repo.FindByBeerID(context.Background(), 1)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "PricingRuleRepository.FindByBeerID", spans[0].Name())
+}
+
+func TestTraceCartRepository(t *testing.T) {
+	recorder := recordSpans(t)
+	repo := TraceCartRepository(inmemory.NewCartRepository())
+	ctx := context.Background()
+
+	cart, _ := orders.NewCart("USD")
+	assert.NoError(t, repo.Save(ctx, cart))
+	assert.Error(t, repo.MarkCheckedOut(ctx, cart.ID, 0, "ord_1"))
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "CartRepository.Save", spans[0].Name())
+	assert.Contains(t, spans[0].Attributes(), attribute.String("cart.id", cart.ID))
+	assert.Equal(t, "CartRepository.MarkCheckedOut", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Contains(t, spans[1].Attributes(), attribute.String("order.id", "ord_1"))
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/core/domain/beers"
+	"beers-challenge/internal/core/ports/primary"
+)
+
+// beerService decorates a primary.BeerService with a span per use case, parent
+// of the repository and currency provider spans it causes
+type beerService struct {
+	next primary.BeerService
+}
+
+// TraceBeerService wraps the beer service so every use case gets a span
+func TraceBeerService(next primary.BeerService) primary.BeerService {
+	return &beerService{next: next}
+}
+
+// startInternalSpan starts a span for work inside the service
+func startInternalSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+func (s *beerService) CreateBeer(ctx context.Context, req primary.CreateBeerRequest) (err error) {
+	ctx, span := startInternalSpan(ctx, "BeerService.CreateBeer", attribute.Int("beer.id", req.ID))
+	defer func() { End(span, err) }()
+	return s.next.CreateBeer(ctx, req)
+}
+
+func (s *beerService) UpdateBeer(ctx context.Context, id int, req primary.UpdateBeerRequest) (_ *beers.Beer, err error) {
+	ctx, span := startInternalSpan(ctx, "BeerService.UpdateBeer", attribute.Int("beer.id", id))
+	defer func() { End(span, err) }()
+	return s.next.UpdateBeer(ctx, id, req)
+}
+
+func (s *beerService) FindBeerByID(ctx context.Context, id int) (_ *beers.Beer, err error) {
+	ctx, span := startInternalSpan(ctx, "BeerService.FindBeerByID", attribute.Int("beer.id", id))
+	defer func() { End(span, err) }()
+	return s.next.FindBeerByID(ctx, id)
+}
+
+func (s *beerService) FindAllBeers(ctx context.Context) (_ []beers.Beer, err error) {
+	ctx, span := startInternalSpan(ctx, "BeerService.FindAllBeers")
+	defer func() { End(span, err) }()
+	return s.next.FindAllBeers(ctx)
+}
+
+func (s *beerService) CalculateBoxPrice(ctx context.Context, req primary.CalculateBoxPriceRequest) (_ *primary.BoxPriceResponse, err error) {
+	ctx, span := startInternalSpan(ctx, "BeerService.CalculateBoxPrice",
+		attribute.Int("beer.id", req.BeerID),
+		attribute.Int("box.quantity", req.Quantity),
+		attribute.String("box.currency", req.Currency))
+	defer func() { End(span, err) }()
+	return s.next.CalculateBoxPrice(ctx, req)
+}
@@ -0,0 +1,34 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+
+	"beers-challenge/internal/core/ports/primary"
+	"beers-challenge/internal/core/services"
+	"beers-challenge/internal/infrastructure/logger"
+	"beers-challenge/internal/infrastructure/storage/inmemory"
+)
+
+func TestTraceBeerServiceParentsRepositorySpans(t *testing.T) {
+	recorder := recordSpans(t)
+	repo := TraceBeerRepository(inmemory.NewRepository())
+	service := TraceBeerService(services.NewBeerService(repo, nil, logger.NewNoOpLogger()))
+
+	_, err := service.CalculateBoxPrice(context.Background(), primary.CalculateBoxPriceRequest{
+		BeerID: 42, Quantity: 6, Currency: "USD",
+	})
+	assert.Error(t, err)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+
+	repository, useCase := spans[0], spans[1]
+	assert.Equal(t, "BeerRepository.FindByID", repository.Name())
+	assert.Equal(t, "BeerService.CalculateBoxPrice", useCase.Name())
+	assert.Equal(t, useCase.SpanContext().SpanID(), repository.Parent().SpanID())
+	assert.Equal(t, codes.Error, useCase.Status().Code)
+}
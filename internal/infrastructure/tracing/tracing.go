@@ -0,0 +1,120 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"beers-challenge/internal/infrastructure/buildinfo"
+)
+
+// Span exporters selected by tracing.exporter
+const (
+	ExporterNone   = "none"
+	ExporterStdout = "stdout"
+	ExporterOTLP   = "otlp"
+)
+
+// ServiceName identifies this service in exported spans
+const ServiceName = "beer-api"
+
+// shutdownTimeout bounds how long Close waits for pending spans to be exported
+const shutdownTimeout = 5 * time.Second
+
+// ConfigProvider interface for configuration access
+type ConfigProvider interface {
+	GetString(key string) string
+	GetBool(key string) bool
+	GetFloat64(key string) float64
+}
+
+// Provider owns the tracer provider of the process and flushes it on shutdown
+type Provider struct {
+	provider *sdktrace.TracerProvider
+}
+
+// NewProvider installs W3C trace context propagation and, unless the exporter is
+// "none", a tracer provider that exports sampled spans. Propagation is installed
+// even without an exporter, so incoming trace ids still reach the logs and
+// outbound calls.
+func NewProvider(ctx context.Context, config ConfigProvider) (*Provider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, err := newExporter(ctx, config, os.Stdout)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return &Provider{}, nil
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", buildinfo.Get().Version),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.GetFloat64("tracing.sample_ratio")))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return &Provider{provider: provider}, nil
+}
+
+// newExporter creates the span exporter named by tracing.exporter, or nil for none
+func newExporter(ctx context.Context, config ConfigProvider, stdout io.Writer) (sdktrace.SpanExporter, error) {
+	switch exporter := config.GetString("tracing.exporter"); exporter {
+	case ExporterNone, "":
+		return nil, nil
+	case ExporterStdout:
+		return stdouttrace.New(stdouttrace.WithWriter(stdout))
+	case ExporterOTLP:
+		// The standard OTEL_EXPORTER_OTLP_* environment variables apply as well
+		opts := []otlptracehttp.Option{}
+		if endpoint := config.GetString("tracing.otlp_endpoint"); endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+		}
+		if config.GetBool("tracing.otlp_insecure") {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	default:
+		return nil, fmt.Errorf("unsupported tracing exporter: %s", exporter)
+	}
+}
+
+// Close flushes pending spans and stops the exporter
+func (p *Provider) Close() error {
+	if p.provider == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	return p.provider.Shutdown(ctx)
+}
+
+// End records err on the span, if any, and ends it
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,70 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type stubConfig map[string]string
+
+func (c stubConfig) GetString(key string) string   { return c[key] }
+func (c stubConfig) GetBool(key string) bool       { return c[key] == "true" }
+func (c stubConfig) GetFloat64(key string) float64 { return 1.0 }
+
+// recordSpans installs a tracer provider that keeps every ended span, restoring
+// the previous provider when the test ends
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+
+	exporter, err := newExporter(ctx, stubConfig{"tracing.exporter": "none"}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.Nil(t, exporter)
+
+	exporter, err = newExporter(ctx, stubConfig{"tracing.exporter": "stdout"}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.NotNil(t, exporter)
+
+	exporter, err = newExporter(ctx, stubConfig{"tracing.exporter": "otlp", "tracing.otlp_endpoint": "localhost:4318"}, &bytes.Buffer{})
+	assert.NoError(t, err)
+	assert.NotNil(t, exporter)
+
+	_, err = newExporter(ctx, stubConfig{"tracing.exporter": "zipkin"}, &bytes.Buffer{})
+	assert.Error(t, err)
+}
+
+func TestNewProviderWithoutExporter(t *testing.T) {
+	provider, err := NewProvider(context.Background(), stubConfig{"tracing.exporter": "none"})
+	assert.NoError(t, err)
+	assert.NoError(t, provider.Close())
+	assert.Contains(t, otel.GetTextMapPropagator().Fields(), "traceparent")
+}
+
+func TestEndRecordsErrors(t *testing.T) {
+	recorder := recordSpans(t)
+
+	_, span := otel.Tracer("test").Start(context.Background(), "failing")
+	End(span, errors.New("connection refused"))
+	_, span = otel.Tracer("test").Start(context.Background(), "succeeding")
+	End(span, nil)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Equal(t, codes.Unset, spans[1].Status().Code)
+}
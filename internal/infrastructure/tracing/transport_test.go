@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+func TestTransportPropagatesTraceContext(t *testing.T) {
+	recorder := recordSpans(t)
+	previous := otel.GetTextMapPropagator()
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(previous) })
+
+	var traceparent string
+	provider := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer provider.Close()
+
+	ctx, parent := otel.Tracer("test").Start(context.Background(), "request")
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, provider.URL+"/live?access_key=secret", nil)
+	resp, err := (&http.Client{Transport: NewTransport(nil)}).Do(req)
+	assert.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	client := spans[0]
+	assert.Equal(t, parent.SpanContext().TraceID(), client.SpanContext().TraceID())
+	assert.Contains(t, traceparent, client.SpanContext().TraceID().String())
+	assert.Contains(t, traceparent, client.SpanContext().SpanID().String())
+
+	for _, attr := range client.Attributes() {
+		assert.NotContains(t, attr.Value.Emit(), "secret", "the query string must not be recorded")
+	}
+}
@@ -1,4 +1,18 @@
--- Drop table if exists (for development purposes)
+-- Drop tables if exist (for development purposes)
+DROP TABLE IF EXISTS webhook_delivery;
+DROP TABLE IF EXISTS webhook_subscription;
+DROP TABLE IF EXISTS outbox_event;
+DROP TABLE IF EXISTS rate_limit_bucket;
+DROP TABLE IF EXISTS idempotency_key;
+DROP TABLE IF EXISTS api_key;
+DROP TABLE IF EXISTS promotion;
+DROP TABLE IF EXISTS order_line;
+DROP TABLE IF EXISTS purchase_order;
+DROP TABLE IF EXISTS cart_item;
+DROP TABLE IF EXISTS cart;
+DROP TABLE IF EXISTS beer_discount_tier;
+DROP TABLE IF EXISTS beer_pack;
+DROP TABLE IF EXISTS beer_pricing_rule;
 DROP TABLE IF EXISTS beer;
 
 -- Create beer table with improved schema
@@ -10,6 +24,8 @@ CREATE TABLE beer
     country    VARCHAR(100) NOT NULL,
     currency   CHAR(3)      NOT NULL,
     price      DECIMAL(10, 6) NOT NULL CHECK (price >= 0),
+    abv        DECIMAL(5, 2)  NOT NULL DEFAULT 0 CHECK (abv >= 0 AND abv <= 100),
+    volume_ml  INTEGER        NOT NULL DEFAULT 0 CHECK (volume_ml >= 0),
     created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
 );
@@ -21,16 +37,200 @@ CREATE INDEX idx_beer_country ON beer(country);
 CREATE INDEX idx_beer_currency ON beer(currency);
 CREATE INDEX idx_beer_created_at ON beer(created_at);
 
+-- Create pricing rule tables (pack SKUs and quantity-break tiers per beer)
+CREATE TABLE beer_pricing_rule
+(
+    beer_id    INTEGER PRIMARY KEY REFERENCES beer (id) ON DELETE CASCADE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE beer_pack
+(
+    beer_id INTEGER        NOT NULL REFERENCES beer_pricing_rule (beer_id) ON DELETE CASCADE,
+    sku     VARCHAR(50)    NOT NULL,
+    size    INTEGER        NOT NULL CHECK (size > 1),
+    price   DECIMAL(10, 6) NOT NULL CHECK (price >= 0),
+    PRIMARY KEY (beer_id, size)
+);
+
+CREATE TABLE beer_discount_tier
+(
+    beer_id   INTEGER       NOT NULL REFERENCES beer_pricing_rule (beer_id) ON DELETE CASCADE,
+    min_units INTEGER       NOT NULL CHECK (min_units > 0),
+    percent   DECIMAL(5, 2) NOT NULL CHECK (percent > 0 AND percent < 100),
+    PRIMARY KEY (beer_id, min_units)
+);
+
+-- Create cart tables
+CREATE TABLE cart
+(
+    id         VARCHAR(40) PRIMARY KEY,
+    currency   CHAR(3)     NOT NULL,
+    order_id   VARCHAR(40),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    version    INTEGER     NOT NULL DEFAULT 0
+);
+
+CREATE TABLE cart_item
+(
+    cart_id  VARCHAR(40) NOT NULL REFERENCES cart (id) ON DELETE CASCADE,
+    position INTEGER     NOT NULL,
+    beer_id  INTEGER     NOT NULL REFERENCES beer (id),
+    quantity INTEGER     NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (cart_id, position)
+);
+
+-- Create order tables (lines are frozen at checkout)
+CREATE TABLE purchase_order
+(
+    id           VARCHAR(40) PRIMARY KEY,
+    cart_id      VARCHAR(40)    NOT NULL REFERENCES cart (id),
+    currency     CHAR(3)        NOT NULL,
+    coupon       VARCHAR(40),
+    discount     DECIMAL(18, 6) NOT NULL DEFAULT 0 CHECK (discount >= 0),
+    total        DECIMAL(18, 6) NOT NULL CHECK (total >= 0),
+    status       VARCHAR(20)    NOT NULL CHECK (status IN ('placed', 'paid', 'shipped', 'cancelled')),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    paid_at      TIMESTAMP WITH TIME ZONE,
+    shipped_at   TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE order_line
+(
+    order_id          VARCHAR(40)    NOT NULL REFERENCES purchase_order (id) ON DELETE CASCADE,
+    position          INTEGER        NOT NULL,
+    beer_id           INTEGER        NOT NULL,
+    beer_name         VARCHAR(100)   NOT NULL,
+    quantity          INTEGER        NOT NULL CHECK (quantity > 0),
+    source_currency   CHAR(3)        NOT NULL,
+    source_unit_price DECIMAL(10, 6) NOT NULL,
+    exchange_rate     DECIMAL(18, 8) NOT NULL,
+    unit_price        DECIMAL(18, 6) NOT NULL,
+    line_total        DECIMAL(18, 6) NOT NULL,
+    PRIMARY KEY (order_id, position)
+);
+
+CREATE INDEX idx_purchase_order_status ON purchase_order(status);
+CREATE INDEX idx_purchase_order_created_at ON purchase_order(created_at);
+
+-- Promotions: time-boxed discounts scoped to breweries, origin countries or beers,
+-- optionally unlocked by a coupon code with a usage limit (0 = unlimited)
+CREATE TABLE promotion
+(
+    id          VARCHAR(40)    PRIMARY KEY,
+    name        VARCHAR(100)   NOT NULL,
+    description TEXT           NOT NULL DEFAULT '',
+    type        VARCHAR(20)    NOT NULL CHECK (type IN ('percentage', 'fixed')),
+    value       DECIMAL(18, 6) NOT NULL CHECK (value > 0),
+    currency    VARCHAR(3)     NOT NULL DEFAULT '',
+    breweries   TEXT[]         NOT NULL DEFAULT '{}',
+    countries   TEXT[]         NOT NULL DEFAULT '{}',
+    beer_ids    INTEGER[]      NOT NULL DEFAULT '{}',
+    starts_at   TIMESTAMP WITH TIME ZONE,
+    ends_at     TIMESTAMP WITH TIME ZONE,
+    coupon_code VARCHAR(50)    UNIQUE,
+    usage_limit INTEGER        NOT NULL DEFAULT 0 CHECK (usage_limit >= 0),
+    usage_count INTEGER        NOT NULL DEFAULT 0 CHECK (usage_count >= 0),
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- API keys: only the SHA-256 hash of each token is stored; revoked keys are kept for auditing
+CREATE TABLE api_key
+(
+    id         VARCHAR(40)  PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    role       VARCHAR(20)  NOT NULL CHECK (role IN ('reader', 'editor', 'admin')),
+    hash       CHAR(64)     NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Idempotency keys: the request hash and recorded response of POST requests, keyed by a
+-- hash of caller, endpoint and Idempotency-Key header; expired rows are purged periodically
+CREATE TABLE idempotency_key
+(
+    key          CHAR(64)    PRIMARY KEY,
+    request_hash CHAR(64)    NOT NULL,
+    completed    BOOLEAN     NOT NULL DEFAULT FALSE,
+    status_code  INTEGER     NOT NULL DEFAULT 0,
+    content_type VARCHAR(100) NOT NULL DEFAULT '',
+    body         BYTEA,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_idempotency_key_expires_at ON idempotency_key(expires_at);
+
+-- Rate limit token buckets shared by every replica, keyed by client and route.
+-- full_at is when a bucket has refilled; full buckets are deleted as they match a new one
+CREATE TABLE rate_limit_bucket
+(
+    key        VARCHAR(300)     PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    full_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_bucket_full_at ON rate_limit_bucket(full_at);
+
+-- Transactional outbox: catalogue events written in the same transaction as the beer
+-- change, relayed to webhook deliveries by the dispatcher and then marked dispatched
+CREATE TABLE outbox_event
+(
+    id            BIGSERIAL   PRIMARY KEY,
+    event_type    VARCHAR(50) NOT NULL,
+    beer_id       INTEGER     NOT NULL,
+    payload       JSONB       NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    dispatched_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_outbox_event_undispatched ON outbox_event(id) WHERE dispatched_at IS NULL;
+
+-- Webhook subscriptions: partner URLs notified of catalogue events (no types = every type),
+-- signed with the HMAC secret shown once when the subscription is created
+CREATE TABLE webhook_subscription
+(
+    id          VARCHAR(40)  PRIMARY KEY,
+    url         TEXT         NOT NULL,
+    event_types TEXT[]       NOT NULL DEFAULT '{}',
+    secret      VARCHAR(100) NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Webhook deliveries: one event to one subscription, retried with exponential backoff until
+-- delivered or dead; dead deliveries outlive their subscription so they can be inspected
+CREATE TABLE webhook_delivery
+(
+    id               VARCHAR(40) PRIMARY KEY,
+    subscription_id  VARCHAR(40) NOT NULL,
+    event            JSONB       NOT NULL,
+    status           VARCHAR(20) NOT NULL CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts         INTEGER     NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    next_attempt_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error       TEXT        NOT NULL DEFAULT '',
+    last_status_code INTEGER     NOT NULL DEFAULT 0,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_delivery_due ON webhook_delivery(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX idx_webhook_delivery_status ON webhook_delivery(status, created_at);
+
 -- Add some sample data for testing
-INSERT INTO beer (id, name, brewery, country, currency, price, created_at, updated_at) VALUES
-(1, 'Cerveza Cristal', 'CCU', 'Chile', 'CLP', 1200.00, NOW(), NOW()),
-(2, 'Escudo', 'CCU', 'Chile', 'CLP', 1100.00, NOW(), NOW()),
-(3, 'Heineken', 'Heineken N.V.', 'Netherlands', 'EUR', 2.50, NOW(), NOW()),
-(4, 'Corona Extra', 'Grupo Modelo', 'Mexico', 'MXN', 35.00, NOW(), NOW()),
-(5, 'Budweiser', 'Anheuser-Busch', 'United States', 'USD', 4.50, NOW(), NOW()),
-(6, 'Stella Artois', 'Anheuser-Busch InBev', 'Belgium', 'EUR', 3.20, NOW(), NOW()),
-(7, 'Guinness', 'Guinness Brewery', 'Ireland', 'EUR', 4.80, NOW(), NOW()),
-(8, 'Asahi Super Dry', 'Asahi Breweries', 'Japan', 'JPY', 250.00, NOW(), NOW());
+INSERT INTO beer (id, name, brewery, country, currency, price, abv, volume_ml, created_at, updated_at) VALUES
+(1, 'Cerveza Cristal', 'CCU', 'Chile', 'CLP', 1200.00, 4.6, 350, NOW(), NOW()),
+(2, 'Escudo', 'CCU', 'Chile', 'CLP', 1100.00, 5.5, 350, NOW(), NOW()),
+(3, 'Heineken', 'Heineken N.V.', 'Netherlands', 'EUR', 2.50, 5.0, 330, NOW(), NOW()),
+(4, 'Corona Extra', 'Grupo Modelo', 'Mexico', 'MXN', 35.00, 4.5, 355, NOW(), NOW()),
+(5, 'Budweiser', 'Anheuser-Busch', 'United States', 'USD', 4.50, 5.0, 355, NOW(), NOW()),
+(6, 'Stella Artois', 'Anheuser-Busch InBev', 'Belgium', 'EUR', 3.20, 5.2, 330, NOW(), NOW()),
+(7, 'Guinness', 'Guinness Brewery', 'Ireland', 'EUR', 4.80, 4.2, 440, NOW(), NOW()),
+(8, 'Asahi Super Dry', 'Asahi Breweries', 'Japan', 'JPY', 250.00, 5.0, 350, NOW(), NOW());
 
 -- Create a function to update updated_at timestamp
 CREATE OR REPLACE FUNCTION update_updated_at_column()